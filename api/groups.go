@@ -0,0 +1,134 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/metrics"
+	"github.com/tateexon/reservation/schema"
+)
+
+func (s *Server) GetProviderGroups(c *gin.Context) {
+	groups, err := s.DB.ListProviderGroups(c.Request.Context())
+	if err != nil {
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to fetch provider groups", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, groups)
+}
+
+func (s *Server) PostProviderGroups(c *gin.Context) {
+	var req schema.CreateProviderGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithBindError(c, err)
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		s.respondWithValidationError(c, "Name is required", schema.FieldError{Field: "name", Message: "is required"})
+		return
+	}
+	strategy := schema.RoundRobin
+	if req.Strategy != nil {
+		strategy = *req.Strategy
+	}
+
+	group, err := s.DB.CreateProviderGroup(c.Request.Context(), name, strategy, req.ProviderIds)
+	if err != nil {
+		if errors.Is(err, db.ErrUserInactive) {
+			s.respondWithError(c, http.StatusConflict, schema.ProblemCodeUserInactive, "Provider has been deactivated", nil)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeProviderNotFound, "Provider not found", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to create provider group", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, group)
+}
+
+//nolint:revive
+func (s *Server) GetProviderGroupsGroupId(c *gin.Context, groupId openapi_types.UUID) {
+	group, err := s.DB.GetProviderGroup(c.Request.Context(), groupId)
+	if err != nil {
+		if errors.Is(err, db.ErrGroupNotFound) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeGroupNotFound, "Provider group not found", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to fetch provider group", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+// reserveFromGroup books the provider a group's strategy assigns at the requested start time. The client
+// does not pick the provider, so the fields that go with one are rejected.
+func (s *Server) reserveFromGroup(c *gin.Context, req schema.PostAppointmentsJSONRequestBody) {
+	var invalid []schema.FieldError
+	if req.StartTime == nil {
+		invalid = append(invalid, schema.FieldError{Field: "start_time", Message: "is required"})
+	}
+	for field, given := range map[string]bool{
+		"provider_id":     req.ProviderId != nil,
+		"availability_id": req.AvailabilityId != nil,
+		"participant_ids": req.ParticipantIds != nil,
+		"room_id":         req.RoomId != nil,
+		"recurrence":      req.Recurrence != nil,
+	} {
+		if given {
+			invalid = append(invalid, schema.FieldError{Field: field, Message: "can not be given with group_id"})
+		}
+	}
+	if len(invalid) > 0 {
+		slices.SortFunc(invalid, func(a, b schema.FieldError) int { return strings.Compare(a.Field, b.Field) })
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedInvalidRequest).Inc()
+		s.respondWithValidationError(c, "Group bookings take a start_time and the server picks the provider", invalid...)
+		return
+	}
+
+	startTime := *req.StartTime
+	if !s.meetsLeadTime(c.Request.Context(), startTime) {
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedLeadTime).Inc()
+		s.respondWithLeadTimeViolation(c)
+		return
+	}
+
+	appointment, err := s.DB.ReserveFromGroup(c.Request.Context(), req.ClientId, *req.GroupId, startTime, intakeOf(req))
+	if err != nil {
+		if s.respondWithLimitError(c, err) || s.respondWithIntakeError(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, db.ErrGroupNotFound):
+			metrics.ReservationsRejected.WithLabelValues(metrics.RejectedUnknownGroup).Inc()
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeGroupNotFound, "Provider group not found", nil)
+		case errors.Is(err, db.ErrUserInactive):
+			metrics.ReservationsRejected.WithLabelValues(metrics.RejectedUserInactive).Inc()
+			s.respondWithError(c, http.StatusConflict, schema.ProblemCodeUserInactive, "Client has been deactivated", nil)
+		case errors.Is(err, sql.ErrNoRows):
+			metrics.ReservationsRejected.WithLabelValues(metrics.RejectedUnknownUser).Inc()
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeUserNotFound, "Client not found", nil)
+		case errors.Is(err, db.ErrSlotUnavailable):
+			metrics.ReservationsRejected.WithLabelValues(metrics.RejectedConflict).Inc()
+			s.respondWithError(c, http.StatusConflict, schema.ProblemCodeSlotUnavailable, "No provider in the group is available at that time", nil)
+		default:
+			s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to reserve appointment", err)
+		}
+		return
+	}
+
+	metrics.ReservationsCreated.Inc()
+	c.JSON(http.StatusCreated, appointment)
+}
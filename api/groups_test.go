@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/schema"
+)
+
+func TestGroupAppointments(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	router := setupTestServer(store)
+
+	first := createTestProvider(t, store)
+	second := createTestProvider(t, store)
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Hour).UTC()
+	availability := fmt.Sprintf(`{"start_time":%q,"end_time":%q}`, startTime.Format(time.RFC3339), startTime.Add(2*db.GetAvailabilityInterval()).Format(time.RFC3339))
+	for _, providerID := range []*uuid.UUID{first, second} {
+		w := serve(t, router, http.MethodPost, "/providers/"+providerID.String()+"/availability", availability)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+	var slots []schema.Appointment
+	w := serve(t, router, http.MethodGet, "/appointments?providerId="+first.String(), "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &slots))
+	slotTime := *slots[0].StartTime
+
+	w = serve(t, router, http.MethodPost, "/provider-groups", fmt.Sprintf(`{"name":" ","provider_ids":[%q]}`, first))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	w = serve(t, router, http.MethodPost, "/provider-groups", fmt.Sprintf(`{"name":"Front desk","provider_ids":[%q]}`, uuid.New()))
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, schema.ProblemCodeProviderNotFound, decodeProblem(t, w).Code)
+	w = serve(t, router, http.MethodPost, "/provider-groups", fmt.Sprintf(`{"name":"Front desk","provider_ids":[%q,%q]}`, first, second))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var group schema.ProviderGroup
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &group))
+	require.Equal(t, schema.RoundRobin, *group.Strategy)
+
+	w = serve(t, router, http.MethodGet, "/provider-groups/"+group.Id.String(), "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = serve(t, router, http.MethodGet, "/provider-groups/"+uuid.New().String(), "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, schema.ProblemCodeGroupNotFound, decodeProblem(t, w).Code)
+
+	book := func(req schema.PostAppointmentsJSONRequestBody) (int, schema.Appointment) {
+		req.ClientId = *createTestClient(t, store)
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+		w := serve(t, router, http.MethodPost, "/appointments", string(body))
+		var appointment schema.Appointment
+		if w.Code == http.StatusCreated {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &appointment))
+		}
+		return w.Code, appointment
+	}
+
+	code, _ := book(schema.PostAppointmentsJSONRequestBody{GroupId: group.Id})
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = book(schema.PostAppointmentsJSONRequestBody{GroupId: group.Id, StartTime: &slotTime, ProviderId: first})
+	require.Equal(t, http.StatusBadRequest, code)
+	unknown := uuid.New()
+	code, _ = book(schema.PostAppointmentsJSONRequestBody{GroupId: &unknown, StartTime: &slotTime})
+	require.Equal(t, http.StatusNotFound, code)
+
+	// Round robin hands the slot to each member in turn
+	code, appointment := book(schema.PostAppointmentsJSONRequestBody{GroupId: group.Id, StartTime: &slotTime})
+	require.Equal(t, http.StatusCreated, code)
+	require.Equal(t, *first, *appointment.ProviderId)
+	require.Equal(t, schema.RoundRobin, *appointment.Assignment.Strategy)
+	require.Len(t, *appointment.Assignment.Candidates, 2)
+	require.Contains(t, *appointment.Assignment.Explanation, first.String())
+	code, appointment = book(schema.PostAppointmentsJSONRequestBody{GroupId: group.Id, StartTime: &slotTime})
+	require.Equal(t, http.StatusCreated, code)
+	require.Equal(t, *second, *appointment.ProviderId)
+	code, _ = book(schema.PostAppointmentsJSONRequestBody{GroupId: group.Id, StartTime: &slotTime})
+	require.Equal(t, http.StatusConflict, code)
+}
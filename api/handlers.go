@@ -1,23 +1,49 @@
 package api
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/clock"
 	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/metrics"
+	"github.com/tateexon/reservation/notify"
 	"github.com/tateexon/reservation/schema"
 	"github.com/tateexon/reservation/utils"
+	"go.opentelemetry.io/otel"
 )
 
+// defaultLeadTime is how far in advance reservations must be made in organizations that do not set their own
+const defaultLeadTime = 24 * time.Hour
+
 // Server implementation
 type Server struct {
-	DB *db.Database
+	DB       db.Store
+	Notifier notify.Notifier
+	Logger   *slog.Logger
+	// Clock is what lead times and waitlist windows are measured against, nil means the system clock
+	Clock clock.Clock
+
+	// draining is set once shutdown starts
+	draining atomic.Bool
+	// notifications counts deliveries still running in the background
+	notifications sync.WaitGroup
 }
 
+// tracer records work that is not part of a request, such as the waitlist worker
+var tracer = otel.Tracer("github.com/tateexon/reservation/api")
+
 // Ensure that Server implements ServerInterface
 var _ schema.ServerInterface = (*Server)(nil)
 
@@ -25,12 +51,29 @@ var _ schema.ServerInterface = (*Server)(nil)
 func (s *Server) GetAppointments(c *gin.Context, params schema.GetAppointmentsParams) {
 	// Parse query parameters
 	providerID := params.ProviderId
+	locationID := params.LocationId
 	date := params.Date
 
+	// Participants are free at the provider's slot times, so there has to be a provider
+	var participantIDs []openapi_types.UUID
+	if params.ParticipantId != nil {
+		if providerID == nil {
+			s.respondWithValidationError(c, "participantId needs providerId",
+				schema.FieldError{Field: "participantId", Message: "needs providerId"})
+			return
+		}
+		var ok bool
+		if participantIDs, ok = participants(*providerID, params.ParticipantId); !ok {
+			s.respondWithValidationError(c, "The provider can not also be a participant",
+				schema.FieldError{Field: "participantId", Message: "must not include providerId"})
+			return
+		}
+	}
+
 	// Get available appointment slots from the database
-	slots, err := s.DB.GetAvailableAppointments(providerID, date)
+	slots, err := s.DB.GetAvailableAppointments(c.Request.Context(), providerID, participantIDs, locationID, date)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch appointments"})
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to fetch appointments", err)
 		return
 	}
 
@@ -41,144 +84,409 @@ func (s *Server) PostAppointments(c *gin.Context) {
 	var req schema.PostAppointmentsJSONRequestBody
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedInvalidRequest).Inc()
+		s.respondWithBindError(c, err)
+		return
+	}
+	if req.GroupId != nil {
+		s.reserveFromGroup(c, req)
+		return
+	}
+	var missing []schema.FieldError
+	if req.ProviderId == nil {
+		missing = append(missing, schema.FieldError{Field: "provider_id", Message: "is required"})
+	}
+	if req.AvailabilityId == nil {
+		missing = append(missing, schema.FieldError{Field: "availability_id", Message: "is required"})
+	}
+	if len(missing) > 0 {
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedInvalidRequest).Inc()
+		s.respondWithValidationError(c, "provider_id and availability_id are required unless group_id is given", missing...)
+		return
+	}
+	participantIDs, ok := participants(*req.ProviderId, req.ParticipantIds)
+	if !ok {
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedInvalidRequest).Inc()
+		s.respondWithValidationError(c, "The provider can not also be a participant",
+			schema.FieldError{Field: "participant_ids", Message: "must not include provider_id"})
 		return
 	}
 
 	// get appointment
-	startTime, err := s.DB.GetAppointmentStartTime(req.AvailabilityId)
+	startTime, err := s.DB.GetAppointmentStartTime(c.Request.Context(), req.AvailabilityId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid availability id"})
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedInvalidAvailability).Inc()
+		s.respondWithError(c, http.StatusBadRequest, schema.ProblemCodeAvailabilityNotFound, "Invalid availability id", err)
 		return
 	}
 
 	// Business logic checks
-	if !s.isReservationAtLeast24HoursInAdvance(&startTime) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Reservations must be made at least 24 hours in advance"})
+	if !s.meetsLeadTime(c.Request.Context(), startTime) {
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedLeadTime).Inc()
+		s.respondWithLeadTimeViolation(c)
+		return
+	}
+
+	if req.Recurrence != nil {
+		s.reserveSeries(c, req, participantIDs, startTime)
 		return
 	}
 
 	// Check if the slot is available
-	available, err := s.DB.IsSlotAvailable(req.ProviderId, &startTime)
+	available, err := s.DB.IsSlotAvailable(c.Request.Context(), req.ProviderId, participantIDs, &startTime)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check slot availability"})
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to check slot availability", err)
 		return
 	}
 	if !available {
-		c.JSON(http.StatusConflict, gin.H{"error": "Slot is not available"})
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedConflict).Inc()
+		s.respondWithError(c, http.StatusConflict, schema.ProblemCodeSlotUnavailable, "Slot is not available", nil)
 		return
 	}
 
-	// Reserve the appointment
-	appointment, err := s.DB.ReserveAppointment(req.ClientId, req.ProviderId, &startTime)
+	// Reserve the appointment, together with the participants and the room if one was asked for
+	appointment, err := s.DB.ReserveAppointment(c.Request.Context(), &req.ClientId, req.ProviderId, participantIDs, req.RoomId, &startTime, intakeOf(req))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reserve appointment"})
+		if errors.Is(err, db.ErrUserInactive) {
+			metrics.ReservationsRejected.WithLabelValues(metrics.RejectedUserInactive).Inc()
+			s.respondWithError(c, http.StatusConflict, schema.ProblemCodeUserInactive, "Client or provider has been deactivated", nil)
+			return
+		}
+		if s.respondWithRoomError(c, err) || s.respondWithLimitError(c, err) || s.respondWithIntakeError(c, err) {
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			metrics.ReservationsRejected.WithLabelValues(metrics.RejectedUnknownUser).Inc()
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeUserNotFound, "Client or provider not found", nil)
+			return
+		}
+		// Another client may have taken the last seat since the check above
+		if errors.Is(err, db.ErrSlotUnavailable) {
+			metrics.ReservationsRejected.WithLabelValues(metrics.RejectedConflict).Inc()
+			s.respondWithError(c, http.StatusConflict, schema.ProblemCodeSlotUnavailable, "Slot is not available", nil)
+			return
+		}
+		if errors.Is(err, db.ErrRoomUnavailable) {
+			metrics.ReservationsRejected.WithLabelValues(metrics.RejectedConflict).Inc()
+			s.respondWithError(c, http.StatusConflict, schema.ProblemCodeRoomUnavailable, "Room is already booked at that time", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to reserve appointment", err)
 		return
 	}
 
+	metrics.ReservationsCreated.Inc()
 	c.JSON(http.StatusCreated, appointment)
 }
 
-func (s *Server) isReservationAtLeast24HoursInAdvance(startTime *time.Time) bool {
-	return time.Until(*startTime) >= 24*time.Hour
+// participants returns the distinct participants asked for, false if the provider is one of them
+func participants(providerID openapi_types.UUID, ids *[]openapi_types.UUID) ([]openapi_types.UUID, bool) {
+	if ids == nil {
+		return nil, true
+	}
+	distinct := make([]openapi_types.UUID, 0, len(*ids))
+	for _, id := range *ids {
+		if id == providerID {
+			return nil, false
+		}
+		if !slices.Contains(distinct, id) {
+			distinct = append(distinct, id)
+		}
+	}
+	return distinct, true
+}
+
+func (s *Server) logger() *slog.Logger {
+	if s.Logger == nil {
+		return slog.Default()
+	}
+	return s.Logger
+}
+
+func (s *Server) now() time.Time {
+	if s.Clock == nil {
+		return time.Now()
+	}
+	return s.Clock.Now()
+}
+
+// leadTime is how far in advance reservations must be made in the organization in ctx
+func (s *Server) leadTime(ctx context.Context) time.Duration {
+	if org, ok := db.OrganizationFrom(ctx); ok && org.Settings != nil && org.Settings.LeadTimeMinutes != nil {
+		return time.Duration(*org.Settings.LeadTimeMinutes) * time.Minute
+	}
+	return defaultLeadTime
+}
+
+func (s *Server) meetsLeadTime(ctx context.Context, startTime time.Time) bool {
+	return startTime.Sub(s.now()) >= s.leadTime(ctx)
+}
+
+func (s *Server) respondWithLeadTimeViolation(c *gin.Context) {
+	s.respondWithError(c, http.StatusBadRequest, schema.ProblemCodeLeadTimeViolation,
+		fmt.Sprintf("Reservations must be made at least %s in advance", describeDuration(s.leadTime(c.Request.Context()))), nil)
+}
+
+// respondWithLimitError answers a booking that broke one of the organization's client limits, false if err
+// is not one of them
+func (s *Server) respondWithLimitError(c *gin.Context, err error) bool {
+	var settings schema.OrganizationSettings
+	if org, ok := db.OrganizationFrom(c.Request.Context()); ok && org.Settings != nil {
+		settings = *org.Settings
+	}
+	switch {
+	case errors.Is(err, db.ErrClientDoubleBooked):
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedDoubleBooked).Inc()
+		s.respondWithError(c, http.StatusConflict, schema.ProblemCodeClientDoubleBooked,
+			"Client already has an appointment at an overlapping time", nil)
+	case errors.Is(err, db.ErrNoShowLimit):
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedNoShowLimit).Inc()
+		s.respondWithError(c, http.StatusConflict, schema.ProblemCodeNoShowLimitReached,
+			fmt.Sprintf("Clients who missed %s in the last 90 days can not book", countOf(settings.MaxNoShows, "appointment")), nil)
+	case errors.Is(err, db.ErrHoldLimit):
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedBookingLimit).Inc()
+		s.respondWithError(c, http.StatusConflict, schema.ProblemCodeHoldLimitReached,
+			fmt.Sprintf("Clients may hold at most %s at once", countOf(settings.MaxActiveHolds, "unconfirmed reservation")), nil)
+	case errors.Is(err, db.ErrAppointmentLimit):
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedBookingLimit).Inc()
+		s.respondWithError(c, http.StatusConflict, schema.ProblemCodeAppointmentLimitReached,
+			fmt.Sprintf("Clients may have at most %s", countOf(settings.MaxFutureAppointments, "upcoming appointment")), nil)
+	case errors.Is(err, db.ErrProviderAppointmentLimit):
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedBookingLimit).Inc()
+		s.respondWithError(c, http.StatusConflict, schema.ProblemCodeProviderAppointmentLimitReached,
+			fmt.Sprintf("Clients may have at most %s with one provider", countOf(settings.MaxFutureAppointmentsPerProvider, "upcoming appointment")), nil)
+	default:
+		return false
+	}
+	return true
+}
+
+// countOf words a limit of something for problem details
+func countOf(limit *int, noun string) string {
+	switch {
+	case limit == nil:
+		return "a limited number of " + noun + "s"
+	case *limit == 1:
+		return "1 " + noun
+	default:
+		return fmt.Sprintf("%d %ss", *limit, noun)
+	}
+}
+
+// describeDuration words a whole number of minutes or hours for problem details
+func describeDuration(d time.Duration) string {
+	switch {
+	case d == time.Hour:
+		return "1 hour"
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%d hours", d/time.Hour)
+	case d == time.Minute:
+		return "1 minute"
+	default:
+		return fmt.Sprintf("%d minutes", d/time.Minute)
+	}
 }
 
 //nolint:revive
 func (s *Server) PostAppointmentsAppointmentIdConfirm(c *gin.Context, appointmentId openapi_types.UUID) {
 	// Confirm the reservation
-	err := s.DB.ConfirmAppointment(appointmentId)
+	err := s.DB.ConfirmAppointment(c.Request.Context(), appointmentId)
 	if err != nil {
+		if s.respondWithTransitionError(c, err, "confirmed") {
+			return
+		}
+		if errors.Is(err, db.ErrHoldExpired) {
+			s.respondWithError(c, http.StatusGone, schema.ProblemCodeHoldExpired, "Reservation was not confirmed within 30 minutes", nil)
+			return
+		}
 		if errors.Is(err, sql.ErrNoRows) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Appointment not found or may have expired"})
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeAppointmentNotFound, "Appointment not found or is not a reservation", nil)
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to confirm appointment"})
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to confirm appointment", err)
 		return
 	}
 
+	metrics.ReservationsConfirmed.Inc()
 	c.JSON(http.StatusOK, gin.H{"message": "Appointment confirmed"})
 }
 
 //nolint:revive
-func (s *Server) PostProvidersProviderIdAvailability(c *gin.Context, providerId openapi_types.UUID) {
-	var availability schema.Availability
-
-	if err := c.ShouldBindJSON(&availability); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+func (s *Server) PostAppointmentsAppointmentIdStatus(c *gin.Context, appointmentId openapi_types.UUID) {
+	var req schema.UpdateAppointmentStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithBindError(c, err)
 		return
 	}
 
-	startTime := roundUpToNearestInterval(*availability.StartTime)
-	// add a microsecond to the end so the .Before will include it
-	endTime := roundDownToNearestInterval(*availability.EndTime).Add(time.Microsecond)
-
-	// Validate time range
-	if endTime.Before(startTime) || !areAtLeastTheIntervalApart(startTime, endTime) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "End time must be after start time and at least 15 minutes apart"})
+	appointment, err := s.DB.UpdateAppointmentStatus(c.Request.Context(), appointmentId, req.Status, req.ChangedBy)
+	if err != nil {
+		if s.respondWithTransitionError(c, err, "changed to "+describeStatus(req.Status)) {
+			return
+		}
+		switch {
+		case errors.Is(err, db.ErrAppointmentNotStarted):
+			s.respondWithError(c, http.StatusConflict, schema.ProblemCodeAppointmentNotStarted,
+				"Appointments are checked in, completed or marked a no-show once they have started", nil)
+		case errors.Is(err, db.ErrNotAppointmentProvider):
+			s.respondWithValidationError(c, "Only the appointment's providers can change its status",
+				schema.FieldError{Field: "changed_by", Message: "must be the provider or a participant of the appointment"})
+		case errors.Is(err, sql.ErrNoRows):
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeAppointmentNotFound, "Appointment not found", nil)
+		default:
+			s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to update appointment status", err)
+		}
 		return
 	}
 
-	// Split time range into 15-minute slots
-	slots := utils.GenerateTimeSlots(startTime, endTime, db.GetAvailabilityInterval())
+	metrics.AppointmentOutcomes.WithLabelValues(string(req.Status)).Inc()
+	c.JSON(http.StatusOK, appointment)
+}
 
-	// Save availability slots to the database
-	err := s.DB.AddAvailability(providerId, slots)
+//nolint:revive
+func (s *Server) GetAppointmentsAppointmentIdHistory(c *gin.Context, appointmentId openapi_types.UUID) {
+	history, err := s.DB.ListStatusHistory(c.Request.Context(), appointmentId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add availability"})
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeAppointmentNotFound, "Appointment not found", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to fetch appointment history", err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "Availability added"})
+	c.JSON(http.StatusOK, history)
 }
 
-// Round up to the nearest 15-minute interval
-func roundUpToNearestInterval(t time.Time) time.Time {
-	t = roundDownToNearestInterval(t)
-	return t.Add(db.GetAvailabilityInterval())
+// describeStatus words an appointment status for problem details
+func describeStatus(status schema.AppointmentStatus) string {
+	return strings.ReplaceAll(string(status), "_", " ")
 }
 
-func roundDownToNearestInterval(t time.Time) time.Time {
-	return t.Truncate(db.GetAvailabilityInterval())
+// respondWithTransitionError answers a request that failed because the appointment's status does not allow
+// what it asked, worded as "can not be <action>", reporting whether err was such a failure
+func (s *Server) respondWithTransitionError(c *gin.Context, err error, action string) bool {
+	var transition *db.TransitionError
+	if !errors.As(err, &transition) {
+		return false
+	}
+	s.respondWithError(c, http.StatusConflict, schema.ProblemCodeInvalidStatusTransition,
+		fmt.Sprintf("A %s appointment can not be %s", describeStatus(transition.From), action), nil)
+	return true
 }
 
-// Check if two times are at least 15 minutes apart
-func areAtLeastTheIntervalApart(t1, t2 time.Time) bool {
-	// Calculate the absolute difference between the two times
-	diff := t2.Sub(t1)
+//nolint:revive
+func (s *Server) PostAppointmentsAppointmentIdCancel(c *gin.Context, appointmentId openapi_types.UUID, params schema.PostAppointmentsAppointmentIdCancelParams) {
+	var err error
+	if params.Scope != nil && *params.Scope == schema.Following {
+		err = s.DB.CancelFollowingAppointments(c.Request.Context(), appointmentId)
+	} else {
+		err = s.DB.CancelAppointment(c.Request.Context(), appointmentId)
+	}
+	if err != nil {
+		if s.respondWithTransitionError(c, err, "cancelled") {
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeAppointmentNotFound, "Appointment not found or may have expired", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to cancel appointment", err)
+		return
+	}
 
-	// Check if the difference is at least 15 minutes
-	return diff >= db.GetAvailabilityInterval()
+	// The freed slot may be wanted by someone on the waitlist
+	s.promoteWaitlist(c.Request.Context())
+
+	c.JSON(http.StatusOK, gin.H{"message": "Appointment cancelled"})
 }
 
-func (s *Server) PostUsers(c *gin.Context) {
-	var req schema.CreateUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+//nolint:revive
+func (s *Server) PostProvidersProviderIdAvailability(c *gin.Context, providerId openapi_types.UUID) {
+	var availability schema.Availability
+
+	if err := c.ShouldBindJSON(&availability); err != nil {
+		s.respondWithBindError(c, err)
 		return
 	}
 
-	user, err := s.DB.CreateUser(req.Name, req.Email, string(req.Role))
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
+	var missing []schema.FieldError
+	if availability.StartTime.IsZero() {
+		missing = append(missing, schema.FieldError{Field: "start_time", Message: "is required"})
+	}
+	if availability.EndTime.IsZero() {
+		missing = append(missing, schema.FieldError{Field: "end_time", Message: "is required"})
+	}
+	if len(missing) > 0 {
+		s.respondWithValidationError(c, "Start and end time are required", missing...)
 		return
 	}
 
-	c.JSON(http.StatusCreated, user)
-}
+	interval := db.SlotInterval(c.Request.Context())
+	startTime := roundUpToNearestInterval(availability.StartTime, interval)
+	// add a microsecond to the end so the .Before will include it
+	endTime := roundDownToNearestInterval(availability.EndTime, interval).Add(time.Microsecond)
 
-//nolint:revive
-func (s *Server) GetUsersUserId(c *gin.Context, userId openapi_types.UUID) {
-	// Retrieve the user from the database
-	user, err := s.DB.GetUser(userId)
+	// Validate time range
+	if endTime.Before(startTime) || !areAtLeastTheIntervalApart(startTime, endTime, interval) {
+		s.respondWithValidationError(c, fmt.Sprintf("End time must be after start time and at least %s apart", describeDuration(interval)),
+			schema.FieldError{Field: "end_time", Message: fmt.Sprintf("must be at least %s after start_time", describeDuration(interval))})
+		return
+	}
+
+	capacity := 1
+	if availability.Capacity != nil {
+		capacity = *availability.Capacity
+	}
+	if capacity < 1 {
+		s.respondWithValidationError(c, "Capacity must be at least 1",
+			schema.FieldError{Field: "capacity", Message: "must be at least 1"})
+		return
+	}
+
+	// Split time range into slots of the organization's interval
+	slots := utils.GenerateTimeSlots(startTime, endTime, interval)
+
+	// Save availability slots to the database
+	err := s.DB.AddAvailability(c.Request.Context(), providerId, availability.LocationId, slots, capacity)
 	if err != nil {
+		if errors.Is(err, db.ErrLocationNotFound) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeLocationNotFound, "Location not found", nil)
+			return
+		}
 		if errors.Is(err, sql.ErrNoRows) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeProviderNotFound, "Provider not found", nil)
+			return
+		}
+		if errors.Is(err, db.ErrUserInactive) {
+			s.respondWithError(c, http.StatusConflict, schema.ProblemCodeUserInactive, "Provider has been deactivated", nil)
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch user"})
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to add availability", err)
 		return
 	}
 
-	// Return the user details
-	c.JSON(http.StatusOK, user)
+	// New slots may be wanted by someone on the waitlist
+	s.promoteWaitlist(c.Request.Context())
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Availability added"})
+}
+
+// Round up to the nearest interval
+func roundUpToNearestInterval(t time.Time, interval time.Duration) time.Time {
+	t = roundDownToNearestInterval(t, interval)
+	return t.Add(interval)
+}
+
+func roundDownToNearestInterval(t time.Time, interval time.Duration) time.Time {
+	return t.Truncate(interval)
+}
+
+// Check if two times are at least the interval apart
+func areAtLeastTheIntervalApart(t1, t2 time.Time, interval time.Duration) bool {
+	// Calculate the absolute difference between the two times
+	diff := t2.Sub(t1)
+
+	// Check if the difference is at least the interval
+	return diff >= interval
 }
@@ -3,18 +3,25 @@ package api
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
+	_ "time/tzdata" // the lead time tests need zone rules on hosts without them
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/oapi-codegen/runtime/types"
 	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/clock"
 	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/logging"
 	"github.com/tateexon/reservation/schema"
 	"github.com/tateexon/reservation/utils"
 )
@@ -34,62 +41,78 @@ func startTestDatabase(t *testing.T) *db.Database {
 	connStr, err := ctr.ConnectionString(ctx, "sslmode=disable")
 	require.NoError(t, err)
 
-	dbInstance, err := db.NewDatabase(connStr)
+	dbInstance, err := db.NewDatabase(connStr, nil)
 	require.NoError(t, err, "Failed to connect to the database")
 
 	return dbInstance
 }
 
 // Helper functions to create test data
-func createTestProvider(t *testing.T, dbInstance *db.Database) *types.UUID {
-	providerID := uuid.New()
-	_, err := dbInstance.Conn.Exec(`
-        INSERT INTO users (id, name, email, role, created_at, updated_at)
-        VALUES ($1, $2, $3, 'provider', NOW(), NOW())
-    `, providerID, "Test Provider", fmt.Sprintf("provider-%s@example.com", providerID.String()))
-	require.NoError(t, err)
-	return (*types.UUID)(&providerID)
+func createTestProvider(t *testing.T, store db.Store) *types.UUID {
+	return createTestUser(t, store, "provider")
 }
 
-func createTestClient(t *testing.T, db *db.Database) *types.UUID {
-	clientID := uuid.New()
-	_, err := db.Conn.Exec(`
-        INSERT INTO users (id, name, email, role, created_at, updated_at)
-        VALUES ($1, $2, $3, 'client', NOW(), NOW())
-    `, clientID, "Test Client", fmt.Sprintf("client-%s@example.com", clientID.String()))
+func createTestClient(t *testing.T, store db.Store) *types.UUID {
+	return createTestUser(t, store, "client")
+}
+
+func createTestUser(t *testing.T, store db.Store, role string) *types.UUID {
+	user, err := store.CreateUser(context.Background(), "Test "+role, fmt.Sprintf("%s-%s@example.com", role, uuid.NewString()), role)
 	require.NoError(t, err)
-	return (*types.UUID)(&clientID)
+	return user.Id
 }
 
-func addTestAvailability(t *testing.T, dbInstance *db.Database, providerID *types.UUID, slots []time.Time) {
-	err := dbInstance.AddAvailability(*providerID, slots)
+func addTestAvailability(t *testing.T, store db.Store, providerID *types.UUID, slots []time.Time) {
+	err := store.AddAvailability(context.Background(), *providerID, nil, slots, 1)
 	require.NoError(t, err)
 }
 
-func setupTestServer(dbInstance *db.Database) *gin.Engine {
+// decodeProblem reads the problem details a handler responded with
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) schema.Problem {
+	require.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	var problem schema.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	require.Equal(t, w.Code, problem.Status)
+	return problem
+}
+
+// testSpec loads the openapi spec the handlers are validated against
+func testSpec() *openapi3.T {
+	spec, err := schema.GetSwagger()
+	if err != nil {
+		panic(err)
+	}
+	return spec
+}
+
+func setupTestServer(store db.Store) *gin.Engine {
+	return setupTestServerWithClock(store, nil)
+}
+
+// setupTestServerWithClock serves with clk, which should be the store's clock too
+func setupTestServerWithClock(store db.Store, clk clock.Clock) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 
-	server := &Server{DB: dbInstance}
-	schema.RegisterHandlers(router, server)
+	server := &Server{DB: store, Clock: clk}
+	server.Register(router, testSpec(), ValidationOptions{Responses: true})
 
 	return router
 }
 
 func TestPostProvidersProviderIdAvailability(t *testing.T) {
 	t.Parallel()
-	dbInstance := startTestDatabase(t)
-	defer dbInstance.Conn.Close()
+	store := memory.New(nil)
 
-	router := setupTestServer(dbInstance)
+	router := setupTestServer(store)
 
-	providerID := createTestProvider(t, dbInstance)
+	providerID := createTestProvider(t, store)
 
 	startTime := time.Now().Add(25 * time.Hour).Truncate(time.Hour)
 	endTime := startTime.Add(2 * time.Hour)
 	availability := schema.Availability{
-		StartTime: &startTime,
-		EndTime:   &endTime,
+		StartTime: startTime,
+		EndTime:   endTime,
 	}
 
 	reqBody, err := json.Marshal(availability)
@@ -108,30 +131,26 @@ func TestPostProvidersProviderIdAvailability(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "Availability added", response["message"])
 
-	// Verify that availability slots were added to the database
-	var count int
-	err = dbInstance.Conn.QueryRow(`
-        SELECT COUNT(*) FROM availability WHERE provider_id = $1
-    `, providerID.String()).Scan(&count)
+	// Verify that availability slots were added to the store
+	appointments, err := store.GetAvailableAppointments(context.Background(), providerID, nil, nil, nil)
 	require.NoError(t, err)
 	expectedSlots := utils.GenerateTimeSlots(startTime, endTime, db.GetAvailabilityInterval())
-	require.Equal(t, len(expectedSlots), count)
+	require.Equal(t, len(expectedSlots), len(appointments))
 }
 
 func TestPostProvidersProviderIdAvailability_InvalidProviderId(t *testing.T) {
 	t.Parallel()
-	dbInstance := startTestDatabase(t)
-	defer dbInstance.Conn.Close()
+	store := memory.New(nil)
 
-	router := setupTestServer(dbInstance)
+	router := setupTestServer(store)
 
-	createTestProvider(t, dbInstance)
+	createTestProvider(t, store)
 
 	startTime := time.Now().Add(25 * time.Hour).Truncate(time.Hour)
 	endTime := startTime.Add(2 * time.Hour)
 	availability := schema.Availability{
-		StartTime: &startTime,
-		EndTime:   &endTime,
+		StartTime: startTime,
+		EndTime:   endTime,
 	}
 
 	reqBody, err := json.Marshal(availability)
@@ -144,22 +163,18 @@ func TestPostProvidersProviderIdAvailability_InvalidProviderId(t *testing.T) {
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
-	require.Equal(t, http.StatusInternalServerError, w.Code)
-	var response map[string]string
-	err = json.Unmarshal(w.Body.Bytes(), &response)
-	require.NoError(t, err)
-	require.Equal(t, "Failed to add availability", response["error"])
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, schema.ProblemCodeProviderNotFound, decodeProblem(t, w).Code)
 }
 
 func TestGetAppointments(t *testing.T) {
 	t.Parallel()
-	dbInstance := startTestDatabase(t)
-	defer dbInstance.Conn.Close()
+	store := memory.New(nil)
 
-	router := setupTestServer(dbInstance)
+	router := setupTestServer(store)
 
 	// Create a test provider and add availability
-	providerID := createTestProvider(t, dbInstance)
+	providerID := createTestProvider(t, store)
 
 	// Get the time zone offset in seconds from UTC
 	_, offsetSeconds := time.Now().Zone()
@@ -172,7 +187,7 @@ func TestGetAppointments(t *testing.T) {
 	endTime := startTime.Add(2 * time.Hour)
 	fmt.Println(endTime)
 	slots := utils.GenerateTimeSlots(startTime, endTime, db.GetAvailabilityInterval())
-	addTestAvailability(t, dbInstance, providerID, slots)
+	addTestAvailability(t, store, providerID, slots)
 
 	req, err := http.NewRequest(http.MethodGet, "/appointments?providerId="+providerID.String(), nil)
 	require.NoError(t, err)
@@ -204,12 +219,11 @@ func TestGetAppointments(t *testing.T) {
 
 func TestGetAppointments_WithDateFilter(t *testing.T) {
 	t.Parallel()
-	dbInstance := startTestDatabase(t)
-	defer dbInstance.Conn.Close()
+	store := memory.New(nil)
 
-	router := setupTestServer(dbInstance)
+	router := setupTestServer(store)
 
-	providerID := createTestProvider(t, dbInstance)
+	providerID := createTestProvider(t, store)
 
 	// Define two different dates
 	date1 := time.Now().Add(48 * time.Hour).Truncate(time.Hour * 24).Add(1 * time.Second)
@@ -220,7 +234,7 @@ func TestGetAppointments_WithDateFilter(t *testing.T) {
 	endTime1 := date1.Add(17 * time.Hour) // 5 PM on date1
 	fmt.Println(endTime1)
 	slots1 := utils.GenerateTimeSlots(startTime1, endTime1, db.GetAvailabilityInterval())
-	addTestAvailability(t, dbInstance, providerID, slots1)
+	addTestAvailability(t, store, providerID, slots1)
 
 	// Define the date to filter
 	filterDate := startTime1.Format("2006-01-02") // YYYY-MM-DD format
@@ -258,25 +272,24 @@ func TestGetAppointments_WithDateFilter(t *testing.T) {
 
 func TestPostAppointments(t *testing.T) {
 	t.Parallel()
-	dbInstance := startTestDatabase(t)
-	defer dbInstance.Conn.Close()
+	store := memory.New(nil)
 
-	router := setupTestServer(dbInstance)
+	router := setupTestServer(store)
 
-	providerID := createTestProvider(t, dbInstance)
-	clientID := createTestClient(t, dbInstance)
+	providerID := createTestProvider(t, store)
+	clientID := createTestClient(t, store)
 
 	startTime := time.Now().Add(25 * time.Hour).Truncate(time.Minute) // Ensure it's more than 24 hours in advance
 	slots := []time.Time{startTime}
-	addTestAvailability(t, dbInstance, providerID, slots)
+	addTestAvailability(t, store, providerID, slots)
 
-	appointments, err := dbInstance.GetAvailableAppointments(providerID, &types.Date{Time: startTime})
+	appointments, err := store.GetAvailableAppointments(context.Background(), providerID, nil, nil, &types.Date{Time: startTime})
 	require.NoError(t, err)
 	require.True(t, len(appointments) > 0)
 
 	// Prepare the request body
 	appointmentReq := schema.PostAppointmentsJSONRequestBody{
-		ClientId:       clientID,
+		ClientId:       *clientID,
 		ProviderId:     providerID,
 		AvailabilityId: appointments[0].Id,
 	}
@@ -297,30 +310,26 @@ func TestPostAppointments(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, schema.AppointmentStatus("reserved"), *appointment.Status)
 
-	// Verify that the appointment was added to the database
-	var status string
-	err = dbInstance.Conn.QueryRow(`
-        SELECT status FROM appointments WHERE id = $1
-    `, appointment.Id.String()).Scan(&status)
+	// Verify that the appointment holds the slot
+	available, err := store.IsSlotAvailable(context.Background(), providerID, nil, appointment.StartTime)
 	require.NoError(t, err)
-	require.Equal(t, "reserved", status)
+	require.False(t, available)
 }
 
 func TestPostAppointmentsAppointmentIdConfirm(t *testing.T) {
 	t.Parallel()
-	dbInstance := startTestDatabase(t)
-	defer dbInstance.Conn.Close()
+	store := memory.New(nil)
 
-	router := setupTestServer(dbInstance)
+	router := setupTestServer(store)
 
-	providerID := createTestProvider(t, dbInstance)
-	clientID := createTestClient(t, dbInstance)
+	providerID := createTestProvider(t, store)
+	clientID := createTestClient(t, store)
 
 	// Add availability and reserve an appointment
 	startTime := time.Now().Add(25 * time.Hour).Truncate(time.Minute)
 	slots := []time.Time{startTime}
-	addTestAvailability(t, dbInstance, providerID, slots)
-	appointment, err := dbInstance.ReserveAppointment(clientID, providerID, &startTime)
+	addTestAvailability(t, store, providerID, slots)
+	appointment, err := store.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &startTime, nil)
 	require.NoError(t, err)
 
 	req, err := http.NewRequest(http.MethodPost, "/appointments/"+appointment.Id.String()+"/confirm", nil)
@@ -335,36 +344,35 @@ func TestPostAppointmentsAppointmentIdConfirm(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "Appointment confirmed", response["message"])
 
-	// Verify that the appointment status is updated in the database
-	var status string
-	err = dbInstance.Conn.QueryRow(`
-        SELECT status FROM appointments WHERE id = $1
-    `, appointment.Id.String()).Scan(&status)
+	// Verify that the appointment is no longer a reservation but still active
+	err = store.ConfirmAppointment(context.Background(), *appointment.Id)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	expired, err := store.ExpireReservations(context.Background())
 	require.NoError(t, err)
-	require.Equal(t, "confirmed", status)
+	require.Zero(t, expired)
+	require.NoError(t, store.CancelAppointment(context.Background(), *appointment.Id))
 }
 
 func TestPostAppointments_LessThan24HoursInAdvance(t *testing.T) {
 	t.Parallel()
-	dbInstance := startTestDatabase(t)
-	defer dbInstance.Conn.Close()
+	store := memory.New(nil)
 
-	router := setupTestServer(dbInstance)
+	router := setupTestServer(store)
 
-	providerID := createTestProvider(t, dbInstance)
-	clientID := createTestClient(t, dbInstance)
+	providerID := createTestProvider(t, store)
+	clientID := createTestClient(t, store)
 
 	startTime := time.Now().Add(2 * time.Hour).Truncate(time.Minute)
 	slots := []time.Time{startTime}
-	addTestAvailability(t, dbInstance, providerID, slots)
+	addTestAvailability(t, store, providerID, slots)
 
-	appointments, err := dbInstance.GetAvailableAppointments(providerID, &types.Date{Time: startTime})
+	appointments, err := store.GetAvailableAppointments(context.Background(), providerID, nil, nil, &types.Date{Time: startTime})
 	require.NoError(t, err)
 	require.True(t, len(appointments) > 0)
 
 	// Prepare the request body
 	appointmentReq := schema.PostAppointmentsJSONRequestBody{
-		ClientId:       clientID,
+		ClientId:       *clientID,
 		ProviderId:     providerID,
 		AvailabilityId: appointments[0].Id,
 	}
@@ -380,33 +388,29 @@ func TestPostAppointments_LessThan24HoursInAdvance(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	require.Equal(t, http.StatusBadRequest, w.Code)
-	var response map[string]string
-	err = json.Unmarshal(w.Body.Bytes(), &response)
-	require.NoError(t, err)
-	require.Equal(t, "Reservations must be made at least 24 hours in advance", response["error"])
+	require.Equal(t, schema.ProblemCodeLeadTimeViolation, decodeProblem(t, w).Code)
 }
 
 func TestPostAppointments_SlotUnavailable(t *testing.T) {
 	t.Parallel()
-	dbInstance := startTestDatabase(t)
-	defer dbInstance.Conn.Close()
-	router := setupTestServer(dbInstance)
+	store := memory.New(nil)
+	router := setupTestServer(store)
 
-	providerID := createTestProvider(t, dbInstance)
-	clientID := createTestClient(t, dbInstance)
-	clientID2 := createTestClient(t, dbInstance) // Second client attempting to reserve
+	providerID := createTestProvider(t, store)
+	clientID := createTestClient(t, store)
+	clientID2 := createTestClient(t, store) // Second client attempting to reserve
 
 	startTime := time.Now().Add(25 * time.Hour).Truncate(time.Minute)
 	slots := []time.Time{startTime}
-	addTestAvailability(t, dbInstance, providerID, slots)
+	addTestAvailability(t, store, providerID, slots)
 
-	appointments, err := dbInstance.GetAvailableAppointments(providerID, &types.Date{Time: startTime})
+	appointments, err := store.GetAvailableAppointments(context.Background(), providerID, nil, nil, &types.Date{Time: startTime})
 	require.NoError(t, err)
 	require.True(t, len(appointments) > 0)
 
 	// Prepare the request body for the first reservation
 	appointmentReq := schema.PostAppointmentsJSONRequestBody{
-		ClientId:       clientID,
+		ClientId:       *clientID,
 		ProviderId:     providerID,
 		AvailabilityId: appointments[0].Id,
 	}
@@ -425,7 +429,7 @@ func TestPostAppointments_SlotUnavailable(t *testing.T) {
 
 	// Prepare the request body for the second reservation attempt
 	appointmentReq2 := schema.PostAppointmentsJSONRequestBody{
-		ClientId:       clientID2,
+		ClientId:       *clientID2,
 		ProviderId:     providerID,
 		AvailabilityId: appointments[0].Id,
 	}
@@ -442,17 +446,13 @@ func TestPostAppointments_SlotUnavailable(t *testing.T) {
 	router.ServeHTTP(w2, req2)
 
 	require.Equal(t, http.StatusConflict, w2.Code)
-	var response map[string]string
-	err = json.Unmarshal(w2.Body.Bytes(), &response)
-	require.NoError(t, err)
-	require.Equal(t, "Slot is not available", response["error"])
+	require.Equal(t, schema.ProblemCodeSlotUnavailable, decodeProblem(t, w2).Code)
 }
 
 func TestPostAppointmentsAppointmentIdConfirm_NonExistent(t *testing.T) {
 	t.Parallel()
-	dbInstance := startTestDatabase(t)
-	defer dbInstance.Conn.Close()
-	router := setupTestServer(dbInstance)
+	store := memory.New(nil)
+	router := setupTestServer(store)
 
 	// Generate a random appointment ID that doesn't exist
 	invalidAppointmentID := uuid.New()
@@ -464,26 +464,22 @@ func TestPostAppointmentsAppointmentIdConfirm_NonExistent(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	require.Equal(t, http.StatusNotFound, w.Code)
-	var response map[string]string
-	err = json.Unmarshal(w.Body.Bytes(), &response)
-	require.NoError(t, err)
-	require.Equal(t, "Appointment not found or may have expired", response["error"])
+	require.Equal(t, schema.ProblemCodeAppointmentNotFound, decodeProblem(t, w).Code)
 }
 
 func TestPostProvidersProviderIdAvailability_InvalidTimeRange(t *testing.T) {
 	t.Parallel()
-	dbInstance := startTestDatabase(t)
-	defer dbInstance.Conn.Close()
-	router := setupTestServer(dbInstance)
+	store := memory.New(nil)
+	router := setupTestServer(store)
 
-	providerID := createTestProvider(t, dbInstance)
+	providerID := createTestProvider(t, store)
 
 	// Prepare the request body with invalid time range (end time before start time)
 	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
 	endTime := startTime.Add(-2 * time.Hour) // End time is before start time
 	availability := schema.Availability{
-		StartTime: &startTime,
-		EndTime:   &endTime,
+		StartTime: startTime,
+		EndTime:   endTime,
 	}
 
 	reqBody, err := json.Marshal(availability)
@@ -497,46 +493,318 @@ func TestPostProvidersProviderIdAvailability_InvalidTimeRange(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	require.Equal(t, http.StatusBadRequest, w.Code)
-	var response map[string]string
-	err = json.Unmarshal(w.Body.Bytes(), &response)
-	require.NoError(t, err)
-	require.Equal(t, "End time must be after start time and at least 15 minutes apart", response["error"])
+	problem := decodeProblem(t, w)
+	require.Equal(t, schema.ProblemCodeValidationFailed, problem.Code)
+	require.Equal(t, []schema.FieldError{{Field: "end_time", Message: "must be at least 15 minutes after start_time"}}, *problem.Errors)
 }
 
 func TestConfirmAppointment_ExpiredReservation(t *testing.T) {
 	t.Parallel()
-	dbInstance := startTestDatabase(t)
-	defer dbInstance.Conn.Close()
-	router := setupTestServer(dbInstance)
+	clk := clock.NewFake(time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC))
+	store := memory.New(nil)
+	store.Clock = clk
+	router := setupTestServerWithClock(store, clk)
 
-	providerID := createTestProvider(t, dbInstance)
-	clientID := createTestClient(t, dbInstance)
+	providerID := createTestProvider(t, store)
+	clientID := createTestClient(t, store)
 
-	// Add availability and reserve an appointment
-	startTime := time.Now().Add(25 * time.Hour).Truncate(time.Minute)
-	slots := []time.Time{startTime}
-	addTestAvailability(t, dbInstance, providerID, slots)
-	appointment, err := dbInstance.ReserveAppointment(clientID, providerID, &startTime)
+	// Add availability and reserve two appointments
+	startTime := clk.Now().Add(25 * time.Hour)
+	slots := []time.Time{startTime, startTime.Add(db.GetAvailabilityInterval())}
+	addTestAvailability(t, store, providerID, slots)
+	held, err := store.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &slots[0], nil)
 	require.NoError(t, err)
+	expired, err := store.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &slots[1], nil)
+	require.NoError(t, err)
+
+	// A hold placed 29 minutes ago can still be confirmed
+	clk.Advance(29 * time.Minute)
+	w := confirmAppointment(t, router, held.Id)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// One placed 31 minutes ago cannot, and the client is told why
+	clk.Advance(2 * time.Minute)
+	w = confirmAppointment(t, router, expired.Id)
+	require.Equal(t, http.StatusGone, w.Code)
+	require.Equal(t, schema.ProblemCodeHoldExpired, decodeProblem(t, w).Code)
+}
 
-	// Simulate passage of time to expire the reservation
-	_, err = dbInstance.Conn.Exec(`
-        UPDATE appointments
-        SET created_at = created_at - INTERVAL '31 minutes'
-        WHERE id = $1
-    `, appointment.Id.String())
+func TestPostAppointments_LeadTime(t *testing.T) {
+	t.Parallel()
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		startTime time.Time
+		want      int
+	}{
+		{
+			name:      "exactly 24 hours",
+			now:       time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC),
+			startTime: time.Date(2030, time.January, 8, 9, 0, 0, 0, time.UTC),
+			want:      http.StatusCreated,
+		},
+		{
+			name:      "one minute short",
+			now:       time.Date(2030, time.January, 7, 9, 1, 0, 0, time.UTC),
+			startTime: time.Date(2030, time.January, 8, 9, 0, 0, 0, time.UTC),
+			want:      http.StatusBadRequest,
+		},
+		{
+			// Clocks spring forward overnight, so the same wall time tomorrow is only 23 hours away
+			name:      "same wall time across spring forward",
+			now:       time.Date(2030, time.March, 9, 9, 0, 0, 0, newYork),
+			startTime: time.Date(2030, time.March, 10, 9, 0, 0, 0, newYork),
+			want:      http.StatusBadRequest,
+		},
+		{
+			name:      "24 hours across spring forward",
+			now:       time.Date(2030, time.March, 9, 9, 0, 0, 0, newYork),
+			startTime: time.Date(2030, time.March, 10, 10, 0, 0, 0, newYork),
+			want:      http.StatusCreated,
+		},
+		{
+			// Clocks fall back overnight, so the same wall time tomorrow is 25 hours away
+			name:      "same wall time across fall back",
+			now:       time.Date(2030, time.November, 2, 9, 30, 0, 0, newYork),
+			startTime: time.Date(2030, time.November, 3, 9, 30, 0, 0, newYork),
+			want:      http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			clk := clock.NewFake(tt.now)
+			store := memory.New(nil)
+			store.Clock = clk
+			router := setupTestServerWithClock(store, clk)
+
+			providerID := createTestProvider(t, store)
+			clientID := createTestClient(t, store)
+			addTestAvailability(t, store, providerID, []time.Time{tt.startTime})
+			appointments, err := store.GetAvailableAppointments(context.Background(), providerID, nil, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, appointments, 1)
+
+			reqBody, err := json.Marshal(schema.PostAppointmentsJSONRequestBody{
+				ClientId:       *clientID,
+				ProviderId:     providerID,
+				AvailabilityId: appointments[0].Id,
+			})
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/appointments", bytes.NewBuffer(reqBody))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, tt.want, w.Code, w.Body.String())
+		})
+	}
+}
+
+func confirmAppointment(t *testing.T, router *gin.Engine, appointmentID *types.UUID) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(http.MethodPost, "/appointments/"+appointmentID.String()+"/confirm", nil)
 	require.NoError(t, err)
 
-	// Attempt to confirm the expired reservation
-	req, err := http.NewRequest(http.MethodPost, "/appointments/"+appointment.Id.String()+"/confirm", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPostProvidersProviderIdAvailability_GroupCapacity(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	router := setupTestServer(store)
+
+	providerID := createTestProvider(t, store)
+
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	// The start is rounded up to the next slot and the end is inclusive, so this offers two slots
+	endTime := startTime.Add(2 * db.GetAvailabilityInterval())
+	availability := schema.Availability{
+		StartTime: startTime,
+		EndTime:   endTime,
+		Capacity:  utils.Ptr(8),
+	}
+
+	reqBody, err := json.Marshal(availability)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, "/providers/"+providerID.String()+"/availability", bytes.NewBuffer(reqBody))
 	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
 
-	require.Equal(t, http.StatusNotFound, w.Code)
-	var response map[string]string
-	err = json.Unmarshal(w.Body.Bytes(), &response)
+	// A group slot stays listed until every seat is taken
+	appointments, err := store.GetAvailableAppointments(context.Background(), providerID, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, appointments, 2)
+	slotStart := *appointments[0].StartTime
+	_, err = store.ReserveAppointment(context.Background(), createTestClient(t, store), providerID, nil, nil, &slotStart, nil)
+	require.NoError(t, err)
+
+	req, err = http.NewRequest(http.MethodGet, "/appointments?providerId="+providerID.String(), nil)
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	err = json.Unmarshal(w.Body.Bytes(), &appointments)
+	require.NoError(t, err)
+	require.Len(t, appointments, 2)
+	require.Equal(t, 8, *appointments[0].Capacity)
+	require.Equal(t, 7, *appointments[0].SeatsRemaining)
+	require.Equal(t, 8, *appointments[1].SeatsRemaining)
+}
+
+func TestRespondWithError(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "info")
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(logging.RequestID())
+	server := &Server{Logger: logger}
+	router.GET("/fail", func(c *gin.Context) {
+		server.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to fetch user", errors.New("connection refused"))
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/fail", nil)
 	require.NoError(t, err)
-	require.Equal(t, "Appointment not found or may have expired", response["error"])
+	req.Header.Set(logging.RequestIDHeader, "req-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// The client only sees the sanitized problem
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	problem := decodeProblem(t, w)
+	require.Equal(t, schema.Problem{
+		Type:     "urn:reservation:problem:internal_error",
+		Title:    "The server failed to handle the request",
+		Status:   http.StatusInternalServerError,
+		Code:     schema.ProblemCodeInternalError,
+		Detail:   utils.Ptr("Failed to fetch user"),
+		Instance: utils.Ptr("/fail"),
+	}, problem)
+
+	// The logs carry the real error and the request id
+	var record map[string]any
+	err = json.Unmarshal(buf.Bytes(), &record)
+	require.NoError(t, err)
+	require.Equal(t, "ERROR", record["level"])
+	require.Equal(t, "connection refused", record["error"])
+	require.Equal(t, "req-1", record["request_id"])
+}
+
+func TestProblemDetails_InvalidInput(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	router := setupTestServer(store)
+	providerID := createTestProvider(t, store)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		code   schema.ProblemCode
+		fields []schema.FieldError
+	}{
+		{
+			name:   "malformed path parameter",
+			method: http.MethodGet,
+			path:   "/users/not-a-uuid",
+			code:   schema.ProblemCodeValidationFailed,
+			fields: []schema.FieldError{{Field: "userId", Message: `string doesn't match the format "uuid" (not a uuid)`}},
+		},
+		{
+			name:   "malformed body",
+			method: http.MethodPost,
+			path:   "/users",
+			body:   `{"name":`,
+			code:   schema.ProblemCodeInvalidRequest,
+		},
+		{
+			name:   "field of the wrong type",
+			method: http.MethodPost,
+			path:   "/providers/" + providerID.String() + "/availability",
+			body:   `{"start_time":"2030-01-09T09:00:00Z","end_time":"2030-01-09T10:00:00Z","capacity":"two"}`,
+			code:   schema.ProblemCodeValidationFailed,
+			fields: []schema.FieldError{{Field: "capacity", Message: "value must be an integer"}},
+		},
+		{
+			name:   "missing fields",
+			method: http.MethodPost,
+			path:   "/providers/" + providerID.String() + "/availability",
+			body:   `{}`,
+			code:   schema.ProblemCodeValidationFailed,
+			fields: []schema.FieldError{{Field: "start_time", Message: "is required"}, {Field: "end_time", Message: "is required"}},
+		},
+		{
+			name:   "missing reservation ids",
+			method: http.MethodPost,
+			path:   "/appointments",
+			body:   `{"client_id":"` + providerID.String() + `"}`,
+			code:   schema.ProblemCodeValidationFailed,
+			fields: []schema.FieldError{{Field: "provider_id", Message: "is required"}, {Field: "availability_id", Message: "is required"}},
+		},
+		{
+			name:   "malformed email",
+			method: http.MethodPost,
+			path:   "/users",
+			body:   `{"name":"Pat","email":"not-an-email","role":"client"}`,
+			code:   schema.ProblemCodeValidationFailed,
+			fields: []schema.FieldError{{Field: "email", Message: `string doesn't match the format "email" (not an email address)`}},
+		},
+		{
+			name:   "empty name and unknown role",
+			method: http.MethodPost,
+			path:   "/users",
+			body:   `{"name":"","email":"pat@example.com","role":"admin"}`,
+			code:   schema.ProblemCodeValidationFailed,
+			fields: []schema.FieldError{
+				{Field: "name", Message: "minimum string length is 1"},
+				{Field: "role", Message: `value is not one of the allowed values ["provider","client"]`},
+			},
+		},
+		{
+			name:   "malformed query parameter",
+			method: http.MethodGet,
+			path:   "/appointments?providerId=42",
+			code:   schema.ProblemCodeValidationFailed,
+			fields: []schema.FieldError{{Field: "providerId", Message: `string doesn't match the format "uuid" (not a uuid)`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req, err := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusBadRequest, w.Code)
+			problem := decodeProblem(t, w)
+			require.Equal(t, tt.code, problem.Code)
+			require.Equal(t, req.URL.Path, *problem.Instance)
+			if tt.fields != nil {
+				require.Equal(t, tt.fields, *problem.Errors)
+			}
+		})
+	}
 }
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tateexon/reservation/migrations"
+	"github.com/tateexon/reservation/schema"
+)
+
+// Healthz reports that the process is alive, it does not check dependencies so a database outage does
+// not get every replica restarted
+func (s *Server) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+// Readyz reports whether the server should receive traffic: it is not shutting down, the database is
+// reachable and its schema is at the newest migration this build knows about
+func (s *Server) Readyz(c *gin.Context) {
+	if s.draining.Load() {
+		s.respondWithError(c, http.StatusServiceUnavailable, schema.ProblemCodeServiceUnavailable, "Server is shutting down", nil)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := s.DB.Ping(ctx); err != nil {
+		s.respondWithError(c, http.StatusServiceUnavailable, schema.ProblemCodeServiceUnavailable, "Database is unreachable", err)
+		return
+	}
+
+	latest, err := migrations.Latest()
+	if err != nil {
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to read migrations", err)
+		return
+	}
+	version, err := s.DB.SchemaVersion(ctx)
+	if err != nil {
+		s.respondWithError(c, http.StatusServiceUnavailable, schema.ProblemCodeServiceUnavailable, "Failed to read the schema version", err)
+		return
+	}
+	// A newer schema is fine, during a rolling deploy the new replicas migrate before the old ones stop
+	if version < latest {
+		err := fmt.Errorf("database is at version %d, expected at least %d", version, latest)
+		s.respondWithError(c, http.StatusServiceUnavailable, schema.ProblemCodeServiceUnavailable, "Database migrations are not current", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ready"})
+}
+
+// StartDraining makes Readyz fail so load balancers stop sending new requests before the server shuts down
+func (s *Server) StartDraining() {
+	s.draining.Store(true)
+}
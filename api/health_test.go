@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/migrations"
+)
+
+// versionedStore reports a fixed schema version
+type versionedStore struct {
+	db.Store
+	version int
+}
+
+func (v versionedStore) SchemaVersion(context.Context) (int, error) {
+	return v.version, nil
+}
+
+func setupHealthRouter(server *Server) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/healthz", server.Healthz)
+	router.GET("/readyz", server.Readyz)
+	return router
+}
+
+func TestHealthz(t *testing.T) {
+	t.Parallel()
+
+	router := setupHealthRouter(&Server{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestReadyz(t *testing.T) {
+	t.Parallel()
+	dbInstance := startTestDatabase(t)
+	defer dbInstance.Conn.Close()
+
+	server := &Server{DB: dbInstance}
+	router := setupHealthRouter(server)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// A schema behind the code is not ready
+	_, err := dbInstance.Conn.Exec(`DELETE FROM schema_migrations WHERE version = (SELECT MAX(version) FROM schema_migrations)`)
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestReadyz_SchemaVersion(t *testing.T) {
+	t.Parallel()
+	latest, err := migrations.Latest()
+	require.NoError(t, err)
+
+	ready := func(version int) int {
+		router := setupHealthRouter(&Server{DB: versionedStore{Store: memory.New(nil), version: version}})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusOK, ready(latest))
+	// Replicas of the previous release stay ready once a newer one has migrated
+	require.Equal(t, http.StatusOK, ready(latest+1))
+	require.Equal(t, http.StatusServiceUnavailable, ready(latest-1))
+}
+
+func TestReadyz_Draining(t *testing.T) {
+	t.Parallel()
+
+	// Draining is checked before the database so none is needed
+	server := &Server{}
+	server.StartDraining()
+	router := setupHealthRouter(server)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/metrics"
+	"github.com/tateexon/reservation/schema"
+)
+
+func (s *Server) GetAppointmentTypes(c *gin.Context) {
+	appointmentTypes, err := s.DB.ListAppointmentTypes(c.Request.Context())
+	if err != nil {
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to fetch appointment types", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, appointmentTypes)
+}
+
+func (s *Server) PostAppointmentTypes(c *gin.Context) {
+	var req schema.CreateAppointmentTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithBindError(c, err)
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		s.respondWithValidationError(c, "Name is required", schema.FieldError{Field: "name", Message: "is required"})
+		return
+	}
+	var questions []schema.IntakeQuestion
+	if req.IntakeQuestions != nil {
+		questions = *req.IntakeQuestions
+	}
+	if invalid := questionErrors(questions); len(invalid) > 0 {
+		s.respondWithValidationError(c, "Intake questions need distinct keys, and choice questions and only they need options", invalid...)
+		return
+	}
+
+	appointmentType, err := s.DB.CreateAppointmentType(c.Request.Context(), name, questions)
+	if err != nil {
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to create appointment type", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, appointmentType)
+}
+
+// questionErrors lists what is wrong with the intake questions of an appointment type that the spec can not
+// tell
+func questionErrors(questions []schema.IntakeQuestion) []schema.FieldError {
+	var invalid []schema.FieldError
+	keys := map[string]bool{}
+	for i, question := range questions {
+		field := fmt.Sprintf("intake_questions[%d]", i)
+		if keys[question.Key] {
+			invalid = append(invalid, schema.FieldError{Field: field + ".key", Message: "is already the key of another question"})
+		}
+		keys[question.Key] = true
+		if strings.TrimSpace(question.Label) == "" {
+			invalid = append(invalid, schema.FieldError{Field: field + ".label", Message: "is required"})
+		}
+		hasOptions := question.Options != nil && len(*question.Options) > 0
+		switch {
+		case question.Type == schema.Choice && !hasOptions:
+			invalid = append(invalid, schema.FieldError{Field: field + ".options", Message: "are required for a choice question"})
+		case question.Type != schema.Choice && question.Options != nil:
+			invalid = append(invalid, schema.FieldError{Field: field + ".options", Message: "are only for choice questions"})
+		}
+	}
+	return invalid
+}
+
+// intakeOf is what the client told about the appointment in a booking request, nil if they told nothing
+func intakeOf(req schema.PostAppointmentsJSONRequestBody) *db.Intake {
+	if req.Reason == nil && req.AppointmentTypeId == nil && req.IntakeAnswers == nil {
+		return nil
+	}
+	intake := &db.Intake{AppointmentTypeID: req.AppointmentTypeId}
+	if req.Reason != nil {
+		intake.Reason = strings.TrimSpace(*req.Reason)
+	}
+	if req.IntakeAnswers != nil {
+		intake.Answers = *req.IntakeAnswers
+	}
+	return intake
+}
+
+// respondWithIntakeError answers a booking that failed because of its intake, reporting whether err was
+// such a failure. It has to be checked before sql.ErrNoRows, which a missing appointment type also is.
+func (s *Server) respondWithIntakeError(c *gin.Context, err error) bool {
+	var intakeErr *db.IntakeError
+	switch {
+	case errors.Is(err, db.ErrAppointmentTypeNotFound):
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedUnknownAppointmentType).Inc()
+		s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeAppointmentTypeNotFound, "Appointment type not found", nil)
+	case errors.As(err, &intakeErr):
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedInvalidRequest).Inc()
+		s.respondWithValidationError(c, "The intake answers do not fit the questions of the appointment type", intakeErr.Errors...)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/clock"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/schema"
+)
+
+func TestAppointmentIntake(t *testing.T) {
+	t.Parallel()
+	clk := clock.NewFake(time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC))
+	store := memory.New(nil)
+	store.Clock = clk
+	router := setupTestServerWithClock(store, clk)
+
+	w := serve(t, router, http.MethodPost, "/appointment-types", `{"name":" ","intake_questions":[
+		{"key":"visit","label":"Visit","type":"choice"},
+		{"key":"visit","label":" ","type":"text","options":["a"]},
+		{"key":"Bad Key","label":"Bad","type":"text"}
+	]}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	w = serve(t, router, http.MethodPost, "/appointment-types", `{"name":"Checkup","intake_questions":[
+		{"key":"visit","label":"Visit","type":"choice"},
+		{"key":"visit","label":" ","type":"text","options":["a"]}
+	]}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, []schema.FieldError{
+		{Field: "intake_questions[0].options", Message: "are required for a choice question"},
+		{Field: "intake_questions[1].key", Message: "is already the key of another question"},
+		{Field: "intake_questions[1].label", Message: "is required"},
+		{Field: "intake_questions[1].options", Message: "are only for choice questions"},
+	}, *decodeProblem(t, w).Errors)
+
+	w = serve(t, router, http.MethodPost, "/appointment-types", `{"name":" Checkup ","intake_questions":[
+		{"key":"symptoms","label":"What brings you in?","type":"text","required":true},
+		{"key":"visit","label":"Visit","type":"choice","options":["in person","video"]}
+	]}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var checkup schema.AppointmentType
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &checkup))
+	require.Equal(t, "Checkup", *checkup.Name)
+
+	w = serve(t, router, http.MethodGet, "/appointment-types", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var appointmentTypes []schema.AppointmentType
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &appointmentTypes))
+	require.Equal(t, []schema.AppointmentType{checkup}, appointmentTypes)
+
+	providerID := createTestProvider(t, store)
+	clientID := createTestClient(t, store)
+	startTime := clk.Now().Add(25 * time.Hour)
+	addTestAvailability(t, store, providerID, []time.Time{startTime})
+	var slots []schema.Appointment
+	w = serve(t, router, http.MethodGet, "/appointments?providerId="+providerID.String(), "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &slots))
+	book := func(intake string) *httptest.ResponseRecorder {
+		return serve(t, router, http.MethodPost, "/appointments", fmt.Sprintf(
+			`{"client_id":%q,"provider_id":%q,"availability_id":%q%s}`, clientID, providerID, slots[0].Id, intake))
+	}
+
+	w = book(fmt.Sprintf(`,"appointment_type_id":%q`, uuid.New()))
+	require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	require.Equal(t, schema.ProblemCodeAppointmentTypeNotFound, decodeProblem(t, w).Code)
+	w = book(fmt.Sprintf(`,"appointment_type_id":%q,"intake_answers":{"visit":"phone"}`, checkup.Id))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, []schema.FieldError{
+		{Field: "intake_answers.symptoms", Message: "is required"},
+		{Field: "intake_answers.visit", Message: "must be one of in person, video"},
+	}, *decodeProblem(t, w).Errors)
+
+	w = book(fmt.Sprintf(`,"reason":" Persistent cough ","appointment_type_id":%q,"intake_answers":{"symptoms":"Cough","visit":"video"}`, checkup.Id))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var appointment schema.Appointment
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &appointment))
+	require.Equal(t, "Persistent cough", *appointment.Reason)
+	require.Equal(t, *checkup.Id, *appointment.AppointmentTypeId)
+	require.Equal(t, map[string]interface{}{"symptoms": "Cough", "visit": "video"}, *appointment.IntakeAnswers)
+}
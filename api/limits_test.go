@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+func TestClientLimits(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	router := setupTestServer(store)
+
+	clinic, err := store.CreateOrganization(context.Background(), db.NewOrganization{
+		Name:      "Busy Clinic",
+		Slug:      "busy",
+		TokenHash: db.HashToken("busy-token"),
+		Settings:  schema.OrganizationSettings{MaxActiveHolds: utils.Ptr(2)},
+	})
+	require.NoError(t, err)
+	ctx := db.WithOrganization(context.Background(), *clinic)
+
+	client, err := store.CreateUser(ctx, "Pat", "pat@example.com", "client")
+	require.NoError(t, err)
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Hour).UTC()
+	slotOf := func(email string, startTime time.Time) (*types.UUID, *types.UUID) {
+		provider, err := store.CreateUser(ctx, "Dr "+email, email, "provider")
+		require.NoError(t, err)
+		require.NoError(t, store.AddAvailability(ctx, *provider.Id, nil, []time.Time{startTime}, 1))
+		slots, err := store.GetAvailableAppointments(ctx, provider.Id, nil, nil, nil)
+		require.NoError(t, err)
+		return provider.Id, slots[0].Id
+	}
+	var booked []types.UUID
+	book := func(providerID, slotID *types.UUID) (int, *schema.Problem) {
+		body, err := json.Marshal(schema.PostAppointmentsJSONRequestBody{ClientId: *client.Id, ProviderId: providerID, AvailabilityId: slotID})
+		require.NoError(t, err)
+		w := serveAs(t, router, "", "busy-token", http.MethodPost, "/appointments", string(body))
+		if w.Code == http.StatusCreated {
+			var appointment schema.Appointment
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &appointment))
+			booked = append(booked, *appointment.Id)
+			return w.Code, nil
+		}
+		problem := decodeProblem(t, w)
+		return w.Code, &problem
+	}
+
+	smith, slotID := slotOf("smith@example.com", startTime)
+	code, _ := book(smith, slotID)
+	require.Equal(t, http.StatusCreated, code)
+	code, problem := book(slotOf("jones@example.com", startTime))
+	require.Equal(t, http.StatusConflict, code)
+	require.Equal(t, schema.ProblemCodeClientDoubleBooked, problem.Code)
+
+	code, _ = book(slotOf("lee@example.com", startTime.Add(time.Hour)))
+	require.Equal(t, http.StatusCreated, code)
+	code, problem = book(slotOf("kim@example.com", startTime.Add(2*time.Hour)))
+	require.Equal(t, http.StatusConflict, code)
+	require.Equal(t, schema.ProblemCodeHoldLimitReached, problem.Code)
+	require.Contains(t, *problem.Detail, "at most 2 unconfirmed reservations")
+
+	// Every occurrence of a series counts against the limits
+	park, slotID := slotOf("park@example.com", startTime.Add(3*time.Hour))
+	require.NoError(t, store.AddAvailability(ctx, *park, nil, []time.Time{startTime.Add(3*time.Hour + 7*24*time.Hour)}, 1))
+	body, err := json.Marshal(schema.PostAppointmentsJSONRequestBody{
+		ClientId:       *client.Id,
+		ProviderId:     park,
+		AvailabilityId: slotID,
+		Recurrence:     &schema.Recurrence{Frequency: schema.Weekly, Count: 2},
+	})
+	require.NoError(t, err)
+	w := serveAs(t, router, "", "busy-token", http.MethodPost, "/appointments", string(body))
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, schema.ProblemCodeHoldLimitReached, decodeProblem(t, w).Code)
+
+	// A rescheduled appointment can not overlap another one of the client
+	require.NoError(t, store.AddAvailability(ctx, *smith, nil, []time.Time{startTime.Add(time.Hour)}, 1))
+	slots, err := store.GetAvailableAppointments(ctx, smith, nil, nil, nil)
+	require.NoError(t, err)
+	w = serveAs(t, router, "", "busy-token", http.MethodPost, "/appointments/"+booked[0].String()+"/reschedule",
+		`{"availability_id":"`+slots[0].Id.String()+`"}`)
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, schema.ProblemCodeClientDoubleBooked, decodeProblem(t, w).Code)
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/metrics"
+	"github.com/tateexon/reservation/schema"
+)
+
+func (s *Server) GetLocations(c *gin.Context) {
+	locations, err := s.DB.ListLocations(c.Request.Context())
+	if err != nil {
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to fetch locations", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, locations)
+}
+
+func (s *Server) PostLocations(c *gin.Context) {
+	var req schema.CreateLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithBindError(c, err)
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		s.respondWithValidationError(c, "Name is required", schema.FieldError{Field: "name", Message: "is required"})
+		return
+	}
+	var address string
+	if req.Address != nil {
+		address = strings.TrimSpace(*req.Address)
+	}
+
+	location, err := s.DB.CreateLocation(c.Request.Context(), name, address)
+	if err != nil {
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to create location", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, location)
+}
+
+//nolint:revive
+func (s *Server) GetLocationsLocationIdRooms(c *gin.Context, locationId openapi_types.UUID) {
+	rooms, err := s.DB.ListRooms(c.Request.Context(), locationId)
+	if err != nil {
+		if errors.Is(err, db.ErrLocationNotFound) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeLocationNotFound, "Location not found", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to fetch rooms", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rooms)
+}
+
+//nolint:revive
+func (s *Server) PostLocationsLocationIdRooms(c *gin.Context, locationId openapi_types.UUID) {
+	var req schema.CreateRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithBindError(c, err)
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		s.respondWithValidationError(c, "Name is required", schema.FieldError{Field: "name", Message: "is required"})
+		return
+	}
+
+	room, err := s.DB.CreateRoom(c.Request.Context(), locationId, name)
+	if err != nil {
+		if errors.Is(err, db.ErrLocationNotFound) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeLocationNotFound, "Location not found", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to create room", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, room)
+}
+
+// GetRoomsRoomIdAppointments returns a room's calendar: the appointments holding it
+//
+//nolint:revive
+func (s *Server) GetRoomsRoomIdAppointments(c *gin.Context, roomId openapi_types.UUID, params schema.GetRoomsRoomIdAppointmentsParams) {
+	appointments, err := s.DB.GetRoomAppointments(c.Request.Context(), roomId, params.Date)
+	if err != nil {
+		if errors.Is(err, db.ErrRoomNotFound) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeRoomNotFound, "Room not found", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to fetch room appointments", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, appointments)
+}
+
+// respondWithRoomError answers a booking that failed because of the room it asked for, reporting whether
+// err was such a failure. It has to be checked before sql.ErrNoRows, which a missing room also is.
+func (s *Server) respondWithRoomError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, db.ErrRoomNotFound):
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedUnknownRoom).Inc()
+		s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeRoomNotFound, "Room not found", nil)
+	case errors.Is(err, db.ErrRoomElsewhere):
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedInvalidRequest).Inc()
+		s.respondWithValidationError(c, "Room is not at the location of the slot",
+			schema.FieldError{Field: "room_id", Message: "must be a room at the location of the slot"})
+	default:
+		return false
+	}
+	return true
+}
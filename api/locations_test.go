@@ -0,0 +1,116 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/schema"
+)
+
+func TestLocationsAndRooms(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	router := setupTestServer(store)
+
+	w := serve(t, router, http.MethodPost, "/locations", `{"name":" Northside ","address":"1 North St"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var location schema.Location
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &location))
+	require.Equal(t, "Northside", *location.Name)
+	w = serve(t, router, http.MethodPost, "/locations", `{"name":"  "}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, schema.ProblemCodeValidationFailed, decodeProblem(t, w).Code)
+
+	w = serve(t, router, http.MethodGet, "/locations", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var locations []schema.Location
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &locations))
+	require.Len(t, locations, 1)
+
+	roomsPath := "/locations/" + location.Id.String() + "/rooms"
+	w = serve(t, router, http.MethodPost, roomsPath, `{"name":"Procedure room"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var room schema.Room
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &room))
+	w = serve(t, router, http.MethodGet, roomsPath, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	w = serve(t, router, http.MethodPost, "/locations/"+uuid.NewString()+"/rooms", `{"name":"Procedure room"}`)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, schema.ProblemCodeLocationNotFound, decodeProblem(t, w).Code)
+
+	// Availability is tied to the location, slots can be filtered by it
+	smith := createTestProvider(t, store)
+	jones := createTestProvider(t, store)
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Hour).UTC()
+	endTime := startTime.Add(2 * db.GetAvailabilityInterval())
+	availability := fmt.Sprintf(`{"start_time":%q,"end_time":%q,"location_id":%q}`,
+		startTime.Format(time.RFC3339), endTime.Format(time.RFC3339), location.Id.String())
+	for _, provider := range []string{smith.String(), jones.String()} {
+		w = serve(t, router, http.MethodPost, "/providers/"+provider+"/availability", availability)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+	w = serve(t, router, http.MethodPost, "/providers/"+smith.String()+"/availability",
+		fmt.Sprintf(`{"start_time":%q,"end_time":%q,"location_id":%q}`, startTime.Format(time.RFC3339), endTime.Format(time.RFC3339), uuid.NewString()))
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, schema.ProblemCodeLocationNotFound, decodeProblem(t, w).Code)
+
+	w = serve(t, router, http.MethodGet, "/appointments?locationId="+location.Id.String(), "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var slots []schema.Appointment
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &slots))
+	require.Len(t, slots, 4)
+	w = serve(t, router, http.MethodGet, "/appointments?locationId="+uuid.NewString(), "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `[]`, w.Body.String())
+
+	slotOf := func(provider uuid.UUID) uuid.UUID {
+		for _, slot := range slots {
+			if *slot.ProviderId == provider {
+				return *slot.Id
+			}
+		}
+		t.Fatalf("no slot for provider %s", provider)
+		return uuid.Nil
+	}
+	book := func(provider, roomID uuid.UUID) *httptest.ResponseRecorder {
+		slot := slotOf(provider)
+		body, err := json.Marshal(schema.PostAppointmentsJSONRequestBody{
+			ClientId:       *createTestClient(t, store),
+			ProviderId:     &provider,
+			AvailabilityId: &slot,
+			RoomId:         &roomID,
+		})
+		require.NoError(t, err)
+		return serve(t, router, http.MethodPost, "/appointments", string(body))
+	}
+
+	// The provider and room are claimed together
+	w = book(*smith, *room.Id)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var appointment schema.Appointment
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &appointment))
+	require.Equal(t, *room.Id, *appointment.RoomId)
+	require.Equal(t, *location.Id, *appointment.LocationId)
+
+	w = book(*jones, *room.Id)
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, schema.ProblemCodeRoomUnavailable, decodeProblem(t, w).Code)
+	w = book(*jones, uuid.New())
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, schema.ProblemCodeRoomNotFound, decodeProblem(t, w).Code)
+
+	w = serve(t, router, http.MethodGet, "/rooms/"+room.Id.String()+"/appointments?date="+startTime.Format(time.DateOnly), "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var calendar []schema.Appointment
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &calendar))
+	require.Len(t, calendar, 1)
+	require.Equal(t, *appointment.Id, *calendar[0].Id)
+}
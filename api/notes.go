@@ -0,0 +1,65 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+)
+
+// GetAppointmentsAppointmentIdNotes lists an appointment's notes to holders of the organization's token,
+// there are no user accounts to keep them private to
+//
+//nolint:revive
+func (s *Server) GetAppointmentsAppointmentIdNotes(c *gin.Context, appointmentId openapi_types.UUID) {
+	if !s.requireToken(c) {
+		return
+	}
+	notes, err := s.DB.ListAppointmentNotes(c.Request.Context(), appointmentId)
+	if err != nil {
+		s.respondWithNoteError(c, err, "Failed to fetch appointment notes")
+		return
+	}
+
+	c.JSON(http.StatusOK, notes)
+}
+
+// PutAppointmentsAppointmentIdNotes saves a new version of an appointment's notes for holders of the
+// organization's token, author_id attributes the version and is not authenticated.
+//
+//nolint:revive
+func (s *Server) PutAppointmentsAppointmentIdNotes(c *gin.Context, appointmentId openapi_types.UUID) {
+	if !s.requireToken(c) {
+		return
+	}
+	var req schema.SaveAppointmentNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithBindError(c, err)
+		return
+	}
+
+	note, err := s.DB.SaveAppointmentNote(c.Request.Context(), appointmentId, req.AuthorId, req.Body)
+	if err != nil {
+		s.respondWithNoteError(c, err, "Failed to save appointment notes")
+		return
+	}
+
+	c.JSON(http.StatusOK, note)
+}
+
+// respondWithNoteError answers a request for the notes of an appointment that failed with err
+func (s *Server) respondWithNoteError(c *gin.Context, err error, failure string) {
+	switch {
+	case errors.Is(err, db.ErrNotAppointmentProvider):
+		s.respondWithValidationError(c, "Notes are written by the appointment's providers",
+			schema.FieldError{Field: "author_id", Message: "must be the provider or a participant of the appointment"})
+	case errors.Is(err, sql.ErrNoRows):
+		s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeAppointmentNotFound, "Appointment not found", nil)
+	default:
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, failure, err)
+	}
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/schema"
+)
+
+func TestAppointmentNotes(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	router := setupTestServer(store)
+
+	clinic, err := store.CreateOrganization(context.Background(), db.NewOrganization{
+		Name:      "Northside Clinic",
+		Slug:      "northside",
+		Hosts:     []string{"northside.example.com"},
+		TokenHash: db.HashToken("northside-token"),
+	})
+	require.NoError(t, err)
+	ctx := db.WithOrganization(context.Background(), *clinic)
+	provider, err := store.CreateUser(ctx, "Test provider", "provider@example.com", "provider")
+	require.NoError(t, err)
+	client, err := store.CreateUser(ctx, "Test client", "client@example.com", "client")
+	require.NoError(t, err)
+	providerID, clientID := provider.Id, client.Id
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	require.NoError(t, store.AddAvailability(ctx, *providerID, nil, []time.Time{startTime}, 1))
+	appointment, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+	notesPath := "/appointments/" + appointment.Id.String() + "/notes"
+
+	save := func(authorID *types.UUID, body string) (int, schema.AppointmentNote) {
+		w := serveAs(t, router, "northside.example.com", "northside-token", http.MethodPut, notesPath, fmt.Sprintf(`{"author_id":%q,"body":%q}`, authorID, body))
+		var note schema.AppointmentNote
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &note))
+		}
+		return w.Code, note
+	}
+
+	code, note := save(providerID, "Bring previous x-rays")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, 1, *note.Version)
+	code, note = save(providerID, "Bring previous x-rays and blood work")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, 2, *note.Version)
+
+	w := serveAs(t, router, "northside.example.com", "northside-token", http.MethodGet, notesPath, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var notes []schema.AppointmentNote
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &notes))
+	require.Len(t, notes, 2)
+	require.Equal(t, "Bring previous x-rays and blood work", *notes[0].Body)
+
+	// Notes are attributed to one of the appointment's providers
+	w = serveAs(t, router, "northside.example.com", "northside-token", http.MethodPut, notesPath, fmt.Sprintf(`{"author_id":%q,"body":"Please be gentle"}`, clientID))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	problem := decodeProblem(t, w)
+	require.Equal(t, schema.ProblemCodeValidationFailed, problem.Code)
+	require.Equal(t, "author_id", (*problem.Errors)[0].Field)
+
+	w = serveAs(t, router, "northside.example.com", "northside-token", http.MethodGet, "/appointments/"+uuid.New().String()+"/notes", "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, schema.ProblemCodeAppointmentNotFound, decodeProblem(t, w).Code)
+
+	// Picking the organization by its host is not enough to read or write notes
+	w = serveAs(t, router, "northside.example.com", "", http.MethodGet, notesPath, "")
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Equal(t, schema.ProblemCodeTokenRequired, decodeProblem(t, w).Code)
+	require.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+	w = serveAs(t, router, "northside.example.com", "", http.MethodPut, notesPath, fmt.Sprintf(`{"author_id":%q,"body":"Overwritten"}`, providerID))
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Equal(t, schema.ProblemCodeTokenRequired, decodeProblem(t, w).Code)
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+)
+
+// GetOrganization returns the organization the request resolved to, its token is never shown
+func (s *Server) GetOrganization(c *gin.Context) {
+	org, ok := db.OrganizationFrom(c.Request.Context())
+	if !ok {
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Request has no organization", nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, org)
+}
+
+// tokenAuthenticated is set on requests whose organization was resolved from their api token
+const tokenAuthenticated = "tokenAuthenticated"
+
+// resolveOrganization scopes every request to an organization: the one whose api token it carries, or
+// else the one serving its host, or else the default organization. A token that matches no organization
+// is rejected rather than falling back, so a mistyped token never reads another organization's data.
+func (s *Server) resolveOrganization(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var org *schema.Organization
+	var err error
+	if header := c.GetHeader("Authorization"); header != "" {
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || strings.TrimSpace(token) == "" {
+			s.respondWithInvalidToken(c, "Authorization must be a bearer token")
+			return
+		}
+		org, err = s.DB.OrganizationByToken(ctx, db.HashToken(strings.TrimSpace(token)))
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithInvalidToken(c, "The bearer token does not belong to any organization")
+			return
+		}
+		c.Set(tokenAuthenticated, true)
+	} else {
+		org, err = s.DB.OrganizationByHost(ctx, requestHost(c.Request))
+		if errors.Is(err, sql.ErrNoRows) {
+			org, err = s.DB.GetOrganization(ctx, db.DefaultOrganizationID)
+		}
+	}
+	if err != nil {
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to resolve organization", err)
+		c.Abort()
+		return
+	}
+
+	c.Request = c.Request.WithContext(db.WithOrganization(ctx, *org))
+	c.Next()
+}
+
+func (s *Server) respondWithInvalidToken(c *gin.Context, detail string) {
+	c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+	s.respondWithError(c, http.StatusUnauthorized, schema.ProblemCodeInvalidToken, detail, nil)
+	c.Abort()
+}
+
+// requireToken answers a request whose organization was not resolved from its api token and reports
+// whether it had one. Anyone who can reach the server can pick an organization by its host.
+func (s *Server) requireToken(c *gin.Context) bool {
+	if c.GetBool(tokenAuthenticated) {
+		return true
+	}
+	c.Header("WWW-Authenticate", "Bearer")
+	s.respondWithError(c, http.StatusUnauthorized, schema.ProblemCodeTokenRequired, "This request needs the organization's api token", nil)
+	return false
+}
+
+// requestHost is the host a request was sent to, without the port and lowercased like stored hosts
+func requestHost(r *http.Request) string {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(host)
+}
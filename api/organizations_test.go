@@ -0,0 +1,162 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/clock"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// serveAs sends a json request to router for host, with token as its bearer token if it is not empty
+func serveAs(t *testing.T, router *gin.Engine, host, token, method, path, body string) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	req.Host = host
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestResolveOrganization(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	router := setupTestServer(store)
+
+	clinic, err := store.CreateOrganization(context.Background(), db.NewOrganization{
+		Name:      "Northside Clinic",
+		Slug:      "northside",
+		Hosts:     []string{"northside.example.com"},
+		TokenHash: db.HashToken("northside-token"),
+	})
+	require.NoError(t, err)
+
+	organization := func(w *httptest.ResponseRecorder) schema.Organization {
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var org schema.Organization
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &org))
+		return org
+	}
+
+	org := organization(serveAs(t, router, "api.example.com", "northside-token", http.MethodGet, "/organization", ""))
+	require.Equal(t, *clinic.Id, *org.Id)
+
+	// The port and case of the host do not matter
+	org = organization(serveAs(t, router, "Northside.example.com:8080", "", http.MethodGet, "/organization", ""))
+	require.Equal(t, *clinic.Id, *org.Id)
+
+	org = organization(serveAs(t, router, "api.example.com", "", http.MethodGet, "/organization", ""))
+	require.Equal(t, db.DefaultOrganizationID, *org.Id)
+
+	// A bad token is refused rather than falling back to the host
+	w := serveAs(t, router, "northside.example.com", "wrong-token", http.MethodGet, "/organization", "")
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Equal(t, schema.ProblemCodeInvalidToken, decodeProblem(t, w).Code)
+	require.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+
+	req, err := http.NewRequest(http.MethodGet, "/organization", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Equal(t, schema.ProblemCodeInvalidToken, decodeProblem(t, w).Code)
+}
+
+func TestOrganizations_Isolated(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	router := setupTestServer(store)
+
+	_, err := store.CreateOrganization(context.Background(), db.NewOrganization{Name: "Northside Clinic", Slug: "northside", TokenHash: db.HashToken("northside-token")})
+	require.NoError(t, err)
+	_, err = store.CreateOrganization(context.Background(), db.NewOrganization{Name: "Southside Clinic", Slug: "southside", TokenHash: db.HashToken("southside-token")})
+	require.NoError(t, err)
+
+	w := serveAs(t, router, "", "northside-token", http.MethodPost, "/users", `{"name":"Dr Smith","email":"smith@example.com","role":"provider"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var provider schema.User
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &provider))
+	path := "/users/" + provider.Id.String()
+
+	w = serveAs(t, router, "", "northside-token", http.MethodGet, path, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	w = serveAs(t, router, "", "southside-token", http.MethodGet, path, "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	w = serveAs(t, router, "", "", http.MethodGet, path, "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// The same email can be used at another clinic
+	w = serveAs(t, router, "", "southside-token", http.MethodPost, "/users", `{"name":"Dr Smith","email":"smith@example.com","role":"provider"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	// Another clinic can not give the provider slots
+	w = serveAs(t, router, "", "southside-token", http.MethodPost, "/providers/"+provider.Id.String()+"/availability", `{"start_time":"2030-01-09T09:00:00Z","end_time":"2030-01-09T10:00:00Z"}`)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, schema.ProblemCodeProviderNotFound, decodeProblem(t, w).Code)
+}
+
+func TestOrganizations_Settings(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(now)
+	store := memory.New(nil)
+	store.Clock = clk
+	router := setupTestServerWithClock(store, clk)
+
+	clinic, err := store.CreateOrganization(context.Background(), db.NewOrganization{
+		Name:      "Walk In Clinic",
+		Slug:      "walk-in",
+		TokenHash: db.HashToken("walk-in-token"),
+		Settings: schema.OrganizationSettings{
+			SlotIntervalMinutes: utils.Ptr(15),
+			LeadTimeMinutes:     utils.Ptr(60),
+		},
+	})
+	require.NoError(t, err)
+	ctx := db.WithOrganization(context.Background(), *clinic)
+
+	provider, err := store.CreateUser(ctx, "Dr Smith", "smith@example.com", "provider")
+	require.NoError(t, err)
+	client, err := store.CreateUser(ctx, "Pat", "pat@example.com", "client")
+	require.NoError(t, err)
+
+	// Slots start every 15 minutes rather than every half hour
+	w := serveAs(t, router, "", "walk-in-token", http.MethodPost, "/providers/"+provider.Id.String()+"/availability", `{"start_time":"2030-01-07T10:59:00Z","end_time":"2030-01-07T11:20:00Z"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	slots, err := store.GetAvailableAppointments(ctx, provider.Id, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, slots, 2)
+	require.Equal(t, now.Add(2*time.Hour), slots[0].StartTime.UTC())
+	require.Equal(t, now.Add(2*time.Hour+15*time.Minute), slots[0].EndTime.UTC())
+
+	// Two hours ahead meets the clinic's hour of lead time, though not the default day
+	body, err := json.Marshal(schema.PostAppointmentsJSONRequestBody{ClientId: *client.Id, ProviderId: provider.Id, AvailabilityId: slots[0].Id})
+	require.NoError(t, err)
+	w = serveAs(t, router, "", "walk-in-token", http.MethodPost, "/appointments", string(body))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	_, err = store.UpdateOrganizationSettings(context.Background(), *clinic.Id, schema.OrganizationSettings{SlotIntervalMinutes: utils.Ptr(15), LeadTimeMinutes: utils.Ptr(180)})
+	require.NoError(t, err)
+	body, err = json.Marshal(schema.PostAppointmentsJSONRequestBody{ClientId: *client.Id, ProviderId: provider.Id, AvailabilityId: slots[1].Id})
+	require.NoError(t, err)
+	w = serveAs(t, router, "", "walk-in-token", http.MethodPost, "/appointments", string(body))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	problem := decodeProblem(t, w)
+	require.Equal(t, schema.ProblemCodeLeadTimeViolation, problem.Code)
+	require.Contains(t, *problem.Detail, "3 hours")
+}
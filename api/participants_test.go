@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/schema"
+)
+
+func TestMultiProviderAppointments(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	router := setupTestServer(store)
+
+	surgeon := createTestProvider(t, store)
+	anesthetist := createTestProvider(t, store)
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Hour).UTC()
+	endTime := startTime.Add(3 * db.GetAvailabilityInterval())
+	availability := fmt.Sprintf(`{"start_time":%q,"end_time":%q}`, startTime.Format(time.RFC3339), endTime.Format(time.RFC3339))
+	w := serve(t, router, http.MethodPost, "/providers/"+surgeon.String()+"/availability", availability)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	// The anesthetist is not around for the surgeon's last slot
+	availability = fmt.Sprintf(`{"start_time":%q,"end_time":%q}`, startTime.Format(time.RFC3339), startTime.Add(2*db.GetAvailabilityInterval()).Format(time.RFC3339))
+	w = serve(t, router, http.MethodPost, "/providers/"+anesthetist.String()+"/availability", availability)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var slots []schema.Appointment
+	w = serve(t, router, http.MethodGet, "/appointments?providerId="+surgeon.String(), "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &slots))
+	require.Len(t, slots, 3)
+	w = serve(t, router, http.MethodGet, "/appointments?providerId="+surgeon.String()+"&participantId="+anesthetist.String(), "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &slots))
+	require.Len(t, slots, 2)
+
+	w = serve(t, router, http.MethodGet, "/appointments?participantId="+anesthetist.String(), "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, "participantId", (*decodeProblem(t, w).Errors)[0].Field)
+	w = serve(t, router, http.MethodGet, "/appointments?providerId="+surgeon.String()+"&participantId="+surgeon.String(), "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	book := func(participants ...uuid.UUID) (int, schema.Appointment) {
+		body, err := json.Marshal(schema.PostAppointmentsJSONRequestBody{
+			ClientId:       *createTestClient(t, store),
+			ProviderId:     surgeon,
+			AvailabilityId: slots[0].Id,
+			ParticipantIds: &participants,
+		})
+		require.NoError(t, err)
+		w := serve(t, router, http.MethodPost, "/appointments", string(body))
+		var appointment schema.Appointment
+		if w.Code == http.StatusCreated {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &appointment))
+		}
+		return w.Code, appointment
+	}
+
+	code, _ := book(*surgeon)
+	require.Equal(t, http.StatusBadRequest, code)
+	// Like any provider, one without a slot at the time is not available
+	code, _ = book(uuid.New())
+	require.Equal(t, http.StatusConflict, code)
+
+	// Repeating a participant books them once
+	code, appointment := book(*anesthetist, *anesthetist)
+	require.Equal(t, http.StatusCreated, code)
+	require.Equal(t, []uuid.UUID{*anesthetist}, *appointment.ParticipantIds)
+
+	code, _ = book(*anesthetist)
+	require.Equal(t, http.StatusConflict, code)
+}
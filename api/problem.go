@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tateexon/reservation/schema"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// problemContentType is the media type of RFC 7807 problem details
+const problemContentType = "application/problem+json"
+
+// problemTypeBase prefixes the code to make a problem's type URI
+const problemTypeBase = "urn:reservation:problem:"
+
+// problemTitles is the summary of every problem code, the same for each occurrence
+var problemTitles = map[schema.ProblemCode]string{
+	schema.ProblemCodeInvalidRequest:                  "The request is malformed",
+	schema.ProblemCodeValidationFailed:                "The request failed validation",
+	schema.ProblemCodeLeadTimeViolation:               "The reservation is not far enough in advance",
+	schema.ProblemCodeSlotUnavailable:                 "The slot is not available",
+	schema.ProblemCodeSlotsAvailable:                  "Slots are available in the requested window",
+	schema.ProblemCodeAvailabilityNotFound:            "The availability does not exist",
+	schema.ProblemCodeHoldExpired:                     "The reservation hold has expired",
+	schema.ProblemCodeAppointmentNotFound:             "The appointment does not exist",
+	schema.ProblemCodeSeriesNotFound:                  "The appointment series does not exist",
+	schema.ProblemCodeUserNotFound:                    "The user does not exist",
+	schema.ProblemCodeUserInactive:                    "The user has been deactivated",
+	schema.ProblemCodeUserHasAppointments:             "The user has appointments that must be kept",
+	schema.ProblemCodeEmailTaken:                      "The email is already in use",
+	schema.ProblemCodeProviderNotFound:                "The provider does not exist",
+	schema.ProblemCodeLocationNotFound:                "The location does not exist",
+	schema.ProblemCodeRoomNotFound:                    "The room does not exist",
+	schema.ProblemCodeRoomUnavailable:                 "The room is already booked",
+	schema.ProblemCodeGroupNotFound:                   "The provider group does not exist",
+	schema.ProblemCodeHoldLimitReached:                "The client holds too many unconfirmed reservations",
+	schema.ProblemCodeAppointmentLimitReached:         "The client has too many upcoming appointments",
+	schema.ProblemCodeProviderAppointmentLimitReached: "The client has too many upcoming appointments with the provider",
+	schema.ProblemCodeNoShowLimitReached:              "The client missed too many recent appointments",
+	schema.ProblemCodeInvalidStatusTransition:         "The appointment can not change to that status",
+	schema.ProblemCodeAppointmentNotStarted:           "The appointment has not started yet",
+	schema.ProblemCodeAppointmentTypeNotFound:         "The appointment type does not exist",
+	schema.ProblemCodeClientDoubleBooked:              "The client already has an appointment at that time",
+	schema.ProblemCodeWaitlistEntryNotFound:           "The waitlist entry does not exist",
+	schema.ProblemCodeInternalError:                   "The server failed to handle the request",
+	schema.ProblemCodeServiceUnavailable:              "The server can not handle requests right now",
+	schema.ProblemCodeInvalidToken:                    "The api token does not belong to any organization",
+	schema.ProblemCodeTokenRequired:                   "The request needs the organization's api token",
+}
+
+// newProblem returns the problem for code with detail describing this occurrence
+func newProblem(c *gin.Context, status int, code schema.ProblemCode, detail string) schema.Problem {
+	instance := c.Request.URL.Path
+	return schema.Problem{
+		Type:     problemTypeBase + string(code),
+		Title:    problemTitles[code],
+		Status:   status,
+		Code:     code,
+		Detail:   &detail,
+		Instance: &instance,
+	}
+}
+
+// respondWithError logs the underlying error next to the sanitized problem sent to the client
+func (s *Server) respondWithError(c *gin.Context, status int, code schema.ProblemCode, detail string, err error) {
+	s.respondWithProblem(c, newProblem(c, status, code, detail), err)
+}
+
+// respondWithProblem sends problem, logging it when there is an underlying error or the server is at fault
+func (s *Server) respondWithProblem(c *gin.Context, problem schema.Problem, err error) {
+	if err != nil || problem.Status >= http.StatusInternalServerError {
+		level := slog.LevelWarn
+		if problem.Status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		s.logger().LogAttrs(c.Request.Context(), level, *problem.Detail,
+			slog.Int("status", problem.Status),
+			slog.String("code", string(problem.Code)),
+			slog.String("route", c.FullPath()),
+			slog.Any("error", err),
+		)
+	}
+	if err != nil {
+		trace.SpanFromContext(c.Request.Context()).RecordError(err)
+	}
+	c.Header("Content-Type", problemContentType)
+	c.JSON(problem.Status, problem)
+}
+
+// respondWithValidationError answers 400 naming the fields that are wrong
+func (s *Server) respondWithValidationError(c *gin.Context, detail string, fields ...schema.FieldError) {
+	problem := newProblem(c, http.StatusBadRequest, schema.ProblemCodeValidationFailed, detail)
+	problem.Errors = &fields
+	s.respondWithProblem(c, problem, nil)
+}
+
+// respondWithBindError answers a request body that could not be decoded, naming the field when json
+// says which one it was
+func (s *Server) respondWithBindError(c *gin.Context, err error) {
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		problem := newProblem(c, http.StatusBadRequest, schema.ProblemCodeValidationFailed, "Invalid request body")
+		problem.Errors = &[]schema.FieldError{{Field: typeErr.Field, Message: "must not be a " + typeErr.Value}}
+		s.respondWithProblem(c, problem, err)
+	case errors.Is(err, io.EOF):
+		s.respondWithError(c, http.StatusBadRequest, schema.ProblemCodeInvalidRequest, "Request body is empty", err)
+	default:
+		s.respondWithError(c, http.StatusBadRequest, schema.ProblemCodeInvalidRequest, "Invalid request body", err)
+	}
+}
+
+// ParameterError answers requests whose path or query parameters could not be parsed, it is the
+// ErrorHandler for schema.RegisterHandlersWithOptions
+func (s *Server) ParameterError(c *gin.Context, err error, status int) {
+	// The generated wrappers put the parameter name before the parse error
+	detail, _, _ := strings.Cut(err.Error(), ":")
+	s.respondWithError(c, status, schema.ProblemCodeInvalidRequest, detail, err)
+}
+
+// respondWithConflicts answers 409 listing the occurrences that could not be booked
+func (s *Server) respondWithConflicts(c *gin.Context, conflicts []schema.OccurrenceConflict) {
+	problem := newProblem(c, http.StatusConflict, schema.ProblemCodeSlotUnavailable, "Slot is not available")
+	problem.Conflicts = &conflicts
+	s.respondWithProblem(c, problem, nil)
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+)
+
+func (s *Server) GetProviders(c *gin.Context, params schema.GetProvidersParams) {
+	query := db.ProviderQuery{
+		AvailableFrom: params.AvailableFrom,
+		AvailableTo:   params.AvailableTo,
+	}
+	if params.Q != nil {
+		query.Text = *params.Q
+	}
+	if params.Specialty != nil {
+		query.Specialty = strings.TrimSpace(*params.Specialty)
+	}
+	if params.Language != nil {
+		query.Language = normalizeLanguage(*params.Language)
+	}
+	if query.AvailableFrom != nil && query.AvailableTo != nil && !query.AvailableFrom.Before(*query.AvailableTo) {
+		s.respondWithValidationError(c, "availableTo must be after availableFrom",
+			schema.FieldError{Field: "availableTo", Message: "must be after availableFrom"})
+		return
+	}
+
+	providers, err := s.DB.SearchProviders(c.Request.Context(), query)
+	if err != nil {
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to search providers", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, providers)
+}
+
+//nolint:revive
+func (s *Server) GetProvidersProviderId(c *gin.Context, providerId openapi_types.UUID) {
+	provider, err := s.DB.GetProvider(c.Request.Context(), providerId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeProviderNotFound, "Provider not found", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to fetch provider", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, provider)
+}
+
+//nolint:revive
+func (s *Server) PutProvidersProviderIdProfile(c *gin.Context, providerId openapi_types.UUID) {
+	var profile schema.ProviderProfile
+	if err := c.ShouldBindJSON(&profile); err != nil {
+		s.respondWithBindError(c, err)
+		return
+	}
+
+	// Languages are matched exactly, so they are stored the way searches spell them
+	if profile.Languages != nil {
+		var languages []string
+		for _, language := range *profile.Languages {
+			language = normalizeLanguage(language)
+			if language != "" && !slices.Contains(languages, language) {
+				languages = append(languages, language)
+			}
+		}
+		profile.Languages = &languages
+	}
+
+	provider, err := s.DB.UpdateProviderProfile(c.Request.Context(), providerId, profile)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeProviderNotFound, "Provider not found", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to update provider profile", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, provider)
+}
+
+// normalizeLanguage makes languages match case insensitively
+func normalizeLanguage(language string) string {
+	return strings.ToLower(strings.TrimSpace(language))
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/schema"
+)
+
+func TestProviderDirectory(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	router := setupTestServer(store)
+
+	providerID := createTestProvider(t, store)
+	createTestProvider(t, store)
+	path := "/providers/" + providerID.String()
+
+	w := serve(t, router, http.MethodPut, path+"/profile",
+		`{"specialty":"Physiotherapy","bio":"Knee rehabilitation","languages":["EN"," es ","en"],"location":"Denver"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	var provider schema.Provider
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &provider))
+	require.Equal(t, []string{"en", "es"}, *provider.Languages)
+
+	w = serve(t, router, http.MethodGet, path, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &provider))
+	require.Equal(t, "Physiotherapy", *provider.Specialty)
+
+	search := func(query url.Values) []schema.Provider {
+		w := serve(t, router, http.MethodGet, "/providers?"+query.Encode(), "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var providers []schema.Provider
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &providers))
+		return providers
+	}
+	require.Len(t, search(url.Values{}), 2)
+	providers := search(url.Values{"q": {"knee"}, "language": {"ES"}, "specialty": {"physiotherapy"}})
+	require.Len(t, providers, 1)
+	require.Equal(t, providerID, providers[0].Id)
+
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	addTestAvailability(t, store, providerID, []time.Time{startTime})
+	providers = search(url.Values{
+		"availableFrom": {startTime.Format(time.RFC3339)},
+		"availableTo":   {startTime.Add(time.Hour).Format(time.RFC3339)},
+	})
+	require.Len(t, providers, 1)
+	require.Equal(t, providerID, providers[0].Id)
+
+	w = serve(t, router, http.MethodGet, "/providers?"+url.Values{
+		"availableFrom": {startTime.Format(time.RFC3339)},
+		"availableTo":   {startTime.Format(time.RFC3339)},
+	}.Encode(), "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, schema.ProblemCodeValidationFailed, decodeProblem(t, w).Code)
+
+	// Clients are not in the directory
+	clientID := createTestClient(t, store)
+	w = serve(t, router, http.MethodPut, "/providers/"+clientID.String()+"/profile", `{}`)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, schema.ProblemCodeProviderNotFound, decodeProblem(t, w).Code)
+	w = serve(t, router, http.MethodGet, "/providers/"+clientID.String(), "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
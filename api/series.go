@@ -0,0 +1,172 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/metrics"
+	"github.com/tateexon/reservation/schema"
+)
+
+// maxSeriesOccurrences caps how many appointments a single series can reserve
+const maxSeriesOccurrences = 52
+
+// reserveSeries reserves a recurring series starting at startTime for PostAppointments
+func (s *Server) reserveSeries(c *gin.Context, req schema.PostAppointmentsJSONRequestBody, participantIDs []openapi_types.UUID, startTime time.Time) {
+	recurrence := *req.Recurrence
+	if !isValidRecurrence(recurrence) {
+		metrics.ReservationsRejected.WithLabelValues(metrics.RejectedInvalidRequest).Inc()
+		s.respondWithValidationError(c, "Recurrence must be daily or weekly with an interval of at least 1 and between 2 and 52 occurrences",
+			schema.FieldError{Field: "recurrence", Message: "must be daily or weekly with an interval of at least 1 and between 2 and 52 occurrences"})
+		return
+	}
+
+	series, err := s.DB.ReserveSeries(c.Request.Context(), db.SeriesRequest{
+		ClientID:       &req.ClientId,
+		ProviderID:     req.ProviderId,
+		ParticipantIDs: participantIDs,
+		RoomID:         req.RoomId,
+		Recurrence:     recurrence,
+		StartTimes:     occurrenceStartTimes(startTime, recurrence),
+		AllowPartial:   req.AllowPartial != nil && *req.AllowPartial,
+		Intake:         intakeOf(req),
+	})
+	if err != nil {
+		var conflictErr *db.ConflictError
+		if errors.As(err, &conflictErr) {
+			metrics.ReservationsRejected.WithLabelValues(metrics.RejectedConflict).Inc()
+			s.respondWithConflicts(c, conflictErr.Conflicts)
+			return
+		}
+		if errors.Is(err, db.ErrUserInactive) {
+			metrics.ReservationsRejected.WithLabelValues(metrics.RejectedUserInactive).Inc()
+			s.respondWithError(c, http.StatusConflict, schema.ProblemCodeUserInactive, "Client or provider has been deactivated", nil)
+			return
+		}
+		if s.respondWithRoomError(c, err) || s.respondWithLimitError(c, err) || s.respondWithIntakeError(c, err) {
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			metrics.ReservationsRejected.WithLabelValues(metrics.RejectedUnknownUser).Inc()
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeUserNotFound, "Client or provider not found", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to reserve appointment", err)
+		return
+	}
+
+	metrics.ReservationsCreated.Add(float64(len(*series.Appointments)))
+	c.JSON(http.StatusCreated, series)
+}
+
+func isValidRecurrence(recurrence schema.Recurrence) bool {
+	if recurrence.Frequency != schema.Daily && recurrence.Frequency != schema.Weekly {
+		return false
+	}
+	if recurrence.Interval != nil && *recurrence.Interval < 1 {
+		return false
+	}
+	return recurrence.Count >= 2 && recurrence.Count <= maxSeriesOccurrences
+}
+
+// occurrenceStartTimes lists the start of every occurrence in a recurrence. Occurrences keep the wall
+// clock time of the first one in its location.
+func occurrenceStartTimes(first time.Time, recurrence schema.Recurrence) []time.Time {
+	days := 1
+	if recurrence.Interval != nil {
+		days = *recurrence.Interval
+	}
+	if recurrence.Frequency == schema.Weekly {
+		days *= 7
+	}
+
+	startTimes := make([]time.Time, 0, recurrence.Count)
+	for i := 0; i < recurrence.Count; i++ {
+		startTimes = append(startTimes, first.AddDate(0, 0, i*days))
+	}
+	return startTimes
+}
+
+//nolint:revive
+func (s *Server) GetAppointmentSeriesSeriesId(c *gin.Context, seriesId openapi_types.UUID) {
+	series, err := s.DB.GetSeries(c.Request.Context(), seriesId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeSeriesNotFound, "Series not found", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to fetch series", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+//nolint:revive
+func (s *Server) PostAppointmentSeriesSeriesIdConfirm(c *gin.Context, seriesId openapi_types.UUID) {
+	confirmed, err := s.DB.ConfirmSeries(c.Request.Context(), seriesId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeSeriesNotFound, "Series not found or may have expired", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to confirm series", err)
+		return
+	}
+
+	metrics.ReservationsConfirmed.Add(float64(confirmed))
+	c.JSON(http.StatusOK, gin.H{"message": "Series confirmed"})
+}
+
+//nolint:revive
+func (s *Server) PostAppointmentsAppointmentIdReschedule(c *gin.Context, appointmentId openapi_types.UUID) {
+	var req schema.RescheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithBindError(c, err)
+		return
+	}
+
+	startTime, err := s.DB.GetAppointmentStartTime(c.Request.Context(), &req.AvailabilityId)
+	if err != nil {
+		s.respondWithError(c, http.StatusBadRequest, schema.ProblemCodeAvailabilityNotFound, "Invalid availability id", err)
+		return
+	}
+
+	if !s.meetsLeadTime(c.Request.Context(), startTime) {
+		s.respondWithLeadTimeViolation(c)
+		return
+	}
+
+	var appointments []schema.Appointment
+	if req.Scope != nil && *req.Scope == schema.Following {
+		appointments, err = s.DB.RescheduleFollowingAppointments(c.Request.Context(), appointmentId, startTime)
+	} else {
+		appointments, err = s.DB.RescheduleAppointment(c.Request.Context(), appointmentId, startTime)
+	}
+	if err != nil {
+		var conflictErr *db.ConflictError
+		if errors.As(err, &conflictErr) {
+			s.respondWithConflicts(c, conflictErr.Conflicts)
+			return
+		}
+		if s.respondWithTransitionError(c, err, "rescheduled") || s.respondWithLimitError(c, err) {
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeAppointmentNotFound, "Appointment not found or may have expired", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to reschedule appointment", err)
+		return
+	}
+
+	// The slots that were moved away from may be wanted by someone on the waitlist
+	s.promoteWaitlist(c.Request.Context())
+
+	c.JSON(http.StatusOK, appointments)
+}
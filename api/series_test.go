@@ -0,0 +1,101 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+func TestOccurrenceStartTimes(t *testing.T) {
+	t.Parallel()
+
+	first := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+
+	weekly := occurrenceStartTimes(first, schema.Recurrence{Frequency: schema.Weekly, Count: 3})
+	require.Equal(t, []time.Time{
+		first,
+		time.Date(2024, time.March, 11, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 18, 9, 0, 0, 0, time.UTC),
+	}, weekly)
+
+	everyOtherDay := occurrenceStartTimes(first, schema.Recurrence{Frequency: schema.Daily, Interval: utils.Ptr(2), Count: 2})
+	require.Equal(t, []time.Time{
+		first,
+		time.Date(2024, time.March, 6, 9, 0, 0, 0, time.UTC),
+	}, everyOtherDay)
+}
+
+func TestIsValidRecurrence(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, isValidRecurrence(schema.Recurrence{Frequency: schema.Weekly, Count: 10}))
+	require.False(t, isValidRecurrence(schema.Recurrence{Frequency: "monthly", Count: 10}))
+	require.False(t, isValidRecurrence(schema.Recurrence{Frequency: schema.Weekly, Count: 1}))
+	require.False(t, isValidRecurrence(schema.Recurrence{Frequency: schema.Weekly, Count: maxSeriesOccurrences + 1}))
+	require.False(t, isValidRecurrence(schema.Recurrence{Frequency: schema.Daily, Interval: utils.Ptr(0), Count: 2}))
+}
+
+func TestPostAppointments_Series(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	router := setupTestServer(store)
+
+	providerID := createTestProvider(t, store)
+	clientID := createTestClient(t, store)
+
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	recurrence := schema.Recurrence{Frequency: schema.Weekly, Count: 3}
+	addTestAvailability(t, store, providerID, occurrenceStartTimes(startTime, recurrence))
+
+	appointments, err := store.GetAvailableAppointments(context.Background(), providerID, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, appointments, 3)
+
+	appointmentReq := schema.PostAppointmentsJSONRequestBody{
+		ClientId:       *clientID,
+		ProviderId:     providerID,
+		AvailabilityId: appointments[0].Id,
+		Recurrence:     &recurrence,
+	}
+	reqBody, err := json.Marshal(appointmentReq)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "/appointments", bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var series schema.AppointmentSeries
+	err = json.Unmarshal(w.Body.Bytes(), &series)
+	require.NoError(t, err)
+	require.Len(t, *series.Appointments, 3)
+
+	// Confirm the whole series with one call
+	req, err = http.NewRequest(http.MethodPost, "/appointment-series/"+series.Id.String()+"/confirm", nil)
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// Cancel the second occurrence and the ones after it
+	second := (*series.Appointments)[1]
+	req, err = http.NewRequest(http.MethodPost, "/appointments/"+second.Id.String()+"/cancel?scope=following", nil)
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	appointments, err = store.GetAvailableAppointments(context.Background(), providerID, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, appointments, 2)
+}
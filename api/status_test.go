@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/clock"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/schema"
+)
+
+func TestAppointmentStatus(t *testing.T) {
+	t.Parallel()
+	clk := clock.NewFake(time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC))
+	store := memory.New(nil)
+	store.Clock = clk
+	router := setupTestServerWithClock(store, clk)
+
+	providerID := createTestProvider(t, store)
+	clientID := createTestClient(t, store)
+	startTime := clk.Now().Add(25 * time.Hour)
+	later := startTime.Add(48 * time.Hour)
+	addTestAvailability(t, store, providerID, []time.Time{startTime, later})
+	appointment, err := store.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.ConfirmAppointment(context.Background(), *appointment.Id))
+
+	mark := func(appointmentID *types.UUID, status schema.AppointmentStatus, changedBy *types.UUID) (int, []byte) {
+		body, err := json.Marshal(schema.UpdateAppointmentStatusRequest{Status: status, ChangedBy: *changedBy})
+		require.NoError(t, err)
+		w := serve(t, router, http.MethodPost, "/appointments/"+appointmentID.String()+"/status", string(body))
+		return w.Code, w.Body.Bytes()
+	}
+	problemOf := func(body []byte) schema.Problem {
+		var problem schema.Problem
+		require.NoError(t, json.Unmarshal(body, &problem))
+		return problem
+	}
+
+	// Nothing can be marked before the appointment starts
+	code, body := mark(appointment.Id, schema.AppointmentStatusCheckedIn, providerID)
+	require.Equal(t, http.StatusConflict, code)
+	require.Equal(t, schema.ProblemCodeAppointmentNotStarted, problemOf(body).Code)
+
+	clk.Advance(25 * time.Hour)
+	code, body = mark(appointment.Id, schema.AppointmentStatusCheckedIn, clientID)
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Equal(t, []schema.FieldError{{Field: "changed_by", Message: "must be the provider or a participant of the appointment"}}, *problemOf(body).Errors)
+
+	code, body = mark(appointment.Id, schema.AppointmentStatusCompleted, providerID)
+	require.Equal(t, http.StatusConflict, code)
+	problem := problemOf(body)
+	require.Equal(t, schema.ProblemCodeInvalidStatusTransition, problem.Code)
+	require.Equal(t, "A confirmed appointment can not be changed to completed", *problem.Detail)
+
+	code, body = mark(appointment.Id, schema.AppointmentStatusCheckedIn, providerID)
+	require.Equal(t, http.StatusOK, code)
+	var checkedIn schema.Appointment
+	require.NoError(t, json.Unmarshal(body, &checkedIn))
+	require.Equal(t, schema.AppointmentStatusCheckedIn, *checkedIn.Status)
+	require.Equal(t, *providerID, *checkedIn.StatusChangedBy)
+	require.True(t, clk.Now().Equal(*checkedIn.StatusChangedAt))
+
+	code, _ = mark(appointment.Id, schema.AppointmentStatusNoShow, providerID)
+	require.Equal(t, http.StatusConflict, code)
+	code, _ = mark(appointment.Id, schema.AppointmentStatusCompleted, providerID)
+	require.Equal(t, http.StatusOK, code)
+
+	// Completed appointments can not be cancelled or moved either
+	w := serve(t, router, http.MethodPost, "/appointments/"+appointment.Id.String()+"/cancel", "")
+	require.Equal(t, http.StatusConflict, w.Code)
+	problem = decodeProblem(t, w)
+	require.Equal(t, schema.ProblemCodeInvalidStatusTransition, problem.Code)
+	require.Equal(t, "A completed appointment can not be cancelled", *problem.Detail)
+	var slots []schema.Appointment
+	w = serve(t, router, http.MethodGet, "/appointments?providerId="+providerID.String(), "")
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &slots))
+	laterSlot := slots[len(slots)-1]
+	require.True(t, later.Equal(*laterSlot.StartTime))
+	w = serve(t, router, http.MethodPost, "/appointments/"+appointment.Id.String()+"/reschedule", `{"availability_id":"`+laterSlot.Id.String()+`"}`)
+	require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	require.Equal(t, "A completed appointment can not be rescheduled", *decodeProblem(t, w).Detail)
+
+	unknown := uuid.New()
+	code, body = mark(&unknown, schema.AppointmentStatusCheckedIn, providerID)
+	require.Equal(t, http.StatusNotFound, code)
+	require.Equal(t, schema.ProblemCodeAppointmentNotFound, problemOf(body).Code)
+
+	// Only the outcomes can be set here
+	w = serve(t, router, http.MethodPost, "/appointments/"+appointment.Id.String()+"/status",
+		`{"status":"cancelled","changed_by":"`+providerID.String()+`"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAppointmentHistory(t *testing.T) {
+	t.Parallel()
+	clk := clock.NewFake(time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC))
+	store := memory.New(nil)
+	store.Clock = clk
+	router := setupTestServerWithClock(store, clk)
+
+	providerID := createTestProvider(t, store)
+	clientID := createTestClient(t, store)
+	startTime := clk.Now().Add(25 * time.Hour)
+	addTestAvailability(t, store, providerID, []time.Time{startTime})
+	appointment, err := store.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+
+	w := serve(t, router, http.MethodPost, "/appointments/"+appointment.Id.String()+"/confirm", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	clk.Advance(25 * time.Hour)
+	w = serve(t, router, http.MethodPost, "/appointments/"+appointment.Id.String()+"/status",
+		`{"status":"checked_in","changed_by":"`+providerID.String()+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serve(t, router, http.MethodGet, "/appointments/"+appointment.Id.String()+"/history", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var history []schema.StatusChange
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+	require.Len(t, history, 3)
+	require.Equal(t, schema.AppointmentStatusReserved, *history[0].Status)
+	require.Equal(t, schema.StatusChangeSourceRequest, *history[1].Source)
+	require.Nil(t, history[1].ChangedBy)
+	require.Equal(t, schema.AppointmentStatusCheckedIn, *history[2].Status)
+	require.Equal(t, schema.StatusChangeSourceProvider, *history[2].Source)
+	require.Equal(t, *providerID, *history[2].ChangedBy)
+	require.True(t, clk.Now().Equal(*history[2].ChangedAt))
+
+	w = serve(t, router, http.MethodGet, "/appointments/"+uuid.New().String()+"/history", "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, schema.ProblemCodeAppointmentNotFound, decodeProblem(t, w).Code)
+}
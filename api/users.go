@@ -0,0 +1,114 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+)
+
+func (s *Server) PostUsers(c *gin.Context) {
+	var req schema.CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithBindError(c, err)
+		return
+	}
+
+	user, err := s.DB.CreateUser(c.Request.Context(), req.Name, string(req.Email), string(req.Role))
+	if err != nil {
+		if errors.Is(err, db.ErrEmailTaken) {
+			s.respondWithError(c, http.StatusConflict, schema.ProblemCodeEmailTaken, "Email is already in use", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to create user", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+//nolint:revive
+func (s *Server) GetUsersUserId(c *gin.Context, userId openapi_types.UUID) {
+	// Retrieve the user from the database
+	user, err := s.DB.GetUser(c.Request.Context(), userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeUserNotFound, "User not found", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to fetch user", err)
+		return
+	}
+
+	// Return the user details
+	c.JSON(http.StatusOK, user)
+}
+
+//nolint:revive
+func (s *Server) PatchUsersUserId(c *gin.Context, userId openapi_types.UUID) {
+	var req schema.UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithBindError(c, err)
+		return
+	}
+
+	update := db.UserUpdate{Name: req.Name}
+	if req.Email != nil {
+		email := string(*req.Email)
+		update.Email = &email
+	}
+	user, err := s.DB.UpdateUser(c.Request.Context(), userId, update)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeUserNotFound, "User not found", nil)
+			return
+		}
+		if errors.Is(err, db.ErrEmailTaken) {
+			s.respondWithError(c, http.StatusConflict, schema.ProblemCodeEmailTaken, "Email is already in use", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to update user", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+//nolint:revive
+func (s *Server) PostUsersUserIdDeactivate(c *gin.Context, userId openapi_types.UUID) {
+	user, err := s.DB.DeactivateUser(c.Request.Context(), userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeUserNotFound, "User not found", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to deactivate user", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+//nolint:revive
+func (s *Server) DeleteUsersUserId(c *gin.Context, userId openapi_types.UUID) {
+	err := s.DB.DeleteUser(c.Request.Context(), userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeUserNotFound, "User not found", nil)
+			return
+		}
+		if errors.Is(err, db.ErrUserHasAppointments) {
+			s.respondWithError(c, http.StatusConflict, schema.ProblemCodeUserHasAppointments,
+				"User has appointments, deactivate them instead to keep their history", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to delete user", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User deleted"})
+}
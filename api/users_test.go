@@ -0,0 +1,113 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/schema"
+)
+
+// serve sends a json request to router
+func serve(t *testing.T, router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUserLifecycle(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	router := setupTestServer(store)
+
+	w := serve(t, router, http.MethodPost, "/users", `{"name":"Dr Smith","email":"smith@example.com","role":"provider"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var user schema.User
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+	path := "/users/" + user.Id.String()
+
+	// Duplicate emails conflict instead of failing
+	w = serve(t, router, http.MethodPost, "/users", `{"name":"Pat","email":"smith@example.com","role":"client"}`)
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, schema.ProblemCodeEmailTaken, decodeProblem(t, w).Code)
+
+	w = serve(t, router, http.MethodPatch, path, `{"name":"Dr Jones"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+	require.Equal(t, "Dr Jones", *user.Name)
+	require.Equal(t, "smith@example.com", *user.Email)
+
+	w = serve(t, router, http.MethodPost, "/users", `{"name":"Pat","email":"pat@example.com","role":"client"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	w = serve(t, router, http.MethodPatch, path, `{"email":"pat@example.com"}`)
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, schema.ProblemCodeEmailTaken, decodeProblem(t, w).Code)
+
+	// An update has to change something
+	w = serve(t, router, http.MethodPatch, path, `{}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, schema.ProblemCodeValidationFailed, decodeProblem(t, w).Code)
+
+	w = serve(t, router, http.MethodPost, path+"/deactivate", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+	require.NotNil(t, user.DeactivatedAt)
+
+	// A deactivated provider can not offer new slots
+	w = serve(t, router, http.MethodPost, "/providers/"+user.Id.String()+"/availability", `{"start_time":"2030-01-09T09:00:00Z","end_time":"2030-01-09T10:00:00Z"}`)
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, schema.ProblemCodeUserInactive, decodeProblem(t, w).Code)
+
+	w = serve(t, router, http.MethodDelete, path, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	w = serve(t, router, http.MethodGet, path, "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	w = serve(t, router, http.MethodDelete, path, "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, schema.ProblemCodeUserNotFound, decodeProblem(t, w).Code)
+}
+
+func TestUserLifecycle_KeepsHistory(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	router := setupTestServer(store)
+
+	providerID := createTestProvider(t, store)
+	clientID := createTestClient(t, store)
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	addTestAvailability(t, store, providerID, []time.Time{startTime, startTime.Add(time.Hour)})
+	_, err := store.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+
+	// Deleting would lose the appointment
+	w := serve(t, router, http.MethodDelete, "/users/"+providerID.String(), "")
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, schema.ProblemCodeUserHasAppointments, decodeProblem(t, w).Code)
+
+	w = serve(t, router, http.MethodPost, "/users/"+clientID.String()+"/deactivate", "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// The deactivated client can not book the provider's other slot
+	slots, err := store.GetAvailableAppointments(context.Background(), providerID, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, slots, 1)
+	body, err := json.Marshal(schema.PostAppointmentsJSONRequestBody{
+		ClientId:       *clientID,
+		ProviderId:     providerID,
+		AvailabilityId: slots[0].Id,
+	})
+	require.NoError(t, err)
+	w = serve(t, router, http.MethodPost, "/appointments", string(body))
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, schema.ProblemCodeUserInactive, decodeProblem(t, w).Code)
+}
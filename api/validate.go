@@ -0,0 +1,239 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+	"github.com/tateexon/reservation/metrics"
+	"github.com/tateexon/reservation/schema"
+)
+
+func init() {
+	// kin-openapi only checks the formats it is told about
+	openapi3.DefineStringFormatValidator("email", patternFormat(openapi3.FormatOfStringForEmail, "not an email address"))
+	openapi3.DefineStringFormatValidator("uuid", patternFormat(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`, "not a uuid"))
+}
+
+// patternFormat checks a string format with pattern, explaining a mismatch with reason rather than the
+// pattern itself
+func patternFormat(pattern, reason string) openapi3.StringFormatValidator {
+	re := regexp.MustCompile(pattern)
+	return openapi3.NewCallbackValidator(func(value string) error {
+		if !re.MatchString(value) {
+			return errors.New(reason)
+		}
+		return nil
+	})
+}
+
+// ValidationOptions controls how requests are checked against the openapi spec
+type ValidationOptions struct {
+	// Responses also checks every response and replaces one that breaks the spec with a 500, meant for
+	// tests since it buffers every response
+	Responses bool
+}
+
+// Register serves the api's operations on router. Every request is scoped to the organization it resolves
+// to and validated against spec before it reaches a handler, and malformed parameters are answered with
+// problem details.
+func (s *Server) Register(router gin.IRouter, spec *openapi3.T, opts ValidationOptions) {
+	api := router.Group("", s.resolveOrganization, s.validate(spec, opts))
+	schema.RegisterHandlersWithOptions(api, s, schema.GinServerOptions{ErrorHandler: s.ParameterError})
+}
+
+// validate answers requests that break the spec with a validation problem
+func (s *Server) validate(spec *openapi3.T, opts ValidationOptions) gin.HandlerFunc {
+	routes := map[string]*routers.Route{}
+	for path, item := range spec.Paths.Map() {
+		for method, operation := range item.Operations() {
+			routes[method+" "+metrics.GinRoute(path)] = &routers.Route{
+				Spec:      spec,
+				Path:      path,
+				PathItem:  item,
+				Method:    method,
+				Operation: operation,
+			}
+		}
+	}
+	filterOptions := &openapi3filter.Options{
+		MultiError:            true,
+		SkipSettingDefaults:   true,
+		IncludeResponseStatus: true,
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(c *gin.Context) {
+		route, ok := routes[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		pathParams := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			pathParams[param.Key] = param.Value
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    filterOptions,
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			s.respondWithRequestError(c, err)
+			c.Abort()
+			return
+		}
+
+		if !opts.Responses {
+			c.Next()
+			return
+		}
+		s.validateResponse(c, input)
+	}
+}
+
+// respondWithRequestError turns what the validator found into a problem listing every bad field
+func (s *Server) respondWithRequestError(c *gin.Context, err error) {
+	var fields []schema.FieldError
+	for _, err := range unpackErrors(err) {
+		var requestErr *openapi3filter.RequestError
+		if !errors.As(err, &requestErr) {
+			s.respondWithError(c, http.StatusBadRequest, schema.ProblemCodeInvalidRequest, "Invalid request", err)
+			return
+		}
+
+		var schemaErrs []*openapi3.SchemaError
+		for _, err := range unpackErrors(requestErr.Err) {
+			var schemaErr *openapi3.SchemaError
+			if errors.As(err, &schemaErr) {
+				schemaErrs = append(schemaErrs, schemaErr)
+			}
+		}
+
+		switch {
+		case requestErr.Parameter != nil:
+			message := requestErr.Reason
+			if len(schemaErrs) > 0 {
+				message = schemaErrorMessage(schemaErrs[0])
+			}
+			fields = append(fields, schema.FieldError{Field: requestErr.Parameter.Name, Message: message})
+		case len(schemaErrs) > 0:
+			for _, schemaErr := range schemaErrs {
+				fields = append(fields, schema.FieldError{Field: strings.Join(schemaErr.JSONPointer(), "."), Message: schemaErrorMessage(schemaErr)})
+			}
+		default:
+			// The body is not json, or is missing
+			s.respondWithError(c, http.StatusBadRequest, schema.ProblemCodeInvalidRequest, "Invalid request body", err)
+			return
+		}
+	}
+	s.respondWithValidationError(c, "The request does not match the api schema", fields...)
+}
+
+// schemaErrorMessage words a schema error the way the handlers word theirs
+func schemaErrorMessage(err *openapi3.SchemaError) string {
+	if err.SchemaField == "required" {
+		return "is required"
+	}
+	return err.Reason
+}
+
+// unpackErrors flattens the multi errors the validator returns
+func unpackErrors(err error) []error {
+	// Not errors.As, which would also unwrap the request errors that hold the details
+	multi, ok := err.(openapi3.MultiError)
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, err := range multi {
+		errs = append(errs, unpackErrors(err)...)
+	}
+	return errs
+}
+
+// validateResponse runs the handlers with their response held back, and only sends it if it matches
+// the spec
+func (s *Server) validateResponse(c *gin.Context, input *openapi3filter.RequestValidationInput) {
+	writer := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+	c.Writer = writer
+	c.Next()
+	c.Writer = writer.ResponseWriter
+
+	err := openapi3filter.ValidateResponse(c.Request.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 writer.status,
+		Header:                 writer.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(writer.body.Bytes())),
+		Options:                input.Options,
+	})
+	if err != nil {
+		s.logger().LogAttrs(c.Request.Context(), slog.LevelError, "Response does not match the api schema",
+			slog.String("route", c.FullPath()),
+			slog.Int("status", writer.status),
+			slog.String("body", writer.body.String()),
+			slog.Any("error", err),
+		)
+		c.Writer.Header().Del("Content-Type")
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError,
+			fmt.Sprintf("The %d response does not match the api schema", writer.status), err)
+		return
+	}
+
+	c.Writer.WriteHeader(writer.status)
+	_, _ = c.Writer.Write(writer.body.Bytes())
+}
+
+// bufferedWriter keeps a response in memory until it has been validated
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status  int
+	written bool
+	body    bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(status int) {
+	if !w.written {
+		w.status = status
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.written
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// adminStore hands out users with a role the spec does not allow
+type adminStore struct {
+	*memory.Store
+}
+
+func (s adminStore) GetUser(ctx context.Context, userID types.UUID) (*schema.User, error) {
+	user, err := s.Store.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user.Role = utils.Ptr(schema.UserRole("admin"))
+	return user, nil
+}
+
+func TestValidate_Responses(t *testing.T) {
+	t.Parallel()
+	store := adminStore{Store: memory.New(nil)}
+	userID := createTestClient(t, store)
+
+	get := func(opts ValidationOptions) *httptest.ResponseRecorder {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		server := &Server{DB: store}
+		server.Register(router, testSpec(), opts)
+
+		req, err := http.NewRequest(http.MethodGet, "/users/"+userID.String(), nil)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Without response validation the handler's answer goes out as is
+	w := get(ValidationOptions{})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"role":"admin"`)
+
+	// With it the broken response is replaced before the client sees it
+	w = get(ValidationOptions{Responses: true})
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	problem := decodeProblem(t, w)
+	require.Equal(t, schema.ProblemCodeInternalError, problem.Code)
+	require.Equal(t, "The 200 response does not match the api schema", *problem.Detail)
+	require.NotContains(t, w.Body.String(), "admin")
+}
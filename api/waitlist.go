@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/metrics"
+	"github.com/tateexon/reservation/notify"
+	"github.com/tateexon/reservation/schema"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func (s *Server) PostWaitlist(c *gin.Context) {
+	var req schema.JoinWaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.respondWithBindError(c, err)
+		return
+	}
+
+	leadTime := s.leadTime(c.Request.Context())
+	earliestStart := s.now().Add(leadTime)
+	if !req.WindowStart.Before(req.WindowEnd) {
+		s.respondWithValidationError(c, "Window end must be after window start",
+			schema.FieldError{Field: "window_end", Message: "must be after window_start"})
+		return
+	}
+	if !req.WindowEnd.After(earliestStart) {
+		s.respondWithValidationError(c, fmt.Sprintf("Window must end at least %s in advance", describeDuration(leadTime)),
+			schema.FieldError{Field: "window_end", Message: fmt.Sprintf("must be at least %s from now", describeDuration(leadTime))})
+		return
+	}
+
+	entry, err := s.DB.JoinWaitlist(c.Request.Context(), req.ClientId, req.ProviderId, req.WindowStart, req.WindowEnd, earliestStart)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeUserNotFound, "Client or provider not found", nil)
+			return
+		}
+		if errors.Is(err, db.ErrUserInactive) {
+			s.respondWithError(c, http.StatusConflict, schema.ProblemCodeUserInactive, "Client or provider has been deactivated", nil)
+			return
+		}
+		if errors.Is(err, db.ErrSlotsAvailable) {
+			s.respondWithError(c, http.StatusConflict, schema.ProblemCodeSlotsAvailable, "Slots are available in the requested window", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to join waitlist", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+//nolint:revive
+func (s *Server) DeleteWaitlistEntryId(c *gin.Context, entryId openapi_types.UUID) {
+	err := s.DB.LeaveWaitlist(c.Request.Context(), entryId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeWaitlistEntryNotFound, "Waitlist entry not found or no longer waiting", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to leave waitlist", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Left the waitlist"})
+}
+
+//nolint:revive
+func (s *Server) GetProvidersProviderIdWaitlist(c *gin.Context, providerId openapi_types.UUID) {
+	entries, err := s.DB.GetProviderWaitlist(c.Request.Context(), providerId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.respondWithError(c, http.StatusNotFound, schema.ProblemCodeProviderNotFound, "Provider not found", nil)
+			return
+		}
+		s.respondWithError(c, http.StatusInternalServerError, schema.ProblemCodeInternalError, "Failed to fetch waitlist", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// RunWaitlistWorker periodically expires holds that were not confirmed in time and offers the freed slots
+// to the waitlist until ctx is done. A sweep that is running when ctx is done is finished before returning.
+func (s *Server) RunWaitlistWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweepWaitlist(context.WithoutCancel(ctx))
+		}
+	}
+}
+
+// sweepWaitlist runs one pass of the waitlist worker as its own trace, in every organization
+func (s *Server) sweepWaitlist(ctx context.Context) {
+	ctx, span := tracer.Start(ctx, "waitlist.sweep", trace.WithNewRoot())
+	defer span.End()
+
+	orgs, err := s.DB.ListOrganizations(ctx)
+	if err != nil {
+		s.logger().ErrorContext(ctx, "Failed to list organizations", slog.Any("error", err))
+		return
+	}
+	for _, org := range orgs {
+		orgCtx := db.WithOrganization(ctx, org)
+		s.expireReservations(orgCtx)
+		s.promoteWaitlist(orgCtx)
+	}
+}
+
+func (s *Server) expireReservations(ctx context.Context) {
+	expired, err := s.DB.ExpireReservations(ctx)
+	if err != nil {
+		s.logger().ErrorContext(ctx, "Failed to expire reservations", slog.Any("error", err))
+		return
+	}
+	metrics.ReservationsExpired.Add(float64(expired))
+}
+
+// notifyTimeout bounds how long the notifications of one promotion may take to deliver
+const notifyTimeout = 30 * time.Second
+
+// promoteWaitlist places holds for waitlisted clients and notifies them in the background. Failures are
+// logged rather than returned because promotion is a side effect of the request that freed the slot.
+func (s *Server) promoteWaitlist(ctx context.Context) {
+	offers, err := s.DB.PromoteWaitlist(ctx, s.now().Add(s.leadTime(ctx)))
+	if err != nil {
+		s.logger().ErrorContext(ctx, "Failed to promote waitlist", slog.Any("error", err))
+		return
+	}
+	metrics.WaitlistOffers.Add(float64(len(offers)))
+
+	notifications := make([]notify.Notification, 0, len(offers))
+	for _, offer := range offers {
+		notifications = append(notifications, notify.Notification{
+			Type:          notify.WaitlistOffer,
+			UserID:        *offer.Entry.ClientId,
+			AppointmentID: *offer.Appointment.Id,
+			StartTime:     *offer.Appointment.StartTime,
+			ExpiresAt:     *offer.Entry.HoldExpiresAt,
+			Message: fmt.Sprintf("A slot at %s is being held for you, confirm it before %s",
+				offer.Appointment.StartTime.Format(time.RFC3339), offer.Entry.HoldExpiresAt.Format(time.RFC3339)),
+		})
+	}
+	s.notify(ctx, notifications)
+}
+
+// notify delivers notifications without holding up the caller. They keep ctx's values but not its
+// cancellation, because the request that caused them is usually answered before they are sent.
+func (s *Server) notify(ctx context.Context, notifications []notify.Notification) {
+	if len(notifications) == 0 {
+		return
+	}
+
+	s.notifications.Add(1)
+	go func() {
+		defer s.notifications.Done()
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), notifyTimeout)
+		defer cancel()
+
+		for _, n := range notifications {
+			if err := s.notifier().Notify(ctx, n); err != nil {
+				s.logger().ErrorContext(ctx, "Failed to notify user of waitlist offer",
+					slog.String("user_id", n.UserID.String()),
+					slog.Any("error", err),
+				)
+			}
+		}
+	}()
+}
+
+// WaitForNotifications waits for notifications that are still being delivered, or until ctx is done
+func (s *Server) WaitForNotifications(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.notifications.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Server) notifier() notify.Notifier {
+	if s.Notifier == nil {
+		return notify.LogNotifier{Logger: s.logger()}
+	}
+	return s.Notifier
+}
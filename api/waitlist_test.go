@@ -0,0 +1,190 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/notify"
+	"github.com/tateexon/reservation/schema"
+)
+
+type recordingNotifier struct {
+	mu            sync.Mutex
+	notifications []notify.Notification
+}
+
+func (r *recordingNotifier) Notify(_ context.Context, n notify.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifications = append(r.notifications, n)
+	return nil
+}
+
+// sent returns the notifications delivered so far, they are sent in the background
+func (r *recordingNotifier) sent() []notify.Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]notify.Notification(nil), r.notifications...)
+}
+
+// blockingNotifier holds every delivery until release is closed or the delivery's context is done
+type blockingNotifier struct {
+	release chan struct{}
+	done    chan error
+}
+
+func (b *blockingNotifier) Notify(ctx context.Context, _ notify.Notification) error {
+	var err error
+	select {
+	case <-b.release:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	b.done <- err
+	return err
+}
+
+func setupTestServerWithNotifier(store db.Store, notifier notify.Notifier) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	server := &Server{DB: store, Notifier: notifier}
+	server.Register(router, testSpec(), ValidationOptions{Responses: true})
+
+	return router
+}
+
+func TestPostWaitlist_OfferedOnCancel(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	notifier := &recordingNotifier{}
+	router := setupTestServerWithNotifier(store, notifier)
+
+	providerID := createTestProvider(t, store)
+	clientID := createTestClient(t, store)
+	waitingClientID := createTestClient(t, store)
+
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	addTestAvailability(t, store, providerID, []time.Time{startTime})
+	appointment, err := store.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+
+	// Join the waitlist now that the provider is fully booked
+	joinReq := schema.JoinWaitlistRequest{
+		ClientId:    *waitingClientID,
+		ProviderId:  *providerID,
+		WindowStart: startTime,
+		WindowEnd:   startTime.Add(time.Hour),
+	}
+	reqBody, err := json.Marshal(joinReq)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "/waitlist", bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var entry schema.WaitlistEntry
+	err = json.Unmarshal(w.Body.Bytes(), &entry)
+	require.NoError(t, err)
+	require.Equal(t, schema.WaitlistEntryStatusWaiting, *entry.Status)
+	require.Equal(t, 1, *entry.Position)
+
+	// Cancelling the booked appointment frees the slot for the waitlisted client
+	req, err = http.NewRequest(http.MethodPost, "/appointments/"+appointment.Id.String()+"/cancel", nil)
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req, err = http.NewRequest(http.MethodGet, "/providers/"+providerID.String()+"/waitlist", nil)
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var waitlist []schema.WaitlistEntry
+	err = json.Unmarshal(w.Body.Bytes(), &waitlist)
+	require.NoError(t, err)
+	require.Len(t, waitlist, 1)
+	require.Equal(t, schema.WaitlistEntryStatusOffered, *waitlist[0].Status)
+	require.NotNil(t, waitlist[0].AppointmentId)
+
+	require.Eventually(t, func() bool { return len(notifier.sent()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	notifications := notifier.sent()
+	require.Equal(t, notify.WaitlistOffer, notifications[0].Type)
+	require.Equal(t, waitingClientID.String(), notifications[0].UserID.String())
+	require.Equal(t, waitlist[0].AppointmentId.String(), notifications[0].AppointmentID.String())
+}
+
+func TestCancel_DoesNotWaitForWaitlistNotifications(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	notifier := &blockingNotifier{release: make(chan struct{}), done: make(chan error, 1)}
+	router := setupTestServerWithNotifier(store, notifier)
+
+	providerID := createTestProvider(t, store)
+	clientID := createTestClient(t, store)
+	waitingClientID := createTestClient(t, store)
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	addTestAvailability(t, store, providerID, []time.Time{startTime})
+	appointment, err := store.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+	_, err = store.JoinWaitlist(context.Background(), *waitingClientID, *providerID, startTime, startTime.Add(time.Hour), time.Now())
+	require.NoError(t, err)
+
+	// The cancellation is answered while the offer's notification is still being delivered, and the
+	// delivery is not cut short when the request ends
+	req, err := http.NewRequest(http.MethodPost, "/appointments/"+appointment.Id.String()+"/cancel", nil)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	close(notifier.release)
+	select {
+	case err := <-notifier.done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the waitlist offer was never notified")
+	}
+}
+
+func TestPostWaitlist_SlotsAvailable(t *testing.T) {
+	t.Parallel()
+	store := memory.New(nil)
+	router := setupTestServer(store)
+
+	providerID := createTestProvider(t, store)
+	clientID := createTestClient(t, store)
+
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	addTestAvailability(t, store, providerID, []time.Time{startTime})
+
+	joinReq := schema.JoinWaitlistRequest{
+		ClientId:    *clientID,
+		ProviderId:  *providerID,
+		WindowStart: startTime,
+		WindowEnd:   startTime.Add(time.Hour),
+	}
+	reqBody, err := json.Marshal(joinReq)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "/waitlist", bytes.NewBuffer(reqBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, schema.ProblemCodeSlotsAvailable, decodeProblem(t, w).Code)
+}
@@ -0,0 +1,514 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/client"
+	"github.com/tateexon/reservation/utils"
+)
+
+// operatorCommands call a running api through the generated client instead of serving it
+var operatorCommands = map[string]func(args []string, env func(string) (string, bool), out io.Writer) error{
+	"user":         runUser,
+	"availability": runAvailability,
+	"schedule":     runSchedule,
+	"appointment":  runAppointment,
+}
+
+const operatorUsage = `  user create --name NAME --email EMAIL --role client|provider
+  user get USER_ID
+  availability add --provider ID --start TIME --end TIME [--capacity N]
+  schedule --provider ID [--date YYYY-MM-DD]
+  appointment reserve --client ID --provider ID (--slot ID | --start TIME)
+  appointment confirm APPOINTMENT_ID
+  appointment cancel APPOINTMENT_ID [--following]
+  appointment status --status checked_in|completed|no_show --by PROVIDER_ID APPOINTMENT_ID
+                                       call the api at --api or RESERVATION_API_URL, TIME is RFC 3339
+`
+
+// errDryRun stops a mutating request after it has been printed
+var errDryRun = errors.New("dry run")
+
+// runOperator runs one of the operatorCommands, reading its defaults from env and printing to out
+func runOperator(command string, args []string, env func(string) (string, bool), out io.Writer) error {
+	err := operatorCommands[command](args, env, out)
+	if errors.Is(err, errDryRun) || errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	return err
+}
+
+// operatorFlags are shared by every operator command, dry-run is only offered by the mutating ones
+type operatorFlags struct {
+	*flag.FlagSet
+	api    string
+	token  string
+	output string
+	dryRun bool
+}
+
+func newOperatorFlags(name string, mutating bool, env func(string) (string, bool)) *operatorFlags {
+	f := &operatorFlags{FlagSet: flag.NewFlagSet(name, flag.ContinueOnError)}
+	api, ok := env("RESERVATION_API_URL")
+	if !ok {
+		api = "http://localhost:8080"
+	}
+	token, _ := env("RESERVATION_API_TOKEN")
+	f.StringVar(&f.api, "api", api, "base url of the api (RESERVATION_API_URL)")
+	f.StringVar(&f.token, "token", token, "bearer token sent to the api (RESERVATION_API_TOKEN)")
+	f.StringVar(&f.output, "output", "table", "output format, table or json")
+	if mutating {
+		f.BoolVar(&f.dryRun, "dry-run", false, "print the request instead of sending it")
+	}
+	return f
+}
+
+// parse parses args and returns the positional arguments, of which there must be want
+func (f *operatorFlags) parse(args []string, want int) ([]string, error) {
+	if err := f.Parse(args); err != nil {
+		return nil, err
+	}
+	if f.output != "table" && f.output != "json" {
+		return nil, fmt.Errorf("unknown output format %q, use table or json", f.output)
+	}
+	if f.NArg() != want {
+		return nil, fmt.Errorf("%s takes %d argument(s), got %d", f.Name(), want, f.NArg())
+	}
+	return f.Args(), nil
+}
+
+// client returns an api client, in a dry run it prints mutating requests to out instead of sending them
+func (f *operatorFlags) client(out io.Writer) (*client.ClientWithResponses, error) {
+	opts := []client.ClientOption{client.WithRetries(2, 200*time.Millisecond)}
+	if f.token != "" {
+		opts = append(opts, client.WithBearerToken(f.token))
+	}
+	if f.dryRun {
+		opts = append(opts, client.WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+			if req.Method == http.MethodGet {
+				return nil
+			}
+			return printRequest(out, req)
+		}))
+	}
+	return client.New(f.api, opts...)
+}
+
+func printRequest(out io.Writer, req *http.Request) error {
+	fmt.Fprintf(out, "%s %s\n", req.Method, req.URL)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+		var indented bytes.Buffer
+		raw, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		if json.Indent(&indented, raw, "", "  ") == nil {
+			fmt.Fprintln(out, indented.String())
+		}
+	}
+	return errDryRun
+}
+
+// apiError turns an unexpected response into an error carrying the api's problem details
+func apiError(status int, body []byte) error {
+	var problem client.Problem
+	if json.Unmarshal(body, &problem) != nil || problem.Code == "" {
+		return fmt.Errorf("api answered %d", status)
+	}
+	detail := problem.Title
+	if problem.Detail != nil {
+		detail = *problem.Detail
+	}
+	for _, field := range deref(problem.Errors) {
+		detail += fmt.Sprintf(", %s %s", field.Field, field.Message)
+	}
+	return fmt.Errorf("api answered %d %s: %s", status, problem.Code, detail)
+}
+
+// write prints v as json, or as a table with header and the rows from row
+func write[T any](f *operatorFlags, out io.Writer, v []T, header string, row func(T) string) error {
+	if f.output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, header)
+	for _, item := range v {
+		fmt.Fprintln(w, row(item))
+	}
+	return w.Flush()
+}
+
+// message prints the outcome of a command that returns nothing else
+func message(f *operatorFlags, out io.Writer, text string) error {
+	if f.output == "json" {
+		return json.NewEncoder(out).Encode(map[string]string{"message": text})
+	}
+	_, err := fmt.Fprintln(out, text)
+	return err
+}
+
+func parseID(name, s string) (openapi_types.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return id, fmt.Errorf("invalid %s %q: %w", name, s, err)
+	}
+	return id, nil
+}
+
+func parseTime(name, s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return t, fmt.Errorf("invalid %s %q, want RFC 3339 such as 2030-01-07T09:00:00Z: %w", name, s, err)
+	}
+	return t, nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04 MST")
+}
+
+func deref[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+	return *v
+}
+
+func userRow(user client.User) string {
+	return fmt.Sprintf("%s\t%s\t%s\t%s", deref(user.Id), deref(user.Name), deref(user.Email), deref(user.Role))
+}
+
+const userHeader = "ID\tNAME\tEMAIL\tROLE"
+
+func runUser(args []string, env func(string) (string, bool), out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: reservation user create | get")
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "create":
+		f := newOperatorFlags("user create", true, env)
+		name := f.String("name", "", "full name")
+		email := f.String("email", "", "email address")
+		role := f.String("role", "client", "client or provider")
+		if _, err := f.parse(args[1:], 0); err != nil {
+			return err
+		}
+		if *name == "" || *email == "" {
+			return errors.New("user create needs --name and --email")
+		}
+		api, err := f.client(out)
+		if err != nil {
+			return err
+		}
+		resp, err := api.PostUsersWithResponse(ctx, client.CreateUserRequest{
+			Name:  *name,
+			Email: openapi_types.Email(*email),
+			Role:  client.CreateUserRequestRole(*role),
+		})
+		if err != nil {
+			return err
+		}
+		if resp.JSON201 == nil {
+			return apiError(resp.StatusCode(), resp.Body)
+		}
+		return write(f, out, []client.User{*resp.JSON201}, userHeader, userRow)
+	case "get":
+		f := newOperatorFlags("user get", false, env)
+		positional, err := f.parse(args[1:], 1)
+		if err != nil {
+			return err
+		}
+		id, err := parseID("user id", positional[0])
+		if err != nil {
+			return err
+		}
+		api, err := f.client(out)
+		if err != nil {
+			return err
+		}
+		resp, err := api.GetUsersUserIdWithResponse(ctx, id)
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.StatusCode(), resp.Body)
+		}
+		return write(f, out, []client.User{*resp.JSON200}, userHeader, userRow)
+	default:
+		return fmt.Errorf("unknown user command %q", args[0])
+	}
+}
+
+func runAvailability(args []string, env func(string) (string, bool), out io.Writer) error {
+	if len(args) == 0 || args[0] != "add" {
+		return errors.New("usage: reservation availability add")
+	}
+
+	f := newOperatorFlags("availability add", true, env)
+	provider := f.String("provider", "", "provider id")
+	start := f.String("start", "", "start of the range")
+	end := f.String("end", "", "end of the range")
+	capacity := f.Int("capacity", 1, "clients per slot")
+	if _, err := f.parse(args[1:], 0); err != nil {
+		return err
+	}
+	providerID, err := parseID("provider id", *provider)
+	if err != nil {
+		return err
+	}
+	startTime, err := parseTime("start", *start)
+	if err != nil {
+		return err
+	}
+	endTime, err := parseTime("end", *end)
+	if err != nil {
+		return err
+	}
+
+	api, err := f.client(out)
+	if err != nil {
+		return err
+	}
+	resp, err := api.PostProvidersProviderIdAvailabilityWithResponse(context.Background(), providerID, client.Availability{
+		StartTime: startTime,
+		EndTime:   endTime,
+		Capacity:  capacity,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusCreated {
+		return apiError(resp.StatusCode(), resp.Body)
+	}
+	return message(f, out, "Availability added")
+}
+
+func runSchedule(args []string, env func(string) (string, bool), out io.Writer) error {
+	f := newOperatorFlags("schedule", false, env)
+	provider := f.String("provider", "", "provider id")
+	date := f.String("date", "", "only list this day, YYYY-MM-DD")
+	if _, err := f.parse(args, 0); err != nil {
+		return err
+	}
+	providerID, err := parseID("provider id", *provider)
+	if err != nil {
+		return err
+	}
+	params := &client.GetAppointmentsParams{ProviderId: &providerID}
+	if *date != "" {
+		day, err := time.Parse(time.DateOnly, *date)
+		if err != nil {
+			return fmt.Errorf("invalid date %q: %w", *date, err)
+		}
+		params.Date = &openapi_types.Date{Time: day}
+	}
+
+	api, err := f.client(out)
+	if err != nil {
+		return err
+	}
+	resp, err := api.GetAppointmentsWithResponse(context.Background(), params)
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return apiError(resp.StatusCode(), resp.Body)
+	}
+	return write(f, out, *resp.JSON200, "SLOT\tSTART\tEND\tSEATS LEFT", func(slot client.Appointment) string {
+		return fmt.Sprintf("%s\t%s\t%s\t%d/%d", deref(slot.Id), formatTime(slot.StartTime), formatTime(slot.EndTime), deref(slot.SeatsRemaining), deref(slot.Capacity))
+	})
+}
+
+func runAppointment(args []string, env func(string) (string, bool), out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: reservation appointment reserve | confirm | cancel | status")
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "reserve":
+		f := newOperatorFlags("appointment reserve", true, env)
+		clientFlag := f.String("client", "", "client id")
+		provider := f.String("provider", "", "provider id")
+		slot := f.String("slot", "", "slot id from the schedule")
+		start := f.String("start", "", "start time of the slot, instead of --slot")
+		if _, err := f.parse(args[1:], 0); err != nil {
+			return err
+		}
+		clientID, err := parseID("client id", *clientFlag)
+		if err != nil {
+			return err
+		}
+		providerID, err := parseID("provider id", *provider)
+		if err != nil {
+			return err
+		}
+		api, err := f.client(out)
+		if err != nil {
+			return err
+		}
+
+		var slotID openapi_types.UUID
+		switch {
+		case *slot != "" && *start == "":
+			if slotID, err = parseID("slot id", *slot); err != nil {
+				return err
+			}
+		case *start != "" && *slot == "":
+			if slotID, err = findSlot(ctx, api, providerID, *start); err != nil {
+				return err
+			}
+		default:
+			return errors.New("appointment reserve needs one of --slot or --start")
+		}
+
+		resp, err := api.PostAppointmentsWithResponse(ctx, client.PostAppointmentsJSONRequestBody{
+			ClientId:       clientID,
+			ProviderId:     &providerID,
+			AvailabilityId: &slotID,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode() != http.StatusCreated {
+			return apiError(resp.StatusCode(), resp.Body)
+		}
+		var appointment client.Appointment
+		if err := json.Unmarshal(resp.Body, &appointment); err != nil {
+			return err
+		}
+		return write(f, out, []client.Appointment{appointment}, "ID\tSTART\tSTATUS", func(appointment client.Appointment) string {
+			return fmt.Sprintf("%s\t%s\t%s", deref(appointment.Id), formatTime(appointment.StartTime), deref(appointment.Status))
+		})
+	case "confirm", "cancel":
+		f := newOperatorFlags("appointment "+args[0], true, env)
+		following := false
+		if args[0] == "cancel" {
+			f.BoolVar(&following, "following", false, "also cancel the later appointments in its series")
+		}
+		positional, err := f.parse(args[1:], 1)
+		if err != nil {
+			return err
+		}
+		id, err := parseID("appointment id", positional[0])
+		if err != nil {
+			return err
+		}
+		api, err := f.client(out)
+		if err != nil {
+			return err
+		}
+
+		var status int
+		var body []byte
+		if args[0] == "confirm" {
+			resp, err := api.PostAppointmentsAppointmentIdConfirmWithResponse(ctx, id)
+			if err != nil {
+				return err
+			}
+			status, body = resp.StatusCode(), resp.Body
+		} else {
+			params := &client.PostAppointmentsAppointmentIdCancelParams{}
+			if following {
+				params.Scope = utils.Ptr(client.Following)
+			}
+			resp, err := api.PostAppointmentsAppointmentIdCancelWithResponse(ctx, id, params)
+			if err != nil {
+				return err
+			}
+			status, body = resp.StatusCode(), resp.Body
+		}
+		if status != http.StatusOK {
+			return apiError(status, body)
+		}
+		if args[0] == "confirm" {
+			return message(f, out, "Appointment confirmed")
+		}
+		return message(f, out, "Appointment cancelled")
+	case "status":
+		f := newOperatorFlags("appointment status", true, env)
+		status := f.String("status", "", "checked_in, completed or no_show")
+		by := f.String("by", "", "id of the provider changing the status")
+		positional, err := f.parse(args[1:], 1)
+		if err != nil {
+			return err
+		}
+		id, err := parseID("appointment id", positional[0])
+		if err != nil {
+			return err
+		}
+		changedBy, err := parseID("provider id", *by)
+		if err != nil {
+			return err
+		}
+		api, err := f.client(out)
+		if err != nil {
+			return err
+		}
+
+		resp, err := api.PostAppointmentsAppointmentIdStatusWithResponse(ctx, id, client.UpdateAppointmentStatusRequest{
+			Status:    client.AppointmentStatus(*status),
+			ChangedBy: changedBy,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.JSON200 == nil {
+			return apiError(resp.StatusCode(), resp.Body)
+		}
+		return write(f, out, []client.Appointment{*resp.JSON200}, "ID\tSTART\tSTATUS", func(appointment client.Appointment) string {
+			return fmt.Sprintf("%s\t%s\t%s", deref(appointment.Id), formatTime(appointment.StartTime), deref(appointment.Status))
+		})
+	default:
+		return fmt.Errorf("unknown appointment command %q", args[0])
+	}
+}
+
+// findSlot looks up the id of the provider's open slot starting at start
+func findSlot(ctx context.Context, api *client.ClientWithResponses, providerID openapi_types.UUID, start string) (openapi_types.UUID, error) {
+	startTime, err := parseTime("start", start)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	resp, err := api.GetAppointmentsWithResponse(ctx, &client.GetAppointmentsParams{
+		ProviderId: &providerID,
+		// Days are utc days in the api
+		Date: &openapi_types.Date{Time: startTime.UTC()},
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if resp.JSON200 == nil {
+		return uuid.Nil, apiError(resp.StatusCode(), resp.Body)
+	}
+	for _, slot := range *resp.JSON200 {
+		if slot.StartTime != nil && slot.StartTime.Equal(startTime) {
+			return *slot.Id, nil
+		}
+	}
+	return uuid.Nil, fmt.Errorf("the provider has no open slot at %s", startTime.Format(time.RFC3339))
+}
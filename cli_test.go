@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/api"
+	"github.com/tateexon/reservation/clock"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/schema"
+)
+
+func startOperatorAPI(t *testing.T) func(string) (string, bool) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	clk := clock.NewFake(time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC))
+	store := memory.New(nil)
+	store.Clock = clk
+	server := &api.Server{DB: store, Clock: clk}
+	spec, err := schema.GetSwagger()
+	require.NoError(t, err)
+	server.Register(router, spec, api.ValidationOptions{Responses: true})
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	env := map[string]string{"RESERVATION_API_URL": srv.URL}
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+// operate runs an operator command line and returns what it printed
+func operate(t *testing.T, env func(string) (string, bool), args ...string) string {
+	var out bytes.Buffer
+	require.NoError(t, runOperator(args[0], args[1:], env, &out))
+	return out.String()
+}
+
+func createdID(t *testing.T, output string) string {
+	var created []struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &created))
+	require.Len(t, created, 1)
+	return created[0].ID
+}
+
+func TestOperatorCommands(t *testing.T) {
+	t.Parallel()
+	env := startOperatorAPI(t)
+
+	providerID := createdID(t, operate(t, env, "user", "create", "--name", "Dr Smith", "--email", "smith@example.com", "--role", "provider", "--output", "json"))
+	clientID := createdID(t, operate(t, env, "user", "create", "--name", "Pat", "--email", "pat@example.com", "--output", "json"))
+	require.Contains(t, operate(t, env, "user", "get", providerID), "smith@example.com")
+
+	out := operate(t, env, "availability", "add", "--provider", providerID, "--start", "2030-01-09T09:00:00Z", "--end", "2030-01-09T10:00:00Z", "--capacity", "2")
+	require.Equal(t, "Availability added\n", out)
+
+	schedule := operate(t, env, "schedule", "--provider", providerID, "--date", "2030-01-09")
+	require.Contains(t, schedule, "SEATS LEFT")
+	require.Contains(t, schedule, "2/2")
+
+	appointmentID := createdID(t, operate(t, env, "appointment", "reserve", "--client", clientID, "--provider", providerID, "--start", "2030-01-09T09:30:00Z", "--output", "json"))
+	require.Contains(t, operate(t, env, "schedule", "--provider", providerID), "1/2")
+
+	require.Equal(t, "Appointment confirmed\n", operate(t, env, "appointment", "confirm", appointmentID))
+	err := runOperator("appointment", []string{"status", "--status", "checked_in", "--by", providerID, appointmentID}, env, &bytes.Buffer{})
+	require.ErrorContains(t, err, "api answered 409 appointment_not_started")
+	require.Equal(t, "Appointment cancelled\n", operate(t, env, "appointment", "cancel", appointmentID))
+
+	// The api's message explains a failure
+	var errOut bytes.Buffer
+	err = runOperator("appointment", []string{"confirm", appointmentID}, env, &errOut)
+	require.ErrorContains(t, err, "api answered 404 appointment_not_found: Appointment not found or is not a reservation")
+}
+
+func TestOperatorCommands_DryRun(t *testing.T) {
+	t.Parallel()
+	env := startOperatorAPI(t)
+
+	out := operate(t, env, "user", "create", "--name", "Pat", "--email", "pat@example.com", "--dry-run")
+	require.Contains(t, out, "POST ")
+	require.Contains(t, out, `"email": "pat@example.com"`)
+
+	// Nothing reached the api, so the email is still free
+	createdID(t, operate(t, env, "user", "create", "--name", "Pat", "--email", "pat@example.com", "--output", "json"))
+
+	// Read commands do not take the flag
+	err := runOperator("schedule", []string{"--dry-run"}, env, &bytes.Buffer{})
+	require.ErrorContains(t, err, "flag provided but not defined")
+}
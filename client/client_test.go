@@ -0,0 +1,201 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/api"
+	"github.com/tateexon/reservation/client"
+	"github.com/tateexon/reservation/clock"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/db/memory"
+	"github.com/tateexon/reservation/schema"
+)
+
+var epoch = time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC)
+
+// token is the api token of the organization startServer creates
+const token = "secret"
+
+// startServer serves the real handlers over a memory store, calling inspect with every request first
+func startServer(t *testing.T, inspect gin.HandlerFunc) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	if inspect != nil {
+		router.Use(inspect)
+	}
+
+	clk := clock.NewFake(epoch)
+	store := memory.New(nil)
+	store.Clock = clk
+	_, err := store.CreateOrganization(context.Background(), db.NewOrganization{Name: "Test clinic", Slug: "test", TokenHash: db.HashToken(token)})
+	require.NoError(t, err)
+	server := &api.Server{DB: store, Clock: clk}
+	spec, err := schema.GetSwagger()
+	require.NoError(t, err)
+	server.Register(router, spec, api.ValidationOptions{Responses: true})
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func createUser(t *testing.T, c *client.ClientWithResponses, role client.CreateUserRequestRole) uuid.UUID {
+	resp, err := c.PostUsersWithResponse(context.Background(), client.CreateUserRequest{
+		Name:  "Test " + string(role),
+		Email: openapi_types.Email(string(role) + "-" + uuid.NewString() + "@example.com"),
+		Role:  role,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode(), string(resp.Body))
+	return *resp.JSON201.Id
+}
+
+func TestClient_ReserveAndConfirm(t *testing.T) {
+	t.Parallel()
+	srv := startServer(t, nil)
+	ctx := context.Background()
+
+	c, err := client.New(srv.URL)
+	require.NoError(t, err)
+
+	providerID := createUser(t, c, client.CreateUserRequestRoleProvider)
+	clientID := createUser(t, c, client.CreateUserRequestRoleClient)
+
+	user, err := c.GetUsersUserIdWithResponse(ctx, providerID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, user.StatusCode())
+	require.Equal(t, client.UserRoleProvider, *user.JSON200.Role)
+
+	startTime := epoch.Add(48 * time.Hour)
+	availability, err := c.PostProvidersProviderIdAvailabilityWithResponse(ctx, providerID, client.Availability{
+		StartTime: startTime,
+		EndTime:   startTime.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, availability.StatusCode(), string(availability.Body))
+
+	slots, err := c.GetAppointmentsWithResponse(ctx, &client.GetAppointmentsParams{ProviderId: &providerID})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, slots.StatusCode())
+	require.NotEmpty(t, *slots.JSON200)
+
+	reserved, err := c.PostAppointmentsWithResponse(ctx, client.PostAppointmentsJSONRequestBody{
+		ClientId:       clientID,
+		ProviderId:     &providerID,
+		AvailabilityId: (*slots.JSON200)[0].Id,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, reserved.StatusCode(), string(reserved.Body))
+
+	// The response is an appointment or a series, a plain reservation decodes as an appointment
+	var appointment client.Appointment
+	require.NoError(t, json.Unmarshal(reserved.Body, &appointment))
+	require.Equal(t, client.AppointmentStatusReserved, *appointment.Status)
+
+	confirmed, err := c.PostAppointmentsAppointmentIdConfirmWithResponse(ctx, *appointment.Id)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, confirmed.StatusCode(), string(confirmed.Body))
+
+	missing, err := c.PostAppointmentsAppointmentIdConfirmWithResponse(ctx, uuid.New())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, missing.StatusCode())
+}
+
+func TestClient_Headers(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	seen := map[string]http.Header{}
+	srv := startServer(t, func(c *gin.Context) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen[c.Request.Method] = c.Request.Header.Clone()
+	})
+	last := func(method string) http.Header {
+		mu.Lock()
+		defer mu.Unlock()
+		return seen[method]
+	}
+
+	c, err := client.New(srv.URL, client.WithBearerToken(token), client.WithIdempotencyKeys())
+	require.NoError(t, err)
+
+	userID := createUser(t, c, client.CreateUserRequestRoleClient)
+	_, err = c.GetUsersUserIdWithResponse(context.Background(), userID)
+	require.NoError(t, err)
+
+	require.Equal(t, "Bearer secret", last(http.MethodPost).Get("Authorization"))
+	require.Equal(t, "Bearer secret", last(http.MethodGet).Get("Authorization"))
+	require.NotEmpty(t, last(http.MethodPost).Get(client.IdempotencyKeyHeader))
+	require.Empty(t, last(http.MethodGet).Get(client.IdempotencyKeyHeader))
+
+	// A key given to the call wins over a generated one
+	_, err = c.PostUsersWithResponse(context.Background(), client.CreateUserRequest{
+		Name:  "Test client",
+		Email: openapi_types.Email("client-" + uuid.NewString() + "@example.com"),
+		Role:  client.CreateUserRequestRoleClient,
+	}, client.WithIdempotencyKey("create-user-1"))
+	require.NoError(t, err)
+	require.Equal(t, "create-user-1", last(http.MethodPost).Get(client.IdempotencyKeyHeader))
+}
+
+func TestClient_Retries(t *testing.T) {
+	t.Parallel()
+
+	// The server is unavailable for the first two attempts at every GET
+	var calls atomic.Int32
+	srv := startServer(t, func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet && calls.Add(1)%3 != 0 {
+			c.AbortWithStatus(http.StatusServiceUnavailable)
+		}
+	})
+
+	c, err := client.New(srv.URL, client.WithRetries(2, time.Millisecond))
+	require.NoError(t, err)
+
+	userID := createUser(t, c, client.CreateUserRequestRoleClient)
+	user, err := c.GetUsersUserIdWithResponse(context.Background(), userID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, user.StatusCode())
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func TestClient_RetriesOnlySafeMethods(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	srv := startServer(t, func(c *gin.Context) {
+		calls.Add(1)
+		c.AbortWithStatus(http.StatusServiceUnavailable)
+	})
+
+	c, err := client.New(srv.URL, client.WithIdempotencyKeys(), client.WithRetries(3, time.Millisecond))
+	require.NoError(t, err)
+
+	// The api does not recognise a repeated POST even with a key, so it is sent once
+	resp, err := c.PostUsersWithResponse(context.Background(), client.CreateUserRequest{
+		Name:  "Test client",
+		Email: "client@example.com",
+		Role:  client.CreateUserRequestRoleClient,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode())
+	require.Equal(t, int32(1), calls.Load())
+
+	// A GET is retried until the attempts run out
+	resp2, err := c.GetUsersUserIdWithResponse(context.Background(), uuid.New())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, resp2.StatusCode())
+	require.Equal(t, int32(5), calls.Load())
+}
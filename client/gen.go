@@ -0,0 +1,6121 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version 2.4.0 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for AppointmentStatus.
+const (
+	AppointmentStatusCancelled AppointmentStatus = "cancelled"
+	AppointmentStatusCheckedIn AppointmentStatus = "checked_in"
+	AppointmentStatusCompleted AppointmentStatus = "completed"
+	AppointmentStatusConfirmed AppointmentStatus = "confirmed"
+	AppointmentStatusExpired   AppointmentStatus = "expired"
+	AppointmentStatusNoShow    AppointmentStatus = "no_show"
+	AppointmentStatusReserved  AppointmentStatus = "reserved"
+)
+
+// Defines values for CreateUserRequestRole.
+const (
+	CreateUserRequestRoleClient   CreateUserRequestRole = "client"
+	CreateUserRequestRoleProvider CreateUserRequestRole = "provider"
+)
+
+// Defines values for GroupStrategy.
+const (
+	LeastBooked GroupStrategy = "least_booked"
+	Priority    GroupStrategy = "priority"
+	RoundRobin  GroupStrategy = "round_robin"
+)
+
+// Defines values for IntakeQuestionType.
+const (
+	Boolean IntakeQuestionType = "boolean"
+	Choice  IntakeQuestionType = "choice"
+	Number  IntakeQuestionType = "number"
+	Text    IntakeQuestionType = "text"
+)
+
+// Defines values for OccurrenceConflictReason.
+const (
+	Booked         OccurrenceConflictReason = "booked"
+	NoAvailability OccurrenceConflictReason = "no_availability"
+	RoomBooked     OccurrenceConflictReason = "room_booked"
+	RoomElsewhere  OccurrenceConflictReason = "room_elsewhere"
+)
+
+// Defines values for OccurrenceScope.
+const (
+	Following OccurrenceScope = "following"
+	This      OccurrenceScope = "this"
+)
+
+// Defines values for ProblemCode.
+const (
+	ProblemCodeAppointmentLimitReached         ProblemCode = "appointment_limit_reached"
+	ProblemCodeAppointmentNotFound             ProblemCode = "appointment_not_found"
+	ProblemCodeAppointmentNotStarted           ProblemCode = "appointment_not_started"
+	ProblemCodeAppointmentTypeNotFound         ProblemCode = "appointment_type_not_found"
+	ProblemCodeAvailabilityNotFound            ProblemCode = "availability_not_found"
+	ProblemCodeClientDoubleBooked              ProblemCode = "client_double_booked"
+	ProblemCodeEmailTaken                      ProblemCode = "email_taken"
+	ProblemCodeGroupNotFound                   ProblemCode = "group_not_found"
+	ProblemCodeHoldExpired                     ProblemCode = "hold_expired"
+	ProblemCodeHoldLimitReached                ProblemCode = "hold_limit_reached"
+	ProblemCodeInternalError                   ProblemCode = "internal_error"
+	ProblemCodeInvalidRequest                  ProblemCode = "invalid_request"
+	ProblemCodeInvalidStatusTransition         ProblemCode = "invalid_status_transition"
+	ProblemCodeInvalidToken                    ProblemCode = "invalid_token"
+	ProblemCodeLeadTimeViolation               ProblemCode = "lead_time_violation"
+	ProblemCodeLocationNotFound                ProblemCode = "location_not_found"
+	ProblemCodeNoShowLimitReached              ProblemCode = "no_show_limit_reached"
+	ProblemCodeProviderAppointmentLimitReached ProblemCode = "provider_appointment_limit_reached"
+	ProblemCodeProviderNotFound                ProblemCode = "provider_not_found"
+	ProblemCodeRoomNotFound                    ProblemCode = "room_not_found"
+	ProblemCodeRoomUnavailable                 ProblemCode = "room_unavailable"
+	ProblemCodeSeriesNotFound                  ProblemCode = "series_not_found"
+	ProblemCodeServiceUnavailable              ProblemCode = "service_unavailable"
+	ProblemCodeSlotUnavailable                 ProblemCode = "slot_unavailable"
+	ProblemCodeSlotsAvailable                  ProblemCode = "slots_available"
+	ProblemCodeTokenRequired                   ProblemCode = "token_required"
+	ProblemCodeUserHasAppointments             ProblemCode = "user_has_appointments"
+	ProblemCodeUserInactive                    ProblemCode = "user_inactive"
+	ProblemCodeUserNotFound                    ProblemCode = "user_not_found"
+	ProblemCodeValidationFailed                ProblemCode = "validation_failed"
+	ProblemCodeWaitlistEntryNotFound           ProblemCode = "waitlist_entry_not_found"
+)
+
+// Defines values for RecurrenceFrequency.
+const (
+	Daily  RecurrenceFrequency = "daily"
+	Weekly RecurrenceFrequency = "weekly"
+)
+
+// Defines values for StatusChangeSource.
+const (
+	StatusChangeSourceHoldExpiry StatusChangeSource = "hold_expiry"
+	StatusChangeSourceMigration  StatusChangeSource = "migration"
+	StatusChangeSourceProvider   StatusChangeSource = "provider"
+	StatusChangeSourceRequest    StatusChangeSource = "request"
+	StatusChangeSourceWaitlist   StatusChangeSource = "waitlist"
+)
+
+// Defines values for UserRole.
+const (
+	UserRoleClient   UserRole = "client"
+	UserRoleProvider UserRole = "provider"
+)
+
+// Defines values for WaitlistEntryStatus.
+const (
+	WaitlistEntryStatusCancelled WaitlistEntryStatus = "cancelled"
+	WaitlistEntryStatusExpired   WaitlistEntryStatus = "expired"
+	WaitlistEntryStatusFulfilled WaitlistEntryStatus = "fulfilled"
+	WaitlistEntryStatusOffered   WaitlistEntryStatus = "offered"
+	WaitlistEntryStatusWaiting   WaitlistEntryStatus = "waiting"
+)
+
+// Appointment defines model for Appointment.
+type Appointment struct {
+	// AppointmentTypeId The kind of appointment booked, whose intake questions intake_answers answers
+	AppointmentTypeId *openapi_types.UUID `json:"appointment_type_id,omitempty"`
+
+	// Assignment How a group booking picked its provider, only set on the response to one
+	Assignment *Assignment `json:"assignment,omitempty"`
+
+	// Capacity Number of clients that can book this slot, only set in slot listings
+	Capacity *int                `json:"capacity,omitempty"`
+	ClientId *openapi_types.UUID `json:"client_id,omitempty"`
+	EndTime  *time.Time          `json:"end_time,omitempty"`
+	Id       *openapi_types.UUID `json:"id,omitempty"`
+
+	// IntakeAnswers The client's answers to the intake questions of the appointment type by question key
+	IntakeAnswers *map[string]interface{} `json:"intake_answers,omitempty"`
+
+	// LocationId Where the slot is, if its availability was given a location
+	LocationId *openapi_types.UUID `json:"location_id,omitempty"`
+
+	// ParticipantIds The other providers who take part in the appointment, each holding a seat in their own slot at the same time
+	ParticipantIds *[]openapi_types.UUID `json:"participant_ids,omitempty"`
+	ProviderId     *openapi_types.UUID   `json:"provider_id,omitempty"`
+
+	// Reason Why the client booked, if they said
+	Reason *string `json:"reason,omitempty"`
+
+	// RoomId The room booked together with the provider, if any
+	RoomId *openapi_types.UUID `json:"room_id,omitempty"`
+
+	// SeatsRemaining Number of seats in this slot that can still be booked, only set in slot listings
+	SeatsRemaining *int `json:"seats_remaining,omitempty"`
+
+	// SeriesId The recurring series this appointment was booked in, if any
+	SeriesId *openapi_types.UUID `json:"series_id,omitempty"`
+
+	// SeriesIndex Zero based position of this appointment in its series
+	SeriesIndex *int               `json:"series_index,omitempty"`
+	StartTime   *time.Time         `json:"start_time,omitempty"`
+	Status      *AppointmentStatus `json:"status,omitempty"`
+
+	// StatusChangedAt When the appointment was last checked in, completed or marked a no-show
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+
+	// StatusChangedBy The provider who last checked the appointment in, completed it or marked it a no-show
+	StatusChangedBy *openapi_types.UUID `json:"status_changed_by,omitempty"`
+}
+
+// AppointmentStatus defines model for Appointment.Status.
+type AppointmentStatus string
+
+// AppointmentNote One version of the providers' notes on an appointment, saving them adds a version
+type AppointmentNote struct {
+	AppointmentId *openapi_types.UUID `json:"appointment_id,omitempty"`
+
+	// AuthorId The author_id given when this version was saved
+	AuthorId  *openapi_types.UUID `json:"author_id,omitempty"`
+	Body      *string             `json:"body,omitempty"`
+	CreatedAt *time.Time          `json:"created_at,omitempty"`
+
+	// Version Starts at 1 and goes up with every save
+	Version *int `json:"version,omitempty"`
+}
+
+// AppointmentSeries defines model for AppointmentSeries.
+type AppointmentSeries struct {
+	Appointments *[]Appointment      `json:"appointments,omitempty"`
+	ClientId     *openapi_types.UUID `json:"client_id,omitempty"`
+
+	// Conflicts Occurrences that could not be reserved when partial series are allowed
+	Conflicts  *[]OccurrenceConflict `json:"conflicts,omitempty"`
+	Id         *openapi_types.UUID   `json:"id,omitempty"`
+	ProviderId *openapi_types.UUID   `json:"provider_id,omitempty"`
+	Recurrence *Recurrence           `json:"recurrence,omitempty"`
+}
+
+// AppointmentType A kind of appointment, clients booking one answer its intake questions
+type AppointmentType struct {
+	Id              *openapi_types.UUID `json:"id,omitempty"`
+	IntakeQuestions *[]IntakeQuestion   `json:"intake_questions,omitempty"`
+	Name            *string             `json:"name,omitempty"`
+}
+
+// Assignment How a group booking picked its provider, only set on the response to one
+type Assignment struct {
+	// Candidates The active members in the order the strategy ranked them
+	Candidates  *[]AssignmentCandidate `json:"candidates,omitempty"`
+	Explanation *string                `json:"explanation,omitempty"`
+	GroupId     *openapi_types.UUID    `json:"group_id,omitempty"`
+
+	// Strategy How a group booking picks among the providers free at the time. round_robin takes the one assigned least recently, least_booked the one with the fewest appointments in the week of the booking and priority the first in the group's order. Ties go to the earlier provider in the group.
+	Strategy *GroupStrategy `json:"strategy,omitempty"`
+}
+
+// AssignmentCandidate defines model for AssignmentCandidate.
+type AssignmentCandidate struct {
+	// AppointmentsThisWeek Reserved and confirmed appointments the provider has in the week of the booking, Monday to Sunday UTC
+	AppointmentsThisWeek *int `json:"appointments_this_week,omitempty"`
+
+	// Available Whether the provider had a free slot at the time
+	Available *bool `json:"available,omitempty"`
+
+	// LastAssignment Number of the group's assignment the provider last got, 0 if they never got one
+	LastAssignment *int `json:"last_assignment,omitempty"`
+
+	// Priority One based position in the group
+	Priority   *int                `json:"priority,omitempty"`
+	ProviderId *openapi_types.UUID `json:"provider_id,omitempty"`
+}
+
+// Availability defines model for Availability.
+type Availability struct {
+	// Capacity Number of clients that can book each slot, more than one for group sessions
+	Capacity *int                `json:"capacity,omitempty"`
+	EndTime  time.Time           `json:"end_time"`
+	Id       *openapi_types.UUID `json:"id,omitempty"`
+
+	// LocationId Where the provider sees clients in these slots, needed to book a room with them
+	LocationId *openapi_types.UUID `json:"location_id,omitempty"`
+	ProviderId *openapi_types.UUID `json:"provider_id,omitempty"`
+	StartTime  time.Time           `json:"start_time"`
+}
+
+// CreateAppointmentTypeRequest defines model for CreateAppointmentTypeRequest.
+type CreateAppointmentTypeRequest struct {
+	IntakeQuestions *[]IntakeQuestion `json:"intake_questions,omitempty"`
+	Name            string            `json:"name"`
+}
+
+// CreateLocationRequest defines model for CreateLocationRequest.
+type CreateLocationRequest struct {
+	Address *string `json:"address,omitempty"`
+	Name    string  `json:"name"`
+}
+
+// CreateProviderGroupRequest defines model for CreateProviderGroupRequest.
+type CreateProviderGroupRequest struct {
+	Name string `json:"name"`
+
+	// ProviderIds The members in priority order
+	ProviderIds []openapi_types.UUID `json:"provider_ids"`
+
+	// Strategy How a group booking picks among the providers free at the time. round_robin takes the one assigned least recently, least_booked the one with the fewest appointments in the week of the booking and priority the first in the group's order. Ties go to the earlier provider in the group.
+	Strategy *GroupStrategy `json:"strategy,omitempty"`
+}
+
+// CreateRoomRequest defines model for CreateRoomRequest.
+type CreateRoomRequest struct {
+	Name string `json:"name"`
+}
+
+// CreateUserRequest defines model for CreateUserRequest.
+type CreateUserRequest struct {
+	Email openapi_types.Email   `json:"email"`
+	Name  string                `json:"name"`
+	Role  CreateUserRequestRole `json:"role"`
+}
+
+// CreateUserRequestRole defines model for CreateUserRequest.Role.
+type CreateUserRequestRole string
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Field Name of the request field or parameter, as it is spelled in the request
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// GroupStrategy How a group booking picks among the providers free at the time. round_robin takes the one assigned least recently, least_booked the one with the fewest appointments in the week of the booking and priority the first in the group's order. Ties go to the earlier provider in the group.
+type GroupStrategy string
+
+// IntakeQuestion A question clients answer when booking an appointment of a type
+type IntakeQuestion struct {
+	// Key Name of the answer in intake_answers
+	Key   string `json:"key"`
+	Label string `json:"label"`
+
+	// Options The answers a choice question allows, needed for choice questions only
+	Options  *[]string `json:"options,omitempty"`
+	Required *bool     `json:"required,omitempty"`
+
+	// Type A text answer is a string, a choice one of the options
+	Type IntakeQuestionType `json:"type"`
+}
+
+// IntakeQuestionType A text answer is a string, a choice one of the options
+type IntakeQuestionType string
+
+// JoinWaitlistRequest defines model for JoinWaitlistRequest.
+type JoinWaitlistRequest struct {
+	ClientId    openapi_types.UUID `json:"client_id"`
+	ProviderId  openapi_types.UUID `json:"provider_id"`
+	WindowEnd   time.Time          `json:"window_end"`
+	WindowStart time.Time          `json:"window_start"`
+}
+
+// Location A site the organization sees clients at, availability is offered at one
+type Location struct {
+	Address *string             `json:"address,omitempty"`
+	Id      *openapi_types.UUID `json:"id,omitempty"`
+	Name    *string             `json:"name,omitempty"`
+}
+
+// OccurrenceConflict defines model for OccurrenceConflict.
+type OccurrenceConflict struct {
+	Index     *int                      `json:"index,omitempty"`
+	Reason    *OccurrenceConflictReason `json:"reason,omitempty"`
+	StartTime *time.Time                `json:"start_time,omitempty"`
+}
+
+// OccurrenceConflictReason defines model for OccurrenceConflict.Reason.
+type OccurrenceConflictReason string
+
+// OccurrenceScope Apply to this occurrence only, or to this and the following occurrences in its series
+type OccurrenceScope string
+
+// Organization A clinic, its users and their bookings are invisible to every other organization
+type Organization struct {
+	// Hosts Request hosts that resolve to this organization when no token is sent
+	Hosts *[]string           `json:"hosts,omitempty"`
+	Id    *openapi_types.UUID `json:"id,omitempty"`
+	Name  *string             `json:"name,omitempty"`
+
+	// Settings Per organization overrides of the deployment's defaults
+	Settings *OrganizationSettings `json:"settings,omitempty"`
+	Slug     *string               `json:"slug,omitempty"`
+}
+
+// OrganizationSettings Per organization overrides of the deployment's defaults
+type OrganizationSettings struct {
+	// AllowOverlappingAppointments Lets a client hold appointments at overlapping times, by default they can not
+	AllowOverlappingAppointments *bool `json:"allow_overlapping_appointments,omitempty"`
+
+	// LeadTimeMinutes How far in advance reservations must be made, defaults to 24 hours
+	LeadTimeMinutes *int `json:"lead_time_minutes,omitempty"`
+
+	// MaxActiveHolds Unconfirmed reservations a client may hold at once, unlimited by default
+	MaxActiveHolds *int `json:"max_active_holds,omitempty"`
+
+	// MaxFutureAppointments Upcoming reservations and appointments a client may have, unlimited by default
+	MaxFutureAppointments *int `json:"max_future_appointments,omitempty"`
+
+	// MaxFutureAppointmentsPerProvider Upcoming reservations and appointments a client may have with one provider, unlimited by default
+	MaxFutureAppointmentsPerProvider *int `json:"max_future_appointments_per_provider,omitempty"`
+
+	// MaxNoShows No-shows in the last 90 days that stop a client from booking, unlimited by default
+	MaxNoShows *int `json:"max_no_shows,omitempty"`
+
+	// SlotIntervalMinutes Length of availability slots, defaults to the deployment's availability interval
+	SlotIntervalMinutes *int `json:"slot_interval_minutes,omitempty"`
+}
+
+// Problem An RFC 7807 problem details object, served as application/problem+json
+type Problem struct {
+	// Code Stable machine readable reason for an error, clients should branch on this and not on the text
+	Code ProblemCode `json:"code"`
+
+	// Conflicts The occurrences that could not be booked
+	Conflicts *[]OccurrenceConflict `json:"conflicts,omitempty"`
+
+	// Detail Explanation of this occurrence of the problem
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors The fields that failed validation
+	Errors *[]FieldError `json:"errors,omitempty"`
+
+	// Instance Path of the request that failed
+	Instance *string `json:"instance,omitempty"`
+	Status   int     `json:"status"`
+
+	// Title Short summary that is the same for every problem with this code
+	Title string `json:"title"`
+
+	// Type URI identifying the kind of problem, one per code
+	Type string `json:"type"`
+}
+
+// ProblemCode Stable machine readable reason for an error, clients should branch on this and not on the text
+type ProblemCode string
+
+// Provider defines model for Provider.
+type Provider struct {
+	Bio       *string             `json:"bio,omitempty"`
+	Id        *openapi_types.UUID `json:"id,omitempty"`
+	Languages *[]string           `json:"languages,omitempty"`
+	Location  *string             `json:"location,omitempty"`
+	Name      *string             `json:"name,omitempty"`
+	Specialty *string             `json:"specialty,omitempty"`
+}
+
+// ProviderGroup Providers that can stand in for each other, like a department, booked without picking one
+type ProviderGroup struct {
+	Id   *openapi_types.UUID `json:"id,omitempty"`
+	Name *string             `json:"name,omitempty"`
+
+	// ProviderIds The members in priority order
+	ProviderIds *[]openapi_types.UUID `json:"provider_ids,omitempty"`
+
+	// Strategy How a group booking picks among the providers free at the time. round_robin takes the one assigned least recently, least_booked the one with the fewest appointments in the week of the booking and priority the first in the group's order. Ties go to the earlier provider in the group.
+	Strategy *GroupStrategy `json:"strategy,omitempty"`
+}
+
+// ProviderProfile What clients see of a provider in the directory, every field is replaced when it is saved
+type ProviderProfile struct {
+	Bio *string `json:"bio,omitempty"`
+
+	// Languages Languages the provider sees clients in, such as ISO 639-1 codes, matched case insensitively
+	Languages *[]string `json:"languages,omitempty"`
+	Location  *string   `json:"location,omitempty"`
+	Specialty *string   `json:"specialty,omitempty"`
+}
+
+// Recurrence defines model for Recurrence.
+type Recurrence struct {
+	// Count Total number of occurrences including the first
+	Count     int                 `json:"count"`
+	Frequency RecurrenceFrequency `json:"frequency"`
+
+	// Interval Number of days or weeks between occurrences
+	Interval *int `json:"interval,omitempty"`
+}
+
+// RecurrenceFrequency defines model for Recurrence.Frequency.
+type RecurrenceFrequency string
+
+// RescheduleRequest defines model for RescheduleRequest.
+type RescheduleRequest struct {
+	AvailabilityId openapi_types.UUID `json:"availability_id"`
+
+	// Scope Apply to this occurrence only, or to this and the following occurrences in its series
+	Scope *OccurrenceScope `json:"scope,omitempty"`
+}
+
+// Room A room at a location, booking one claims it together with the provider
+type Room struct {
+	Id         *openapi_types.UUID `json:"id,omitempty"`
+	LocationId *openapi_types.UUID `json:"location_id,omitempty"`
+	Name       *string             `json:"name,omitempty"`
+}
+
+// SaveAppointmentNoteRequest defines model for SaveAppointmentNoteRequest.
+type SaveAppointmentNoteRequest struct {
+	// AuthorId The appointment's provider or one of its participants, recorded with the version. The server has no user accounts, so this attributes the note and is not authenticated
+	AuthorId openapi_types.UUID `json:"author_id"`
+	Body     string             `json:"body"`
+}
+
+// StatusChange One status an appointment took, its history lists every one from the reservation on
+type StatusChange struct {
+	ChangedAt *time.Time `json:"changed_at,omitempty"`
+
+	// ChangedBy The provider who recorded an outcome, the api has no user accounts to attribute other changes to
+	ChangedBy *openapi_types.UUID `json:"changed_by,omitempty"`
+
+	// Source What made the change: a request to the api, a provider recording an outcome, the waitlist holding a freed slot, a hold running out, or the status the appointment had when its history started being kept
+	Source *StatusChangeSource `json:"source,omitempty"`
+	Status *AppointmentStatus  `json:"status,omitempty"`
+}
+
+// StatusChangeSource What made the change: a request to the api, a provider recording an outcome, the waitlist holding a freed slot, a hold running out, or the status the appointment had when its history started being kept
+type StatusChangeSource string
+
+// UpdateAppointmentStatusRequest defines model for UpdateAppointmentStatusRequest.
+type UpdateAppointmentStatusRequest struct {
+	// ChangedBy The appointment's provider or one of its participants
+	ChangedBy openapi_types.UUID `json:"changed_by"`
+
+	// Status Confirmed appointments are checked in or marked a no-show and checked in ones completed, once they have started
+	Status AppointmentStatus `json:"status"`
+}
+
+// UpdateUserRequest defines model for UpdateUserRequest.
+type UpdateUserRequest struct {
+	Email *openapi_types.Email `json:"email,omitempty"`
+	Name  *string              `json:"name,omitempty"`
+}
+
+// User defines model for User.
+type User struct {
+	// DeactivatedAt When the user was deactivated, a deactivated user can not book or be booked
+	DeactivatedAt *time.Time          `json:"deactivated_at,omitempty"`
+	Email         *string             `json:"email,omitempty"`
+	Id            *openapi_types.UUID `json:"id,omitempty"`
+	Name          *string             `json:"name,omitempty"`
+	Role          *UserRole           `json:"role,omitempty"`
+}
+
+// UserRole defines model for User.Role.
+type UserRole string
+
+// WaitlistEntry defines model for WaitlistEntry.
+type WaitlistEntry struct {
+	// AppointmentId The hold placed for this client once a slot was offered
+	AppointmentId *openapi_types.UUID `json:"appointment_id,omitempty"`
+	ClientId      *openapi_types.UUID `json:"client_id,omitempty"`
+	CreatedAt     *time.Time          `json:"created_at,omitempty"`
+
+	// HoldExpiresAt Deadline for confirming the offered hold
+	HoldExpiresAt *time.Time          `json:"hold_expires_at,omitempty"`
+	Id            *openapi_types.UUID `json:"id,omitempty"`
+
+	// Position Place in the provider's queue, only set while waiting
+	Position    *int                 `json:"position,omitempty"`
+	ProviderId  *openapi_types.UUID  `json:"provider_id,omitempty"`
+	Status      *WaitlistEntryStatus `json:"status,omitempty"`
+	WindowEnd   *time.Time           `json:"window_end,omitempty"`
+	WindowStart *time.Time           `json:"window_start,omitempty"`
+}
+
+// WaitlistEntryStatus defines model for WaitlistEntry.Status.
+type WaitlistEntryStatus string
+
+// BadRequest An RFC 7807 problem details object, served as application/problem+json
+type BadRequest = Problem
+
+// Conflict An RFC 7807 problem details object, served as application/problem+json
+type Conflict = Problem
+
+// Gone An RFC 7807 problem details object, served as application/problem+json
+type Gone = Problem
+
+// InternalError An RFC 7807 problem details object, served as application/problem+json
+type InternalError = Problem
+
+// NotFound An RFC 7807 problem details object, served as application/problem+json
+type NotFound = Problem
+
+// Unauthorized An RFC 7807 problem details object, served as application/problem+json
+type Unauthorized = Problem
+
+// GetAppointmentsParams defines parameters for GetAppointments.
+type GetAppointmentsParams struct {
+	ProviderId *openapi_types.UUID `form:"providerId,omitempty" json:"providerId,omitempty"`
+	LocationId *openapi_types.UUID `form:"locationId,omitempty" json:"locationId,omitempty"`
+
+	// ParticipantId Keep the provider's slots where this provider is free at the same time too, repeat it for several. Needs providerId.
+	ParticipantId *[]openapi_types.UUID `form:"participantId,omitempty" json:"participantId,omitempty"`
+	Date          *openapi_types.Date   `form:"date,omitempty" json:"date,omitempty"`
+}
+
+// PostAppointmentsJSONBody defines parameters for PostAppointments.
+type PostAppointmentsJSONBody struct {
+	// AllowPartial Reserve the available occurrences of a series instead of failing when some conflict
+	AllowPartial *bool `json:"allow_partial,omitempty"`
+
+	// AppointmentTypeId The kind of appointment, needed to give intake_answers
+	AppointmentTypeId *openapi_types.UUID `json:"appointment_type_id,omitempty"`
+
+	// AvailabilityId Needed unless group_id is given
+	AvailabilityId *openapi_types.UUID `json:"availability_id,omitempty"`
+	ClientId       openapi_types.UUID  `json:"client_id"`
+
+	// GroupId Book whichever provider of the group its strategy assigns, at start_time
+	GroupId *openapi_types.UUID `json:"group_id,omitempty"`
+
+	// IntakeAnswers Answers to the intake questions of the appointment type by question key
+	IntakeAnswers *map[string]interface{} `json:"intake_answers,omitempty"`
+
+	// ParticipantIds Other providers needed at the same time, the reservation holds a seat in each of their slots or fails
+	ParticipantIds *[]openapi_types.UUID `json:"participant_ids,omitempty"`
+
+	// ProviderId Needed unless group_id is given
+	ProviderId *openapi_types.UUID `json:"provider_id,omitempty"`
+
+	// Reason Why the client is booking, shown to the client and the providers
+	Reason     *string     `json:"reason,omitempty"`
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+
+	// RoomId A room at the slot's location to book together with the provider
+	RoomId *openapi_types.UUID `json:"room_id,omitempty"`
+
+	// StartTime The slot start time to assign a group provider at, needed with group_id
+	StartTime *time.Time `json:"start_time,omitempty"`
+}
+
+// PostAppointmentsAppointmentIdCancelParams defines parameters for PostAppointmentsAppointmentIdCancel.
+type PostAppointmentsAppointmentIdCancelParams struct {
+	Scope *OccurrenceScope `form:"scope,omitempty" json:"scope,omitempty"`
+}
+
+// GetProvidersParams defines parameters for GetProviders.
+type GetProvidersParams struct {
+	// Q Words that must all appear in the provider's name or bio
+	Q         *string `form:"q,omitempty" json:"q,omitempty"`
+	Specialty *string `form:"specialty,omitempty" json:"specialty,omitempty"`
+	Language  *string `form:"language,omitempty" json:"language,omitempty"`
+
+	// AvailableFrom Only providers with a bookable slot starting at or after this time
+	AvailableFrom *time.Time `form:"availableFrom,omitempty" json:"availableFrom,omitempty"`
+
+	// AvailableTo Only providers with a bookable slot ending at or before this time
+	AvailableTo *time.Time `form:"availableTo,omitempty" json:"availableTo,omitempty"`
+}
+
+// GetRoomsRoomIdAppointmentsParams defines parameters for GetRoomsRoomIdAppointments.
+type GetRoomsRoomIdAppointmentsParams struct {
+	Date *openapi_types.Date `form:"date,omitempty" json:"date,omitempty"`
+}
+
+// PostAppointmentTypesJSONRequestBody defines body for PostAppointmentTypes for application/json ContentType.
+type PostAppointmentTypesJSONRequestBody = CreateAppointmentTypeRequest
+
+// PostAppointmentsJSONRequestBody defines body for PostAppointments for application/json ContentType.
+type PostAppointmentsJSONRequestBody PostAppointmentsJSONBody
+
+// PutAppointmentsAppointmentIdNotesJSONRequestBody defines body for PutAppointmentsAppointmentIdNotes for application/json ContentType.
+type PutAppointmentsAppointmentIdNotesJSONRequestBody = SaveAppointmentNoteRequest
+
+// PostAppointmentsAppointmentIdRescheduleJSONRequestBody defines body for PostAppointmentsAppointmentIdReschedule for application/json ContentType.
+type PostAppointmentsAppointmentIdRescheduleJSONRequestBody = RescheduleRequest
+
+// PostAppointmentsAppointmentIdStatusJSONRequestBody defines body for PostAppointmentsAppointmentIdStatus for application/json ContentType.
+type PostAppointmentsAppointmentIdStatusJSONRequestBody = UpdateAppointmentStatusRequest
+
+// PostLocationsJSONRequestBody defines body for PostLocations for application/json ContentType.
+type PostLocationsJSONRequestBody = CreateLocationRequest
+
+// PostLocationsLocationIdRoomsJSONRequestBody defines body for PostLocationsLocationIdRooms for application/json ContentType.
+type PostLocationsLocationIdRoomsJSONRequestBody = CreateRoomRequest
+
+// PostProviderGroupsJSONRequestBody defines body for PostProviderGroups for application/json ContentType.
+type PostProviderGroupsJSONRequestBody = CreateProviderGroupRequest
+
+// PostProvidersProviderIdAvailabilityJSONRequestBody defines body for PostProvidersProviderIdAvailability for application/json ContentType.
+type PostProvidersProviderIdAvailabilityJSONRequestBody = Availability
+
+// PutProvidersProviderIdProfileJSONRequestBody defines body for PutProvidersProviderIdProfile for application/json ContentType.
+type PutProvidersProviderIdProfileJSONRequestBody = ProviderProfile
+
+// PostUsersJSONRequestBody defines body for PostUsers for application/json ContentType.
+type PostUsersJSONRequestBody = CreateUserRequest
+
+// PatchUsersUserIdJSONRequestBody defines body for PatchUsersUserId for application/json ContentType.
+type PatchUsersUserIdJSONRequestBody = UpdateUserRequest
+
+// PostWaitlistJSONRequestBody defines body for PostWaitlist for application/json ContentType.
+type PostWaitlistJSONRequestBody = JoinWaitlistRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetAppointmentSeriesSeriesId request
+	GetAppointmentSeriesSeriesId(ctx context.Context, seriesId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAppointmentSeriesSeriesIdConfirm request
+	PostAppointmentSeriesSeriesIdConfirm(ctx context.Context, seriesId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAppointmentTypes request
+	GetAppointmentTypes(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAppointmentTypesWithBody request with any body
+	PostAppointmentTypesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAppointmentTypes(ctx context.Context, body PostAppointmentTypesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAppointments request
+	GetAppointments(ctx context.Context, params *GetAppointmentsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAppointmentsWithBody request with any body
+	PostAppointmentsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAppointments(ctx context.Context, body PostAppointmentsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAppointmentsAppointmentIdCancel request
+	PostAppointmentsAppointmentIdCancel(ctx context.Context, appointmentId openapi_types.UUID, params *PostAppointmentsAppointmentIdCancelParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAppointmentsAppointmentIdConfirm request
+	PostAppointmentsAppointmentIdConfirm(ctx context.Context, appointmentId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAppointmentsAppointmentIdHistory request
+	GetAppointmentsAppointmentIdHistory(ctx context.Context, appointmentId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAppointmentsAppointmentIdNotes request
+	GetAppointmentsAppointmentIdNotes(ctx context.Context, appointmentId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutAppointmentsAppointmentIdNotesWithBody request with any body
+	PutAppointmentsAppointmentIdNotesWithBody(ctx context.Context, appointmentId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutAppointmentsAppointmentIdNotes(ctx context.Context, appointmentId openapi_types.UUID, body PutAppointmentsAppointmentIdNotesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAppointmentsAppointmentIdRescheduleWithBody request with any body
+	PostAppointmentsAppointmentIdRescheduleWithBody(ctx context.Context, appointmentId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAppointmentsAppointmentIdReschedule(ctx context.Context, appointmentId openapi_types.UUID, body PostAppointmentsAppointmentIdRescheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAppointmentsAppointmentIdStatusWithBody request with any body
+	PostAppointmentsAppointmentIdStatusWithBody(ctx context.Context, appointmentId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAppointmentsAppointmentIdStatus(ctx context.Context, appointmentId openapi_types.UUID, body PostAppointmentsAppointmentIdStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLocations request
+	GetLocations(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostLocationsWithBody request with any body
+	PostLocationsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostLocations(ctx context.Context, body PostLocationsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLocationsLocationIdRooms request
+	GetLocationsLocationIdRooms(ctx context.Context, locationId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostLocationsLocationIdRoomsWithBody request with any body
+	PostLocationsLocationIdRoomsWithBody(ctx context.Context, locationId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostLocationsLocationIdRooms(ctx context.Context, locationId openapi_types.UUID, body PostLocationsLocationIdRoomsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOrganization request
+	GetOrganization(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetProviderGroups request
+	GetProviderGroups(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostProviderGroupsWithBody request with any body
+	PostProviderGroupsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostProviderGroups(ctx context.Context, body PostProviderGroupsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetProviderGroupsGroupId request
+	GetProviderGroupsGroupId(ctx context.Context, groupId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetProviders request
+	GetProviders(ctx context.Context, params *GetProvidersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetProvidersProviderId request
+	GetProvidersProviderId(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostProvidersProviderIdAvailabilityWithBody request with any body
+	PostProvidersProviderIdAvailabilityWithBody(ctx context.Context, providerId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostProvidersProviderIdAvailability(ctx context.Context, providerId openapi_types.UUID, body PostProvidersProviderIdAvailabilityJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutProvidersProviderIdProfileWithBody request with any body
+	PutProvidersProviderIdProfileWithBody(ctx context.Context, providerId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutProvidersProviderIdProfile(ctx context.Context, providerId openapi_types.UUID, body PutProvidersProviderIdProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetProvidersProviderIdWaitlist request
+	GetProvidersProviderIdWaitlist(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRoomsRoomIdAppointments request
+	GetRoomsRoomIdAppointments(ctx context.Context, roomId openapi_types.UUID, params *GetRoomsRoomIdAppointmentsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostUsersWithBody request with any body
+	PostUsersWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostUsers(ctx context.Context, body PostUsersJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteUsersUserId request
+	DeleteUsersUserId(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUsersUserId request
+	GetUsersUserId(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchUsersUserIdWithBody request with any body
+	PatchUsersUserIdWithBody(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchUsersUserId(ctx context.Context, userId openapi_types.UUID, body PatchUsersUserIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostUsersUserIdDeactivate request
+	PostUsersUserIdDeactivate(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostWaitlistWithBody request with any body
+	PostWaitlistWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostWaitlist(ctx context.Context, body PostWaitlistJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteWaitlistEntryId request
+	DeleteWaitlistEntryId(ctx context.Context, entryId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetAppointmentSeriesSeriesId(ctx context.Context, seriesId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAppointmentSeriesSeriesIdRequest(c.Server, seriesId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAppointmentSeriesSeriesIdConfirm(ctx context.Context, seriesId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAppointmentSeriesSeriesIdConfirmRequest(c.Server, seriesId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAppointmentTypes(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAppointmentTypesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAppointmentTypesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAppointmentTypesRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAppointmentTypes(ctx context.Context, body PostAppointmentTypesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAppointmentTypesRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAppointments(ctx context.Context, params *GetAppointmentsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAppointmentsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAppointmentsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAppointmentsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAppointments(ctx context.Context, body PostAppointmentsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAppointmentsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAppointmentsAppointmentIdCancel(ctx context.Context, appointmentId openapi_types.UUID, params *PostAppointmentsAppointmentIdCancelParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAppointmentsAppointmentIdCancelRequest(c.Server, appointmentId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAppointmentsAppointmentIdConfirm(ctx context.Context, appointmentId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAppointmentsAppointmentIdConfirmRequest(c.Server, appointmentId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAppointmentsAppointmentIdHistory(ctx context.Context, appointmentId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAppointmentsAppointmentIdHistoryRequest(c.Server, appointmentId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAppointmentsAppointmentIdNotes(ctx context.Context, appointmentId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAppointmentsAppointmentIdNotesRequest(c.Server, appointmentId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAppointmentsAppointmentIdNotesWithBody(ctx context.Context, appointmentId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAppointmentsAppointmentIdNotesRequestWithBody(c.Server, appointmentId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAppointmentsAppointmentIdNotes(ctx context.Context, appointmentId openapi_types.UUID, body PutAppointmentsAppointmentIdNotesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAppointmentsAppointmentIdNotesRequest(c.Server, appointmentId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAppointmentsAppointmentIdRescheduleWithBody(ctx context.Context, appointmentId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAppointmentsAppointmentIdRescheduleRequestWithBody(c.Server, appointmentId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAppointmentsAppointmentIdReschedule(ctx context.Context, appointmentId openapi_types.UUID, body PostAppointmentsAppointmentIdRescheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAppointmentsAppointmentIdRescheduleRequest(c.Server, appointmentId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAppointmentsAppointmentIdStatusWithBody(ctx context.Context, appointmentId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAppointmentsAppointmentIdStatusRequestWithBody(c.Server, appointmentId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAppointmentsAppointmentIdStatus(ctx context.Context, appointmentId openapi_types.UUID, body PostAppointmentsAppointmentIdStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAppointmentsAppointmentIdStatusRequest(c.Server, appointmentId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLocations(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLocationsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostLocationsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostLocationsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostLocations(ctx context.Context, body PostLocationsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostLocationsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLocationsLocationIdRooms(ctx context.Context, locationId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLocationsLocationIdRoomsRequest(c.Server, locationId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostLocationsLocationIdRoomsWithBody(ctx context.Context, locationId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostLocationsLocationIdRoomsRequestWithBody(c.Server, locationId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostLocationsLocationIdRooms(ctx context.Context, locationId openapi_types.UUID, body PostLocationsLocationIdRoomsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostLocationsLocationIdRoomsRequest(c.Server, locationId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOrganization(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOrganizationRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetProviderGroups(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetProviderGroupsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostProviderGroupsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostProviderGroupsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostProviderGroups(ctx context.Context, body PostProviderGroupsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostProviderGroupsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetProviderGroupsGroupId(ctx context.Context, groupId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetProviderGroupsGroupIdRequest(c.Server, groupId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetProviders(ctx context.Context, params *GetProvidersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetProvidersRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetProvidersProviderId(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetProvidersProviderIdRequest(c.Server, providerId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostProvidersProviderIdAvailabilityWithBody(ctx context.Context, providerId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostProvidersProviderIdAvailabilityRequestWithBody(c.Server, providerId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostProvidersProviderIdAvailability(ctx context.Context, providerId openapi_types.UUID, body PostProvidersProviderIdAvailabilityJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostProvidersProviderIdAvailabilityRequest(c.Server, providerId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutProvidersProviderIdProfileWithBody(ctx context.Context, providerId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutProvidersProviderIdProfileRequestWithBody(c.Server, providerId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutProvidersProviderIdProfile(ctx context.Context, providerId openapi_types.UUID, body PutProvidersProviderIdProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutProvidersProviderIdProfileRequest(c.Server, providerId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetProvidersProviderIdWaitlist(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetProvidersProviderIdWaitlistRequest(c.Server, providerId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRoomsRoomIdAppointments(ctx context.Context, roomId openapi_types.UUID, params *GetRoomsRoomIdAppointmentsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRoomsRoomIdAppointmentsRequest(c.Server, roomId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUsersWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUsersRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUsers(ctx context.Context, body PostUsersJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUsersRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteUsersUserId(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteUsersUserIdRequest(c.Server, userId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetUsersUserId(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUsersUserIdRequest(c.Server, userId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchUsersUserIdWithBody(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchUsersUserIdRequestWithBody(c.Server, userId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchUsersUserId(ctx context.Context, userId openapi_types.UUID, body PatchUsersUserIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchUsersUserIdRequest(c.Server, userId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUsersUserIdDeactivate(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUsersUserIdDeactivateRequest(c.Server, userId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostWaitlistWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostWaitlistRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostWaitlist(ctx context.Context, body PostWaitlistJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostWaitlistRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteWaitlistEntryId(ctx context.Context, entryId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteWaitlistEntryIdRequest(c.Server, entryId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAppointmentSeriesSeriesIdRequest generates requests for GetAppointmentSeriesSeriesId
+func NewGetAppointmentSeriesSeriesIdRequest(server string, seriesId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "seriesId", runtime.ParamLocationPath, seriesId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/appointment-series/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAppointmentSeriesSeriesIdConfirmRequest generates requests for PostAppointmentSeriesSeriesIdConfirm
+func NewPostAppointmentSeriesSeriesIdConfirmRequest(server string, seriesId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "seriesId", runtime.ParamLocationPath, seriesId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/appointment-series/%s/confirm", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAppointmentTypesRequest generates requests for GetAppointmentTypes
+func NewGetAppointmentTypesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/appointment-types")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAppointmentTypesRequest calls the generic PostAppointmentTypes builder with application/json body
+func NewPostAppointmentTypesRequest(server string, body PostAppointmentTypesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAppointmentTypesRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAppointmentTypesRequestWithBody generates requests for PostAppointmentTypes with any type of body
+func NewPostAppointmentTypesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/appointment-types")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAppointmentsRequest generates requests for GetAppointments
+func NewGetAppointmentsRequest(server string, params *GetAppointmentsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/appointments")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ProviderId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "providerId", runtime.ParamLocationQuery, *params.ProviderId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.LocationId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "locationId", runtime.ParamLocationQuery, *params.LocationId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ParticipantId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "participantId", runtime.ParamLocationQuery, *params.ParticipantId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Date != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "date", runtime.ParamLocationQuery, *params.Date); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAppointmentsRequest calls the generic PostAppointments builder with application/json body
+func NewPostAppointmentsRequest(server string, body PostAppointmentsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAppointmentsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAppointmentsRequestWithBody generates requests for PostAppointments with any type of body
+func NewPostAppointmentsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/appointments")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAppointmentsAppointmentIdCancelRequest generates requests for PostAppointmentsAppointmentIdCancel
+func NewPostAppointmentsAppointmentIdCancelRequest(server string, appointmentId openapi_types.UUID, params *PostAppointmentsAppointmentIdCancelParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "appointmentId", runtime.ParamLocationPath, appointmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/appointments/%s/cancel", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Scope != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "scope", runtime.ParamLocationQuery, *params.Scope); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostAppointmentsAppointmentIdConfirmRequest generates requests for PostAppointmentsAppointmentIdConfirm
+func NewPostAppointmentsAppointmentIdConfirmRequest(server string, appointmentId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "appointmentId", runtime.ParamLocationPath, appointmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/appointments/%s/confirm", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAppointmentsAppointmentIdHistoryRequest generates requests for GetAppointmentsAppointmentIdHistory
+func NewGetAppointmentsAppointmentIdHistoryRequest(server string, appointmentId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "appointmentId", runtime.ParamLocationPath, appointmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/appointments/%s/history", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAppointmentsAppointmentIdNotesRequest generates requests for GetAppointmentsAppointmentIdNotes
+func NewGetAppointmentsAppointmentIdNotesRequest(server string, appointmentId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "appointmentId", runtime.ParamLocationPath, appointmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/appointments/%s/notes", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutAppointmentsAppointmentIdNotesRequest calls the generic PutAppointmentsAppointmentIdNotes builder with application/json body
+func NewPutAppointmentsAppointmentIdNotesRequest(server string, appointmentId openapi_types.UUID, body PutAppointmentsAppointmentIdNotesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutAppointmentsAppointmentIdNotesRequestWithBody(server, appointmentId, "application/json", bodyReader)
+}
+
+// NewPutAppointmentsAppointmentIdNotesRequestWithBody generates requests for PutAppointmentsAppointmentIdNotes with any type of body
+func NewPutAppointmentsAppointmentIdNotesRequestWithBody(server string, appointmentId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "appointmentId", runtime.ParamLocationPath, appointmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/appointments/%s/notes", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAppointmentsAppointmentIdRescheduleRequest calls the generic PostAppointmentsAppointmentIdReschedule builder with application/json body
+func NewPostAppointmentsAppointmentIdRescheduleRequest(server string, appointmentId openapi_types.UUID, body PostAppointmentsAppointmentIdRescheduleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAppointmentsAppointmentIdRescheduleRequestWithBody(server, appointmentId, "application/json", bodyReader)
+}
+
+// NewPostAppointmentsAppointmentIdRescheduleRequestWithBody generates requests for PostAppointmentsAppointmentIdReschedule with any type of body
+func NewPostAppointmentsAppointmentIdRescheduleRequestWithBody(server string, appointmentId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "appointmentId", runtime.ParamLocationPath, appointmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/appointments/%s/reschedule", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAppointmentsAppointmentIdStatusRequest calls the generic PostAppointmentsAppointmentIdStatus builder with application/json body
+func NewPostAppointmentsAppointmentIdStatusRequest(server string, appointmentId openapi_types.UUID, body PostAppointmentsAppointmentIdStatusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAppointmentsAppointmentIdStatusRequestWithBody(server, appointmentId, "application/json", bodyReader)
+}
+
+// NewPostAppointmentsAppointmentIdStatusRequestWithBody generates requests for PostAppointmentsAppointmentIdStatus with any type of body
+func NewPostAppointmentsAppointmentIdStatusRequestWithBody(server string, appointmentId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "appointmentId", runtime.ParamLocationPath, appointmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/appointments/%s/status", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetLocationsRequest generates requests for GetLocations
+func NewGetLocationsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/locations")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostLocationsRequest calls the generic PostLocations builder with application/json body
+func NewPostLocationsRequest(server string, body PostLocationsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostLocationsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostLocationsRequestWithBody generates requests for PostLocations with any type of body
+func NewPostLocationsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/locations")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetLocationsLocationIdRoomsRequest generates requests for GetLocationsLocationIdRooms
+func NewGetLocationsLocationIdRoomsRequest(server string, locationId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "locationId", runtime.ParamLocationPath, locationId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/locations/%s/rooms", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostLocationsLocationIdRoomsRequest calls the generic PostLocationsLocationIdRooms builder with application/json body
+func NewPostLocationsLocationIdRoomsRequest(server string, locationId openapi_types.UUID, body PostLocationsLocationIdRoomsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostLocationsLocationIdRoomsRequestWithBody(server, locationId, "application/json", bodyReader)
+}
+
+// NewPostLocationsLocationIdRoomsRequestWithBody generates requests for PostLocationsLocationIdRooms with any type of body
+func NewPostLocationsLocationIdRoomsRequestWithBody(server string, locationId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "locationId", runtime.ParamLocationPath, locationId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/locations/%s/rooms", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetOrganizationRequest generates requests for GetOrganization
+func NewGetOrganizationRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/organization")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetProviderGroupsRequest generates requests for GetProviderGroups
+func NewGetProviderGroupsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/provider-groups")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostProviderGroupsRequest calls the generic PostProviderGroups builder with application/json body
+func NewPostProviderGroupsRequest(server string, body PostProviderGroupsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostProviderGroupsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostProviderGroupsRequestWithBody generates requests for PostProviderGroups with any type of body
+func NewPostProviderGroupsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/provider-groups")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetProviderGroupsGroupIdRequest generates requests for GetProviderGroupsGroupId
+func NewGetProviderGroupsGroupIdRequest(server string, groupId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "groupId", runtime.ParamLocationPath, groupId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/provider-groups/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetProvidersRequest generates requests for GetProviders
+func NewGetProvidersRequest(server string, params *GetProvidersParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/providers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Q != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "q", runtime.ParamLocationQuery, *params.Q); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Specialty != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "specialty", runtime.ParamLocationQuery, *params.Specialty); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Language != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "language", runtime.ParamLocationQuery, *params.Language); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.AvailableFrom != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "availableFrom", runtime.ParamLocationQuery, *params.AvailableFrom); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.AvailableTo != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "availableTo", runtime.ParamLocationQuery, *params.AvailableTo); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetProvidersProviderIdRequest generates requests for GetProvidersProviderId
+func NewGetProvidersProviderIdRequest(server string, providerId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "providerId", runtime.ParamLocationPath, providerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/providers/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostProvidersProviderIdAvailabilityRequest calls the generic PostProvidersProviderIdAvailability builder with application/json body
+func NewPostProvidersProviderIdAvailabilityRequest(server string, providerId openapi_types.UUID, body PostProvidersProviderIdAvailabilityJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostProvidersProviderIdAvailabilityRequestWithBody(server, providerId, "application/json", bodyReader)
+}
+
+// NewPostProvidersProviderIdAvailabilityRequestWithBody generates requests for PostProvidersProviderIdAvailability with any type of body
+func NewPostProvidersProviderIdAvailabilityRequestWithBody(server string, providerId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "providerId", runtime.ParamLocationPath, providerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/providers/%s/availability", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPutProvidersProviderIdProfileRequest calls the generic PutProvidersProviderIdProfile builder with application/json body
+func NewPutProvidersProviderIdProfileRequest(server string, providerId openapi_types.UUID, body PutProvidersProviderIdProfileJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutProvidersProviderIdProfileRequestWithBody(server, providerId, "application/json", bodyReader)
+}
+
+// NewPutProvidersProviderIdProfileRequestWithBody generates requests for PutProvidersProviderIdProfile with any type of body
+func NewPutProvidersProviderIdProfileRequestWithBody(server string, providerId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "providerId", runtime.ParamLocationPath, providerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/providers/%s/profile", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetProvidersProviderIdWaitlistRequest generates requests for GetProvidersProviderIdWaitlist
+func NewGetProvidersProviderIdWaitlistRequest(server string, providerId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "providerId", runtime.ParamLocationPath, providerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/providers/%s/waitlist", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRoomsRoomIdAppointmentsRequest generates requests for GetRoomsRoomIdAppointments
+func NewGetRoomsRoomIdAppointmentsRequest(server string, roomId openapi_types.UUID, params *GetRoomsRoomIdAppointmentsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "roomId", runtime.ParamLocationPath, roomId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/rooms/%s/appointments", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Date != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "date", runtime.ParamLocationQuery, *params.Date); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostUsersRequest calls the generic PostUsers builder with application/json body
+func NewPostUsersRequest(server string, body PostUsersJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostUsersRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostUsersRequestWithBody generates requests for PostUsers with any type of body
+func NewPostUsersRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteUsersUserIdRequest generates requests for DeleteUsersUserId
+func NewDeleteUsersUserIdRequest(server string, userId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetUsersUserIdRequest generates requests for GetUsersUserId
+func NewGetUsersUserIdRequest(server string, userId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPatchUsersUserIdRequest calls the generic PatchUsersUserId builder with application/json body
+func NewPatchUsersUserIdRequest(server string, userId openapi_types.UUID, body PatchUsersUserIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchUsersUserIdRequestWithBody(server, userId, "application/json", bodyReader)
+}
+
+// NewPatchUsersUserIdRequestWithBody generates requests for PatchUsersUserId with any type of body
+func NewPatchUsersUserIdRequestWithBody(server string, userId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostUsersUserIdDeactivateRequest generates requests for PostUsersUserIdDeactivate
+func NewPostUsersUserIdDeactivateRequest(server string, userId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/%s/deactivate", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostWaitlistRequest calls the generic PostWaitlist builder with application/json body
+func NewPostWaitlistRequest(server string, body PostWaitlistJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostWaitlistRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostWaitlistRequestWithBody generates requests for PostWaitlist with any type of body
+func NewPostWaitlistRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/waitlist")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteWaitlistEntryIdRequest generates requests for DeleteWaitlistEntryId
+func NewDeleteWaitlistEntryIdRequest(server string, entryId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "entryId", runtime.ParamLocationPath, entryId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/waitlist/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAppointmentSeriesSeriesIdWithResponse request
+	GetAppointmentSeriesSeriesIdWithResponse(ctx context.Context, seriesId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetAppointmentSeriesSeriesIdResponse, error)
+
+	// PostAppointmentSeriesSeriesIdConfirmWithResponse request
+	PostAppointmentSeriesSeriesIdConfirmWithResponse(ctx context.Context, seriesId openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostAppointmentSeriesSeriesIdConfirmResponse, error)
+
+	// GetAppointmentTypesWithResponse request
+	GetAppointmentTypesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAppointmentTypesResponse, error)
+
+	// PostAppointmentTypesWithBodyWithResponse request with any body
+	PostAppointmentTypesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAppointmentTypesResponse, error)
+
+	PostAppointmentTypesWithResponse(ctx context.Context, body PostAppointmentTypesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAppointmentTypesResponse, error)
+
+	// GetAppointmentsWithResponse request
+	GetAppointmentsWithResponse(ctx context.Context, params *GetAppointmentsParams, reqEditors ...RequestEditorFn) (*GetAppointmentsResponse, error)
+
+	// PostAppointmentsWithBodyWithResponse request with any body
+	PostAppointmentsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAppointmentsResponse, error)
+
+	PostAppointmentsWithResponse(ctx context.Context, body PostAppointmentsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAppointmentsResponse, error)
+
+	// PostAppointmentsAppointmentIdCancelWithResponse request
+	PostAppointmentsAppointmentIdCancelWithResponse(ctx context.Context, appointmentId openapi_types.UUID, params *PostAppointmentsAppointmentIdCancelParams, reqEditors ...RequestEditorFn) (*PostAppointmentsAppointmentIdCancelResponse, error)
+
+	// PostAppointmentsAppointmentIdConfirmWithResponse request
+	PostAppointmentsAppointmentIdConfirmWithResponse(ctx context.Context, appointmentId openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostAppointmentsAppointmentIdConfirmResponse, error)
+
+	// GetAppointmentsAppointmentIdHistoryWithResponse request
+	GetAppointmentsAppointmentIdHistoryWithResponse(ctx context.Context, appointmentId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetAppointmentsAppointmentIdHistoryResponse, error)
+
+	// GetAppointmentsAppointmentIdNotesWithResponse request
+	GetAppointmentsAppointmentIdNotesWithResponse(ctx context.Context, appointmentId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetAppointmentsAppointmentIdNotesResponse, error)
+
+	// PutAppointmentsAppointmentIdNotesWithBodyWithResponse request with any body
+	PutAppointmentsAppointmentIdNotesWithBodyWithResponse(ctx context.Context, appointmentId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAppointmentsAppointmentIdNotesResponse, error)
+
+	PutAppointmentsAppointmentIdNotesWithResponse(ctx context.Context, appointmentId openapi_types.UUID, body PutAppointmentsAppointmentIdNotesJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAppointmentsAppointmentIdNotesResponse, error)
+
+	// PostAppointmentsAppointmentIdRescheduleWithBodyWithResponse request with any body
+	PostAppointmentsAppointmentIdRescheduleWithBodyWithResponse(ctx context.Context, appointmentId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAppointmentsAppointmentIdRescheduleResponse, error)
+
+	PostAppointmentsAppointmentIdRescheduleWithResponse(ctx context.Context, appointmentId openapi_types.UUID, body PostAppointmentsAppointmentIdRescheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAppointmentsAppointmentIdRescheduleResponse, error)
+
+	// PostAppointmentsAppointmentIdStatusWithBodyWithResponse request with any body
+	PostAppointmentsAppointmentIdStatusWithBodyWithResponse(ctx context.Context, appointmentId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAppointmentsAppointmentIdStatusResponse, error)
+
+	PostAppointmentsAppointmentIdStatusWithResponse(ctx context.Context, appointmentId openapi_types.UUID, body PostAppointmentsAppointmentIdStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAppointmentsAppointmentIdStatusResponse, error)
+
+	// GetLocationsWithResponse request
+	GetLocationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLocationsResponse, error)
+
+	// PostLocationsWithBodyWithResponse request with any body
+	PostLocationsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostLocationsResponse, error)
+
+	PostLocationsWithResponse(ctx context.Context, body PostLocationsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostLocationsResponse, error)
+
+	// GetLocationsLocationIdRoomsWithResponse request
+	GetLocationsLocationIdRoomsWithResponse(ctx context.Context, locationId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetLocationsLocationIdRoomsResponse, error)
+
+	// PostLocationsLocationIdRoomsWithBodyWithResponse request with any body
+	PostLocationsLocationIdRoomsWithBodyWithResponse(ctx context.Context, locationId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostLocationsLocationIdRoomsResponse, error)
+
+	PostLocationsLocationIdRoomsWithResponse(ctx context.Context, locationId openapi_types.UUID, body PostLocationsLocationIdRoomsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostLocationsLocationIdRoomsResponse, error)
+
+	// GetOrganizationWithResponse request
+	GetOrganizationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOrganizationResponse, error)
+
+	// GetProviderGroupsWithResponse request
+	GetProviderGroupsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetProviderGroupsResponse, error)
+
+	// PostProviderGroupsWithBodyWithResponse request with any body
+	PostProviderGroupsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostProviderGroupsResponse, error)
+
+	PostProviderGroupsWithResponse(ctx context.Context, body PostProviderGroupsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostProviderGroupsResponse, error)
+
+	// GetProviderGroupsGroupIdWithResponse request
+	GetProviderGroupsGroupIdWithResponse(ctx context.Context, groupId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetProviderGroupsGroupIdResponse, error)
+
+	// GetProvidersWithResponse request
+	GetProvidersWithResponse(ctx context.Context, params *GetProvidersParams, reqEditors ...RequestEditorFn) (*GetProvidersResponse, error)
+
+	// GetProvidersProviderIdWithResponse request
+	GetProvidersProviderIdWithResponse(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetProvidersProviderIdResponse, error)
+
+	// PostProvidersProviderIdAvailabilityWithBodyWithResponse request with any body
+	PostProvidersProviderIdAvailabilityWithBodyWithResponse(ctx context.Context, providerId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostProvidersProviderIdAvailabilityResponse, error)
+
+	PostProvidersProviderIdAvailabilityWithResponse(ctx context.Context, providerId openapi_types.UUID, body PostProvidersProviderIdAvailabilityJSONRequestBody, reqEditors ...RequestEditorFn) (*PostProvidersProviderIdAvailabilityResponse, error)
+
+	// PutProvidersProviderIdProfileWithBodyWithResponse request with any body
+	PutProvidersProviderIdProfileWithBodyWithResponse(ctx context.Context, providerId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutProvidersProviderIdProfileResponse, error)
+
+	PutProvidersProviderIdProfileWithResponse(ctx context.Context, providerId openapi_types.UUID, body PutProvidersProviderIdProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*PutProvidersProviderIdProfileResponse, error)
+
+	// GetProvidersProviderIdWaitlistWithResponse request
+	GetProvidersProviderIdWaitlistWithResponse(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetProvidersProviderIdWaitlistResponse, error)
+
+	// GetRoomsRoomIdAppointmentsWithResponse request
+	GetRoomsRoomIdAppointmentsWithResponse(ctx context.Context, roomId openapi_types.UUID, params *GetRoomsRoomIdAppointmentsParams, reqEditors ...RequestEditorFn) (*GetRoomsRoomIdAppointmentsResponse, error)
+
+	// PostUsersWithBodyWithResponse request with any body
+	PostUsersWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersResponse, error)
+
+	PostUsersWithResponse(ctx context.Context, body PostUsersJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersResponse, error)
+
+	// DeleteUsersUserIdWithResponse request
+	DeleteUsersUserIdWithResponse(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteUsersUserIdResponse, error)
+
+	// GetUsersUserIdWithResponse request
+	GetUsersUserIdWithResponse(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetUsersUserIdResponse, error)
+
+	// PatchUsersUserIdWithBodyWithResponse request with any body
+	PatchUsersUserIdWithBodyWithResponse(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchUsersUserIdResponse, error)
+
+	PatchUsersUserIdWithResponse(ctx context.Context, userId openapi_types.UUID, body PatchUsersUserIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchUsersUserIdResponse, error)
+
+	// PostUsersUserIdDeactivateWithResponse request
+	PostUsersUserIdDeactivateWithResponse(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostUsersUserIdDeactivateResponse, error)
+
+	// PostWaitlistWithBodyWithResponse request with any body
+	PostWaitlistWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostWaitlistResponse, error)
+
+	PostWaitlistWithResponse(ctx context.Context, body PostWaitlistJSONRequestBody, reqEditors ...RequestEditorFn) (*PostWaitlistResponse, error)
+
+	// DeleteWaitlistEntryIdWithResponse request
+	DeleteWaitlistEntryIdWithResponse(ctx context.Context, entryId openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteWaitlistEntryIdResponse, error)
+}
+
+type GetAppointmentSeriesSeriesIdResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *AppointmentSeries
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAppointmentSeriesSeriesIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAppointmentSeriesSeriesIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAppointmentSeriesSeriesIdConfirmResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAppointmentSeriesSeriesIdConfirmResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAppointmentSeriesSeriesIdConfirmResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAppointmentTypesResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]AppointmentType
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAppointmentTypesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAppointmentTypesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAppointmentTypesResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON201                   *AppointmentType
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAppointmentTypesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAppointmentTypesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAppointmentsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]Appointment
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAppointmentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAppointmentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAppointmentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		union json.RawMessage
+	}
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON409 *Conflict
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAppointmentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAppointmentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAppointmentsAppointmentIdCancelResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON409 *Conflict
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAppointmentsAppointmentIdCancelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAppointmentsAppointmentIdCancelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAppointmentsAppointmentIdConfirmResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON409 *Conflict
+	ApplicationproblemJSON410 *Gone
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAppointmentsAppointmentIdConfirmResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAppointmentsAppointmentIdConfirmResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAppointmentsAppointmentIdHistoryResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]StatusChange
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAppointmentsAppointmentIdHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAppointmentsAppointmentIdHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAppointmentsAppointmentIdNotesResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]AppointmentNote
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAppointmentsAppointmentIdNotesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAppointmentsAppointmentIdNotesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutAppointmentsAppointmentIdNotesResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *AppointmentNote
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PutAppointmentsAppointmentIdNotesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutAppointmentsAppointmentIdNotesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAppointmentsAppointmentIdRescheduleResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]Appointment
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON409 *Conflict
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAppointmentsAppointmentIdRescheduleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAppointmentsAppointmentIdRescheduleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAppointmentsAppointmentIdStatusResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Appointment
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON409 *Conflict
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAppointmentsAppointmentIdStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAppointmentsAppointmentIdStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLocationsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]Location
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLocationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLocationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostLocationsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON201                   *Location
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostLocationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostLocationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLocationsLocationIdRoomsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]Room
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLocationsLocationIdRoomsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLocationsLocationIdRoomsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostLocationsLocationIdRoomsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON201                   *Room
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostLocationsLocationIdRoomsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostLocationsLocationIdRoomsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOrganizationResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Organization
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOrganizationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOrganizationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetProviderGroupsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]ProviderGroup
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetProviderGroupsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetProviderGroupsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostProviderGroupsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON201                   *ProviderGroup
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON409 *Conflict
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostProviderGroupsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostProviderGroupsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetProviderGroupsGroupIdResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *ProviderGroup
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetProviderGroupsGroupIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetProviderGroupsGroupIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetProvidersResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]Provider
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetProvidersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetProvidersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetProvidersProviderIdResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Provider
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetProvidersProviderIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetProvidersProviderIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostProvidersProviderIdAvailabilityResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON409 *Conflict
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostProvidersProviderIdAvailabilityResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostProvidersProviderIdAvailabilityResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutProvidersProviderIdProfileResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Provider
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PutProvidersProviderIdProfileResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutProvidersProviderIdProfileResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetProvidersProviderIdWaitlistResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]WaitlistEntry
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetProvidersProviderIdWaitlistResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetProvidersProviderIdWaitlistResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRoomsRoomIdAppointmentsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *[]Appointment
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRoomsRoomIdAppointmentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRoomsRoomIdAppointmentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUsersResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON201                   *User
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON409 *Conflict
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUsersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUsersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteUsersUserIdResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON409 *Conflict
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteUsersUserIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteUsersUserIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUsersUserIdResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *User
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUsersUserIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUsersUserIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchUsersUserIdResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *User
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON409 *Conflict
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchUsersUserIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchUsersUserIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUsersUserIdDeactivateResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *User
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUsersUserIdDeactivateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUsersUserIdDeactivateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostWaitlistResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON201                   *WaitlistEntry
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON409 *Conflict
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostWaitlistResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostWaitlistResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteWaitlistEntryIdResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON401 *Unauthorized
+	ApplicationproblemJSON404 *NotFound
+	ApplicationproblemJSON500 *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteWaitlistEntryIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteWaitlistEntryIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetAppointmentSeriesSeriesIdWithResponse request returning *GetAppointmentSeriesSeriesIdResponse
+func (c *ClientWithResponses) GetAppointmentSeriesSeriesIdWithResponse(ctx context.Context, seriesId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetAppointmentSeriesSeriesIdResponse, error) {
+	rsp, err := c.GetAppointmentSeriesSeriesId(ctx, seriesId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAppointmentSeriesSeriesIdResponse(rsp)
+}
+
+// PostAppointmentSeriesSeriesIdConfirmWithResponse request returning *PostAppointmentSeriesSeriesIdConfirmResponse
+func (c *ClientWithResponses) PostAppointmentSeriesSeriesIdConfirmWithResponse(ctx context.Context, seriesId openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostAppointmentSeriesSeriesIdConfirmResponse, error) {
+	rsp, err := c.PostAppointmentSeriesSeriesIdConfirm(ctx, seriesId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAppointmentSeriesSeriesIdConfirmResponse(rsp)
+}
+
+// GetAppointmentTypesWithResponse request returning *GetAppointmentTypesResponse
+func (c *ClientWithResponses) GetAppointmentTypesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAppointmentTypesResponse, error) {
+	rsp, err := c.GetAppointmentTypes(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAppointmentTypesResponse(rsp)
+}
+
+// PostAppointmentTypesWithBodyWithResponse request with arbitrary body returning *PostAppointmentTypesResponse
+func (c *ClientWithResponses) PostAppointmentTypesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAppointmentTypesResponse, error) {
+	rsp, err := c.PostAppointmentTypesWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAppointmentTypesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAppointmentTypesWithResponse(ctx context.Context, body PostAppointmentTypesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAppointmentTypesResponse, error) {
+	rsp, err := c.PostAppointmentTypes(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAppointmentTypesResponse(rsp)
+}
+
+// GetAppointmentsWithResponse request returning *GetAppointmentsResponse
+func (c *ClientWithResponses) GetAppointmentsWithResponse(ctx context.Context, params *GetAppointmentsParams, reqEditors ...RequestEditorFn) (*GetAppointmentsResponse, error) {
+	rsp, err := c.GetAppointments(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAppointmentsResponse(rsp)
+}
+
+// PostAppointmentsWithBodyWithResponse request with arbitrary body returning *PostAppointmentsResponse
+func (c *ClientWithResponses) PostAppointmentsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAppointmentsResponse, error) {
+	rsp, err := c.PostAppointmentsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAppointmentsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAppointmentsWithResponse(ctx context.Context, body PostAppointmentsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAppointmentsResponse, error) {
+	rsp, err := c.PostAppointments(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAppointmentsResponse(rsp)
+}
+
+// PostAppointmentsAppointmentIdCancelWithResponse request returning *PostAppointmentsAppointmentIdCancelResponse
+func (c *ClientWithResponses) PostAppointmentsAppointmentIdCancelWithResponse(ctx context.Context, appointmentId openapi_types.UUID, params *PostAppointmentsAppointmentIdCancelParams, reqEditors ...RequestEditorFn) (*PostAppointmentsAppointmentIdCancelResponse, error) {
+	rsp, err := c.PostAppointmentsAppointmentIdCancel(ctx, appointmentId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAppointmentsAppointmentIdCancelResponse(rsp)
+}
+
+// PostAppointmentsAppointmentIdConfirmWithResponse request returning *PostAppointmentsAppointmentIdConfirmResponse
+func (c *ClientWithResponses) PostAppointmentsAppointmentIdConfirmWithResponse(ctx context.Context, appointmentId openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostAppointmentsAppointmentIdConfirmResponse, error) {
+	rsp, err := c.PostAppointmentsAppointmentIdConfirm(ctx, appointmentId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAppointmentsAppointmentIdConfirmResponse(rsp)
+}
+
+// GetAppointmentsAppointmentIdHistoryWithResponse request returning *GetAppointmentsAppointmentIdHistoryResponse
+func (c *ClientWithResponses) GetAppointmentsAppointmentIdHistoryWithResponse(ctx context.Context, appointmentId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetAppointmentsAppointmentIdHistoryResponse, error) {
+	rsp, err := c.GetAppointmentsAppointmentIdHistory(ctx, appointmentId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAppointmentsAppointmentIdHistoryResponse(rsp)
+}
+
+// GetAppointmentsAppointmentIdNotesWithResponse request returning *GetAppointmentsAppointmentIdNotesResponse
+func (c *ClientWithResponses) GetAppointmentsAppointmentIdNotesWithResponse(ctx context.Context, appointmentId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetAppointmentsAppointmentIdNotesResponse, error) {
+	rsp, err := c.GetAppointmentsAppointmentIdNotes(ctx, appointmentId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAppointmentsAppointmentIdNotesResponse(rsp)
+}
+
+// PutAppointmentsAppointmentIdNotesWithBodyWithResponse request with arbitrary body returning *PutAppointmentsAppointmentIdNotesResponse
+func (c *ClientWithResponses) PutAppointmentsAppointmentIdNotesWithBodyWithResponse(ctx context.Context, appointmentId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAppointmentsAppointmentIdNotesResponse, error) {
+	rsp, err := c.PutAppointmentsAppointmentIdNotesWithBody(ctx, appointmentId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAppointmentsAppointmentIdNotesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutAppointmentsAppointmentIdNotesWithResponse(ctx context.Context, appointmentId openapi_types.UUID, body PutAppointmentsAppointmentIdNotesJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAppointmentsAppointmentIdNotesResponse, error) {
+	rsp, err := c.PutAppointmentsAppointmentIdNotes(ctx, appointmentId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAppointmentsAppointmentIdNotesResponse(rsp)
+}
+
+// PostAppointmentsAppointmentIdRescheduleWithBodyWithResponse request with arbitrary body returning *PostAppointmentsAppointmentIdRescheduleResponse
+func (c *ClientWithResponses) PostAppointmentsAppointmentIdRescheduleWithBodyWithResponse(ctx context.Context, appointmentId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAppointmentsAppointmentIdRescheduleResponse, error) {
+	rsp, err := c.PostAppointmentsAppointmentIdRescheduleWithBody(ctx, appointmentId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAppointmentsAppointmentIdRescheduleResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAppointmentsAppointmentIdRescheduleWithResponse(ctx context.Context, appointmentId openapi_types.UUID, body PostAppointmentsAppointmentIdRescheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAppointmentsAppointmentIdRescheduleResponse, error) {
+	rsp, err := c.PostAppointmentsAppointmentIdReschedule(ctx, appointmentId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAppointmentsAppointmentIdRescheduleResponse(rsp)
+}
+
+// PostAppointmentsAppointmentIdStatusWithBodyWithResponse request with arbitrary body returning *PostAppointmentsAppointmentIdStatusResponse
+func (c *ClientWithResponses) PostAppointmentsAppointmentIdStatusWithBodyWithResponse(ctx context.Context, appointmentId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAppointmentsAppointmentIdStatusResponse, error) {
+	rsp, err := c.PostAppointmentsAppointmentIdStatusWithBody(ctx, appointmentId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAppointmentsAppointmentIdStatusResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAppointmentsAppointmentIdStatusWithResponse(ctx context.Context, appointmentId openapi_types.UUID, body PostAppointmentsAppointmentIdStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAppointmentsAppointmentIdStatusResponse, error) {
+	rsp, err := c.PostAppointmentsAppointmentIdStatus(ctx, appointmentId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAppointmentsAppointmentIdStatusResponse(rsp)
+}
+
+// GetLocationsWithResponse request returning *GetLocationsResponse
+func (c *ClientWithResponses) GetLocationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLocationsResponse, error) {
+	rsp, err := c.GetLocations(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLocationsResponse(rsp)
+}
+
+// PostLocationsWithBodyWithResponse request with arbitrary body returning *PostLocationsResponse
+func (c *ClientWithResponses) PostLocationsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostLocationsResponse, error) {
+	rsp, err := c.PostLocationsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostLocationsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostLocationsWithResponse(ctx context.Context, body PostLocationsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostLocationsResponse, error) {
+	rsp, err := c.PostLocations(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostLocationsResponse(rsp)
+}
+
+// GetLocationsLocationIdRoomsWithResponse request returning *GetLocationsLocationIdRoomsResponse
+func (c *ClientWithResponses) GetLocationsLocationIdRoomsWithResponse(ctx context.Context, locationId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetLocationsLocationIdRoomsResponse, error) {
+	rsp, err := c.GetLocationsLocationIdRooms(ctx, locationId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLocationsLocationIdRoomsResponse(rsp)
+}
+
+// PostLocationsLocationIdRoomsWithBodyWithResponse request with arbitrary body returning *PostLocationsLocationIdRoomsResponse
+func (c *ClientWithResponses) PostLocationsLocationIdRoomsWithBodyWithResponse(ctx context.Context, locationId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostLocationsLocationIdRoomsResponse, error) {
+	rsp, err := c.PostLocationsLocationIdRoomsWithBody(ctx, locationId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostLocationsLocationIdRoomsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostLocationsLocationIdRoomsWithResponse(ctx context.Context, locationId openapi_types.UUID, body PostLocationsLocationIdRoomsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostLocationsLocationIdRoomsResponse, error) {
+	rsp, err := c.PostLocationsLocationIdRooms(ctx, locationId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostLocationsLocationIdRoomsResponse(rsp)
+}
+
+// GetOrganizationWithResponse request returning *GetOrganizationResponse
+func (c *ClientWithResponses) GetOrganizationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOrganizationResponse, error) {
+	rsp, err := c.GetOrganization(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOrganizationResponse(rsp)
+}
+
+// GetProviderGroupsWithResponse request returning *GetProviderGroupsResponse
+func (c *ClientWithResponses) GetProviderGroupsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetProviderGroupsResponse, error) {
+	rsp, err := c.GetProviderGroups(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetProviderGroupsResponse(rsp)
+}
+
+// PostProviderGroupsWithBodyWithResponse request with arbitrary body returning *PostProviderGroupsResponse
+func (c *ClientWithResponses) PostProviderGroupsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostProviderGroupsResponse, error) {
+	rsp, err := c.PostProviderGroupsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostProviderGroupsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostProviderGroupsWithResponse(ctx context.Context, body PostProviderGroupsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostProviderGroupsResponse, error) {
+	rsp, err := c.PostProviderGroups(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostProviderGroupsResponse(rsp)
+}
+
+// GetProviderGroupsGroupIdWithResponse request returning *GetProviderGroupsGroupIdResponse
+func (c *ClientWithResponses) GetProviderGroupsGroupIdWithResponse(ctx context.Context, groupId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetProviderGroupsGroupIdResponse, error) {
+	rsp, err := c.GetProviderGroupsGroupId(ctx, groupId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetProviderGroupsGroupIdResponse(rsp)
+}
+
+// GetProvidersWithResponse request returning *GetProvidersResponse
+func (c *ClientWithResponses) GetProvidersWithResponse(ctx context.Context, params *GetProvidersParams, reqEditors ...RequestEditorFn) (*GetProvidersResponse, error) {
+	rsp, err := c.GetProviders(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetProvidersResponse(rsp)
+}
+
+// GetProvidersProviderIdWithResponse request returning *GetProvidersProviderIdResponse
+func (c *ClientWithResponses) GetProvidersProviderIdWithResponse(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetProvidersProviderIdResponse, error) {
+	rsp, err := c.GetProvidersProviderId(ctx, providerId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetProvidersProviderIdResponse(rsp)
+}
+
+// PostProvidersProviderIdAvailabilityWithBodyWithResponse request with arbitrary body returning *PostProvidersProviderIdAvailabilityResponse
+func (c *ClientWithResponses) PostProvidersProviderIdAvailabilityWithBodyWithResponse(ctx context.Context, providerId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostProvidersProviderIdAvailabilityResponse, error) {
+	rsp, err := c.PostProvidersProviderIdAvailabilityWithBody(ctx, providerId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostProvidersProviderIdAvailabilityResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostProvidersProviderIdAvailabilityWithResponse(ctx context.Context, providerId openapi_types.UUID, body PostProvidersProviderIdAvailabilityJSONRequestBody, reqEditors ...RequestEditorFn) (*PostProvidersProviderIdAvailabilityResponse, error) {
+	rsp, err := c.PostProvidersProviderIdAvailability(ctx, providerId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostProvidersProviderIdAvailabilityResponse(rsp)
+}
+
+// PutProvidersProviderIdProfileWithBodyWithResponse request with arbitrary body returning *PutProvidersProviderIdProfileResponse
+func (c *ClientWithResponses) PutProvidersProviderIdProfileWithBodyWithResponse(ctx context.Context, providerId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutProvidersProviderIdProfileResponse, error) {
+	rsp, err := c.PutProvidersProviderIdProfileWithBody(ctx, providerId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutProvidersProviderIdProfileResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutProvidersProviderIdProfileWithResponse(ctx context.Context, providerId openapi_types.UUID, body PutProvidersProviderIdProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*PutProvidersProviderIdProfileResponse, error) {
+	rsp, err := c.PutProvidersProviderIdProfile(ctx, providerId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutProvidersProviderIdProfileResponse(rsp)
+}
+
+// GetProvidersProviderIdWaitlistWithResponse request returning *GetProvidersProviderIdWaitlistResponse
+func (c *ClientWithResponses) GetProvidersProviderIdWaitlistWithResponse(ctx context.Context, providerId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetProvidersProviderIdWaitlistResponse, error) {
+	rsp, err := c.GetProvidersProviderIdWaitlist(ctx, providerId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetProvidersProviderIdWaitlistResponse(rsp)
+}
+
+// GetRoomsRoomIdAppointmentsWithResponse request returning *GetRoomsRoomIdAppointmentsResponse
+func (c *ClientWithResponses) GetRoomsRoomIdAppointmentsWithResponse(ctx context.Context, roomId openapi_types.UUID, params *GetRoomsRoomIdAppointmentsParams, reqEditors ...RequestEditorFn) (*GetRoomsRoomIdAppointmentsResponse, error) {
+	rsp, err := c.GetRoomsRoomIdAppointments(ctx, roomId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRoomsRoomIdAppointmentsResponse(rsp)
+}
+
+// PostUsersWithBodyWithResponse request with arbitrary body returning *PostUsersResponse
+func (c *ClientWithResponses) PostUsersWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersResponse, error) {
+	rsp, err := c.PostUsersWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostUsersWithResponse(ctx context.Context, body PostUsersJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersResponse, error) {
+	rsp, err := c.PostUsers(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersResponse(rsp)
+}
+
+// DeleteUsersUserIdWithResponse request returning *DeleteUsersUserIdResponse
+func (c *ClientWithResponses) DeleteUsersUserIdWithResponse(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteUsersUserIdResponse, error) {
+	rsp, err := c.DeleteUsersUserId(ctx, userId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteUsersUserIdResponse(rsp)
+}
+
+// GetUsersUserIdWithResponse request returning *GetUsersUserIdResponse
+func (c *ClientWithResponses) GetUsersUserIdWithResponse(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetUsersUserIdResponse, error) {
+	rsp, err := c.GetUsersUserId(ctx, userId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUsersUserIdResponse(rsp)
+}
+
+// PatchUsersUserIdWithBodyWithResponse request with arbitrary body returning *PatchUsersUserIdResponse
+func (c *ClientWithResponses) PatchUsersUserIdWithBodyWithResponse(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchUsersUserIdResponse, error) {
+	rsp, err := c.PatchUsersUserIdWithBody(ctx, userId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchUsersUserIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchUsersUserIdWithResponse(ctx context.Context, userId openapi_types.UUID, body PatchUsersUserIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchUsersUserIdResponse, error) {
+	rsp, err := c.PatchUsersUserId(ctx, userId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchUsersUserIdResponse(rsp)
+}
+
+// PostUsersUserIdDeactivateWithResponse request returning *PostUsersUserIdDeactivateResponse
+func (c *ClientWithResponses) PostUsersUserIdDeactivateWithResponse(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostUsersUserIdDeactivateResponse, error) {
+	rsp, err := c.PostUsersUserIdDeactivate(ctx, userId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersUserIdDeactivateResponse(rsp)
+}
+
+// PostWaitlistWithBodyWithResponse request with arbitrary body returning *PostWaitlistResponse
+func (c *ClientWithResponses) PostWaitlistWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostWaitlistResponse, error) {
+	rsp, err := c.PostWaitlistWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostWaitlistResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostWaitlistWithResponse(ctx context.Context, body PostWaitlistJSONRequestBody, reqEditors ...RequestEditorFn) (*PostWaitlistResponse, error) {
+	rsp, err := c.PostWaitlist(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostWaitlistResponse(rsp)
+}
+
+// DeleteWaitlistEntryIdWithResponse request returning *DeleteWaitlistEntryIdResponse
+func (c *ClientWithResponses) DeleteWaitlistEntryIdWithResponse(ctx context.Context, entryId openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteWaitlistEntryIdResponse, error) {
+	rsp, err := c.DeleteWaitlistEntryId(ctx, entryId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteWaitlistEntryIdResponse(rsp)
+}
+
+// ParseGetAppointmentSeriesSeriesIdResponse parses an HTTP response from a GetAppointmentSeriesSeriesIdWithResponse call
+func ParseGetAppointmentSeriesSeriesIdResponse(rsp *http.Response) (*GetAppointmentSeriesSeriesIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAppointmentSeriesSeriesIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AppointmentSeries
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAppointmentSeriesSeriesIdConfirmResponse parses an HTTP response from a PostAppointmentSeriesSeriesIdConfirmWithResponse call
+func ParsePostAppointmentSeriesSeriesIdConfirmResponse(rsp *http.Response) (*PostAppointmentSeriesSeriesIdConfirmResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAppointmentSeriesSeriesIdConfirmResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAppointmentTypesResponse parses an HTTP response from a GetAppointmentTypesWithResponse call
+func ParseGetAppointmentTypesResponse(rsp *http.Response) (*GetAppointmentTypesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAppointmentTypesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []AppointmentType
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAppointmentTypesResponse parses an HTTP response from a PostAppointmentTypesWithResponse call
+func ParsePostAppointmentTypesResponse(rsp *http.Response) (*PostAppointmentTypesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAppointmentTypesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest AppointmentType
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAppointmentsResponse parses an HTTP response from a GetAppointmentsWithResponse call
+func ParseGetAppointmentsResponse(rsp *http.Response) (*GetAppointmentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAppointmentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Appointment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAppointmentsResponse parses an HTTP response from a PostAppointmentsWithResponse call
+func ParsePostAppointmentsResponse(rsp *http.Response) (*PostAppointmentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAppointmentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			union json.RawMessage
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAppointmentsAppointmentIdCancelResponse parses an HTTP response from a PostAppointmentsAppointmentIdCancelWithResponse call
+func ParsePostAppointmentsAppointmentIdCancelResponse(rsp *http.Response) (*PostAppointmentsAppointmentIdCancelResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAppointmentsAppointmentIdCancelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAppointmentsAppointmentIdConfirmResponse parses an HTTP response from a PostAppointmentsAppointmentIdConfirmWithResponse call
+func ParsePostAppointmentsAppointmentIdConfirmResponse(rsp *http.Response) (*PostAppointmentsAppointmentIdConfirmResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAppointmentsAppointmentIdConfirmResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 410:
+		var dest Gone
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON410 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAppointmentsAppointmentIdHistoryResponse parses an HTTP response from a GetAppointmentsAppointmentIdHistoryWithResponse call
+func ParseGetAppointmentsAppointmentIdHistoryResponse(rsp *http.Response) (*GetAppointmentsAppointmentIdHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAppointmentsAppointmentIdHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []StatusChange
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAppointmentsAppointmentIdNotesResponse parses an HTTP response from a GetAppointmentsAppointmentIdNotesWithResponse call
+func ParseGetAppointmentsAppointmentIdNotesResponse(rsp *http.Response) (*GetAppointmentsAppointmentIdNotesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAppointmentsAppointmentIdNotesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []AppointmentNote
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutAppointmentsAppointmentIdNotesResponse parses an HTTP response from a PutAppointmentsAppointmentIdNotesWithResponse call
+func ParsePutAppointmentsAppointmentIdNotesResponse(rsp *http.Response) (*PutAppointmentsAppointmentIdNotesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutAppointmentsAppointmentIdNotesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AppointmentNote
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAppointmentsAppointmentIdRescheduleResponse parses an HTTP response from a PostAppointmentsAppointmentIdRescheduleWithResponse call
+func ParsePostAppointmentsAppointmentIdRescheduleResponse(rsp *http.Response) (*PostAppointmentsAppointmentIdRescheduleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAppointmentsAppointmentIdRescheduleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Appointment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAppointmentsAppointmentIdStatusResponse parses an HTTP response from a PostAppointmentsAppointmentIdStatusWithResponse call
+func ParsePostAppointmentsAppointmentIdStatusResponse(rsp *http.Response) (*PostAppointmentsAppointmentIdStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAppointmentsAppointmentIdStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Appointment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLocationsResponse parses an HTTP response from a GetLocationsWithResponse call
+func ParseGetLocationsResponse(rsp *http.Response) (*GetLocationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLocationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Location
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostLocationsResponse parses an HTTP response from a PostLocationsWithResponse call
+func ParsePostLocationsResponse(rsp *http.Response) (*PostLocationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostLocationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Location
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLocationsLocationIdRoomsResponse parses an HTTP response from a GetLocationsLocationIdRoomsWithResponse call
+func ParseGetLocationsLocationIdRoomsResponse(rsp *http.Response) (*GetLocationsLocationIdRoomsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLocationsLocationIdRoomsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Room
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostLocationsLocationIdRoomsResponse parses an HTTP response from a PostLocationsLocationIdRoomsWithResponse call
+func ParsePostLocationsLocationIdRoomsResponse(rsp *http.Response) (*PostLocationsLocationIdRoomsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostLocationsLocationIdRoomsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Room
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetOrganizationResponse parses an HTTP response from a GetOrganizationWithResponse call
+func ParseGetOrganizationResponse(rsp *http.Response) (*GetOrganizationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOrganizationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Organization
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetProviderGroupsResponse parses an HTTP response from a GetProviderGroupsWithResponse call
+func ParseGetProviderGroupsResponse(rsp *http.Response) (*GetProviderGroupsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetProviderGroupsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ProviderGroup
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostProviderGroupsResponse parses an HTTP response from a PostProviderGroupsWithResponse call
+func ParsePostProviderGroupsResponse(rsp *http.Response) (*PostProviderGroupsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostProviderGroupsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest ProviderGroup
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetProviderGroupsGroupIdResponse parses an HTTP response from a GetProviderGroupsGroupIdWithResponse call
+func ParseGetProviderGroupsGroupIdResponse(rsp *http.Response) (*GetProviderGroupsGroupIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetProviderGroupsGroupIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ProviderGroup
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetProvidersResponse parses an HTTP response from a GetProvidersWithResponse call
+func ParseGetProvidersResponse(rsp *http.Response) (*GetProvidersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetProvidersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Provider
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetProvidersProviderIdResponse parses an HTTP response from a GetProvidersProviderIdWithResponse call
+func ParseGetProvidersProviderIdResponse(rsp *http.Response) (*GetProvidersProviderIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetProvidersProviderIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Provider
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostProvidersProviderIdAvailabilityResponse parses an HTTP response from a PostProvidersProviderIdAvailabilityWithResponse call
+func ParsePostProvidersProviderIdAvailabilityResponse(rsp *http.Response) (*PostProvidersProviderIdAvailabilityResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostProvidersProviderIdAvailabilityResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutProvidersProviderIdProfileResponse parses an HTTP response from a PutProvidersProviderIdProfileWithResponse call
+func ParsePutProvidersProviderIdProfileResponse(rsp *http.Response) (*PutProvidersProviderIdProfileResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutProvidersProviderIdProfileResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Provider
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetProvidersProviderIdWaitlistResponse parses an HTTP response from a GetProvidersProviderIdWaitlistWithResponse call
+func ParseGetProvidersProviderIdWaitlistResponse(rsp *http.Response) (*GetProvidersProviderIdWaitlistResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetProvidersProviderIdWaitlistResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []WaitlistEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRoomsRoomIdAppointmentsResponse parses an HTTP response from a GetRoomsRoomIdAppointmentsWithResponse call
+func ParseGetRoomsRoomIdAppointmentsResponse(rsp *http.Response) (*GetRoomsRoomIdAppointmentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRoomsRoomIdAppointmentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Appointment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostUsersResponse parses an HTTP response from a PostUsersWithResponse call
+func ParsePostUsersResponse(rsp *http.Response) (*PostUsersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostUsersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteUsersUserIdResponse parses an HTTP response from a DeleteUsersUserIdWithResponse call
+func ParseDeleteUsersUserIdResponse(rsp *http.Response) (*DeleteUsersUserIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteUsersUserIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetUsersUserIdResponse parses an HTTP response from a GetUsersUserIdWithResponse call
+func ParseGetUsersUserIdResponse(rsp *http.Response) (*GetUsersUserIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUsersUserIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchUsersUserIdResponse parses an HTTP response from a PatchUsersUserIdWithResponse call
+func ParsePatchUsersUserIdResponse(rsp *http.Response) (*PatchUsersUserIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchUsersUserIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostUsersUserIdDeactivateResponse parses an HTTP response from a PostUsersUserIdDeactivateWithResponse call
+func ParsePostUsersUserIdDeactivateResponse(rsp *http.Response) (*PostUsersUserIdDeactivateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostUsersUserIdDeactivateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostWaitlistResponse parses an HTTP response from a PostWaitlistWithResponse call
+func ParsePostWaitlistResponse(rsp *http.Response) (*PostWaitlistResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostWaitlistResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest WaitlistEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteWaitlistEntryIdResponse parses an HTTP response from a DeleteWaitlistEntryIdWithResponse call
+func ParseDeleteWaitlistEntryIdResponse(rsp *http.Response) (*DeleteWaitlistEntryIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteWaitlistEntryIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
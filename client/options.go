@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyHeader carries a key that identifies repeats of the same call to proxies and servers that
+// deduplicate by it. The reservation api does not, so a POST is never retried.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// New returns a typed client for the reservation api at server, which is the base url such as
+// http://localhost:8080
+func New(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	return NewClientWithResponses(server, opts...)
+}
+
+// WithBearerToken sends token in the Authorization header of every request
+func WithBearerToken(token string) ClientOption {
+	return WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// WithIdempotencyKeys gives every POST without an idempotency key a random one
+func WithIdempotencyKeys() ClientOption {
+	return WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+		if req.Method == http.MethodPost && req.Header.Get(IdempotencyKeyHeader) == "" {
+			req.Header.Set(IdempotencyKeyHeader, uuid.NewString())
+		}
+		return nil
+	})
+}
+
+// WithIdempotencyKey sets the idempotency key of a single call, pass it as one of the call's editors
+func WithIdempotencyKey(key string) RequestEditorFn {
+	return func(_ context.Context, req *http.Request) error {
+		req.Header.Set(IdempotencyKeyHeader, key)
+		return nil
+	}
+}
+
+// WithRetries retries a request up to attempts more times when it fails to reach the server or the
+// server answers 429, 502, 503 or 504, waiting backoff before the first retry and doubling it after
+// each one. Only GET, HEAD and OPTIONS requests are retried: a POST, PUT, PATCH or DELETE whose answer
+// was lost may have taken effect, and the api does not recognise a repeat of it. It wraps the doer set
+// so far, so it goes after WithHTTPClient.
+func WithRetries(attempts int, backoff time.Duration) ClientOption {
+	return func(c *Client) error {
+		next := c.Client
+		if next == nil {
+			next = &http.Client{}
+		}
+		c.Client = &retryDoer{next: next, attempts: attempts, backoff: backoff}
+		return nil
+	}
+}
+
+type retryDoer struct {
+	next     HttpRequestDoer
+	attempts int
+	backoff  time.Duration
+}
+
+func (d *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	if !retryable(req) {
+		return d.next.Do(req)
+	}
+
+	wait := d.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := d.next.Do(req)
+		if attempt == d.attempts || !transient(resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		wait *= 2
+
+		// The body was read by the failed attempt, send it again from the start
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+func retryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func transient(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
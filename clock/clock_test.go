@@ -0,0 +1,22 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFake(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, time.March, 9, 12, 0, 0, 0, time.UTC)
+	fake := NewFake(start)
+	require.True(t, start.Equal(fake.Now()))
+
+	fake.Advance(29 * time.Minute)
+	require.True(t, start.Add(29*time.Minute).Equal(fake.Now()))
+
+	fake.Set(start)
+	require.True(t, start.Equal(fake.Now()))
+}
@@ -0,0 +1,257 @@
+// Package config loads the service configuration from a yaml or toml file, the environment and flags
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/tateexon/reservation/tracing"
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces secrets when the configuration is printed
+const redacted = "REDACTED"
+
+// Storage drivers
+const (
+	DriverPostgres = "postgres"
+	// DriverSQLite keeps everything in a single file, for small deployments without a database server
+	DriverSQLite = "sqlite"
+	// DriverMemory keeps everything in memory for demos, nothing survives a restart
+	DriverMemory = "memory"
+)
+
+// Config is the effective configuration of the service
+type Config struct {
+	Server   Server   `yaml:"server" toml:"server"`
+	Database Database `yaml:"database" toml:"database"`
+
+	// LogLevel is one of debug, info, warn or error
+	LogLevel string `yaml:"log_level" toml:"log_level"`
+	// TraceExporter is one of otlp, stdout or none
+	TraceExporter string `yaml:"trace_exporter" toml:"trace_exporter"`
+	// NotifyWebhookURL receives notifications as json, they are logged when it is empty
+	NotifyWebhookURL string `yaml:"notify_webhook_url" toml:"notify_webhook_url"`
+
+	AvailabilityInterval Duration `yaml:"availability_interval" toml:"availability_interval"`
+	WaitlistInterval     Duration `yaml:"waitlist_interval" toml:"waitlist_interval"`
+
+	// MigrateOnStart applies pending migrations before serving
+	MigrateOnStart bool `yaml:"migrate_on_start" toml:"migrate_on_start"`
+}
+
+// Server configures the http server
+type Server struct {
+	// Listen is every address the api is served on
+	Listen []string `yaml:"listen" toml:"listen"`
+	// CORSOrigins are the origins browsers may call the api from, * allows any without credentials and none,
+	// the default, disables CORS
+	CORSOrigins []string `yaml:"cors_origins" toml:"cors_origins"`
+	// ShutdownDelay keeps serving after a shutdown signal while readiness fails
+	ShutdownDelay Duration `yaml:"shutdown_delay" toml:"shutdown_delay"`
+	// ShutdownTimeout bounds how long in-flight requests and workers get to finish
+	ShutdownTimeout Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+}
+
+// Database picks the storage driver and configures the postgres connection, either as a full DSN or from its parts
+type Database struct {
+	// Driver is postgres, sqlite, or memory for a demo that needs no database
+	Driver string `yaml:"driver" toml:"driver"`
+	// Path is the sqlite database file, it is created if it does not exist
+	Path string `yaml:"path" toml:"path"`
+
+	// DSN is a postgres url or key=value connection string, when set the other fields are ignored
+	DSN      string `yaml:"dsn" toml:"dsn"`
+	Host     string `yaml:"host" toml:"host"`
+	Name     string `yaml:"name" toml:"name"`
+	User     string `yaml:"user" toml:"user"`
+	Password string `yaml:"password" toml:"password"`
+	// PasswordFile is read for the password so it does not have to be in the configuration file
+	PasswordFile string `yaml:"password_file" toml:"password_file"`
+
+	// SSLMode is one of disable, require, verify-ca or verify-full
+	SSLMode     string `yaml:"sslmode" toml:"sslmode"`
+	SSLRootCert string `yaml:"sslrootcert" toml:"sslrootcert"`
+	SSLCert     string `yaml:"sslcert" toml:"sslcert"`
+	SSLKey      string `yaml:"sslkey" toml:"sslkey"`
+}
+
+// Duration is a time.Duration written like 1m30s in configuration files
+type Duration struct {
+	time.Duration
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
+
+// Default returns the configuration used for anything that is not set
+func Default() Config {
+	return Config{
+		Server: Server{
+			Listen:          []string{":8080"},
+			ShutdownTimeout: Duration{30 * time.Second},
+		},
+		Database: Database{
+			Driver:  DriverPostgres,
+			Path:    "reservation.db",
+			SSLMode: "require",
+		},
+		LogLevel:             "info",
+		TraceExporter:        tracing.ExporterNone,
+		AvailabilityInterval: Duration{15 * time.Minute},
+		WaitlistInterval:     Duration{time.Minute},
+	}
+}
+
+var sslModes = map[string]bool{"disable": true, "allow": true, "prefer": true, "require": true, "verify-ca": true, "verify-full": true}
+
+// Validate reports every problem with the configuration at once
+func (c *Config) Validate() error {
+	var errs []error
+
+	if len(c.Server.Listen) == 0 {
+		errs = append(errs, errors.New("server.listen needs at least one address"))
+	}
+	if c.Server.ShutdownDelay.Duration < 0 {
+		errs = append(errs, errors.New("server.shutdown_delay must not be negative"))
+	}
+	if c.Server.ShutdownTimeout.Duration <= 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout must be positive"))
+	}
+
+	switch c.Database.Driver {
+	case DriverPostgres, DriverSQLite, DriverMemory:
+	default:
+		errs = append(errs, fmt.Errorf("database.driver %q must be postgres, sqlite or memory", c.Database.Driver))
+	}
+	if c.Database.Driver == DriverSQLite && c.Database.Path == "" {
+		errs = append(errs, errors.New("database.path is not set (SQLITE_PATH)"))
+	}
+	if c.Database.Driver == DriverPostgres && c.Database.DSN == "" {
+		if c.Database.Host == "" {
+			errs = append(errs, errors.New("database.host is not set (POSTGRES_URL)"))
+		}
+		if c.Database.Name == "" {
+			errs = append(errs, errors.New("database.name is not set (POSTGRES_DB)"))
+		}
+		if c.Database.User == "" {
+			errs = append(errs, errors.New("database.user is not set (POSTGRES_USER)"))
+		}
+		if !sslModes[c.Database.SSLMode] {
+			errs = append(errs, fmt.Errorf("database.sslmode %q is not a postgres sslmode", c.Database.SSLMode))
+		}
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		errs = append(errs, fmt.Errorf("log_level: %w", err))
+	}
+	switch c.TraceExporter {
+	case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	default:
+		errs = append(errs, fmt.Errorf("trace_exporter %q must be one of otlp, stdout or none", c.TraceExporter))
+	}
+	if c.AvailabilityInterval.Duration <= 0 {
+		errs = append(errs, errors.New("availability_interval must be positive"))
+	}
+	if c.WaitlistInterval.Duration <= 0 {
+		errs = append(errs, errors.New("waitlist_interval must be positive"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ConnectionString returns the DSN, or builds a postgres url from the other database fields
+func (d Database) ConnectionString() string {
+	if d.DSN != "" {
+		return d.DSN
+	}
+
+	query := url.Values{}
+	query.Set("sslmode", d.SSLMode)
+	for key, value := range map[string]string{"sslrootcert": d.SSLRootCert, "sslcert": d.SSLCert, "sslkey": d.SSLKey} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	// Without a password the server has to trust the client or its certificate
+	user := url.User(d.User)
+	if d.Password != "" {
+		user = url.UserPassword(d.User, d.Password)
+	}
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     user,
+		Host:     d.Host,
+		Path:     "/" + d.Name,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// keyValuePassword matches the password in a key=value connection string
+var keyValuePassword = regexp.MustCompile(`(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// Redacted returns a copy of the configuration that is safe to print
+func (c Config) Redacted() Config {
+	if c.Database.Password != "" {
+		c.Database.Password = redacted
+	}
+	if c.Database.DSN != "" {
+		if u, err := url.Parse(c.Database.DSN); err == nil && u.Scheme != "" {
+			c.Database.DSN = redactURL(u)
+		} else {
+			c.Database.DSN = keyValuePassword.ReplaceAllString(c.Database.DSN, "${1}"+redacted)
+		}
+	}
+	if c.NotifyWebhookURL != "" {
+		if u, err := url.Parse(c.NotifyWebhookURL); err == nil {
+			c.NotifyWebhookURL = redactURL(u)
+		} else {
+			c.NotifyWebhookURL = redacted
+		}
+	}
+	return c
+}
+
+// WriteRedacted writes the configuration as yaml with its secrets redacted
+func (c Config) WriteRedacted(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c.Redacted()); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// redactURL hides the password and query values of a url, which is where tokens usually are
+func redactURL(u *url.URL) string {
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key := range query {
+			if key != "sslmode" {
+				query.Set(key, redacted)
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
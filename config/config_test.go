@@ -0,0 +1,234 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// env is a fake environment for LookupEnv
+type env map[string]string
+
+func (e env) lookup(key string) (string, bool) {
+	value, ok := e[key]
+	return value, ok
+}
+
+// load parses args as flags and loads the configuration from them and e
+func load(t *testing.T, e env, args ...string) (*Config, error) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := RegisterFlags(fs)
+	require.NoError(t, fs.Parse(args))
+	return flags.Load(e.lookup)
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+var databaseEnv = env{
+	"POSTGRES_USER":     "youruser",
+	"POSTGRES_PASSWORD": "yourpassword",
+	"POSTGRES_URL":      "db:5432",
+	"POSTGRES_DB":       "yourdb",
+}
+
+func TestLoad_Env(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := load(t, databaseEnv)
+	require.NoError(t, err)
+	require.Equal(t, []string{":8080"}, cfg.Server.Listen)
+	require.Empty(t, cfg.Server.CORSOrigins)
+	require.Equal(t, time.Minute, cfg.WaitlistInterval.Duration)
+	require.Equal(t, "postgres://youruser:yourpassword@db:5432/yourdb?sslmode=require", cfg.Database.ConnectionString())
+}
+
+func TestLoad_MissingDatabase(t *testing.T) {
+	t.Parallel()
+
+	_, err := load(t, env{"POSTGRES_USER": "youruser"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "POSTGRES_URL")
+	require.Contains(t, err.Error(), "POSTGRES_DB")
+	require.NotContains(t, err.Error(), "POSTGRES_USER")
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	t.Parallel()
+
+	yamlFile := writeFile(t, "config.yaml", `
+server:
+  listen: [":9000", ":9001"]
+  cors_origins: ["https://app.example.com"]
+log_level: debug
+waitlist_interval: 5m
+database:
+  host: file-host:5432
+  name: filedb
+  user: fileuser
+  sslmode: verify-full
+  sslrootcert: /etc/ssl/ca.pem
+`)
+	tomlFile := writeFile(t, "config.toml", `
+log_level = "debug"
+waitlist_interval = "5m"
+
+[server]
+listen = [":9000", ":9001"]
+cors_origins = ["https://app.example.com"]
+
+[database]
+host = "file-host:5432"
+name = "filedb"
+user = "fileuser"
+sslmode = "verify-full"
+sslrootcert = "/etc/ssl/ca.pem"
+`)
+
+	for _, path := range []string{yamlFile, tomlFile} {
+		// The file overrides the defaults
+		cfg, err := load(t, env{}, "-config", path)
+		require.NoError(t, err, path)
+		require.Equal(t, []string{":9000", ":9001"}, cfg.Server.Listen)
+		require.Equal(t, []string{"https://app.example.com"}, cfg.Server.CORSOrigins)
+		require.Equal(t, 5*time.Minute, cfg.WaitlistInterval.Duration)
+		require.Equal(t, 30*time.Second, cfg.Server.ShutdownTimeout.Duration)
+		require.Equal(t, "postgres://fileuser@file-host:5432/filedb?sslmode=verify-full&sslrootcert=%2Fetc%2Fssl%2Fca.pem", cfg.Database.ConnectionString())
+
+		// The environment overrides the file and the flags override both
+		cfg, err = load(t, env{"CONFIG_FILE": path, "LOG_LEVEL": "warn", "LISTEN_ADDRS": ":7000", "WAITLIST_INTERVAL": "10s"}, "-log-level", "error")
+		require.NoError(t, err, path)
+		require.Equal(t, "error", cfg.LogLevel)
+		require.Equal(t, []string{":7000"}, cfg.Server.Listen)
+		require.Equal(t, 10*time.Second, cfg.WaitlistInterval.Duration)
+	}
+}
+
+func TestLoad_MemoryDriver(t *testing.T) {
+	t.Parallel()
+
+	// The memory driver needs no database settings
+	cfg, err := load(t, env{"DATABASE_DRIVER": "memory"})
+	require.NoError(t, err)
+	require.Equal(t, DriverMemory, cfg.Database.Driver)
+
+	_, err = load(t, env{"DATABASE_DRIVER": "mysql"})
+	require.Error(t, err)
+}
+
+func TestLoad_SQLiteDriver(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := load(t, env{"DATABASE_DRIVER": "sqlite", "SQLITE_PATH": "/var/lib/reservation/clinic.db"})
+	require.NoError(t, err)
+	require.Equal(t, DriverSQLite, cfg.Database.Driver)
+	require.Equal(t, "/var/lib/reservation/clinic.db", cfg.Database.Path)
+
+	_, err = load(t, env{"DATABASE_DRIVER": "sqlite", "SQLITE_PATH": ""})
+	require.ErrorContains(t, err, "database.path")
+}
+
+func TestLoad_UnknownKey(t *testing.T) {
+	t.Parallel()
+
+	path := writeFile(t, "config.yaml", "databse:\n  host: db:5432\n")
+	_, err := load(t, databaseEnv, "-config", path)
+	require.Error(t, err)
+}
+
+func TestLoad_SecretFiles(t *testing.T) {
+	t.Parallel()
+
+	secret := writeFile(t, "password", "s3cret\n")
+	e := env{
+		"POSTGRES_USER":          "youruser",
+		"POSTGRES_PASSWORD_FILE": secret,
+		"POSTGRES_URL":           "db:5432",
+		"POSTGRES_DB":            "yourdb",
+	}
+	cfg, err := load(t, e)
+	require.NoError(t, err)
+	require.Equal(t, "s3cret", cfg.Database.Password)
+
+	// Setting both is ambiguous
+	e["POSTGRES_PASSWORD"] = "other"
+	_, err = load(t, e)
+	require.Error(t, err)
+
+	// The configuration file can point at a secret too
+	path := writeFile(t, "config.yaml", "database:\n  password_file: "+secret+"\n")
+	e = env{"POSTGRES_USER": "youruser", "POSTGRES_URL": "db:5432", "POSTGRES_DB": "yourdb"}
+	cfg, err = load(t, e, "-config", path)
+	require.NoError(t, err)
+	require.Equal(t, "s3cret", cfg.Database.Password)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for name, e := range map[string]env{
+		"duration":     {"WAITLIST_INTERVAL": "soon"},
+		"zero":         {"WAITLIST_INTERVAL": "0s"},
+		"log level":    {"LOG_LEVEL": "loud"},
+		"exporter":     {"TRACE_EXPORTER": "zipkin"},
+		"sslmode":      {"POSTGRES_SSLMODE": "sometimes"},
+		"bool":         {"MIGRATE_ON_START": "maybe"},
+		"no listeners": {"LISTEN_ADDRS": ""},
+	} {
+		for key, value := range databaseEnv {
+			if _, ok := e[key]; !ok {
+				e[key] = value
+			}
+		}
+		_, err := load(t, e)
+		require.Error(t, err, name)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	t.Parallel()
+
+	cfg := Default()
+	cfg.Database.Password = "s3cret"
+	cfg.NotifyWebhookURL = "https://hooks.example.com/notify?token=abc123"
+
+	var buf bytes.Buffer
+	require.NoError(t, cfg.WriteRedacted(&buf))
+	require.NotContains(t, buf.String(), "s3cret")
+	require.NotContains(t, buf.String(), "abc123")
+	require.Contains(t, buf.String(), "hooks.example.com")
+	require.Contains(t, buf.String(), "waitlist_interval: 1m0s")
+
+	// The original is untouched
+	require.Equal(t, "s3cret", cfg.Database.Password)
+
+	for dsn, secret := range map[string]string{
+		"postgres://user:s3cret@db:5432/yourdb?sslmode=require": "s3cret",
+		"host=db user=user password=s3cret dbname=yourdb":       "s3cret",
+		"host=db password='with space' dbname=yourdb":           "with space",
+	} {
+		cfg.Database.DSN = dsn
+		redacted := cfg.Redacted().Database.DSN
+		require.NotContains(t, redacted, secret)
+		require.Contains(t, redacted, "db")
+	}
+}
+
+func TestLoad_Example(t *testing.T) {
+	t.Parallel()
+
+	// The example documents every key so it has to stay loadable
+	secret := writeFile(t, "password", "s3cret")
+	cfg, err := load(t, env{"POSTGRES_PASSWORD_FILE": secret}, "-config", "example.yaml")
+	require.NoError(t, err)
+	require.Equal(t, "yourdb", cfg.Database.Name)
+	require.Equal(t, "s3cret", cfg.Database.Password)
+}
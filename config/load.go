@@ -0,0 +1,225 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// LookupEnv reads an environment variable, os.LookupEnv outside of tests
+type LookupEnv func(key string) (string, bool)
+
+// envVar overrides a setting from the environment
+type envVar struct {
+	name string
+	set  func(c *Config, value string) error
+}
+
+// envVars lists the environment variables in the order they are applied. Every one of them can also be read
+// from the file named by the same variable with a _FILE suffix, which is how container secrets are mounted.
+var envVars = []envVar{
+	stringEnv("DATABASE_DRIVER", func(c *Config) *string { return &c.Database.Driver }),
+	stringEnv("SQLITE_PATH", func(c *Config) *string { return &c.Database.Path }),
+	stringEnv("DATABASE_URL", func(c *Config) *string { return &c.Database.DSN }),
+	stringEnv("POSTGRES_URL", func(c *Config) *string { return &c.Database.Host }),
+	stringEnv("POSTGRES_DB", func(c *Config) *string { return &c.Database.Name }),
+	stringEnv("POSTGRES_USER", func(c *Config) *string { return &c.Database.User }),
+	stringEnv("POSTGRES_PASSWORD", func(c *Config) *string { return &c.Database.Password }),
+	stringEnv("POSTGRES_SSLMODE", func(c *Config) *string { return &c.Database.SSLMode }),
+	stringEnv("POSTGRES_SSLROOTCERT", func(c *Config) *string { return &c.Database.SSLRootCert }),
+	stringEnv("POSTGRES_SSLCERT", func(c *Config) *string { return &c.Database.SSLCert }),
+	stringEnv("POSTGRES_SSLKEY", func(c *Config) *string { return &c.Database.SSLKey }),
+	listEnv("LISTEN_ADDRS", func(c *Config) *[]string { return &c.Server.Listen }),
+	listEnv("CORS_ORIGINS", func(c *Config) *[]string { return &c.Server.CORSOrigins }),
+	durationEnv("SHUTDOWN_DELAY", func(c *Config) *Duration { return &c.Server.ShutdownDelay }),
+	durationEnv("SHUTDOWN_TIMEOUT", func(c *Config) *Duration { return &c.Server.ShutdownTimeout }),
+	stringEnv("LOG_LEVEL", func(c *Config) *string { return &c.LogLevel }),
+	stringEnv("TRACE_EXPORTER", func(c *Config) *string { return &c.TraceExporter }),
+	stringEnv("NOTIFY_WEBHOOK_URL", func(c *Config) *string { return &c.NotifyWebhookURL }),
+	durationEnv("AVAILABILITY_INTERVAL", func(c *Config) *Duration { return &c.AvailabilityInterval }),
+	durationEnv("WAITLIST_INTERVAL", func(c *Config) *Duration { return &c.WaitlistInterval }),
+	boolEnv("MIGRATE_ON_START", func(c *Config) *bool { return &c.MigrateOnStart }),
+}
+
+func stringEnv(name string, field func(c *Config) *string) envVar {
+	return envVar{name: name, set: func(c *Config, value string) error {
+		*field(c) = value
+		return nil
+	}}
+}
+
+func listEnv(name string, field func(c *Config) *[]string) envVar {
+	return envVar{name: name, set: func(c *Config, value string) error {
+		*field(c) = splitList(value)
+		return nil
+	}}
+}
+
+func durationEnv(name string, field func(c *Config) *Duration) envVar {
+	return envVar{name: name, set: func(c *Config, value string) error {
+		return field(c).UnmarshalText([]byte(value))
+	}}
+}
+
+func boolEnv(name string, field func(c *Config) *bool) envVar {
+	return envVar{name: name, set: func(c *Config, value string) error {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*field(c) = parsed
+		return nil
+	}}
+}
+
+// splitList splits a comma separated list, an empty string is an empty list
+func splitList(value string) []string {
+	list := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// Flags are the command line flags that override the file and the environment
+type Flags struct {
+	fs            *flag.FlagSet
+	configPath    *string
+	listen        *string
+	corsOrigins   *string
+	logLevel      *string
+	traceExporter *string
+	migrate       *bool
+}
+
+// RegisterFlags adds the configuration flags to fs, call Load once fs is parsed
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	return &Flags{
+		fs:            fs,
+		configPath:    fs.String("config", "", "yaml or toml configuration `file`, defaults to CONFIG_FILE"),
+		listen:        fs.String("listen", "", "comma separated `addresses` to serve on"),
+		corsOrigins:   fs.String("cors-origins", "", "comma separated `origins` allowed to call the api"),
+		logLevel:      fs.String("log-level", "", "one of debug, info, warn or error"),
+		traceExporter: fs.String("trace-exporter", "", "one of otlp, stdout or none"),
+		migrate:       fs.Bool("migrate", false, "apply pending migrations before serving"),
+	}
+}
+
+// Load builds the configuration from the defaults, then the configuration file, then the environment and
+// finally the flags that were set, and validates it
+func (f *Flags) Load(lookupEnv LookupEnv) (*Config, error) {
+	path := *f.configPath
+	if path == "" {
+		path, _ = lookupEnv("CONFIG_FILE")
+	}
+
+	cfg := Default()
+	if path != "" {
+		if err := readFile(path, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	if err := applyEnv(&cfg, lookupEnv); err != nil {
+		return nil, err
+	}
+	f.fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "listen":
+			cfg.Server.Listen = splitList(*f.listen)
+		case "cors-origins":
+			cfg.Server.CORSOrigins = splitList(*f.corsOrigins)
+		case "log-level":
+			cfg.LogLevel = *f.logLevel
+		case "trace-exporter":
+			cfg.TraceExporter = *f.traceExporter
+		case "migrate":
+			cfg.MigrateOnStart = *f.migrate
+		}
+	})
+
+	if cfg.Database.Password == "" && cfg.Database.PasswordFile != "" {
+		password, err := readSecret(cfg.Database.PasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("database.password_file: %w", err)
+		}
+		cfg.Database.Password = password
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return &cfg, nil
+}
+
+// readFile decodes a yaml or toml file, picked by its extension, over cfg. Unknown keys are an error so typos
+// do not go unnoticed.
+func readFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+
+	switch ext := filepath.Ext(path); ext {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(cfg)
+		if errors.Is(err, io.EOF) {
+			// An empty file changes nothing
+			err = nil
+		}
+	case ".toml":
+		decoder := toml.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(cfg)
+	default:
+		return fmt.Errorf("configuration file %s must be .yaml, .yml or .toml, not %q", path, ext)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read configuration file %s: %w", path, err)
+	}
+	return nil
+}
+
+func applyEnv(cfg *Config, lookupEnv LookupEnv) error {
+	for _, env := range envVars {
+		value, ok := lookupEnv(env.name)
+		if path, fromFile := lookupEnv(env.name + "_FILE"); fromFile {
+			if ok {
+				return fmt.Errorf("only one of %s and %s_FILE can be set", env.name, env.name)
+			}
+			secret, err := readSecret(path)
+			if err != nil {
+				return fmt.Errorf("%s_FILE: %w", env.name, err)
+			}
+			value, ok = secret, true
+		}
+		if !ok {
+			continue
+		}
+		if err := env.set(cfg, value); err != nil {
+			return fmt.Errorf("invalid %s: %w", env.name, err)
+		}
+	}
+	return nil
+}
+
+// readSecret reads a secret file without the trailing newline editors and kubernetes leave in
+func readSecret(path string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -1,29 +1,55 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"time"
 
+	"github.com/XSAM/otelsql"
 	"github.com/google/uuid"
-	_ "github.com/lib/pq"                   // PostgreSQL driver
+	"github.com/lib/pq"                     // PostgreSQL driver
 	"github.com/oapi-codegen/runtime/types" // Import openapi_types
+	"github.com/tateexon/reservation/clock"
 	"github.com/tateexon/reservation/schema"
 	"github.com/tateexon/reservation/utils"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 const availabilityInterval = 15 * time.Minute
 
+// ErrSlotUnavailable is returned when a slot has no seats left or no longer exists
+var ErrSlotUnavailable = errors.New("slot is not available")
+
+// ErrHoldExpired is returned when a reservation was not confirmed in time, it is also sql.ErrNoRows
+var ErrHoldExpired = fmt.Errorf("reservation hold has expired: %w", sql.ErrNoRows)
+
+// ReservationHoldDuration is how long a reservation is held before it expires if not confirmed
+const ReservationHoldDuration = 30 * time.Minute
+
 var avInterval time.Duration
 
+// tracer records a span for every Database method, the statements a method runs are its children
+var tracer = otel.Tracer("github.com/tateexon/reservation/db")
+
 type Database struct {
-	Conn *sql.DB
+	Conn   *sql.DB
+	Logger *slog.Logger
+	// Clock is the time queries run at, the database's own clock is never used
+	Clock clock.Clock
 }
 
-// Initialize database connection
-func NewDatabase(connStr string) (*Database, error) {
-	conn, err := sql.Open("postgres", connStr)
+// Initialize database connection, a nil logger uses the default logger
+func NewDatabase(connStr string, logger *slog.Logger) (*Database, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	// Every statement is traced as a child of the span in its context
+	conn, err := otelsql.Open("postgres", connStr, otelsql.WithAttributes(semconv.DBSystemPostgreSQL))
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +57,40 @@ func NewDatabase(connStr string) (*Database, error) {
 	if err := conn.Ping(); err != nil {
 		return nil, err
 	}
-	return &Database{Conn: conn}, nil
+	logger.Info("Connected to database")
+	return &Database{Conn: conn, Logger: logger, Clock: clock.System{}}, nil
+}
+
+// holdCutoff is the creation time before which reservations have expired at now
+func holdCutoff(now time.Time) time.Time {
+	return now.Add(-ReservationHoldDuration)
+}
+
+// rollback ends a transaction that was not committed, it is a no-op after a commit
+func (db *Database) rollback(tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		db.Logger.Error("Failed to roll back transaction", slog.Any("error", err))
+	}
+}
+
+// Ping checks that the database can be reached
+func (db *Database) Ping(ctx context.Context) error {
+	return db.Conn.PingContext(ctx)
+}
+
+// SchemaVersion returns the version of the newest migration applied to the database
+func (db *Database) SchemaVersion(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "db.SchemaVersion")
+	defer span.End()
+
+	var version int
+	err := db.Conn.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	return version, err
+}
+
+// SetAvailabilityInterval sets the length of availability slots instead of AVAILABILITY_INTERVAL
+func SetAvailabilityInterval(interval time.Duration) {
+	avInterval = interval
 }
 
 func GetAvailabilityInterval() time.Duration {
@@ -46,23 +105,29 @@ func GetAvailabilityInterval() time.Duration {
 
 }
 
-func (db *Database) GetAvailableAppointments(providerID *types.UUID, date *types.Date) ([]schema.Appointment, error) {
-	var appointments []schema.Appointment
+// GetAvailableAppointments lists the slots with a seat left. With participants only the slots at times when
+// every participant has a free slot too are listed.
+func (db *Database) GetAvailableAppointments(ctx context.Context, providerID *types.UUID, participantIDs []types.UUID, locationID *types.UUID, date *types.Date) ([]schema.Appointment, error) {
+	ctx, span := tracer.Start(ctx, "db.GetAvailableAppointments")
+	defer span.End()
+
+	appointments := []schema.Appointment{}
 
 	query := `
-    SELECT a.id, a.provider_id, a.start_time, a.end_time
+    SELECT a.id, a.provider_id, a.location_id, a.start_time, a.end_time, a.capacity, a.capacity - COUNT(appt.id)
     FROM availability a
-    LEFT JOIN appointments appt ON a.provider_id = appt.provider_id AND a.start_time = appt.start_time
+    JOIN users u ON u.id = a.provider_id AND u.deactivated_at IS NULL
+    LEFT JOIN appointments appt ON ` + seatOf("a.provider_id") + ` AND a.start_time = appt.start_time
       AND appt.status IN ('reserved', 'confirmed')
       AND (
         appt.status = 'confirmed' OR
-        (appt.status = 'reserved' AND appt.created_at > NOW() - INTERVAL '30 minutes')
+        (appt.status = 'reserved' AND appt.created_at > $1)
       )
-    WHERE appt.id IS NULL
+    WHERE TRUE
     `
 
-	var args []interface{}
-	argIndex := 1
+	args := []interface{}{holdCutoff(db.Clock.Now())}
+	argIndex := 2
 
 	if providerID != nil {
 		query += fmt.Sprintf(" AND a.provider_id = $%d", argIndex)
@@ -70,6 +135,20 @@ func (db *Database) GetAvailableAppointments(providerID *types.UUID, date *types
 		argIndex++
 	}
 
+	if len(participantIDs) > 0 {
+		// Deactivated participants can not be booked, so they are never free
+		query += fmt.Sprintf(" AND %s = $%d", freeSlots(fmt.Sprintf("$%d", argIndex), "a.start_time", "$1"), argIndex+1)
+		query += fmt.Sprintf(" AND NOT EXISTS (SELECT 1 FROM users pu WHERE pu.id = ANY($%d::uuid[]) AND pu.deactivated_at IS NOT NULL)", argIndex)
+		args = append(args, pq.Array(uuidStrings(participantIDs)), len(participantIDs))
+		argIndex += 2
+	}
+
+	if locationID != nil {
+		query += fmt.Sprintf(" AND a.location_id = $%d", argIndex)
+		args = append(args, locationID.String())
+		argIndex++
+	}
+
 	if date != nil {
 		// We need to filter on date
 		// a.start_time >= date and a.start_time < date + 1 day
@@ -80,7 +159,16 @@ func (db *Database) GetAvailableAppointments(providerID *types.UUID, date *types
 		args = append(args, startOfDay, endOfDay)
 	}
 
-	rows, err := db.Conn.Query(query, args...)
+	// Only slots with seats left are available
+	query += " GROUP BY a.id HAVING COUNT(appt.id) < a.capacity ORDER BY a.start_time"
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.rollback(tx)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -90,18 +178,23 @@ func (db *Database) GetAvailableAppointments(providerID *types.UUID, date *types
 		var appointment schema.Appointment
 		var id uuid.UUID
 		var providerID uuid.UUID
+		var locationID uuid.NullUUID
 		var startTime time.Time
 		var endTime time.Time
+		var capacity, seatsRemaining int
 
-		err := rows.Scan(&id, &providerID, &startTime, &endTime)
+		err := rows.Scan(&id, &providerID, &locationID, &startTime, &endTime, &capacity, &seatsRemaining)
 		if err != nil {
 			return nil, err
 		}
 
 		appointment.Id = (*types.UUID)(&id)
 		appointment.ProviderId = (*types.UUID)(&providerID)
+		appointment.LocationId = nullUUID(locationID)
 		appointment.StartTime = &startTime
 		appointment.EndTime = &endTime
+		appointment.Capacity = &capacity
+		appointment.SeatsRemaining = &seatsRemaining
 
 		appointments = append(appointments, appointment)
 	}
@@ -113,63 +206,203 @@ func (db *Database) GetAvailableAppointments(providerID *types.UUID, date *types
 	return appointments, nil
 }
 
-func (db *Database) GetAppointmentStartTime(availabilityID *types.UUID) (time.Time, error) {
+func (db *Database) GetAppointmentStartTime(ctx context.Context, availabilityID *types.UUID) (time.Time, error) {
+	ctx, span := tracer.Start(ctx, "db.GetAppointmentStartTime")
+	defer span.End()
+
 	var startTime time.Time
-	err := db.Conn.QueryRow(`
-	SELECT start_time
-	FROM availability
-	WHERE id = $1
-`, availabilityID.String()).Scan(&startTime)
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, `
+		SELECT start_time
+		FROM availability
+		WHERE id = $1
+	`, availabilityID.String()).Scan(&startTime)
+	})
 
 	return startTime, err
 }
 
-func (db *Database) IsSlotAvailable(providerID *types.UUID, startTime *time.Time) (bool, error) {
+// IsSlotAvailable reports whether the provider and every participant have a slot with a seat left at startTime
+func (db *Database) IsSlotAvailable(ctx context.Context, providerID *types.UUID, participantIDs []types.UUID, startTime *time.Time) (bool, error) {
+	ctx, span := tracer.Start(ctx, "db.IsSlotAvailable")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer db.rollback(tx)
+
+	providers := append([]string{providerID.String()}, uuidStrings(participantIDs)...)
 	var count int
-	err := db.Conn.QueryRow(`
-        SELECT COUNT(*)
-        FROM availability a
-        WHERE a.provider_id = $1 AND a.start_time = $2
-          AND NOT EXISTS (
-            SELECT 1 FROM appointments appt
-            WHERE appt.provider_id = a.provider_id
-              AND appt.start_time = a.start_time
-              AND appt.status IN ('reserved', 'confirmed')
-              AND (
-                appt.status = 'confirmed' OR
-                (appt.status = 'reserved' AND appt.created_at > NOW() - INTERVAL '30 minutes')
-              )
-          )
-    `, providerID.String(), *startTime).Scan(&count)
+	err = tx.QueryRowContext(ctx, `SELECT `+freeSlots("$1", "$2", "$3"), pq.Array(providers), *startTime, holdCutoff(db.Clock.Now())).Scan(&count)
 	if err != nil {
 		return false, err
 	}
-	return count > 0, nil
+	return count == len(providers), nil
 }
 
-func (db *Database) ReserveAppointment(clientID, providerID *types.UUID, startTime *time.Time) (*schema.Appointment, error) {
+// ReserveAppointment holds a seat in a provider's slot, in the slot of every participant at the same time
+// and the room if roomID is not nil. Any of them being taken fails the whole reservation, and so does an
+// intake that does not fit its appointment type.
+func (db *Database) ReserveAppointment(ctx context.Context, clientID, providerID *types.UUID, participantIDs []types.UUID, roomID *types.UUID, startTime *time.Time, intake *Intake) (*schema.Appointment, error) {
+	ctx, span := tracer.Start(ctx, "db.ReserveAppointment")
+	defer span.End()
+
 	// Insert new appointment with status 'reserved' and current timestamp
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.rollback(tx)
+
+	if err := lockClient(ctx, tx, clientID); err != nil {
+		return nil, err
+	}
+	if err := bookable(ctx, tx, append([]*types.UUID{clientID, providerID}, uuidPtrs(participantIDs)...)...); err != nil {
+		return nil, err
+	}
+	now := db.Clock.Now()
+	if err := clientLimits(ctx, tx, clientID, providerID, *startTime, nil, now); err != nil {
+		return nil, err
+	}
+	room, err := lockRoom(ctx, tx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	// First, lock the slots and check that they all still have a seat
+	reason, err := seatsConflict(ctx, tx, providerID.String(), participantIDs, room, *startTime, nil, now)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		return nil, SlotError(reason)
+	}
 
-	// First, check that the slot is still available
-	available, err := db.IsSlotAvailable(providerID, startTime)
+	appointment, err := insertReservedAppointment(ctx, tx, clientID, providerID, roomID, startTime, nil, schema.StatusChangeSourceRequest, now)
 	if err != nil {
 		return nil, err
 	}
-	if !available {
-		return nil, fmt.Errorf("slot is not available")
+	if err := insertParticipants(ctx, tx, appointment, participantIDs, now); err != nil {
+		return nil, err
+	}
+	if err := saveIntake(ctx, tx, appointment, intake); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return appointment, nil
+}
+
+// excludedIDs is the array to exclude appointments with in NOT (appt.id = ANY($n::uuid[])). pq sends a nil
+// slice as NULL, which would exclude every appointment instead of none.
+func excludedIDs(excluded []string) interface{} {
+	if excluded == nil {
+		excluded = []string{}
+	}
+	return pq.Array(excluded)
+}
+
+// slotConflict reports why a provider's slot, and room if it is not nil, can not be booked at now, ignoring
+// the appointments in excluded. The availability row is locked until tx ends so concurrent bookings can not
+// take more seats than the slot has, the room has to be locked by lockRoom.
+func slotConflict(ctx context.Context, tx *sql.Tx, providerID string, room *bookedRoom, startTime time.Time, excluded []string, now time.Time) (schema.OccurrenceConflictReason, error) {
+	var capacity int
+	var locationID uuid.NullUUID
+	err := tx.QueryRowContext(ctx, `
+	SELECT capacity, location_id
+	FROM availability
+	WHERE provider_id = $1 AND start_time = $2
+	FOR UPDATE
+`, providerID, startTime).Scan(&capacity, &locationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return schema.NoAvailability, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var booked int
+	err = tx.QueryRowContext(ctx, `
+	SELECT COUNT(*)
+	FROM appointments appt
+	WHERE `+seatOf("$1")+`
+	  AND appt.start_time = $2
+	  AND NOT (appt.id = ANY($3::uuid[]))
+	  AND (
+	    appt.status = 'confirmed' OR
+	    (appt.status = 'reserved' AND appt.created_at > $4)
+	  )
+`, providerID, startTime, excludedIDs(excluded), holdCutoff(now)).Scan(&booked)
+	if err != nil {
+		return "", err
+	}
+	if booked >= capacity {
+		return schema.Booked, nil
+	}
+
+	if room == nil {
+		return "", nil
+	}
+	if locationID.UUID != room.locationID {
+		return schema.RoomElsewhere, nil
 	}
+	taken, err := roomConflict(ctx, tx, room, providerID, startTime, excluded, now)
+	if err != nil {
+		return "", err
+	}
+	if taken {
+		return schema.RoomBooked, nil
+	}
+	return "", nil
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// seriesLink places an appointment in a recurring series
+type seriesLink struct {
+	ID    uuid.UUID
+	Index int
+}
 
-	endTime := startTime.Add(GetAvailabilityInterval())
+// insertReservedAppointment holds a seat in a slot, the appointment takes the location of the slot. Its
+// history starts with the reservation, made by source.
+func insertReservedAppointment(ctx context.Context, conn queryer, clientID, providerID, roomID *types.UUID, startTime *time.Time, series *seriesLink, source schema.StatusChangeSource, now time.Time) (*schema.Appointment, error) {
+	endTime := startTime.Add(SlotInterval(ctx))
 	appointmentID := uuid.New()
 
-	_, err = db.Conn.Exec(`
-		INSERT INTO appointments (id, client_id, provider_id, start_time, end_time, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, 'reserved', NOW(), NOW())
-		`, appointmentID, clientID.String(), providerID.String(), *startTime, endTime)
+	var seriesID uuid.NullUUID
+	var seriesIndex sql.NullInt64
+	if series != nil {
+		seriesID = uuid.NullUUID{UUID: series.ID, Valid: true}
+		seriesIndex = sql.NullInt64{Int64: int64(series.Index), Valid: true}
+	}
+
+	var room, locationID uuid.NullUUID
+	if roomID != nil {
+		room = uuid.NullUUID{UUID: *roomID, Valid: true}
+	}
+
+	err := conn.QueryRowContext(ctx, `
+		INSERT INTO appointments (id, client_id, provider_id, start_time, end_time, status, series_id, series_index, room_id, location_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, 'reserved', $6, $7, $8,
+		  (SELECT location_id FROM availability WHERE provider_id = $3 AND start_time = $4), $9, $9)
+		RETURNING location_id
+		`, appointmentID, clientID.String(), providerID.String(), *startTime, endTime, seriesID, seriesIndex, room, now).Scan(&locationID)
 	if err != nil {
 		return nil, err
 	}
 	status := schema.AppointmentStatus("reserved")
+	if err := recordStatus(ctx, conn, appointmentID.String(), statusChange{status: status, source: source, at: now}); err != nil {
+		return nil, err
+	}
 	appointment := &schema.Appointment{
 		Id:         (*types.UUID)(&appointmentID),
 		ClientId:   clientID,
@@ -177,50 +410,154 @@ func (db *Database) ReserveAppointment(clientID, providerID *types.UUID, startTi
 		StartTime:  startTime,
 		EndTime:    &endTime,
 		Status:     &status,
+		LocationId: nullUUID(locationID),
+		RoomId:     roomID,
+	}
+	if series != nil {
+		appointment.SeriesId = (*types.UUID)(&series.ID)
+		appointment.SeriesIndex = utils.Ptr(series.Index)
 	}
 	return appointment, nil
 }
 
-func (db *Database) ConfirmAppointment(appointmentID types.UUID) error {
-	result, err := db.Conn.Exec(`
+func (db *Database) ConfirmAppointment(ctx context.Context, appointmentID types.UUID) error {
+	ctx, span := tracer.Start(ctx, "db.ConfirmAppointment")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.rollback(tx)
+
+	now := db.Clock.Now()
+	change := statusChange{status: schema.AppointmentStatusConfirmed, source: schema.StatusChangeSourceRequest, at: now}
+	rowsAffected, err := changeStatus(ctx, tx, change, `
 	UPDATE appointments
-	SET status = 'confirmed', updated_at = NOW()
+	SET status = 'confirmed', updated_at = $2
 	WHERE id = $1
-	  AND status = 'reserved'
-	  AND created_at > NOW() - INTERVAL '30 minutes'
-`, appointmentID.String())
+	  AND status = ANY($4)
+	  AND NOT (status = 'reserved' AND created_at <= $3)
+	RETURNING id
+`, appointmentID.String(), now, holdCutoff(now), changeableTo(schema.AppointmentStatusConfirmed))
 	if err != nil {
 		return err
 	}
-	rowsAffected, err := result.RowsAffected()
+	if rowsAffected == 0 {
+		return unconfirmable(ctx, tx, appointmentID, now)
+	}
+	return tx.Commit()
+}
+
+// unconfirmable tells why an appointment could not be confirmed, ErrHoldExpired if its hold ran out
+// and otherwise TransitionFailure, sql.ErrNoRows if it does not exist
+func unconfirmable(ctx context.Context, tx *sql.Tx, appointmentID types.UUID, now time.Time) error {
+	var status schema.AppointmentStatus
+	var expired bool
+	err := tx.QueryRowContext(ctx, `
+	SELECT status, status = 'expired' OR (status = 'reserved' AND created_at <= $2)
+	FROM appointments
+	WHERE id = $1
+`, appointmentID.String(), holdCutoff(now)).Scan(&status, &expired)
 	if err != nil {
 		return err
 	}
-	if rowsAffected == 0 {
-		return sql.ErrNoRows // Return sql.ErrNoRows to indicate not found
+	if expired {
+		return ErrHoldExpired
 	}
-	return nil
+	return TransitionFailure(status, schema.AppointmentStatusConfirmed)
+}
+
+// ExpireReservations marks reservations that were not confirmed in time as expired and returns how many were.
+// Queries already treat these holds as expired, this only makes the status explicit.
+func (db *Database) ExpireReservations(ctx context.Context) (int64, error) {
+	ctx, span := tracer.Start(ctx, "db.ExpireReservations")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer db.rollback(tx)
+
+	now := db.Clock.Now()
+	change := statusChange{status: schema.AppointmentStatusExpired, source: schema.StatusChangeSourceHoldExpiry, at: now}
+	expired, err := changeStatus(ctx, tx, change, `
+	UPDATE appointments
+	SET status = 'expired', updated_at = $1
+	WHERE status = ANY($3)
+	  AND created_at <= $2
+	RETURNING id
+`, now, holdCutoff(now), changeableTo(schema.AppointmentStatusExpired))
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	if expired > 0 {
+		db.Logger.Debug("Expired reservations", slog.Int64("count", expired))
+	}
+	return expired, nil
 }
 
-//nolint:errcheck
-func (db *Database) AddAvailability(providerID types.UUID, slots []time.Time) error {
-	pExists, err := db.providerExists(providerID)
+// CancelAppointment cancels an active reservation or a confirmed appointment, freeing its slot. It returns
+// the error of TransitionFailure if the appointment can not be cancelled.
+func (db *Database) CancelAppointment(ctx context.Context, appointmentID types.UUID) error {
+	ctx, span := tracer.Start(ctx, "db.CancelAppointment")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.rollback(tx)
+
+	now := db.Clock.Now()
+	change := statusChange{status: schema.AppointmentStatusCancelled, source: schema.StatusChangeSourceRequest, at: now}
+	rowsAffected, err := changeStatus(ctx, tx, change, `
+	UPDATE appointments
+	SET status = 'cancelled', updated_at = $2
+	WHERE id = $1
+	  AND status = ANY($4)
+	  AND NOT (status = 'reserved' AND created_at <= $3)
+	RETURNING id
+`, appointmentID.String(), now, holdCutoff(now), changeableTo(schema.AppointmentStatusCancelled))
 	if err != nil {
 		return err
 	}
-	if !pExists {
-		return fmt.Errorf("provider does not exist")
+	if rowsAffected == 0 {
+		return transitionFailure(ctx, tx, appointmentID, schema.AppointmentStatusCancelled)
 	}
+	return tx.Commit()
+}
+
+// AddAvailability adds slots that up to capacity clients can book, at a location if locationID is not nil.
+// It returns ErrLocationNotFound if the location is not in the organization.
+func (db *Database) AddAvailability(ctx context.Context, providerID types.UUID, locationID *types.UUID, slots []time.Time, capacity int) error {
+	ctx, span := tracer.Start(ctx, "db.AddAvailability")
+	defer span.End()
 
-	tx, err := db.Conn.Begin()
+	tx, err := db.begin(ctx)
 	if err != nil {
 		return err
 	}
-	defer tx.Rollback()
+	defer db.rollback(tx)
+
+	if err := activeUserWithRole(ctx, tx, providerID, "provider"); err != nil {
+		return err
+	}
+	var location uuid.NullUUID
+	if locationID != nil {
+		if err := locationExists(ctx, tx, *locationID); err != nil {
+			return err
+		}
+		location = uuid.NullUUID{UUID: *locationID, Valid: true}
+	}
 
-	stmt, err := tx.Prepare(`
-	INSERT INTO availability (id, provider_id, start_time, end_time, created_at, updated_at)
-	VALUES ($1, $2, $3, $4, NOW(), NOW())
+	stmt, err := tx.PrepareContext(ctx, `
+	INSERT INTO availability (id, provider_id, start_time, end_time, capacity, location_id, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
 	ON CONFLICT (provider_id, start_time) DO NOTHING
 	`)
 	if err != nil {
@@ -228,10 +565,11 @@ func (db *Database) AddAvailability(providerID types.UUID, slots []time.Time) er
 	}
 	defer stmt.Close()
 
+	now := db.Clock.Now()
 	for _, startTime := range slots {
-		endTime := startTime.Add(GetAvailabilityInterval())
+		endTime := startTime.Add(SlotInterval(ctx))
 		availabilityID := uuid.New()
-		_, err := stmt.Exec(availabilityID, providerID.String(), startTime, endTime)
+		_, err := stmt.ExecContext(ctx, availabilityID, providerID.String(), startTime, endTime, capacity, location, now)
 		if err != nil {
 			return err
 		}
@@ -245,14 +583,18 @@ func (db *Database) AddAvailability(providerID types.UUID, slots []time.Time) er
 	return nil
 }
 
-func (db *Database) providerExists(providerID types.UUID) (bool, error) {
+func providerExists(ctx context.Context, tx *sql.Tx, providerID types.UUID) (bool, error) {
+	return userWithRoleExists(ctx, tx, providerID, "provider")
+}
+
+func userWithRoleExists(ctx context.Context, tx *sql.Tx, userID types.UUID, role string) (bool, error) {
 	var id uuid.UUID
-	err := db.Conn.QueryRow(`
+	err := tx.QueryRowContext(ctx, `
 	SELECT id
 	FROM users
 	WHERE id = $1
-	AND role = 'provider'
-`, providerID.String()).Scan(&id)
+	AND role = $2
+`, userID.String(), role).Scan(&id)
 
 	if err != nil {
 		return false, err
@@ -261,12 +603,40 @@ func (db *Database) providerExists(providerID types.UUID) (bool, error) {
 	return id != uuid.Nil, nil
 }
 
-func (db *Database) CreateUser(name, email, role string) (*schema.User, error) {
+// activeUserWithRole returns sql.ErrNoRows unless there is a user with the id and role, and ErrUserInactive
+// if they have been deactivated
+func activeUserWithRole(ctx context.Context, tx *sql.Tx, userID types.UUID, role string) error {
+	var deactivatedAt sql.NullTime
+	err := tx.QueryRowContext(ctx, `
+	SELECT deactivated_at
+	FROM users
+	WHERE id = $1
+	AND role = $2
+`, userID.String(), role).Scan(&deactivatedAt)
+	if err != nil {
+		return err
+	}
+	if deactivatedAt.Valid {
+		return ErrUserInactive
+	}
+	return nil
+}
+
+func (db *Database) CreateUser(ctx context.Context, name, email, role string) (*schema.User, error) {
+	ctx, span := tracer.Start(ctx, "db.CreateUser")
+	defer span.End()
+
 	userID := uuid.New()
-	_, err := db.Conn.Exec(`
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
         INSERT INTO users (id, name, email, role, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, NOW(), NOW())
-    `, userID, name, email, role)
+        VALUES ($1, $2, $3, $4, $5, $5)
+    `, userID, name, email, role, db.Clock.Now())
+		return err
+	})
+	if isPQError(err, uniqueViolation) {
+		return nil, ErrEmailTaken
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -281,28 +651,19 @@ func (db *Database) CreateUser(name, email, role string) (*schema.User, error) {
 	return user, nil
 }
 
-func (db *Database) GetUser(userID types.UUID) (*schema.User, error) {
-	var user schema.User
-	var id uuid.UUID
-	var name, email, role string
-
-	err := db.Conn.QueryRow(`
-		SELECT id, name, email, role
-		FROM users
-		WHERE id = $1
-	`, userID.String()).Scan(&id, &name, &email, &role)
+func (db *Database) GetUser(ctx context.Context, userID types.UUID) (*schema.User, error) {
+	ctx, span := tracer.Start(ctx, "db.GetUser")
+	defer span.End()
 
+	tx, err := db.begin(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer db.rollback(tx)
 
-	userRole := schema.UserRole(role)
-	user = schema.User{
-		Id:    (*types.UUID)(&id),
-		Name:  utils.Ptr(name),
-		Email: utils.Ptr(email),
-		Role:  &userRole,
-	}
-
-	return &user, nil
+	return scanUser(tx.QueryRowContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE id = $1
+	`, userID.String()))
 }
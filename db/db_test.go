@@ -3,12 +3,17 @@ package db
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/clock"
+	"github.com/tateexon/reservation/migrations"
 	"github.com/tateexon/reservation/schema"
 	"github.com/tateexon/reservation/utils"
 )
@@ -28,7 +33,7 @@ func startTestDatabase(t *testing.T) *Database {
 	connStr, err := ctr.ConnectionString(ctx, "sslmode=disable")
 	require.NoError(t, err)
 
-	dbInstance, err := NewDatabase(connStr)
+	dbInstance, err := NewDatabase(connStr, nil)
 	require.NoError(t, err, "Failed to connect to the database")
 
 	return dbInstance
@@ -56,7 +61,7 @@ func createTestClient(t *testing.T, db *Database) *types.UUID {
 }
 
 func addTestAvailability(t *testing.T, dbInstance *Database, providerID *types.UUID, slots []time.Time) {
-	err := dbInstance.AddAvailability(*providerID, slots)
+	err := dbInstance.AddAvailability(context.Background(), *providerID, nil, slots, 1)
 	require.NoError(t, err)
 }
 
@@ -75,7 +80,7 @@ func TestAddAvailability(t *testing.T) {
 	startTime := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
 	slots := utils.GenerateTimeSlots(startTime, startTime.Add(2*time.Hour), GetAvailabilityInterval())
 
-	err := dbInstance.AddAvailability(*providerID, slots)
+	err := dbInstance.AddAvailability(context.Background(), *providerID, nil, slots, 1)
 	require.NoError(t, err)
 
 	// Verify slots were added
@@ -98,17 +103,17 @@ func TestIsSlotAvailable(t *testing.T) {
 
 	addTestAvailability(t, dbInstance, providerID, slots)
 
-	available, err := dbInstance.IsSlotAvailable(providerID, &startTime)
+	available, err := dbInstance.IsSlotAvailable(context.Background(), providerID, nil, &startTime)
 	require.NoError(t, err)
 	require.True(t, available)
 
 	// Reserve the slot
 	clientID := createTestClient(t, dbInstance)
-	_, err = dbInstance.ReserveAppointment(clientID, providerID, &startTime)
+	_, err = dbInstance.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &startTime, nil)
 	require.NoError(t, err)
 
 	// Check availability again
-	available, err = dbInstance.IsSlotAvailable(providerID, &startTime)
+	available, err = dbInstance.IsSlotAvailable(context.Background(), providerID, nil, &startTime)
 	require.NoError(t, err)
 	require.False(t, available)
 }
@@ -125,13 +130,13 @@ func TestReserveAppointment(t *testing.T) {
 
 	addTestAvailability(t, dbInstance, providerID, slots)
 
-	appointment, err := dbInstance.ReserveAppointment(clientID, providerID, &startTime)
+	appointment, err := dbInstance.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &startTime, nil)
 	require.NoError(t, err)
 	require.NotNil(t, appointment)
 	require.Equal(t, schema.AppointmentStatus("reserved"), *appointment.Status)
 
 	// Attempt to reserve the same slot again
-	_, err = dbInstance.ReserveAppointment(clientID, providerID, &startTime)
+	_, err = dbInstance.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &startTime, nil)
 	require.Error(t, err)
 }
 
@@ -147,11 +152,11 @@ func TestConfirmAppointment(t *testing.T) {
 
 	addTestAvailability(t, dbInstance, providerID, slots)
 
-	appointment, err := dbInstance.ReserveAppointment(clientID, providerID, &startTime)
+	appointment, err := dbInstance.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &startTime, nil)
 	require.NoError(t, err)
 
 	// Confirm the appointment
-	err = dbInstance.ConfirmAppointment(*appointment.Id)
+	err = dbInstance.ConfirmAppointment(context.Background(), *appointment.Id)
 	require.NoError(t, err)
 
 	// Verify status is updated
@@ -175,17 +180,17 @@ func TestGetAvailableAppointments(t *testing.T) {
 	addTestAvailability(t, dbInstance, providerID, slots)
 
 	// Initially, all slots should be available
-	appointments, err := dbInstance.GetAvailableAppointments(providerID, nil)
+	appointments, err := dbInstance.GetAvailableAppointments(context.Background(), providerID, nil, nil, nil)
 	require.NoError(t, err)
 	require.Equal(t, len(slots), len(appointments))
 
 	// Reserve a slot
 	clientID := createTestClient(t, dbInstance)
-	_, err = dbInstance.ReserveAppointment(clientID, providerID, &slots[0])
+	_, err = dbInstance.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &slots[0], nil)
 	require.NoError(t, err)
 
 	// Now, one slot should be unavailable
-	appointments, err = dbInstance.GetAvailableAppointments(providerID, nil)
+	appointments, err = dbInstance.GetAvailableAppointments(context.Background(), providerID, nil, nil, nil)
 	require.NoError(t, err)
 	require.Equal(t, len(slots)-1, len(appointments))
 }
@@ -194,6 +199,8 @@ func TestReservationExpiryLogic(t *testing.T) {
 	// Start test database and server setup
 	dbInstance := startTestDatabase(t)
 	defer dbInstance.Conn.Close()
+	clk := clock.NewFake(time.Now())
+	dbInstance.Clock = clk
 
 	// Create a test provider and client
 	providerID := createTestProvider(t, dbInstance)
@@ -205,26 +212,21 @@ func TestReservationExpiryLogic(t *testing.T) {
 	addTestAvailability(t, dbInstance, providerID, slots)
 
 	// Reserve the appointment
-	appointment, err := dbInstance.ReserveAppointment(clientID, providerID, &startTime)
+	appointment, err := dbInstance.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &startTime, nil)
 	require.NoError(t, err)
 	require.NotNil(t, appointment)
 
-	// Simulate passage of time by updating the 'created_at' to more than 30 minutes ago
-	_, err = dbInstance.Conn.Exec(`
-        UPDATE appointments
-        SET created_at = created_at - INTERVAL '31 minutes'
-        WHERE id = $1
-    `, appointment.Id.String())
-	require.NoError(t, err)
+	// Let the reservation expire
+	clk.Advance(31 * time.Minute)
 
 	// Check if the slot is now available
-	available, err := dbInstance.IsSlotAvailable(providerID, &startTime)
+	available, err := dbInstance.IsSlotAvailable(context.Background(), providerID, nil, &startTime)
 	require.NoError(t, err)
 	require.True(t, available, "Slot should be available after reservation has expired")
 
 	// Attempt to reserve the slot again
 	clientID2 := createTestClient(t, dbInstance)
-	appointment2, err := dbInstance.ReserveAppointment(clientID2, providerID, &startTime)
+	appointment2, err := dbInstance.ReserveAppointment(context.Background(), clientID2, providerID, nil, nil, &startTime, nil)
 	require.NoError(t, err)
 	require.NotNil(t, appointment2)
 
@@ -232,3 +234,114 @@ func TestReservationExpiryLogic(t *testing.T) {
 	require.Equal(t, *appointment2.StartTime, *appointment.StartTime)
 	require.NotEqual(t, appointment2.ClientId.String(), appointment.ClientId.String())
 }
+
+func TestReserveAppointment_GroupCapacity(t *testing.T) {
+	t.Parallel()
+	dbInstance := startTestDatabase(t)
+	defer dbInstance.Conn.Close()
+
+	providerID := createTestProvider(t, dbInstance)
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	err := dbInstance.AddAvailability(context.Background(), *providerID, nil, []time.Time{startTime}, 3)
+	require.NoError(t, err)
+
+	for seatsRemaining := 3; seatsRemaining > 0; seatsRemaining-- {
+		appointments, err := dbInstance.GetAvailableAppointments(context.Background(), providerID, nil, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, appointments, 1)
+		require.Equal(t, 3, *appointments[0].Capacity)
+		require.Equal(t, seatsRemaining, *appointments[0].SeatsRemaining)
+
+		_, err = dbInstance.ReserveAppointment(context.Background(), createTestClient(t, dbInstance), providerID, nil, nil, &startTime, nil)
+		require.NoError(t, err)
+	}
+
+	appointments, err := dbInstance.GetAvailableAppointments(context.Background(), providerID, nil, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, appointments)
+
+	_, err = dbInstance.ReserveAppointment(context.Background(), createTestClient(t, dbInstance), providerID, nil, nil, &startTime, nil)
+	require.ErrorIs(t, err, ErrSlotUnavailable)
+}
+
+func TestReserveAppointment_ConcurrentSeats(t *testing.T) {
+	t.Parallel()
+	dbInstance := startTestDatabase(t)
+	defer dbInstance.Conn.Close()
+
+	providerID := createTestProvider(t, dbInstance)
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	err := dbInstance.AddAvailability(context.Background(), *providerID, nil, []time.Time{startTime}, 3)
+	require.NoError(t, err)
+
+	clients := make([]*types.UUID, 10)
+	for i := range clients {
+		clients[i] = createTestClient(t, dbInstance)
+	}
+
+	var wg sync.WaitGroup
+	var reserved atomic.Int32
+	for _, clientID := range clients {
+		wg.Add(1)
+		go func(clientID *types.UUID) {
+			defer wg.Done()
+			_, err := dbInstance.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &startTime, nil)
+			if err == nil {
+				reserved.Add(1)
+				return
+			}
+			assert.ErrorIs(t, err, ErrSlotUnavailable)
+		}(clientID)
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(3), reserved.Load())
+}
+
+func TestExpireReservations(t *testing.T) {
+	t.Parallel()
+	dbInstance := startTestDatabase(t)
+	defer dbInstance.Conn.Close()
+	clk := clock.NewFake(time.Now())
+	dbInstance.Clock = clk
+
+	providerID := createTestProvider(t, dbInstance)
+	clientID := createTestClient(t, dbInstance)
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	slots := []time.Time{startTime, startTime.Add(GetAvailabilityInterval())}
+	addTestAvailability(t, dbInstance, providerID, slots)
+
+	stale, err := dbInstance.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &slots[0], nil)
+	require.NoError(t, err)
+	clk.Advance(31 * time.Minute)
+	_, err = dbInstance.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &slots[1], nil)
+	require.NoError(t, err)
+
+	expired, err := dbInstance.ExpireReservations(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), expired)
+
+	var status string
+	err = dbInstance.Conn.QueryRow(`
+        SELECT status FROM appointments WHERE id = $1
+    `, stale.Id.String()).Scan(&status)
+	require.NoError(t, err)
+	require.Equal(t, "expired", status)
+
+	// Already expired holds are not counted twice
+	expired, err = dbInstance.ExpireReservations(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, expired)
+}
+
+func TestSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	dbInstance := startTestDatabase(t)
+	latest, err := migrations.Latest()
+	require.NoError(t, err)
+
+	version, err := dbInstance.SchemaVersion(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, latest, version)
+}
@@ -0,0 +1,323 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// ErrGroupNotFound is returned when a provider group is not in the organization, it is also sql.ErrNoRows
+var ErrGroupNotFound = fmt.Errorf("provider group not found: %w", sql.ErrNoRows)
+
+// WeekOf returns the start of the week t is in, Monday 00:00 UTC, and the start of the next one
+func WeekOf(t time.Time) (time.Time, time.Time) {
+	day := t.UTC().Truncate(24 * time.Hour)
+	start := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	return start, start.AddDate(0, 0, 7)
+}
+
+// RankCandidates orders the members of a group the way strategy prefers them, the first available one
+// is assigned. Ties go to the member earlier in the group.
+func RankCandidates(strategy schema.GroupStrategy, candidates []schema.AssignmentCandidate) {
+	slices.SortStableFunc(candidates, func(a, b schema.AssignmentCandidate) int {
+		switch strategy {
+		case schema.RoundRobin:
+			if c := cmp.Compare(*a.LastAssignment, *b.LastAssignment); c != 0 {
+				return c
+			}
+		case schema.LeastBooked:
+			if c := cmp.Compare(*a.AppointmentsThisWeek, *b.AppointmentsThisWeek); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(*a.Priority, *b.Priority)
+	})
+}
+
+// NewAssignment explains why strategy assigned the candidate at index chosen of the ranked candidates
+func NewAssignment(groupID types.UUID, strategy schema.GroupStrategy, candidates []schema.AssignmentCandidate, chosen int, startTime time.Time) *schema.Assignment {
+	picked := candidates[chosen]
+	var explanation string
+	switch strategy {
+	case schema.RoundRobin:
+		last := "never before"
+		if *picked.LastAssignment > 0 {
+			last = fmt.Sprintf("last at assignment %d", *picked.LastAssignment)
+		}
+		explanation = fmt.Sprintf("Round robin assigned provider %s, the available member assigned least recently (%s).", picked.ProviderId, last)
+	case schema.LeastBooked:
+		weekStart, _ := WeekOf(startTime)
+		explanation = fmt.Sprintf("Least booked assigned provider %s, the available member with the fewest appointments in the week of %s (%d).",
+			picked.ProviderId, weekStart.Format(time.DateOnly), *picked.AppointmentsThisWeek)
+	default:
+		explanation = fmt.Sprintf("Priority assigned provider %s, the available member highest in the group's order (priority %d).", picked.ProviderId, *picked.Priority)
+	}
+	if chosen > 0 {
+		explanation += fmt.Sprintf(" The %d members ranked ahead could not be booked at the time.", chosen)
+	}
+	return &schema.Assignment{
+		GroupId:     &groupID,
+		Strategy:    &strategy,
+		Explanation: utils.Ptr(explanation),
+		Candidates:  &candidates,
+	}
+}
+
+// lockGroup locks a provider group until tx ends so its assignments happen one at a time, returning
+// ErrGroupNotFound if it is not in the organization
+func lockGroup(ctx context.Context, tx *sql.Tx, groupID types.UUID) (schema.GroupStrategy, error) {
+	var strategy schema.GroupStrategy
+	err := tx.QueryRowContext(ctx, `
+	SELECT strategy
+	FROM provider_groups
+	WHERE id = $1
+	FOR UPDATE
+`, groupID.String()).Scan(&strategy)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrGroupNotFound
+	}
+	return strategy, err
+}
+
+// CreateProviderGroup adds a group of providers in priority order. It returns sql.ErrNoRows if one of them
+// is not a provider in the organization and ErrUserInactive if one has been deactivated.
+func (db *Database) CreateProviderGroup(ctx context.Context, name string, strategy schema.GroupStrategy, providerIDs []types.UUID) (*schema.ProviderGroup, error) {
+	ctx, span := tracer.Start(ctx, "db.CreateProviderGroup")
+	defer span.End()
+
+	groupID := uuid.New()
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		for _, providerID := range providerIDs {
+			if err := activeUserWithRole(ctx, tx, providerID, string(schema.UserRoleProvider)); err != nil {
+				return err
+			}
+		}
+		now := db.Clock.Now()
+		_, err := tx.ExecContext(ctx, `
+		INSERT INTO provider_groups (id, name, strategy, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+	`, groupID, name, string(strategy), now)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `
+		INSERT INTO provider_group_members (group_id, provider_id, position)
+		SELECT $1::uuid, m.provider_id, m.position
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS m(provider_id, position)
+	`, groupID, pq.Array(uuidStrings(providerIDs)))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &schema.ProviderGroup{Id: &groupID, Name: utils.Ptr(name), Strategy: &strategy, ProviderIds: utils.Ptr(slices.Clone(providerIDs))}, nil
+}
+
+const providerGroupColumns = `g.id, g.name, g.strategy,
+	  ARRAY(SELECT m.provider_id::text FROM provider_group_members m WHERE m.group_id = g.id ORDER BY m.position)`
+
+// scanProviderGroup reads a row of providerGroupColumns
+func scanProviderGroup(row interface{ Scan(dest ...any) error }) (*schema.ProviderGroup, error) {
+	var id uuid.UUID
+	var name string
+	var strategy schema.GroupStrategy
+	var members []string
+	if err := row.Scan(&id, &name, &strategy, pq.Array(&members)); err != nil {
+		return nil, err
+	}
+	providerIDs := make([]types.UUID, 0, len(members))
+	for _, member := range members {
+		providerID, err := uuid.Parse(member)
+		if err != nil {
+			return nil, err
+		}
+		providerIDs = append(providerIDs, providerID)
+	}
+	return &schema.ProviderGroup{Id: &id, Name: &name, Strategy: &strategy, ProviderIds: &providerIDs}, nil
+}
+
+// ListProviderGroups returns the organization's provider groups by name
+func (db *Database) ListProviderGroups(ctx context.Context) ([]schema.ProviderGroup, error) {
+	ctx, span := tracer.Start(ctx, "db.ListProviderGroups")
+	defer span.End()
+
+	groups := []schema.ProviderGroup{}
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `SELECT `+providerGroupColumns+` FROM provider_groups g ORDER BY g.name, g.id`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			group, err := scanProviderGroup(rows)
+			if err != nil {
+				return err
+			}
+			groups = append(groups, *group)
+		}
+		return rows.Err()
+	})
+	return groups, err
+}
+
+// GetProviderGroup returns a provider group, ErrGroupNotFound if it is not in the organization
+func (db *Database) GetProviderGroup(ctx context.Context, groupID types.UUID) (*schema.ProviderGroup, error) {
+	ctx, span := tracer.Start(ctx, "db.GetProviderGroup")
+	defer span.End()
+
+	var group *schema.ProviderGroup
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		var err error
+		group, err = scanProviderGroup(tx.QueryRowContext(ctx, `SELECT `+providerGroupColumns+` FROM provider_groups g WHERE g.id = $1`, groupID.String()))
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrGroupNotFound
+		}
+		return err
+	})
+	return group, err
+}
+
+// ReserveFromGroup holds a seat at startTime with the active member of a group its strategy ranks first
+// among the ones with a free slot, explaining the choice in the appointment's assignment. The group is
+// locked while a provider is picked so concurrent bookings from it see each other's assignments. It
+// returns ErrGroupNotFound for a group that is not in the organization and ErrSlotUnavailable if no member
+// is free. Members the client has reached their per provider limit with are passed over, and the limit
+// is returned if that leaves none.
+func (db *Database) ReserveFromGroup(ctx context.Context, clientID, groupID types.UUID, startTime time.Time, intake *Intake) (*schema.Appointment, error) {
+	ctx, span := tracer.Start(ctx, "db.ReserveFromGroup")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.rollback(tx)
+
+	strategy, err := lockGroup(ctx, tx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockClient(ctx, tx, &clientID); err != nil {
+		return nil, err
+	}
+	if err := bookable(ctx, tx, &clientID); err != nil {
+		return nil, err
+	}
+
+	now := db.Clock.Now()
+	weekStart, weekEnd := WeekOf(startTime)
+	rows, err := tx.QueryContext(ctx, `
+	SELECT m.provider_id, m.position, m.last_assignment,
+	  (
+	    SELECT COUNT(*) FROM appointments appt
+	    WHERE `+seatOf("m.provider_id")+`
+	      AND appt.start_time >= $2 AND appt.start_time < $3
+	      AND (
+	        appt.status = 'confirmed' OR
+	        (appt.status = 'reserved' AND appt.created_at > $4)
+	      )
+	  ),
+	  `+freeSlots("ARRAY[m.provider_id]", "$5", "$4")+` > 0
+	FROM provider_group_members m
+	JOIN users u ON u.id = m.provider_id AND u.deactivated_at IS NULL
+	WHERE m.group_id = $1
+	ORDER BY m.position
+`, groupID.String(), weekStart, weekEnd, holdCutoff(now), startTime)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := scanCandidates(rows)
+	if err != nil {
+		return nil, err
+	}
+	RankCandidates(strategy, candidates)
+
+	var limited error
+	for i := range candidates {
+		if !*candidates[i].Available {
+			continue
+		}
+		providerID := *candidates[i].ProviderId
+		// A client at their limit with one member can still be assigned another
+		err := clientLimits(ctx, tx, &clientID, &providerID, startTime, nil, now)
+		if errors.Is(err, ErrProviderAppointmentLimit) {
+			candidates[i].Available = utils.Ptr(false)
+			limited = err
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Lock the slot, a direct booking may have taken its last seat since the candidates were read
+		reason, err := slotConflict(ctx, tx, providerID.String(), nil, startTime, nil, now)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			candidates[i].Available = utils.Ptr(false)
+			continue
+		}
+
+		appointment, err := insertReservedAppointment(ctx, tx, &clientID, &providerID, nil, &startTime, nil, schema.StatusChangeSourceRequest, now)
+		if err != nil {
+			return nil, err
+		}
+		if err := saveIntake(ctx, tx, appointment, intake); err != nil {
+			return nil, err
+		}
+		_, err = tx.ExecContext(ctx, `
+		WITH assigned AS (
+		  UPDATE provider_groups SET assignments = assignments + 1 WHERE id = $1 RETURNING assignments
+		)
+		UPDATE provider_group_members SET last_assignment = (SELECT assignments FROM assigned)
+		WHERE group_id = $1 AND provider_id = $2
+	`, groupID.String(), providerID.String())
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+		appointment.Assignment = NewAssignment(groupID, strategy, candidates, i, startTime)
+		return appointment, nil
+	}
+	if limited != nil {
+		return nil, limited
+	}
+	return nil, ErrSlotUnavailable
+}
+
+// scanCandidates reads provider id, position, last assignment, week count and availability rows and
+// closes them
+func scanCandidates(rows *sql.Rows) ([]schema.AssignmentCandidate, error) {
+	defer rows.Close()
+
+	candidates := []schema.AssignmentCandidate{}
+	for rows.Next() {
+		var providerID uuid.UUID
+		var priority, lastAssignment, booked int
+		var available bool
+		if err := rows.Scan(&providerID, &priority, &lastAssignment, &booked, &available); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, schema.AssignmentCandidate{
+			ProviderId:           &providerID,
+			Priority:             &priority,
+			LastAssignment:       &lastAssignment,
+			AppointmentsThisWeek: &booked,
+			Available:            &available,
+		})
+	}
+	return candidates, rows.Err()
+}
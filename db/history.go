@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+)
+
+// statusChange is what the history of an appointment records about a change of its status
+type statusChange struct {
+	status    schema.AppointmentStatus
+	source    schema.StatusChangeSource
+	changedBy *types.UUID
+	at        time.Time
+}
+
+// recordStatus appends a change to the history of an appointment, after the ones before it
+func recordStatus(ctx context.Context, conn queryer, appointmentID string, change statusChange) error {
+	var changedBy uuid.NullUUID
+	if change.changedBy != nil {
+		changedBy = uuid.NullUUID{UUID: *change.changedBy, Valid: true}
+	}
+	var seq int
+	return conn.QueryRowContext(ctx, `
+	INSERT INTO appointment_status_history (appointment_id, seq, organization_id, status, changed_at, changed_by, source)
+	SELECT appt.id, COALESCE((SELECT MAX(seq) FROM appointment_status_history WHERE appointment_id = appt.id), 0) + 1,
+	  appt.organization_id, $2, $3, $4, $5
+	FROM appointments appt
+	WHERE appt.id = $1
+	RETURNING seq
+`, appointmentID, string(change.status), change.at, changedBy, string(change.source)).Scan(&seq)
+}
+
+// changeStatus runs update, which changes appointments to the status of change and returns their ids, and
+// records the change in the history of each. It returns how many appointments changed.
+func changeStatus(ctx context.Context, tx *sql.Tx, change statusChange, update string, args ...any) (int64, error) {
+	rows, err := tx.QueryContext(ctx, update, args...)
+	if err != nil {
+		return 0, err
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Close(); err != nil {
+		return 0, err
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		if err := recordStatus(ctx, tx, id, change); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(ids)), nil
+}
+
+// ListStatusHistory returns every status an appointment has had, oldest first. It returns sql.ErrNoRows if
+// the appointment is not in the organization.
+func (db *Database) ListStatusHistory(ctx context.Context, appointmentID types.UUID) ([]schema.StatusChange, error) {
+	ctx, span := tracer.Start(ctx, "db.ListStatusHistory")
+	defer span.End()
+
+	history := []schema.StatusChange{}
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		var id string
+		if err := tx.QueryRowContext(ctx, `SELECT id FROM appointments WHERE id = $1`, appointmentID.String()).Scan(&id); err != nil {
+			return err
+		}
+		rows, err := tx.QueryContext(ctx, `
+		SELECT status, changed_at, changed_by, source
+		FROM appointment_status_history
+		WHERE appointment_id = $1
+		ORDER BY seq
+	`, appointmentID.String())
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var status schema.AppointmentStatus
+			var source schema.StatusChangeSource
+			var changedAt time.Time
+			var changedBy uuid.NullUUID
+			if err := rows.Scan(&status, &changedAt, &changedBy, &source); err != nil {
+				return err
+			}
+			history = append(history, schema.StatusChange{
+				Status:    &status,
+				ChangedAt: &changedAt,
+				ChangedBy: nullUUID(changedBy),
+				Source:    &source,
+			})
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
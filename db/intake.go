@@ -0,0 +1,249 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// ErrAppointmentTypeNotFound is returned when an appointment type is not in the organization, it is also
+// sql.ErrNoRows
+var ErrAppointmentTypeNotFound = fmt.Errorf("appointment type not found: %w", sql.ErrNoRows)
+
+// Intake is what a client tells about an appointment when booking it
+type Intake struct {
+	// Reason is why they are booking, empty if they did not say
+	Reason string
+	// AppointmentTypeID is the kind of appointment, Answers answer its intake questions
+	AppointmentTypeID *types.UUID
+	Answers           map[string]interface{}
+}
+
+// IntakeError lists the intake answers that do not fit the questions of the appointment type
+type IntakeError struct {
+	Errors []schema.FieldError
+}
+
+func (e *IntakeError) Error() string {
+	fields := make([]string, 0, len(e.Errors))
+	for _, field := range e.Errors {
+		fields = append(fields, field.Field+" "+field.Message)
+	}
+	return "invalid intake answers: " + strings.Join(fields, ", ")
+}
+
+// answerField is how an answer is named in field errors, the way it is spelled in the request
+func answerField(key string) string {
+	return "intake_answers." + key
+}
+
+// EncodeAnswers checks the answers of intake against questions, the intake questions of its appointment
+// type, and returns them as the json object they are stored as, empty if there are none. It returns an
+// IntakeError if a required question is not answered, an answer is not to one of the questions or of the
+// wrong kind.
+func EncodeAnswers(intake Intake, questions []schema.IntakeQuestion) (string, error) {
+	if len(intake.Answers) == 0 && len(questions) == 0 {
+		return "", nil
+	}
+	if intake.AppointmentTypeID == nil {
+		return "", &IntakeError{Errors: []schema.FieldError{{Field: "intake_answers", Message: "need an appointment_type_id"}}}
+	}
+
+	// Round trip the answers so they are checked as they will be read back
+	raw, err := json.Marshal(intake.Answers)
+	if err != nil {
+		return "", err
+	}
+	answers := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &answers); err != nil {
+		return "", err
+	}
+
+	var invalid []schema.FieldError
+	asked := map[string]bool{}
+	for _, question := range questions {
+		asked[question.Key] = true
+		answer := answers[question.Key]
+		if answer == nil || answer == "" {
+			delete(answers, question.Key)
+			if question.Required != nil && *question.Required {
+				invalid = append(invalid, schema.FieldError{Field: answerField(question.Key), Message: "is required"})
+			}
+			continue
+		}
+		if message := answerError(question, answer); message != "" {
+			invalid = append(invalid, schema.FieldError{Field: answerField(question.Key), Message: message})
+		}
+	}
+	keys := make([]string, 0, len(answers))
+	for key := range answers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !asked[key] {
+			invalid = append(invalid, schema.FieldError{Field: answerField(key), Message: "is not an intake question of the appointment type"})
+		}
+	}
+	if len(invalid) > 0 {
+		return "", &IntakeError{Errors: invalid}
+	}
+	if len(answers) == 0 {
+		return "", nil
+	}
+
+	raw, err = json.Marshal(answers)
+	return string(raw), err
+}
+
+// answerError tells what is wrong with an answer to a question, empty if nothing is
+func answerError(question schema.IntakeQuestion, answer interface{}) string {
+	switch question.Type {
+	case schema.Number:
+		if _, ok := answer.(float64); !ok {
+			return "must be a number"
+		}
+	case schema.Boolean:
+		if _, ok := answer.(bool); !ok {
+			return "must be true or false"
+		}
+	case schema.Choice:
+		var options []string
+		if question.Options != nil {
+			options = *question.Options
+		}
+		if choice, ok := answer.(string); !ok || !slices.Contains(options, choice) {
+			return "must be one of " + strings.Join(options, ", ")
+		}
+	default:
+		if _, ok := answer.(string); !ok {
+			return "must be text"
+		}
+	}
+	return ""
+}
+
+// SetIntake fills in the intake of an appointment from how it is stored, answers is the json object of
+// EncodeAnswers
+func SetIntake(appointment *schema.Appointment, reason string, appointmentTypeID uuid.NullUUID, answers string) error {
+	if reason != "" {
+		appointment.Reason = utils.Ptr(reason)
+	}
+	if appointmentTypeID.Valid {
+		appointment.AppointmentTypeId = (*types.UUID)(&appointmentTypeID.UUID)
+	}
+	if answers == "" {
+		return nil
+	}
+	decoded := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(answers), &decoded); err != nil {
+		return err
+	}
+	appointment.IntakeAnswers = &decoded
+	return nil
+}
+
+// saveIntake checks intake against the questions of its appointment type and stores it with an appointment
+// tx just reserved, a nil intake leaves the appointment without one
+func saveIntake(ctx context.Context, tx *sql.Tx, appointment *schema.Appointment, intake *Intake) error {
+	if intake == nil {
+		return nil
+	}
+	var questions []schema.IntakeQuestion
+	var appointmentTypeID uuid.NullUUID
+	if intake.AppointmentTypeID != nil {
+		var raw []byte
+		err := tx.QueryRowContext(ctx, `SELECT intake_questions FROM appointment_types WHERE id = $1`, intake.AppointmentTypeID.String()).Scan(&raw)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrAppointmentTypeNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(raw, &questions); err != nil {
+			return err
+		}
+		appointmentTypeID = uuid.NullUUID{UUID: *intake.AppointmentTypeID, Valid: true}
+	}
+	answers, err := EncodeAnswers(*intake, questions)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE appointments
+	SET reason = NULLIF($2, ''), appointment_type_id = $3, intake_answers = NULLIF($4, '')::jsonb
+	WHERE id = $1
+`, appointment.Id.String(), intake.Reason, appointmentTypeID, answers)
+	if err != nil {
+		return err
+	}
+	return SetIntake(appointment, intake.Reason, appointmentTypeID, answers)
+}
+
+// CreateAppointmentType adds a kind of appointment with the intake questions clients booking one answer
+func (db *Database) CreateAppointmentType(ctx context.Context, name string, questions []schema.IntakeQuestion) (*schema.AppointmentType, error) {
+	ctx, span := tracer.Start(ctx, "db.CreateAppointmentType")
+	defer span.End()
+
+	if questions == nil {
+		questions = []schema.IntakeQuestion{}
+	}
+	raw, err := json.Marshal(questions)
+	if err != nil {
+		return nil, err
+	}
+	appointmentTypeID := uuid.New()
+	err = db.inTenant(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+		INSERT INTO appointment_types (id, name, intake_questions, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+	`, appointmentTypeID, name, string(raw), db.Clock.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &schema.AppointmentType{Id: (*types.UUID)(&appointmentTypeID), Name: utils.Ptr(name), IntakeQuestions: &questions}, nil
+}
+
+// ListAppointmentTypes returns the organization's appointment types by name
+func (db *Database) ListAppointmentTypes(ctx context.Context) ([]schema.AppointmentType, error) {
+	ctx, span := tracer.Start(ctx, "db.ListAppointmentTypes")
+	defer span.End()
+
+	appointmentTypes := []schema.AppointmentType{}
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `SELECT id, name, intake_questions FROM appointment_types ORDER BY name, id`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var id uuid.UUID
+			var name string
+			var raw []byte
+			if err := rows.Scan(&id, &name, &raw); err != nil {
+				return err
+			}
+			questions := []schema.IntakeQuestion{}
+			if err := json.Unmarshal(raw, &questions); err != nil {
+				return err
+			}
+			appointmentTypes = append(appointmentTypes, schema.AppointmentType{Id: (*types.UUID)(&id), Name: utils.Ptr(name), IntakeQuestions: &questions})
+		}
+		return rows.Err()
+	})
+	return appointmentTypes, err
+}
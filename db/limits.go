@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+var (
+	// ErrHoldLimit is returned when a client already holds as many unconfirmed reservations as allowed
+	ErrHoldLimit = errors.New("client has too many active holds")
+	// ErrAppointmentLimit is returned when a client already has as many upcoming appointments as allowed
+	ErrAppointmentLimit = errors.New("client has too many upcoming appointments")
+	// ErrProviderAppointmentLimit is returned when a client already has as many upcoming appointments with
+	// the provider as allowed
+	ErrProviderAppointmentLimit = errors.New("client has too many upcoming appointments with the provider")
+	// ErrClientDoubleBooked is returned when a client already has an appointment at an overlapping time
+	ErrClientDoubleBooked = errors.New("client already has an appointment at an overlapping time")
+	// ErrNoShowLimit is returned when a client missed as many recent appointments as allowed
+	ErrNoShowLimit = errors.New("client has too many recent no-shows")
+)
+
+// NoShowWindow is how far back a client's no-shows count against them
+const NoShowWindow = 90 * 24 * time.Hour
+
+// ClientBookings counts a client's active reservations and appointments that a new booking is checked against
+type ClientBookings struct {
+	// Holds are the unconfirmed reservations that have not expired
+	Holds int
+	// Future are the ones that have not started yet, FutureWithProvider only those with the booked provider
+	Future             int
+	FutureWithProvider int
+	// Overlapping are the ones at a time overlapping the booking
+	Overlapping int
+	// NoShows are the appointments the client missed that started in the last NoShowWindow
+	NoShows int
+}
+
+// CheckClientLimits returns the error for the first limit of the organization in ctx that one more booking
+// would break, nil if there is none
+func CheckClientLimits(ctx context.Context, bookings ClientBookings) error {
+	org, ok := OrganizationFrom(ctx)
+	if !ok || org.Settings == nil {
+		return overlapLimit(false, bookings)
+	}
+	settings := org.Settings
+	if err := overlapLimit(settings.AllowOverlappingAppointments != nil && *settings.AllowOverlappingAppointments, bookings); err != nil {
+		return err
+	}
+	if settings.MaxNoShows != nil && bookings.NoShows >= *settings.MaxNoShows {
+		return ErrNoShowLimit
+	}
+	if settings.MaxActiveHolds != nil && bookings.Holds >= *settings.MaxActiveHolds {
+		return ErrHoldLimit
+	}
+	if settings.MaxFutureAppointments != nil && bookings.Future >= *settings.MaxFutureAppointments {
+		return ErrAppointmentLimit
+	}
+	if settings.MaxFutureAppointmentsPerProvider != nil && bookings.FutureWithProvider >= *settings.MaxFutureAppointmentsPerProvider {
+		return ErrProviderAppointmentLimit
+	}
+	return nil
+}
+
+// IsLimitError reports whether err is one of the errors CheckClientLimits returns
+func IsLimitError(err error) bool {
+	return errors.Is(err, ErrHoldLimit) || errors.Is(err, ErrAppointmentLimit) || errors.Is(err, ErrProviderAppointmentLimit) ||
+		errors.Is(err, ErrClientDoubleBooked) || errors.Is(err, ErrNoShowLimit)
+}
+
+func overlapLimit(allowed bool, bookings ClientBookings) error {
+	if !allowed && bookings.Overlapping > 0 {
+		return ErrClientDoubleBooked
+	}
+	return nil
+}
+
+// lockClient locks a client until tx ends so the limits of concurrent bookings for them count each other.
+// It goes before bookable, which only shares the lock.
+func lockClient(ctx context.Context, tx *sql.Tx, clientID *types.UUID) error {
+	var id string
+	err := tx.QueryRowContext(ctx, `SELECT id FROM users WHERE id = $1 FOR UPDATE`, clientID.String()).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		// bookable reports the unknown client
+		return nil
+	}
+	return err
+}
+
+// clientLimits checks booking the client with the provider at startTime against the organization's limits,
+// not counting the appointments in excluded. The client has to be locked by lockClient.
+func clientLimits(ctx context.Context, tx *sql.Tx, clientID, providerID *types.UUID, startTime time.Time, excluded []string, now time.Time) error {
+	var bookings ClientBookings
+	err := tx.QueryRowContext(ctx, `
+	SELECT
+	  COUNT(*) FILTER (WHERE appt.status = 'reserved'),
+	  COUNT(*) FILTER (WHERE appt.status <> 'no_show' AND appt.start_time > $2),
+	  COUNT(*) FILTER (WHERE appt.status <> 'no_show' AND appt.start_time > $2 AND appt.provider_id = $3),
+	  COUNT(*) FILTER (WHERE appt.status <> 'no_show' AND appt.start_time < $5 AND appt.end_time > $4),
+	  COUNT(*) FILTER (WHERE appt.status = 'no_show')
+	FROM appointments appt
+	WHERE appt.client_id = $1
+	  AND (
+	    appt.status = 'confirmed' OR
+	    (appt.status = 'reserved' AND appt.created_at > $6) OR
+	    (appt.status = 'no_show' AND appt.start_time > $7)
+	  )
+	  AND NOT (appt.id = ANY($8::uuid[]))
+`, clientID.String(), now, providerID.String(), startTime, startTime.Add(SlotInterval(ctx)), holdCutoff(now), now.Add(-NoShowWindow),
+		excludedIDs(excluded)).Scan(
+		&bookings.Holds, &bookings.Future, &bookings.FutureWithProvider, &bookings.Overlapping, &bookings.NoShows)
+	if err != nil {
+		return err
+	}
+	return CheckClientLimits(ctx, bookings)
+}
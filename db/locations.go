@@ -0,0 +1,241 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+var (
+	// ErrLocationNotFound is returned when a location is not in the organization, it is also sql.ErrNoRows
+	ErrLocationNotFound = fmt.Errorf("location not found: %w", sql.ErrNoRows)
+	// ErrRoomNotFound is returned when a room is not in the organization, it is also sql.ErrNoRows
+	ErrRoomNotFound = fmt.Errorf("room not found: %w", sql.ErrNoRows)
+	// ErrRoomUnavailable is returned when another appointment has the room at an overlapping time
+	ErrRoomUnavailable = errors.New("room is already booked")
+	// ErrRoomElsewhere is returned when a room is not at the location of the slot it is booked with
+	ErrRoomElsewhere = errors.New("room is not at the slot's location")
+)
+
+// SlotError is the error a single booking fails with for a conflict reason
+func SlotError(reason schema.OccurrenceConflictReason) error {
+	switch reason {
+	case schema.RoomBooked:
+		return ErrRoomUnavailable
+	case schema.RoomElsewhere:
+		return ErrRoomElsewhere
+	default:
+		return ErrSlotUnavailable
+	}
+}
+
+// bookedRoom is a room a booking claims together with its provider
+type bookedRoom struct {
+	id         uuid.UUID
+	locationID uuid.UUID
+}
+
+// lockRoom locks a room until tx ends so overlapping bookings of it are serialized, it returns nil for a
+// nil id and ErrRoomNotFound if the room is not in the organization
+func lockRoom(ctx context.Context, tx *sql.Tx, roomID *types.UUID) (*bookedRoom, error) {
+	if roomID == nil {
+		return nil, nil
+	}
+	room := &bookedRoom{id: *roomID}
+	err := tx.QueryRowContext(ctx, `
+	SELECT location_id
+	FROM rooms
+	WHERE id = $1
+	FOR UPDATE
+`, roomID.String()).Scan(&room.locationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrRoomNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// roomConflict reports whether an active appointment other than the excluded ones has the room at an
+// overlapping time. The other seats of the same group session share its room.
+func roomConflict(ctx context.Context, tx *sql.Tx, room *bookedRoom, providerID string, startTime time.Time, excluded []string, now time.Time) (bool, error) {
+	var overlapping int
+	err := tx.QueryRowContext(ctx, `
+	SELECT COUNT(*)
+	FROM appointments appt
+	WHERE appt.room_id = $1
+	  AND appt.start_time < $3
+	  AND appt.end_time > $2
+	  AND NOT (appt.provider_id = $4 AND appt.start_time = $2)
+	  AND NOT (appt.id = ANY($5::uuid[]))
+	  AND (
+	    appt.status = 'confirmed' OR
+	    (appt.status = 'reserved' AND appt.created_at > $6)
+	  )
+`, room.id, startTime, startTime.Add(SlotInterval(ctx)), providerID, excludedIDs(excluded), holdCutoff(now)).Scan(&overlapping)
+	return overlapping > 0, err
+}
+
+// locationExists returns ErrLocationNotFound unless the location is in the organization
+func locationExists(ctx context.Context, tx *sql.Tx, locationID types.UUID) error {
+	var id uuid.UUID
+	err := tx.QueryRowContext(ctx, `SELECT id FROM locations WHERE id = $1`, locationID.String()).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrLocationNotFound
+	}
+	return err
+}
+
+// CreateLocation adds a location, an empty address is left out
+func (db *Database) CreateLocation(ctx context.Context, name, address string) (*schema.Location, error) {
+	ctx, span := tracer.Start(ctx, "db.CreateLocation")
+	defer span.End()
+
+	locationID := uuid.New()
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+		INSERT INTO locations (id, name, address, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $4)
+	`, locationID, name, address, db.Clock.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newLocation(locationID, name, address), nil
+}
+
+func newLocation(id uuid.UUID, name, address string) *schema.Location {
+	location := &schema.Location{Id: (*types.UUID)(&id), Name: utils.Ptr(name)}
+	if address != "" {
+		location.Address = utils.Ptr(address)
+	}
+	return location
+}
+
+// ListLocations returns the organization's locations by name
+func (db *Database) ListLocations(ctx context.Context) ([]schema.Location, error) {
+	ctx, span := tracer.Start(ctx, "db.ListLocations")
+	defer span.End()
+
+	locations := []schema.Location{}
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `SELECT id, name, COALESCE(address, '') FROM locations ORDER BY name, id`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var id uuid.UUID
+			var name, address string
+			if err := rows.Scan(&id, &name, &address); err != nil {
+				return err
+			}
+			locations = append(locations, *newLocation(id, name, address))
+		}
+		return rows.Err()
+	})
+	return locations, err
+}
+
+// CreateRoom adds a room to a location, returning ErrLocationNotFound if it is not in the organization
+func (db *Database) CreateRoom(ctx context.Context, locationID types.UUID, name string) (*schema.Room, error) {
+	ctx, span := tracer.Start(ctx, "db.CreateRoom")
+	defer span.End()
+
+	roomID := uuid.New()
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		// The foreign key would accept another organization's location
+		if err := locationExists(ctx, tx, locationID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `
+		INSERT INTO rooms (id, location_id, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+	`, roomID, locationID.String(), name, db.Clock.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &schema.Room{Id: (*types.UUID)(&roomID), LocationId: &locationID, Name: utils.Ptr(name)}, nil
+}
+
+// ListRooms returns the rooms at a location by name, ErrLocationNotFound if it is not in the organization
+func (db *Database) ListRooms(ctx context.Context, locationID types.UUID) ([]schema.Room, error) {
+	ctx, span := tracer.Start(ctx, "db.ListRooms")
+	defer span.End()
+
+	rooms := []schema.Room{}
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		if err := locationExists(ctx, tx, locationID); err != nil {
+			return err
+		}
+		rows, err := tx.QueryContext(ctx, `SELECT id, name FROM rooms WHERE location_id = $1 ORDER BY name, id`, locationID.String())
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var id uuid.UUID
+			var name string
+			if err := rows.Scan(&id, &name); err != nil {
+				return err
+			}
+			rooms = append(rooms, schema.Room{Id: (*types.UUID)(&id), LocationId: &locationID, Name: utils.Ptr(name)})
+		}
+		return rows.Err()
+	})
+	return rooms, err
+}
+
+// GetRoomAppointments returns the active appointments in a room by start time, on date if it is given.
+// It returns ErrRoomNotFound if the room is not in the organization.
+func (db *Database) GetRoomAppointments(ctx context.Context, roomID types.UUID, date *types.Date) ([]schema.Appointment, error) {
+	ctx, span := tracer.Start(ctx, "db.GetRoomAppointments")
+	defer span.End()
+
+	var appointments []schema.Appointment
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		var id uuid.UUID
+		err := tx.QueryRowContext(ctx, `SELECT id FROM rooms WHERE id = $1`, roomID.String()).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRoomNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		from, to := time.Time{}, time.Time{}
+		if date != nil {
+			from, to = date.Time, date.Time.Add(24*time.Hour)
+		}
+		rows, err := tx.QueryContext(ctx, `
+		SELECT `+appointmentColumns+`
+		FROM appointments appt
+		WHERE appt.room_id = $1
+		  AND ($2 OR (appt.start_time >= $3 AND appt.start_time < $4))
+		  AND (
+		    appt.status = 'confirmed' OR
+		    (appt.status = 'reserved' AND appt.created_at > $5)
+		  )
+		ORDER BY appt.start_time, appt.id
+	`, roomID.String(), date == nil, from, to, holdCutoff(db.Clock.Now()))
+		if err != nil {
+			return err
+		}
+		appointments, err = scanAppointments(rows)
+		return err
+	})
+	return appointments, err
+}
@@ -0,0 +1,195 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+type providerGroup struct {
+	id       uuid.UUID
+	orgID    uuid.UUID
+	name     string
+	strategy schema.GroupStrategy
+	// assignments counts the providers assigned from the group
+	assignments int
+	// members are in priority order
+	members []groupMember
+}
+
+type groupMember struct {
+	providerID uuid.UUID
+	// position is one based and keeps its gaps when members are deleted, like the postgres column
+	position int
+	// lastAssignment is the number of the group's assignment the member last got, 0 for none
+	lastAssignment int
+}
+
+func (g *providerGroup) toSchema() schema.ProviderGroup {
+	id, strategy := g.id, g.strategy
+	providerIDs := make([]types.UUID, 0, len(g.members))
+	for _, m := range g.members {
+		providerIDs = append(providerIDs, m.providerID)
+	}
+	return schema.ProviderGroup{Id: (*types.UUID)(&id), Name: utils.Ptr(g.name), Strategy: &strategy, ProviderIds: &providerIDs}
+}
+
+// group returns a provider group of the organization in ctx
+func (s *Store) group(ctx context.Context, groupID uuid.UUID) (*providerGroup, bool) {
+	g, ok := s.groups[groupID]
+	if !ok || g.orgID != db.OrganizationID(ctx) {
+		return nil, false
+	}
+	return g, true
+}
+
+// bookedInWeek counts the active appointments holding a seat of the provider that start in the week
+// from weekStart to weekEnd
+func (s *Store) bookedInWeek(providerID uuid.UUID, weekStart, weekEnd, now time.Time) int {
+	count := 0
+	for _, appt := range s.appointments {
+		if appt.holdsSeatOf(providerID) && !appt.startTime.Before(weekStart) && appt.startTime.Before(weekEnd) && appt.active(now) {
+			count++
+		}
+	}
+	return count
+}
+
+// CreateProviderGroup adds a group of providers in priority order. It returns sql.ErrNoRows if one of them
+// is not a provider in the organization and db.ErrUserInactive if one has been deactivated.
+func (s *Store) CreateProviderGroup(ctx context.Context, name string, strategy schema.GroupStrategy, providerIDs []types.UUID) (*schema.ProviderGroup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g := &providerGroup{id: uuid.New(), orgID: db.OrganizationID(ctx), name: name, strategy: strategy}
+	for i, providerID := range providerIDs {
+		if err := s.activeUserWithRole(ctx, providerID, string(schema.UserRoleProvider)); err != nil {
+			return nil, err
+		}
+		g.members = append(g.members, groupMember{providerID: providerID, position: i + 1})
+	}
+	s.groups[g.id] = g
+	created := g.toSchema()
+	return &created, nil
+}
+
+// ListProviderGroups returns the organization's provider groups by name
+func (s *Store) ListProviderGroups(ctx context.Context) ([]schema.ProviderGroup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matching []*providerGroup
+	for _, g := range s.groups {
+		if g.orgID == db.OrganizationID(ctx) {
+			matching = append(matching, g)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].name != matching[j].name {
+			return matching[i].name < matching[j].name
+		}
+		return matching[i].id.String() < matching[j].id.String()
+	})
+
+	groups := make([]schema.ProviderGroup, 0, len(matching))
+	for _, g := range matching {
+		groups = append(groups, g.toSchema())
+	}
+	return groups, nil
+}
+
+// GetProviderGroup returns a provider group, db.ErrGroupNotFound if it is not in the organization
+func (s *Store) GetProviderGroup(ctx context.Context, groupID types.UUID) (*schema.ProviderGroup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.group(ctx, groupID)
+	if !ok {
+		return nil, db.ErrGroupNotFound
+	}
+	group := g.toSchema()
+	return &group, nil
+}
+
+// ReserveFromGroup holds a seat at startTime with the active member of a group its strategy ranks first
+// among the ones with a free slot, explaining the choice in the appointment's assignment. It returns
+// db.ErrGroupNotFound for a group that is not in the organization and db.ErrSlotUnavailable if no member
+// is free. Members the client has reached their per provider limit with are passed over, and the limit
+// is returned if that leaves none.
+func (s *Store) ReserveFromGroup(ctx context.Context, clientID, groupID types.UUID, startTime time.Time, in *db.Intake) (*schema.Appointment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.group(ctx, groupID)
+	if !ok {
+		return nil, db.ErrGroupNotFound
+	}
+	if err := s.bookable(ctx, clientID); err != nil {
+		return nil, err
+	}
+
+	now := s.Clock.Now()
+	weekStart, weekEnd := db.WeekOf(startTime)
+	candidates := []schema.AssignmentCandidate{}
+	for _, m := range g.members {
+		if u, ok := s.user(ctx, m.providerID); !ok || !u.deactivatedAt.IsZero() {
+			continue
+		}
+		candidates = append(candidates, schema.AssignmentCandidate{
+			ProviderId:           utils.Ptr(m.providerID),
+			Priority:             utils.Ptr(m.position),
+			LastAssignment:       utils.Ptr(m.lastAssignment),
+			AppointmentsThisWeek: utils.Ptr(s.bookedInWeek(m.providerID, weekStart, weekEnd, now)),
+			Available:            utils.Ptr(s.freeAt(ctx, m.providerID, startTime, now)),
+		})
+	}
+	db.RankCandidates(g.strategy, candidates)
+
+	// A client at their limit with one member can still be assigned another
+	var limited error
+	chosen := slices.IndexFunc(candidates, func(c schema.AssignmentCandidate) bool {
+		if !*c.Available {
+			return false
+		}
+		if err := s.clientLimits(ctx, clientID, *c.ProviderId, startTime, nil, now); errors.Is(err, db.ErrProviderAppointmentLimit) {
+			*c.Available = false
+			limited = err
+			return false
+		}
+		return true
+	})
+	if chosen < 0 {
+		if limited != nil {
+			return nil, limited
+		}
+		return nil, db.ErrSlotUnavailable
+	}
+	if err := s.clientLimits(ctx, clientID, *candidates[chosen].ProviderId, startTime, nil, now); err != nil {
+		return nil, err
+	}
+	checked, err := s.checkIntake(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	providerID := *candidates[chosen].ProviderId
+	appt := s.insertReservedAppointment(ctx, clientID, providerID, nil, startTime, schema.StatusChangeSourceRequest, now, uuid.NullUUID{}, 0)
+	appt.intake = checked
+	g.assignments++
+	for i := range g.members {
+		if g.members[i].providerID == providerID {
+			g.members[i].lastAssignment = g.assignments
+		}
+	}
+
+	reserved := appt.toSchema()
+	reserved.Assignment = db.NewAssignment(groupID, g.strategy, candidates, chosen, startTime)
+	return &reserved, nil
+}
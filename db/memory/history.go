@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+)
+
+// statusChange is what the history of an appointment records about a change of its status
+type statusChange struct {
+	status    schema.AppointmentStatus
+	source    schema.StatusChangeSource
+	changedBy uuid.NullUUID
+	at        time.Time
+}
+
+func (c statusChange) toSchema() schema.StatusChange {
+	status, source, at := c.status, c.source, c.at
+	return schema.StatusChange{
+		Status:    &status,
+		ChangedAt: &at,
+		ChangedBy: nullUUID(c.changedBy),
+		Source:    &source,
+	}
+}
+
+// setStatus changes the status of an appointment and appends the change to its history
+func (a *appointment) setStatus(status schema.AppointmentStatus, source schema.StatusChangeSource, changedBy uuid.NullUUID, now time.Time) {
+	a.status = status
+	a.history = append(a.history, statusChange{status: status, source: source, changedBy: changedBy, at: normalize(now)})
+}
+
+// ListStatusHistory returns every status an appointment has had, oldest first. It returns sql.ErrNoRows if
+// the appointment is not in the organization.
+func (s *Store) ListStatusHistory(ctx context.Context, appointmentID types.UUID) ([]schema.StatusChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	appt, ok := s.appointment(ctx, appointmentID)
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	history := make([]schema.StatusChange, 0, len(appt.history))
+	for _, change := range appt.history {
+		history = append(history, change.toSchema())
+	}
+	return history, nil
+}
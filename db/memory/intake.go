@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+type appointmentType struct {
+	id    uuid.UUID
+	orgID uuid.UUID
+	name  string
+	// questions is the json array of intake questions, kept encoded so callers can not change them
+	questions string
+}
+
+func (t *appointmentType) toSchema() (schema.AppointmentType, error) {
+	id := t.id
+	questions := []schema.IntakeQuestion{}
+	if err := json.Unmarshal([]byte(t.questions), &questions); err != nil {
+		return schema.AppointmentType{}, err
+	}
+	return schema.AppointmentType{Id: (*types.UUID)(&id), Name: utils.Ptr(t.name), IntakeQuestions: &questions}, nil
+}
+
+// intake is what the client told about an appointment, answers is the json object of db.EncodeAnswers
+type intake struct {
+	reason            string
+	appointmentTypeID uuid.NullUUID
+	answers           string
+}
+
+// checkIntake checks an intake against the questions of its appointment type, returning it as it is stored
+func (s *Store) checkIntake(ctx context.Context, in *db.Intake) (intake, error) {
+	if in == nil {
+		return intake{}, nil
+	}
+	var questions []schema.IntakeQuestion
+	var appointmentTypeID uuid.NullUUID
+	if in.AppointmentTypeID != nil {
+		t, ok := s.appointmentTypes[*in.AppointmentTypeID]
+		if !ok || t.orgID != db.OrganizationID(ctx) {
+			return intake{}, db.ErrAppointmentTypeNotFound
+		}
+		if err := json.Unmarshal([]byte(t.questions), &questions); err != nil {
+			return intake{}, err
+		}
+		appointmentTypeID = uuid.NullUUID{UUID: t.id, Valid: true}
+	}
+	answers, err := db.EncodeAnswers(*in, questions)
+	if err != nil {
+		return intake{}, err
+	}
+	return intake{reason: in.Reason, appointmentTypeID: appointmentTypeID, answers: answers}, nil
+}
+
+// CreateAppointmentType adds a kind of appointment with the intake questions clients booking one answer
+func (s *Store) CreateAppointmentType(ctx context.Context, name string, questions []schema.IntakeQuestion) (*schema.AppointmentType, error) {
+	if questions == nil {
+		questions = []schema.IntakeQuestion{}
+	}
+	raw, err := json.Marshal(questions)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := &appointmentType{id: uuid.New(), orgID: db.OrganizationID(ctx), name: name, questions: string(raw)}
+	s.appointmentTypes[t.id] = t
+	appointmentType, err := t.toSchema()
+	if err != nil {
+		return nil, err
+	}
+	return &appointmentType, nil
+}
+
+// ListAppointmentTypes returns the organization's appointment types by name
+func (s *Store) ListAppointmentTypes(ctx context.Context) ([]schema.AppointmentType, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matching []*appointmentType
+	for _, t := range s.appointmentTypes {
+		if t.orgID == db.OrganizationID(ctx) {
+			matching = append(matching, t)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].name != matching[j].name {
+			return matching[i].name < matching[j].name
+		}
+		return matching[i].id.String() < matching[j].id.String()
+	})
+
+	appointmentTypes := []schema.AppointmentType{}
+	for _, t := range matching {
+		appointmentType, err := t.toSchema()
+		if err != nil {
+			return nil, err
+		}
+		appointmentTypes = append(appointmentTypes, appointmentType)
+	}
+	return appointmentTypes, nil
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+)
+
+// clientLimits checks booking the client with the provider at startTime against the organization's limits,
+// not counting the appointments in excluded
+func (s *Store) clientLimits(ctx context.Context, clientID, providerID uuid.UUID, startTime time.Time, excluded map[uuid.UUID]bool, now time.Time) error {
+	var bookings db.ClientBookings
+	endTime := startTime.Add(db.SlotInterval(ctx))
+	for _, appt := range s.appointments {
+		if appt.clientID != clientID || excluded[appt.id] {
+			continue
+		}
+		if appt.status == schema.AppointmentStatusNoShow && appt.startTime.After(now.Add(-db.NoShowWindow)) {
+			bookings.NoShows++
+		}
+		if !appt.active(now) {
+			continue
+		}
+		if appt.status == schema.AppointmentStatusReserved {
+			bookings.Holds++
+		}
+		if appt.startTime.After(now) {
+			bookings.Future++
+			if appt.providerID == providerID {
+				bookings.FutureWithProvider++
+			}
+		}
+		if appt.startTime.Before(endTime) && appt.endTime.After(startTime) {
+			bookings.Overlapping++
+		}
+	}
+	return db.CheckClientLimits(ctx, bookings)
+}
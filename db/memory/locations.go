@@ -0,0 +1,201 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+type location struct {
+	id      uuid.UUID
+	orgID   uuid.UUID
+	name    string
+	address string
+}
+
+type room struct {
+	id         uuid.UUID
+	orgID      uuid.UUID
+	locationID uuid.UUID
+	name       string
+}
+
+func (l *location) toSchema() schema.Location {
+	id := l.id
+	loc := schema.Location{Id: (*types.UUID)(&id), Name: utils.Ptr(l.name)}
+	if l.address != "" {
+		loc.Address = utils.Ptr(l.address)
+	}
+	return loc
+}
+
+func (r *room) toSchema() schema.Room {
+	id, locationID := r.id, r.locationID
+	return schema.Room{Id: (*types.UUID)(&id), LocationId: (*types.UUID)(&locationID), Name: utils.Ptr(r.name)}
+}
+
+// location returns a location of the organization in ctx
+func (s *Store) location(ctx context.Context, locationID uuid.UUID) (*location, bool) {
+	loc, ok := s.locations[locationID]
+	if !ok || loc.orgID != db.OrganizationID(ctx) {
+		return nil, false
+	}
+	return loc, true
+}
+
+// room returns a room of the organization in ctx
+func (s *Store) room(ctx context.Context, roomID uuid.UUID) (*room, bool) {
+	r, ok := s.rooms[roomID]
+	if !ok || r.orgID != db.OrganizationID(ctx) {
+		return nil, false
+	}
+	return r, true
+}
+
+// bookedRoom returns the room a booking claims, nil for a nil id and db.ErrRoomNotFound if it is not in
+// the organization
+func (s *Store) bookedRoom(ctx context.Context, roomID *types.UUID) (*room, error) {
+	if roomID == nil {
+		return nil, nil
+	}
+	r, ok := s.room(ctx, *roomID)
+	if !ok {
+		return nil, db.ErrRoomNotFound
+	}
+	return r, nil
+}
+
+// roomTaken reports whether an active appointment other than the excluded ones has the room at an
+// overlapping time. The other seats of the same group session share its room.
+func (s *Store) roomTaken(r *room, providerID uuid.UUID, startTime, endTime, now time.Time, excluded map[uuid.UUID]bool) bool {
+	for _, appt := range s.appointments {
+		if !appt.roomID.Valid || appt.roomID.UUID != r.id || excluded[appt.id] || !appt.active(now) {
+			continue
+		}
+		if appt.providerID == providerID && appt.startTime.Equal(startTime) {
+			continue
+		}
+		if appt.startTime.Before(endTime) && appt.endTime.After(startTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateLocation adds a location, an empty address is left out
+func (s *Store) CreateLocation(ctx context.Context, name, address string) (*schema.Location, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	loc := &location{id: uuid.New(), orgID: db.OrganizationID(ctx), name: name, address: address}
+	s.locations[loc.id] = loc
+	created := loc.toSchema()
+	return &created, nil
+}
+
+// ListLocations returns the organization's locations by name
+func (s *Store) ListLocations(ctx context.Context) ([]schema.Location, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matching []*location
+	for _, loc := range s.locations {
+		if loc.orgID == db.OrganizationID(ctx) {
+			matching = append(matching, loc)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].name != matching[j].name {
+			return matching[i].name < matching[j].name
+		}
+		return matching[i].id.String() < matching[j].id.String()
+	})
+
+	locations := make([]schema.Location, 0, len(matching))
+	for _, loc := range matching {
+		locations = append(locations, loc.toSchema())
+	}
+	return locations, nil
+}
+
+// CreateRoom adds a room to a location, returning db.ErrLocationNotFound if it is not in the organization
+func (s *Store) CreateRoom(ctx context.Context, locationID types.UUID, name string) (*schema.Room, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.location(ctx, locationID); !ok {
+		return nil, db.ErrLocationNotFound
+	}
+	r := &room{id: uuid.New(), orgID: db.OrganizationID(ctx), locationID: locationID, name: name}
+	s.rooms[r.id] = r
+	created := r.toSchema()
+	return &created, nil
+}
+
+// ListRooms returns the rooms at a location by name, db.ErrLocationNotFound if it is not in the organization
+func (s *Store) ListRooms(ctx context.Context, locationID types.UUID) ([]schema.Room, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.location(ctx, locationID); !ok {
+		return nil, db.ErrLocationNotFound
+	}
+	var matching []*room
+	for _, r := range s.rooms {
+		if r.locationID == locationID {
+			matching = append(matching, r)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].name != matching[j].name {
+			return matching[i].name < matching[j].name
+		}
+		return matching[i].id.String() < matching[j].id.String()
+	})
+
+	rooms := make([]schema.Room, 0, len(matching))
+	for _, r := range matching {
+		rooms = append(rooms, r.toSchema())
+	}
+	return rooms, nil
+}
+
+// GetRoomAppointments returns the active appointments in a room by start time, on date if it is given.
+// It returns db.ErrRoomNotFound if the room is not in the organization.
+func (s *Store) GetRoomAppointments(ctx context.Context, roomID types.UUID, date *types.Date) ([]schema.Appointment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.room(ctx, roomID); !ok {
+		return nil, db.ErrRoomNotFound
+	}
+	now := s.Clock.Now()
+	var matching []*appointment
+	for _, appt := range s.appointments {
+		if !appt.roomID.Valid || appt.roomID.UUID != roomID || !appt.active(now) {
+			continue
+		}
+		if date != nil && (appt.startTime.Before(date.Time) || !appt.startTime.Before(date.Time.Add(24*time.Hour))) {
+			continue
+		}
+		matching = append(matching, appt)
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if !matching[i].startTime.Equal(matching[j].startTime) {
+			return matching[i].startTime.Before(matching[j].startTime)
+		}
+		return matching[i].id.String() < matching[j].id.String()
+	})
+
+	appointments := make([]schema.Appointment, 0, len(matching))
+	for _, appt := range matching {
+		appointments = append(appointments, appt.toSchema())
+	}
+	return appointments, nil
+}
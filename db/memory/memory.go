@@ -0,0 +1,572 @@
+// Package memory keeps everything in process memory. It has the same semantics as the postgres Database,
+// which db/storetest checks, so it backs fast handler tests and the demo mode. Nothing survives a restart.
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/clock"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/migrations"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+type user struct {
+	id    uuid.UUID
+	orgID uuid.UUID
+	name  string
+	email string
+	role  string
+	// deactivatedAt is zero while the user is active
+	deactivatedAt time.Time
+	// profile is what providers show in the directory
+	profile schema.ProviderProfile
+}
+
+type availability struct {
+	id         uuid.UUID
+	orgID      uuid.UUID
+	providerID uuid.UUID
+	startTime  time.Time
+	endTime    time.Time
+	capacity   int
+	locationID uuid.NullUUID
+}
+
+type appointment struct {
+	id          uuid.UUID
+	orgID       uuid.UUID
+	clientID    uuid.UUID
+	providerID  uuid.UUID
+	startTime   time.Time
+	endTime     time.Time
+	status      schema.AppointmentStatus
+	seriesID    uuid.NullUUID
+	seriesIndex int
+	locationID  uuid.NullUUID
+	roomID      uuid.NullUUID
+	// participants are the other providers taking part, sorted
+	participants []uuid.UUID
+	// statusChangedAt and statusChangedBy record the last check in, completion or no-show
+	statusChangedAt time.Time
+	statusChangedBy uuid.NullUUID
+	intake          intake
+	// notes are the versions of the providers' notes, oldest first
+	notes []note
+	// history is every status the appointment has had, oldest first
+	history   []statusChange
+	createdAt time.Time
+}
+
+// slotKey identifies a slot the way the unique (provider_id, start_time) constraint does
+type slotKey struct {
+	providerID uuid.UUID
+	startTime  int64
+}
+
+func keyOf(providerID uuid.UUID, startTime time.Time) slotKey {
+	return slotKey{providerID: providerID, startTime: startTime.UnixMicro()}
+}
+
+// emailKey is unique like the (organization_id, email) constraint
+type emailKey struct {
+	orgID uuid.UUID
+	email string
+}
+
+// Store implements db.Store. A single mutex serializes every operation, which gives the same guarantees
+// as the row locks the postgres queries take.
+type Store struct {
+	Logger *slog.Logger
+	// Clock decides when holds expire
+	Clock clock.Clock
+
+	mu            sync.Mutex
+	organizations map[uuid.UUID]*organization
+	locations     map[uuid.UUID]*location
+	rooms         map[uuid.UUID]*room
+	groups        map[uuid.UUID]*providerGroup
+	// appointmentTypes are the kinds of appointment with their intake questions
+	appointmentTypes map[uuid.UUID]*appointmentType
+	users            map[uuid.UUID]*user
+	emails           map[emailKey]uuid.UUID
+	availability     map[uuid.UUID]*availability
+	slots            map[slotKey]*availability
+	appointments     map[uuid.UUID]*appointment
+	series           map[uuid.UUID]*series
+	// waitlist is in the order clients joined, which is the order they are served in
+	waitlist []*waitlistEntry
+}
+
+// Ensure that Store implements db.Store
+var _ db.Store = (*Store)(nil)
+
+// New returns a store with only the default organization, a nil logger uses the default logger
+func New(logger *slog.Logger) *Store {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Store{
+		Logger:           logger,
+		Clock:            clock.System{},
+		organizations:    map[uuid.UUID]*organization{db.DefaultOrganizationID: defaultOrganization()},
+		locations:        map[uuid.UUID]*location{},
+		rooms:            map[uuid.UUID]*room{},
+		groups:           map[uuid.UUID]*providerGroup{},
+		appointmentTypes: map[uuid.UUID]*appointmentType{},
+		users:            map[uuid.UUID]*user{},
+		emails:           map[emailKey]uuid.UUID{},
+		availability:     map[uuid.UUID]*availability{},
+		slots:            map[slotKey]*availability{},
+		appointments:     map[uuid.UUID]*appointment{},
+		series:           map[uuid.UUID]*series{},
+	}
+}
+
+// normalize drops the monotonic clock reading and anything below the microseconds postgres keeps
+func normalize(t time.Time) time.Time {
+	return t.Truncate(time.Microsecond)
+}
+
+// Ping always succeeds, the store is in the same process
+func (s *Store) Ping(_ context.Context) error {
+	return nil
+}
+
+// SchemaVersion returns the newest migration, there is no schema to fall behind
+func (s *Store) SchemaVersion(_ context.Context) (int, error) {
+	return migrations.Latest()
+}
+
+// active reports whether an appointment holds its seat, the same condition the postgres queries use
+func (a *appointment) active(now time.Time) bool {
+	return a.status == schema.AppointmentStatusConfirmed ||
+		(a.status == schema.AppointmentStatusReserved && a.createdAt.After(now.Add(-db.ReservationHoldDuration)))
+}
+
+// canChangeTo reports whether an appointment can change to status at now by db.CheckTransition
+func (a *appointment) canChangeTo(status schema.AppointmentStatus, now time.Time) bool {
+	return db.CheckTransition(a.status, status, a.createdAt, a.startTime, now) == nil
+}
+
+// transitionFailure is db.TransitionFailure for an appointment a change to status was not made to,
+// sql.ErrNoRows if it is not in the organization
+func (s *Store) transitionFailure(ctx context.Context, appointmentID uuid.UUID, status schema.AppointmentStatus) error {
+	appt, ok := s.appointment(ctx, appointmentID)
+	if !ok {
+		return sql.ErrNoRows
+	}
+	return db.TransitionFailure(appt.status, status)
+}
+
+func (a *appointment) toSchema() schema.Appointment {
+	id, clientID, providerID := a.id, a.clientID, a.providerID
+	startTime, endTime, status := a.startTime, a.endTime, a.status
+	appt := schema.Appointment{
+		Id:         (*types.UUID)(&id),
+		ClientId:   (*types.UUID)(&clientID),
+		ProviderId: (*types.UUID)(&providerID),
+		StartTime:  &startTime,
+		EndTime:    &endTime,
+		Status:     &status,
+	}
+	if a.seriesID.Valid {
+		seriesID := a.seriesID.UUID
+		appt.SeriesId = (*types.UUID)(&seriesID)
+		appt.SeriesIndex = utils.Ptr(a.seriesIndex)
+	}
+	appt.LocationId = nullUUID(a.locationID)
+	appt.RoomId = nullUUID(a.roomID)
+	appt.StatusChangedBy = nullUUID(a.statusChangedBy)
+	if !a.statusChangedAt.IsZero() {
+		changedAt := a.statusChangedAt
+		appt.StatusChangedAt = &changedAt
+	}
+	if len(a.participants) > 0 {
+		participants := slices.Clone(a.participants)
+		appt.ParticipantIds = &participants
+	}
+	// The answers were encoded by db.EncodeAnswers, so they always decode
+	_ = db.SetIntake(&appt, a.intake.reason, a.intake.appointmentTypeID, a.intake.answers)
+	return appt
+}
+
+// nullUUID is the id, or nil if it is not valid
+func nullUUID(id uuid.NullUUID) *types.UUID {
+	if !id.Valid {
+		return nil
+	}
+	return (*types.UUID)(&id.UUID)
+}
+
+// booked counts the seats taken in a slot, ignoring the appointments in excluded
+func (s *Store) booked(slot *availability, now time.Time, excluded map[uuid.UUID]bool) int {
+	count := 0
+	for _, appt := range s.appointments {
+		if appt.holdsSeatOf(slot.providerID) && appt.startTime.Equal(slot.startTime) && !excluded[appt.id] && appt.active(now) {
+			count++
+		}
+	}
+	return count
+}
+
+// slotConflict reports why a provider's slot, and room if it is not nil, can not be booked, ignoring the
+// appointments in excluded
+func (s *Store) slotConflict(providerID uuid.UUID, r *room, startTime, now time.Time, excluded map[uuid.UUID]bool) schema.OccurrenceConflictReason {
+	slot, ok := s.slots[keyOf(providerID, startTime)]
+	if !ok {
+		return schema.NoAvailability
+	}
+	if s.booked(slot, now, excluded) >= slot.capacity {
+		return schema.Booked
+	}
+	if r == nil {
+		return ""
+	}
+	if slot.locationID.UUID != r.locationID {
+		return schema.RoomElsewhere
+	}
+	if s.roomTaken(r, providerID, slot.startTime, slot.endTime, now, excluded) {
+		return schema.RoomBooked
+	}
+	return ""
+}
+
+// user returns a user of the organization in ctx, the others are invisible like they are to the postgres policies
+func (s *Store) user(ctx context.Context, userID uuid.UUID) (*user, bool) {
+	u, ok := s.users[userID]
+	if !ok || u.orgID != db.OrganizationID(ctx) {
+		return nil, false
+	}
+	return u, true
+}
+
+// appointment returns an appointment of the organization in ctx
+func (s *Store) appointment(ctx context.Context, appointmentID uuid.UUID) (*appointment, bool) {
+	appt, ok := s.appointments[appointmentID]
+	if !ok || appt.orgID != db.OrganizationID(ctx) {
+		return nil, false
+	}
+	return appt, true
+}
+
+// userWithRoleExists returns sql.ErrNoRows like the postgres lookup does when there is no such user
+func (s *Store) userWithRoleExists(ctx context.Context, userID uuid.UUID, role string) error {
+	if u, ok := s.user(ctx, userID); !ok || u.role != role {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// activeUserWithRole returns sql.ErrNoRows unless there is a user with the id and role, and
+// db.ErrUserInactive if they have been deactivated
+func (s *Store) activeUserWithRole(ctx context.Context, userID uuid.UUID, role string) error {
+	if err := s.userWithRoleExists(ctx, userID, role); err != nil {
+		return err
+	}
+	if !s.users[userID].deactivatedAt.IsZero() {
+		return db.ErrUserInactive
+	}
+	return nil
+}
+
+// bookable returns db.ErrUserInactive if any of the users has been deactivated and sql.ErrNoRows if
+// any is not in the organization
+func (s *Store) bookable(ctx context.Context, ids ...uuid.UUID) error {
+	for _, id := range ids {
+		if u, ok := s.user(ctx, id); ok && !u.deactivatedAt.IsZero() {
+			return db.ErrUserInactive
+		}
+	}
+	for _, id := range ids {
+		if _, ok := s.user(ctx, id); !ok {
+			return sql.ErrNoRows
+		}
+	}
+	return nil
+}
+
+// insertReservedAppointment holds a seat in a slot, the appointment takes the location of the slot
+func (s *Store) insertReservedAppointment(ctx context.Context, clientID, providerID uuid.UUID, r *room, startTime time.Time, source schema.StatusChangeSource, now time.Time, seriesID uuid.NullUUID, seriesIndex int) *appointment {
+	appt := &appointment{
+		id:          uuid.New(),
+		orgID:       db.OrganizationID(ctx),
+		clientID:    clientID,
+		providerID:  providerID,
+		startTime:   startTime,
+		endTime:     startTime.Add(db.SlotInterval(ctx)),
+		seriesID:    seriesID,
+		seriesIndex: seriesIndex,
+		createdAt:   now,
+	}
+	appt.setStatus(schema.AppointmentStatusReserved, source, uuid.NullUUID{}, now)
+	if slot, ok := s.slots[keyOf(providerID, startTime)]; ok {
+		appt.locationID = slot.locationID
+	}
+	if r != nil {
+		appt.roomID = uuid.NullUUID{UUID: r.id, Valid: true}
+	}
+	s.appointments[appt.id] = appt
+	return appt
+}
+
+func (s *Store) CreateUser(ctx context.Context, name, email, role string) (*schema.User, error) {
+	if role != string(schema.UserRoleProvider) && role != string(schema.UserRoleClient) {
+		return nil, fmt.Errorf("invalid role %q", role)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := emailKey{orgID: db.OrganizationID(ctx), email: email}
+	if _, ok := s.emails[key]; ok {
+		return nil, db.ErrEmailTaken
+	}
+	u := &user{id: uuid.New(), orgID: key.orgID, name: name, email: email, role: role}
+	s.users[u.id] = u
+	s.emails[key] = u.id
+
+	return u.toSchema(), nil
+}
+
+func (s *Store) GetUser(ctx context.Context, userID types.UUID) (*schema.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.user(ctx, userID)
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return u.toSchema(), nil
+}
+
+func (u *user) toSchema() *schema.User {
+	id := u.id
+	role := schema.UserRole(u.role)
+	user := &schema.User{
+		Id:    (*types.UUID)(&id),
+		Name:  utils.Ptr(u.name),
+		Email: utils.Ptr(u.email),
+		Role:  &role,
+	}
+	if !u.deactivatedAt.IsZero() {
+		user.DeactivatedAt = utils.Ptr(u.deactivatedAt)
+	}
+	return user
+}
+
+// AddAvailability adds slots that up to capacity clients can book, at a location if locationID is not nil.
+// Slots that already exist are kept as they are.
+func (s *Store) AddAvailability(ctx context.Context, providerID types.UUID, locationID *types.UUID, slots []time.Time, capacity int) error {
+	if capacity < 1 {
+		return fmt.Errorf("capacity must be at least 1, got %d", capacity)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.activeUserWithRole(ctx, providerID, string(schema.UserRoleProvider)); err != nil {
+		return err
+	}
+	var location uuid.NullUUID
+	if locationID != nil {
+		if _, ok := s.location(ctx, *locationID); !ok {
+			return db.ErrLocationNotFound
+		}
+		location = uuid.NullUUID{UUID: *locationID, Valid: true}
+	}
+
+	for _, startTime := range slots {
+		startTime = normalize(startTime)
+		key := keyOf(providerID, startTime)
+		if _, ok := s.slots[key]; ok {
+			continue
+		}
+		slot := &availability{
+			id:         uuid.New(),
+			orgID:      db.OrganizationID(ctx),
+			providerID: providerID,
+			startTime:  startTime,
+			endTime:    startTime.Add(db.SlotInterval(ctx)),
+			capacity:   capacity,
+			locationID: location,
+		}
+		s.availability[slot.id] = slot
+		s.slots[key] = slot
+	}
+	return nil
+}
+
+// GetAvailableAppointments lists the slots with a seat left. With participants only the slots at times when
+// every participant has a free slot too are listed.
+func (s *Store) GetAvailableAppointments(ctx context.Context, providerID *types.UUID, participantIDs []types.UUID, locationID *types.UUID, date *types.Date) ([]schema.Appointment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.Clock.Now()
+	if s.bookable(ctx, participantIDs...) != nil {
+		return []schema.Appointment{}, nil
+	}
+	var slots []*availability
+	for _, slot := range s.availability {
+		if providerID != nil && slot.providerID != *providerID {
+			continue
+		}
+		if locationID != nil && (!slot.locationID.Valid || slot.locationID.UUID != *locationID) {
+			continue
+		}
+		if s.bookable(ctx, slot.providerID) != nil {
+			continue
+		}
+		if date != nil && (slot.startTime.Before(date.Time) || !slot.startTime.Before(date.Time.Add(24*time.Hour))) {
+			continue
+		}
+		if !s.participantsFree(ctx, participantIDs, slot.startTime, now) {
+			continue
+		}
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].startTime.Before(slots[j].startTime)
+	})
+
+	// Only slots with seats left are available
+	appointments := []schema.Appointment{}
+	for _, slot := range slots {
+		booked := s.booked(slot, now, nil)
+		if booked >= slot.capacity {
+			continue
+		}
+		id, pID := slot.id, slot.providerID
+		startTime, endTime := slot.startTime, slot.endTime
+		appointments = append(appointments, schema.Appointment{
+			Id:             (*types.UUID)(&id),
+			ProviderId:     (*types.UUID)(&pID),
+			StartTime:      &startTime,
+			EndTime:        &endTime,
+			Capacity:       utils.Ptr(slot.capacity),
+			SeatsRemaining: utils.Ptr(slot.capacity - booked),
+			LocationId:     nullUUID(slot.locationID),
+		})
+	}
+	return appointments, nil
+}
+
+func (s *Store) GetAppointmentStartTime(ctx context.Context, availabilityID *types.UUID) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	slot, ok := s.availability[*availabilityID]
+	if !ok || slot.orgID != db.OrganizationID(ctx) {
+		return time.Time{}, sql.ErrNoRows
+	}
+	return slot.startTime, nil
+}
+
+// IsSlotAvailable reports whether the provider and every participant have a slot with a seat left at startTime
+func (s *Store) IsSlotAvailable(ctx context.Context, providerID *types.UUID, participantIDs []types.UUID, startTime *time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.Clock.Now()
+	return s.freeAt(ctx, *providerID, *startTime, now) && s.participantsFree(ctx, participantIDs, *startTime, now), nil
+}
+
+// ReserveAppointment holds a seat in a provider's slot, in the slot of every participant at the same time
+// and the room if roomID is not nil. Any of them being taken fails the whole reservation, and so does an
+// intake that does not fit its appointment type.
+func (s *Store) ReserveAppointment(ctx context.Context, clientID, providerID *types.UUID, participantIDs []types.UUID, roomID *types.UUID, startTime *time.Time, in *db.Intake) (*schema.Appointment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.bookable(ctx, append([]uuid.UUID{*clientID, *providerID}, participantIDs...)...); err != nil {
+		return nil, err
+	}
+	now := s.Clock.Now()
+	if err := s.clientLimits(ctx, *clientID, *providerID, *startTime, nil, now); err != nil {
+		return nil, err
+	}
+	r, err := s.bookedRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if reason := s.seatsConflict(*providerID, participantIDs, r, *startTime, now, nil); reason != "" {
+		return nil, db.SlotError(reason)
+	}
+	checked, err := s.checkIntake(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	appt := s.insertReservedAppointment(ctx, *clientID, *providerID, r, normalize(*startTime), schema.StatusChangeSourceRequest, now, uuid.NullUUID{}, 0)
+	appt.participants = participantsOf(participantIDs)
+	appt.intake = checked
+	appointment := appt.toSchema()
+	return &appointment, nil
+}
+
+func (s *Store) ConfirmAppointment(ctx context.Context, appointmentID types.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	appt, ok := s.appointment(ctx, appointmentID)
+	switch {
+	case !ok:
+		return sql.ErrNoRows
+	case appt.status == schema.AppointmentStatusExpired,
+		appt.status == schema.AppointmentStatusReserved && !appt.active(s.Clock.Now()):
+		return db.ErrHoldExpired
+	case !appt.canChangeTo(schema.AppointmentStatusConfirmed, s.Clock.Now()):
+		return db.TransitionFailure(appt.status, schema.AppointmentStatusConfirmed)
+	}
+	appt.setStatus(schema.AppointmentStatusConfirmed, schema.StatusChangeSourceRequest, uuid.NullUUID{}, s.Clock.Now())
+	return nil
+}
+
+// ExpireReservations marks reservations that were not confirmed in time as expired and returns how many were
+func (s *Store) ExpireReservations(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.Clock.Now()
+	orgID := db.OrganizationID(ctx)
+	var expired int64
+	for _, appt := range s.appointments {
+		if appt.orgID == orgID && !appt.active(now) && appt.canChangeTo(schema.AppointmentStatusExpired, now) {
+			appt.setStatus(schema.AppointmentStatusExpired, schema.StatusChangeSourceHoldExpiry, uuid.NullUUID{}, now)
+			expired++
+		}
+	}
+	if expired > 0 {
+		s.Logger.Debug("Expired reservations", slog.Int64("count", expired))
+	}
+	return expired, nil
+}
+
+// CancelAppointment cancels an active reservation or a confirmed appointment, freeing its slot. It returns
+// the error of db.TransitionFailure if the appointment can not be cancelled.
+func (s *Store) CancelAppointment(ctx context.Context, appointmentID types.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	appt, ok := s.appointment(ctx, appointmentID)
+	if !ok {
+		return sql.ErrNoRows
+	}
+	now := s.Clock.Now()
+	if !appt.canChangeTo(schema.AppointmentStatusCancelled, now) {
+		return db.TransitionFailure(appt.status, schema.AppointmentStatusCancelled)
+	}
+	appt.setStatus(schema.AppointmentStatusCancelled, schema.StatusChangeSourceRequest, uuid.NullUUID{}, now)
+	return nil
+}
@@ -0,0 +1,23 @@
+package memory
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/tateexon/reservation/clock"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/db/storetest"
+)
+
+func TestConformance(t *testing.T) {
+	t.Parallel()
+
+	storetest.Run(t, storetest.Harness{
+		New: func(_ *testing.T, clock clock.Clock) db.Store {
+			store := New(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			store.Clock = clock
+			return store
+		},
+	})
+}
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// note is one version of the providers' notes on an appointment
+type note struct {
+	version   int
+	body      string
+	authorID  uuid.UUID
+	createdAt time.Time
+}
+
+func (n note) toSchema(appointmentID uuid.UUID) schema.AppointmentNote {
+	authorID, createdAt := n.authorID, n.createdAt
+	return schema.AppointmentNote{
+		AppointmentId: (*types.UUID)(&appointmentID),
+		Version:       utils.Ptr(n.version),
+		Body:          utils.Ptr(n.body),
+		AuthorId:      (*types.UUID)(&authorID),
+		CreatedAt:     &createdAt,
+	}
+}
+
+// appointmentProvider returns an appointment of the organization in ctx, sql.ErrNoRows if there is none and
+// db.ErrNotAppointmentProvider unless the user is its provider or a participant
+func (s *Store) appointmentProvider(ctx context.Context, appointmentID, userID types.UUID) (*appointment, error) {
+	appt, ok := s.appointment(ctx, appointmentID)
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	if appt.providerID != userID && !slices.Contains(appt.participants, userID) {
+		return nil, db.ErrNotAppointmentProvider
+	}
+	return appt, nil
+}
+
+// SaveAppointmentNote saves the providers' notes on an appointment as its next version. It returns
+// sql.ErrNoRows if the appointment is not in the organization and db.ErrNotAppointmentProvider if the
+// author is not its provider or a participant.
+func (s *Store) SaveAppointmentNote(ctx context.Context, appointmentID, authorID types.UUID, body string) (*schema.AppointmentNote, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	appt, err := s.appointmentProvider(ctx, appointmentID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	n := note{version: len(appt.notes) + 1, body: body, authorID: authorID, createdAt: normalize(s.Clock.Now())}
+	appt.notes = append(appt.notes, n)
+	saved := n.toSchema(appt.id)
+	return &saved, nil
+}
+
+// ListAppointmentNotes returns every version of the providers' notes on an appointment, latest first. It
+// returns sql.ErrNoRows if the appointment is not in the organization.
+func (s *Store) ListAppointmentNotes(ctx context.Context, appointmentID types.UUID) ([]schema.AppointmentNote, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	appt, ok := s.appointment(ctx, appointmentID)
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	notes := make([]schema.AppointmentNote, 0, len(appt.notes))
+	for i := len(appt.notes) - 1; i >= 0; i-- {
+		notes = append(notes, appt.notes[i].toSchema(appt.id))
+	}
+	return notes, nil
+}
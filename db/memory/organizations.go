@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+type organization struct {
+	id        uuid.UUID
+	name      string
+	slug      string
+	hosts     []string
+	tokenHash string
+	settings  schema.OrganizationSettings
+}
+
+// defaultOrganization is the one the migrations create
+func defaultOrganization() *organization {
+	return &organization{id: db.DefaultOrganizationID, name: "Default", slug: "default"}
+}
+
+func (o *organization) toSchema() *schema.Organization {
+	id := o.id
+	hosts := slices.Clone(o.hosts)
+	sort.Strings(hosts)
+	if hosts == nil {
+		hosts = []string{}
+	}
+	return &schema.Organization{
+		Id:    (*types.UUID)(&id),
+		Name:  utils.Ptr(o.name),
+		Slug:  utils.Ptr(o.slug),
+		Hosts: &hosts,
+		Settings: &schema.OrganizationSettings{
+			SlotIntervalMinutes:              o.settings.SlotIntervalMinutes,
+			LeadTimeMinutes:                  o.settings.LeadTimeMinutes,
+			MaxActiveHolds:                   o.settings.MaxActiveHolds,
+			MaxFutureAppointments:            o.settings.MaxFutureAppointments,
+			MaxFutureAppointmentsPerProvider: o.settings.MaxFutureAppointmentsPerProvider,
+			AllowOverlappingAppointments:     o.settings.AllowOverlappingAppointments,
+			MaxNoShows:                       o.settings.MaxNoShows,
+		},
+	}
+}
+
+// CreateOrganization adds an organization, returning db.ErrOrganizationExists if its slug, a host or its token is taken
+func (s *Store) CreateOrganization(_ context.Context, org db.NewOrganization) (*schema.Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.organizations {
+		if existing.slug == org.Slug || (org.TokenHash != "" && existing.tokenHash == org.TokenHash) {
+			return nil, db.ErrOrganizationExists
+		}
+		for _, host := range org.Hosts {
+			if slices.Contains(existing.hosts, host) {
+				return nil, db.ErrOrganizationExists
+			}
+		}
+	}
+	hosts := slices.Compact(slices.Sorted(slices.Values(org.Hosts)))
+	if len(hosts) != len(org.Hosts) {
+		return nil, db.ErrOrganizationExists
+	}
+
+	o := &organization{
+		id:        uuid.New(),
+		name:      org.Name,
+		slug:      org.Slug,
+		hosts:     hosts,
+		tokenHash: org.TokenHash,
+		settings:  org.Settings,
+	}
+	s.organizations[o.id] = o
+	return o.toSchema(), nil
+}
+
+// GetOrganization returns an organization by id
+func (s *Store) GetOrganization(_ context.Context, orgID types.UUID) (*schema.Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.organizations[orgID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return o.toSchema(), nil
+}
+
+// OrganizationByHost returns the organization serving host
+func (s *Store) OrganizationByHost(_ context.Context, host string) (*schema.Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, o := range s.organizations {
+		if slices.Contains(o.hosts, host) {
+			return o.toSchema(), nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// OrganizationByToken returns the organization whose api token has the hash
+func (s *Store) OrganizationByToken(_ context.Context, tokenHash string) (*schema.Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, o := range s.organizations {
+		if tokenHash != "" && o.tokenHash == tokenHash {
+			return o.toSchema(), nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// ListOrganizations returns every organization by slug, for work that runs in each of them
+func (s *Store) ListOrganizations(_ context.Context) ([]schema.Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orgs := make([]schema.Organization, 0, len(s.organizations))
+	for _, o := range s.organizations {
+		orgs = append(orgs, *o.toSchema())
+	}
+	sort.Slice(orgs, func(i, j int) bool {
+		return *orgs[i].Slug < *orgs[j].Slug
+	})
+	return orgs, nil
+}
+
+// UpdateOrganizationSettings replaces an organization's settings, nil settings fall back to the defaults
+func (s *Store) UpdateOrganizationSettings(_ context.Context, orgID types.UUID, settings schema.OrganizationSettings) (*schema.Organization, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.organizations[orgID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	o.settings = settings
+	return o.toSchema(), nil
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+)
+
+// holdsSeatOf reports whether the appointment takes a seat in the provider's slot: it was booked with the
+// provider or the provider takes part in it
+func (a *appointment) holdsSeatOf(providerID uuid.UUID) bool {
+	return a.providerID == providerID || slices.Contains(a.participants, providerID)
+}
+
+// participantsOf returns the participants sorted like the sql stores read them back
+func participantsOf(participantIDs []types.UUID) []uuid.UUID {
+	participants := slices.Clone(participantIDs)
+	slices.SortFunc(participants, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	return participants
+}
+
+// freeAt reports whether a provider has a slot with a seat left at startTime
+func (s *Store) freeAt(ctx context.Context, providerID uuid.UUID, startTime, now time.Time) bool {
+	if _, ok := s.user(ctx, providerID); !ok {
+		return false
+	}
+	slot, ok := s.slots[keyOf(providerID, startTime)]
+	return ok && s.booked(slot, now, nil) < slot.capacity
+}
+
+// participantsFree reports whether every participant is free at startTime
+func (s *Store) participantsFree(ctx context.Context, participantIDs []types.UUID, startTime, now time.Time) bool {
+	for _, id := range participantIDs {
+		if !s.freeAt(ctx, id, startTime, now) {
+			return false
+		}
+	}
+	return true
+}
+
+// seatsConflict reports why the slots of a provider and the participants at startTime can not all be
+// booked, checking the room with the provider's slot. Providers are checked in the order the sql stores
+// lock them so every store reports the same reason.
+func (s *Store) seatsConflict(providerID uuid.UUID, participants []uuid.UUID, r *room, startTime, now time.Time, excluded map[uuid.UUID]bool) schema.OccurrenceConflictReason {
+	providers := participantsOf(append([]uuid.UUID{providerID}, participants...))
+	for _, id := range providers {
+		var slotRoom *room
+		if id == providerID {
+			slotRoom = r
+		}
+		if reason := s.slotConflict(id, slotRoom, startTime, now, excluded); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
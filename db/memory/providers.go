@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+func (u *user) toProvider() schema.Provider {
+	id := u.id
+	provider := schema.Provider{
+		Id:        (*types.UUID)(&id),
+		Name:      utils.Ptr(u.name),
+		Specialty: u.profile.Specialty,
+		Bio:       u.profile.Bio,
+		Location:  u.profile.Location,
+	}
+	if u.profile.Languages != nil && len(*u.profile.Languages) > 0 {
+		provider.Languages = utils.Ptr(slices.Clone(*u.profile.Languages))
+	}
+	return provider
+}
+
+// GetProvider returns the profile of an active provider
+func (s *Store) GetProvider(ctx context.Context, providerID types.UUID) (*schema.Provider, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.activeUserWithRole(ctx, providerID, string(schema.UserRoleProvider)); err != nil {
+		return nil, sql.ErrNoRows
+	}
+	provider := s.users[providerID].toProvider()
+	return &provider, nil
+}
+
+// UpdateProviderProfile replaces a provider's profile, deactivated providers can still change theirs
+func (s *Store) UpdateProviderProfile(ctx context.Context, providerID types.UUID, profile schema.ProviderProfile) (*schema.Provider, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.userWithRoleExists(ctx, providerID, string(schema.UserRoleProvider)); err != nil {
+		return nil, err
+	}
+	u := s.users[providerID]
+	u.profile = profile
+	if profile.Languages != nil {
+		u.profile.Languages = utils.Ptr(slices.Clone(*profile.Languages))
+	}
+	provider := u.toProvider()
+	return &provider, nil
+}
+
+// SearchProviders lists the active providers matching query by name, there is no text ranking
+func (s *Store) SearchProviders(ctx context.Context, query db.ProviderQuery) ([]schema.Provider, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.Clock.Now()
+	orgID := db.OrganizationID(ctx)
+	terms := db.SearchTerms(query.Text)
+	var matches []*user
+	for _, u := range s.users {
+		if u.orgID != orgID || u.role != string(schema.UserRoleProvider) || !u.deactivatedAt.IsZero() {
+			continue
+		}
+		if !db.MatchesTerms(terms, u.name, deref(u.profile.Bio)) {
+			continue
+		}
+		if query.Specialty != "" && !strings.EqualFold(deref(u.profile.Specialty), query.Specialty) {
+			continue
+		}
+		if query.Language != "" && (u.profile.Languages == nil || !slices.Contains(*u.profile.Languages, query.Language)) {
+			continue
+		}
+		if (query.AvailableFrom != nil || query.AvailableTo != nil) && !s.hasOpenSlot(u, query, now) {
+			continue
+		}
+		matches = append(matches, u)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].name != matches[j].name {
+			return matches[i].name < matches[j].name
+		}
+		return matches[i].id.String() < matches[j].id.String()
+	})
+
+	providers := []schema.Provider{}
+	for _, u := range matches {
+		providers = append(providers, u.toProvider())
+	}
+	return providers, nil
+}
+
+// hasOpenSlot reports whether the provider has a slot with seats left inside the query's range
+func (s *Store) hasOpenSlot(u *user, query db.ProviderQuery, now time.Time) bool {
+	for _, slot := range s.availability {
+		if slot.providerID != u.id {
+			continue
+		}
+		if query.AvailableFrom != nil && slot.startTime.Before(*query.AvailableFrom) {
+			continue
+		}
+		if query.AvailableTo != nil && slot.endTime.After(*query.AvailableTo) {
+			continue
+		}
+		if s.booked(slot, now, nil) < slot.capacity {
+			return true
+		}
+	}
+	return false
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,280 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+type series struct {
+	id         uuid.UUID
+	orgID      uuid.UUID
+	clientID   uuid.UUID
+	providerID uuid.UUID
+	frequency  schema.RecurrenceFrequency
+	interval   int
+	count      int
+}
+
+// seriesAppointments returns the appointments of a series in occurrence order
+func (s *Store) seriesAppointments(seriesID uuid.UUID) []*appointment {
+	var appointments []*appointment
+	for _, appt := range s.appointments {
+		if appt.seriesID.Valid && appt.seriesID.UUID == seriesID {
+			appointments = append(appointments, appt)
+		}
+	}
+	sort.Slice(appointments, func(i, j int) bool {
+		return appointments[i].seriesIndex < appointments[j].seriesIndex
+	})
+	return appointments
+}
+
+// ReserveSeries reserves every occurrence of a recurring series at once
+func (s *Store) ReserveSeries(ctx context.Context, req db.SeriesRequest) (*schema.AppointmentSeries, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.bookable(ctx, append([]uuid.UUID{*req.ClientID, *req.ProviderID}, req.ParticipantIDs...)...); err != nil {
+		return nil, err
+	}
+	r, err := s.bookedRoom(ctx, req.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	checked, err := s.checkIntake(ctx, req.Intake)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.Clock.Now()
+	conflicts := []schema.OccurrenceConflict{}
+	var free []int
+	for i, startTime := range req.StartTimes {
+		if reason := s.seatsConflict(*req.ProviderID, req.ParticipantIDs, r, startTime, now, nil); reason != "" {
+			conflicts = append(conflicts, schema.OccurrenceConflict{
+				Index:     utils.Ptr(i),
+				StartTime: utils.Ptr(startTime),
+				Reason:    &reason,
+			})
+			continue
+		}
+		free = append(free, i)
+	}
+	if len(free) == 0 || (len(conflicts) > 0 && !req.AllowPartial) {
+		return nil, &db.ConflictError{Conflicts: conflicts}
+	}
+
+	interval := 1
+	if req.Recurrence.Interval != nil {
+		interval = *req.Recurrence.Interval
+	}
+
+	sr := &series{
+		id:         uuid.New(),
+		orgID:      db.OrganizationID(ctx),
+		clientID:   *req.ClientID,
+		providerID: *req.ProviderID,
+		frequency:  req.Recurrence.Frequency,
+		interval:   interval,
+		count:      len(req.StartTimes),
+	}
+
+	appointments := make([]schema.Appointment, 0, len(free))
+	for _, i := range free {
+		// The occurrences reserved before count against the client's limits too, which are undone when
+		// one breaks them
+		if err := s.clientLimits(ctx, *req.ClientID, *req.ProviderID, normalize(req.StartTimes[i]), nil, now); err != nil {
+			for _, appt := range appointments {
+				delete(s.appointments, *appt.Id)
+			}
+			return nil, err
+		}
+		appt := s.insertReservedAppointment(ctx, *req.ClientID, *req.ProviderID, r, normalize(req.StartTimes[i]), schema.StatusChangeSourceRequest, now, uuid.NullUUID{UUID: sr.id, Valid: true}, i)
+		appt.participants = participantsOf(req.ParticipantIDs)
+		appt.intake = checked
+		appointments = append(appointments, appt.toSchema())
+	}
+	s.series[sr.id] = sr
+
+	return &schema.AppointmentSeries{
+		Id:           (*types.UUID)(&sr.id),
+		ClientId:     req.ClientID,
+		ProviderId:   req.ProviderID,
+		Recurrence:   &schema.Recurrence{Frequency: sr.frequency, Interval: &interval, Count: sr.count},
+		Appointments: &appointments,
+		Conflicts:    &conflicts,
+	}, nil
+}
+
+// GetSeries returns a series with all of its appointments, including cancelled ones
+func (s *Store) GetSeries(ctx context.Context, seriesID types.UUID) (*schema.AppointmentSeries, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sr, ok := s.series[seriesID]
+	if !ok || sr.orgID != db.OrganizationID(ctx) {
+		return nil, sql.ErrNoRows
+	}
+
+	appointments := []schema.Appointment{}
+	for _, appt := range s.seriesAppointments(sr.id) {
+		appointments = append(appointments, appt.toSchema())
+	}
+
+	id, clientID, providerID := sr.id, sr.clientID, sr.providerID
+	return &schema.AppointmentSeries{
+		Id:           (*types.UUID)(&id),
+		ClientId:     (*types.UUID)(&clientID),
+		ProviderId:   (*types.UUID)(&providerID),
+		Recurrence:   &schema.Recurrence{Frequency: sr.frequency, Interval: utils.Ptr(sr.interval), Count: sr.count},
+		Appointments: &appointments,
+	}, nil
+}
+
+// ConfirmSeries confirms every reservation in a series that has not expired and returns how many were
+func (s *Store) ConfirmSeries(ctx context.Context, seriesID types.UUID) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.Clock.Now()
+	orgID := db.OrganizationID(ctx)
+	var confirmed int64
+	for _, appt := range s.seriesAppointments(seriesID) {
+		if appt.orgID == orgID && appt.canChangeTo(schema.AppointmentStatusConfirmed, now) {
+			appt.setStatus(schema.AppointmentStatusConfirmed, schema.StatusChangeSourceRequest, uuid.NullUUID{}, now)
+			confirmed++
+		}
+	}
+	if confirmed == 0 {
+		return 0, sql.ErrNoRows
+	}
+	return confirmed, nil
+}
+
+// following returns the active appointment and, when withSeries is set, every later active appointment in
+// its series, ordered by start time. Appointments are active as long as they can be cancelled.
+func (s *Store) following(ctx context.Context, appointmentID uuid.UUID, now time.Time, withSeries bool) []*appointment {
+	target, ok := s.appointment(ctx, appointmentID)
+	if !ok {
+		return nil
+	}
+
+	var appointments []*appointment
+	for _, appt := range s.appointments {
+		inSeries := withSeries && target.seriesID.Valid && appt.seriesID == target.seriesID && appt.seriesIndex >= target.seriesIndex
+		if (appt.id == target.id || inSeries) && appt.canChangeTo(schema.AppointmentStatusCancelled, now) {
+			appointments = append(appointments, appt)
+		}
+	}
+	sort.Slice(appointments, func(i, j int) bool {
+		return appointments[i].startTime.Before(appointments[j].startTime)
+	})
+	return appointments
+}
+
+// CancelFollowingAppointments cancels an appointment and every later active appointment in its series. It
+// returns the error of db.TransitionFailure if the appointment can not be cancelled.
+func (s *Store) CancelFollowingAppointments(ctx context.Context, appointmentID types.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.Clock.Now()
+	appointments := s.following(ctx, appointmentID, now, true)
+	if len(appointments) == 0 {
+		return s.transitionFailure(ctx, appointmentID, schema.AppointmentStatusCancelled)
+	}
+	for _, appt := range appointments {
+		appt.setStatus(schema.AppointmentStatusCancelled, schema.StatusChangeSourceRequest, uuid.NullUUID{}, now)
+	}
+	return nil
+}
+
+// RescheduleAppointment moves an active appointment to a new start time. Appointments can be moved as long
+// as they can be cancelled, it returns the error of db.TransitionFailure for one that can not.
+func (s *Store) RescheduleAppointment(ctx context.Context, appointmentID types.UUID, newStart time.Time) ([]schema.Appointment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.reschedule(ctx, s.following(ctx, appointmentID, s.Clock.Now(), false), appointmentID, newStart)
+}
+
+// RescheduleFollowingAppointments moves an active appointment to a new start time and shifts every later
+// active appointment in its series by the same amount
+func (s *Store) RescheduleFollowingAppointments(ctx context.Context, appointmentID types.UUID, newStart time.Time) ([]schema.Appointment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.reschedule(ctx, s.following(ctx, appointmentID, s.Clock.Now(), true), appointmentID, newStart)
+}
+
+func (s *Store) reschedule(ctx context.Context, moving []*appointment, appointmentID uuid.UUID, newStart time.Time) ([]schema.Appointment, error) {
+	var shift time.Duration
+	found := false
+	ids := map[uuid.UUID]bool{}
+	for _, appt := range moving {
+		ids[appt.id] = true
+		if appt.id == appointmentID {
+			shift = newStart.Sub(appt.startTime)
+			found = true
+		}
+	}
+	if !found {
+		return nil, s.transitionFailure(ctx, appointmentID, schema.AppointmentStatusCancelled)
+	}
+
+	now := s.Clock.Now()
+	conflicts := []schema.OccurrenceConflict{}
+	for i, appt := range moving {
+		// Appointments keep their room, which has to be free and at the location of the new slot, and
+		// their participants, who all need a free slot at the new time
+		var r *room
+		if appt.roomID.Valid {
+			r = s.rooms[appt.roomID.UUID]
+		}
+		startTime := appt.startTime.Add(shift)
+		if reason := s.seatsConflict(appt.providerID, appt.participants, r, startTime, now, ids); reason != "" {
+			index := i
+			if appt.seriesID.Valid {
+				index = appt.seriesIndex
+			}
+			conflicts = append(conflicts, schema.OccurrenceConflict{
+				Index:     &index,
+				StartTime: &startTime,
+				Reason:    &reason,
+			})
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, &db.ConflictError{Conflicts: conflicts}
+	}
+
+	previous := make([]appointment, 0, len(moving))
+	for _, appt := range moving {
+		previous = append(previous, *appt)
+		appt.startTime = normalize(appt.startTime.Add(shift))
+		appt.endTime = appt.startTime.Add(db.SlotInterval(ctx))
+		appt.locationID = s.slots[keyOf(appt.providerID, appt.startTime)].locationID
+	}
+
+	// Each moved appointment is checked against the client's other ones at their new times, the move is
+	// undone when one breaks a limit
+	appointments := make([]schema.Appointment, 0, len(moving))
+	for _, appt := range moving {
+		if err := s.clientLimits(ctx, appt.clientID, appt.providerID, appt.startTime, map[uuid.UUID]bool{appt.id: true}, now); err != nil {
+			for i := range moving {
+				*moving[i] = previous[i]
+			}
+			return nil, err
+		}
+		appointments = append(appointments, appt.toSchema())
+	}
+	return appointments, nil
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+)
+
+// UpdateAppointmentStatus moves an appointment along its lifecycle, recording the provider who did and when.
+// It returns sql.ErrNoRows if the appointment is not in the organization, db.ErrNotAppointmentProvider if
+// changedBy is not its provider or a participant and the error of db.CheckTransition if the change is not
+// allowed.
+func (s *Store) UpdateAppointmentStatus(ctx context.Context, appointmentID types.UUID, status schema.AppointmentStatus, changedBy types.UUID) (*schema.Appointment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	appt, ok := s.appointment(ctx, appointmentID)
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	if appt.providerID != changedBy && !slices.Contains(appt.participants, changedBy) {
+		return nil, db.ErrNotAppointmentProvider
+	}
+	now := s.Clock.Now()
+	if err := db.CheckTransition(appt.status, status, appt.createdAt, appt.startTime, now); err != nil {
+		return nil, err
+	}
+
+	appt.statusChangedAt = normalize(now)
+	appt.statusChangedBy = uuid.NullUUID{UUID: changedBy, Valid: true}
+	appt.setStatus(status, schema.StatusChangeSourceProvider, appt.statusChangedBy, now)
+	appointment := appt.toSchema()
+	return &appointment, nil
+}
@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+)
+
+// UpdateUser changes a user's name or email, returning db.ErrEmailTaken if another user has the email
+func (s *Store) UpdateUser(ctx context.Context, userID types.UUID, update db.UserUpdate) (*schema.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.user(ctx, userID)
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	if update.Email != nil && *update.Email != u.email {
+		key := emailKey{orgID: u.orgID, email: *update.Email}
+		if _, taken := s.emails[key]; taken {
+			return nil, db.ErrEmailTaken
+		}
+		delete(s.emails, emailKey{orgID: u.orgID, email: u.email})
+		s.emails[key] = u.id
+		u.email = *update.Email
+	}
+	if update.Name != nil {
+		u.name = *update.Name
+	}
+	return u.toSchema(), nil
+}
+
+// DeactivateUser stops a user from booking or being booked and cancels the waitlist entries still
+// waiting for them. Their appointments are kept. Deactivating a user again keeps the first time.
+func (s *Store) DeactivateUser(ctx context.Context, userID types.UUID) (*schema.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.user(ctx, userID)
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	if u.deactivatedAt.IsZero() {
+		u.deactivatedAt = normalize(s.Clock.Now())
+	}
+	for _, entry := range s.waitlist {
+		if entry.status == schema.WaitlistEntryStatusWaiting && (entry.clientID == u.id || entry.providerID == u.id) {
+			entry.status = schema.WaitlistEntryStatusCancelled
+		}
+	}
+	return u.toSchema(), nil
+}
+
+// DeleteUser removes a user with their availability and waitlist entries. A user who has appointments,
+// even cancelled ones, is not deleted and db.ErrUserHasAppointments is returned so they can be deactivated instead.
+func (s *Store) DeleteUser(ctx context.Context, userID types.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.user(ctx, userID)
+	if !ok {
+		return sql.ErrNoRows
+	}
+	for _, appt := range s.appointments {
+		if appt.clientID == u.id || appt.holdsSeatOf(u.id) {
+			return db.ErrUserHasAppointments
+		}
+	}
+
+	// What the foreign keys cascade to
+	for id, slot := range s.availability {
+		if slot.providerID == u.id {
+			delete(s.availability, id)
+			delete(s.slots, keyOf(slot.providerID, slot.startTime))
+		}
+	}
+	waitlist := s.waitlist[:0]
+	for _, entry := range s.waitlist {
+		if entry.clientID != u.id && entry.providerID != u.id {
+			waitlist = append(waitlist, entry)
+		}
+	}
+	s.waitlist = waitlist
+	for _, g := range s.groups {
+		g.members = slices.DeleteFunc(g.members, func(m groupMember) bool { return m.providerID == u.id })
+	}
+	delete(s.emails, emailKey{orgID: u.orgID, email: u.email})
+	delete(s.users, u.id)
+	return nil
+}
@@ -0,0 +1,231 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+var errInvalidWindow = errors.New("waitlist window must start before it ends")
+
+type waitlistEntry struct {
+	id            uuid.UUID
+	orgID         uuid.UUID
+	clientID      uuid.UUID
+	providerID    uuid.UUID
+	windowStart   time.Time
+	windowEnd     time.Time
+	status        schema.WaitlistEntryStatus
+	appointmentID uuid.NullUUID
+	createdAt     time.Time
+}
+
+func (w *waitlistEntry) toSchema() schema.WaitlistEntry {
+	id, clientID, providerID := w.id, w.clientID, w.providerID
+	windowStart, windowEnd, status, createdAt := w.windowStart, w.windowEnd, w.status, w.createdAt
+	return schema.WaitlistEntry{
+		Id:          (*types.UUID)(&id),
+		ClientId:    (*types.UUID)(&clientID),
+		ProviderId:  (*types.UUID)(&providerID),
+		WindowStart: &windowStart,
+		WindowEnd:   &windowEnd,
+		Status:      &status,
+		CreatedAt:   &createdAt,
+	}
+}
+
+// freeSlots returns the slots with a free seat in a provider's window that start at or after earliestStart,
+// earliest first
+func (s *Store) freeSlots(providerID uuid.UUID, windowStart, windowEnd, earliestStart, now time.Time) []*availability {
+	var free []*availability
+	for _, slot := range s.availability {
+		if slot.providerID != providerID || slot.startTime.Before(windowStart) || slot.startTime.Before(earliestStart) || slot.endTime.After(windowEnd) {
+			continue
+		}
+		if s.booked(slot, now, nil) < slot.capacity {
+			free = append(free, slot)
+		}
+	}
+	sort.Slice(free, func(i, j int) bool {
+		return free[i].startTime.Before(free[j].startTime)
+	})
+	return free
+}
+
+// JoinWaitlist adds a client to a provider's waitlist for a time window. Only slots starting at or
+// after earliestStart are considered bookable.
+func (s *Store) JoinWaitlist(ctx context.Context, clientID, providerID types.UUID, windowStart, windowEnd, earliestStart time.Time) (*schema.WaitlistEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.activeUserWithRole(ctx, providerID, string(schema.UserRoleProvider)); err != nil {
+		return nil, err
+	}
+	if err := s.activeUserWithRole(ctx, clientID, string(schema.UserRoleClient)); err != nil {
+		return nil, err
+	}
+
+	now := s.Clock.Now()
+	if len(s.freeSlots(providerID, windowStart, windowEnd, earliestStart, now)) > 0 {
+		return nil, db.ErrSlotsAvailable
+	}
+	if !windowStart.Before(windowEnd) {
+		return nil, errInvalidWindow
+	}
+
+	entry := &waitlistEntry{
+		id:          uuid.New(),
+		orgID:       db.OrganizationID(ctx),
+		clientID:    clientID,
+		providerID:  providerID,
+		windowStart: normalize(windowStart),
+		windowEnd:   normalize(windowEnd),
+		status:      schema.WaitlistEntryStatusWaiting,
+		createdAt:   normalize(now),
+	}
+	s.waitlist = append(s.waitlist, entry)
+
+	position := 0
+	for _, w := range s.waitlist {
+		if w.providerID == providerID && w.status == schema.WaitlistEntryStatusWaiting {
+			position++
+		}
+	}
+
+	result := entry.toSchema()
+	result.Position = &position
+	return &result, nil
+}
+
+// LeaveWaitlist removes a waiting client from the waitlist
+func (s *Store) LeaveWaitlist(ctx context.Context, entryID types.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orgID := db.OrganizationID(ctx)
+	for _, entry := range s.waitlist {
+		if entry.id == entryID && entry.orgID == orgID && entry.status == schema.WaitlistEntryStatusWaiting {
+			entry.status = schema.WaitlistEntryStatusCancelled
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+// GetProviderWaitlist returns the offered and waiting entries for a provider in the order they are served
+func (s *Store) GetProviderWaitlist(ctx context.Context, providerID types.UUID) ([]schema.WaitlistEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.userWithRoleExists(ctx, providerID, string(schema.UserRoleProvider)); err != nil {
+		return nil, err
+	}
+
+	offered := []schema.WaitlistEntry{}
+	waiting := []schema.WaitlistEntry{}
+	for _, w := range s.waitlist {
+		if w.providerID != providerID {
+			continue
+		}
+		entry := w.toSchema()
+		if w.appointmentID.Valid {
+			entry.AppointmentId = (*types.UUID)(&w.appointmentID.UUID)
+			if appt, ok := s.appointments[w.appointmentID.UUID]; ok {
+				entry.HoldExpiresAt = utils.Ptr(appt.createdAt.Add(db.ReservationHoldDuration))
+			}
+		}
+		switch w.status {
+		case schema.WaitlistEntryStatusOffered:
+			offered = append(offered, entry)
+		case schema.WaitlistEntryStatusWaiting:
+			entry.Position = utils.Ptr(len(waiting) + 1)
+			waiting = append(waiting, entry)
+		}
+	}
+	return append(offered, waiting...), nil
+}
+
+// PromoteWaitlist settles earlier offers and then walks every waiting entry first-come-first-served,
+// placing a hold on the earliest free slot in its window that starts at or after earliestStart.
+func (s *Store) PromoteWaitlist(ctx context.Context, earliestStart time.Time) ([]db.WaitlistOffer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.Clock.Now()
+	orgID := db.OrganizationID(ctx)
+
+	// Offers are done once their hold is confirmed, cancelled or expired
+	for _, entry := range s.waitlist {
+		if entry.orgID != orgID || entry.status != schema.WaitlistEntryStatusOffered || !entry.appointmentID.Valid {
+			continue
+		}
+		appt, ok := s.appointments[entry.appointmentID.UUID]
+		if !ok || (appt.status == schema.AppointmentStatusReserved && appt.active(now)) {
+			continue
+		}
+		switch appt.status {
+		case schema.AppointmentStatusConfirmed:
+			entry.status = schema.WaitlistEntryStatusFulfilled
+		case schema.AppointmentStatusCancelled:
+			entry.status = schema.WaitlistEntryStatusCancelled
+		default:
+			entry.status = schema.WaitlistEntryStatusExpired
+		}
+	}
+
+	var offers []db.WaitlistOffer
+	for _, entry := range s.waitlist {
+		if entry.orgID != orgID || entry.status != schema.WaitlistEntryStatusWaiting {
+			continue
+		}
+		// Windows that can no longer be booked will never be served
+		if !entry.windowEnd.After(earliestStart) {
+			entry.status = schema.WaitlistEntryStatusExpired
+			continue
+		}
+
+		// Entries of deactivated users keep waiting
+		if s.bookable(ctx, entry.clientID, entry.providerID) != nil {
+			continue
+		}
+		// A slot overlapping another of the client's appointments is passed over, a later one may not
+		var startTime time.Time
+		for _, slot := range s.freeSlots(entry.providerID, entry.windowStart, entry.windowEnd, earliestStart, now) {
+			if err := s.clientLimits(ctx, entry.clientID, entry.providerID, slot.startTime, nil, now); err == nil {
+				startTime = slot.startTime
+				break
+			}
+		}
+		if startTime.IsZero() {
+			continue
+		}
+
+		appt := s.insertReservedAppointment(ctx, entry.clientID, entry.providerID, nil, startTime, schema.StatusChangeSourceWaitlist, now, uuid.NullUUID{}, 0)
+		entry.status = schema.WaitlistEntryStatusOffered
+		entry.appointmentID = uuid.NullUUID{UUID: appt.id, Valid: true}
+
+		offer := db.WaitlistOffer{Entry: entry.toSchema(), Appointment: appt.toSchema()}
+		offer.Entry.AppointmentId = offer.Appointment.Id
+		offer.Entry.HoldExpiresAt = utils.Ptr(now.Add(db.ReservationHoldDuration))
+		offers = append(offers, offer)
+	}
+
+	for _, offer := range offers {
+		s.Logger.Info("Offered slot to waitlisted client",
+			slog.String("waitlist_entry_id", offer.Entry.Id.String()),
+			slog.String("appointment_id", offer.Appointment.Id.String()),
+			slog.Time("start_time", *offer.Appointment.StartTime),
+		)
+	}
+
+	return offers, nil
+}
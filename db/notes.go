@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// appointmentProvider returns sql.ErrNoRows if the appointment is not in the organization and
+// ErrNotAppointmentProvider unless the user is its provider or a participant. The appointment is locked
+// until tx ends.
+func appointmentProvider(ctx context.Context, tx *sql.Tx, appointmentID, userID types.UUID) error {
+	var provider bool
+	err := tx.QueryRowContext(ctx, `
+	SELECT appt.provider_id = $2 OR EXISTS (
+	  SELECT 1 FROM appointment_participants ap WHERE ap.appointment_id = appt.id AND ap.provider_id = $2
+	)
+	FROM appointments appt
+	WHERE appt.id = $1
+	FOR UPDATE
+`, appointmentID.String(), userID.String()).Scan(&provider)
+	if err != nil {
+		return err
+	}
+	if !provider {
+		return ErrNotAppointmentProvider
+	}
+	return nil
+}
+
+func newAppointmentNote(appointmentID types.UUID, version int, body string, authorID types.UUID, createdAt time.Time) schema.AppointmentNote {
+	return schema.AppointmentNote{
+		AppointmentId: &appointmentID,
+		Version:       utils.Ptr(version),
+		Body:          utils.Ptr(body),
+		AuthorId:      &authorID,
+		CreatedAt:     &createdAt,
+	}
+}
+
+// SaveAppointmentNote saves the providers' notes on an appointment as its next version. It returns
+// sql.ErrNoRows if the appointment is not in the organization and ErrNotAppointmentProvider if the author
+// is not its provider or a participant.
+func (db *Database) SaveAppointmentNote(ctx context.Context, appointmentID, authorID types.UUID, body string) (*schema.AppointmentNote, error) {
+	ctx, span := tracer.Start(ctx, "db.SaveAppointmentNote")
+	defer span.End()
+
+	var note schema.AppointmentNote
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		// Locking the appointment numbers concurrent saves one after the other
+		if err := appointmentProvider(ctx, tx, appointmentID, authorID); err != nil {
+			return err
+		}
+		var version int
+		var createdAt time.Time
+		err := tx.QueryRowContext(ctx, `
+		INSERT INTO appointment_notes (appointment_id, version, body, author_id, created_at)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4
+		FROM appointment_notes
+		WHERE appointment_id = $1
+		RETURNING version, created_at
+	`, appointmentID.String(), body, authorID.String(), db.Clock.Now()).Scan(&version, &createdAt)
+		if err != nil {
+			return err
+		}
+		note = newAppointmentNote(appointmentID, version, body, authorID, createdAt)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// ListAppointmentNotes returns every version of the providers' notes on an appointment, latest first. It
+// returns sql.ErrNoRows if the appointment is not in the organization.
+func (db *Database) ListAppointmentNotes(ctx context.Context, appointmentID types.UUID) ([]schema.AppointmentNote, error) {
+	ctx, span := tracer.Start(ctx, "db.ListAppointmentNotes")
+	defer span.End()
+
+	notes := []schema.AppointmentNote{}
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		var id string
+		if err := tx.QueryRowContext(ctx, `SELECT id FROM appointments WHERE id = $1`, appointmentID.String()).Scan(&id); err != nil {
+			return err
+		}
+		rows, err := tx.QueryContext(ctx, `
+		SELECT version, body, author_id, created_at
+		FROM appointment_notes
+		WHERE appointment_id = $1
+		ORDER BY version DESC
+	`, appointmentID.String())
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var version int
+			var body string
+			var authorID uuid.UUID
+			var createdAt time.Time
+			if err := rows.Scan(&version, &body, &authorID, &createdAt); err != nil {
+				return err
+			}
+			notes = append(notes, newAppointmentNote(appointmentID, version, body, authorID, createdAt))
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
@@ -0,0 +1,268 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// ErrOrganizationExists is returned when another organization already has the slug, a host or the token
+var ErrOrganizationExists = errors.New("organization slug, host or token is already in use")
+
+// DefaultOrganizationID is the organization everything created before there were organizations belongs to,
+// and the one a context without an organization works in
+var DefaultOrganizationID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// NewOrganization describes an organization to create
+type NewOrganization struct {
+	Name  string
+	Slug  string
+	Hosts []string
+	// TokenHash is HashToken of the organization's api token, empty if it has none
+	TokenHash string
+	Settings  schema.OrganizationSettings
+}
+
+// HashToken is how api tokens are stored and looked up, the tokens themselves are never kept
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+type organizationKey struct{}
+
+// WithOrganization returns a context that store methods scope to org
+func WithOrganization(ctx context.Context, org schema.Organization) context.Context {
+	return context.WithValue(ctx, organizationKey{}, org)
+}
+
+// OrganizationFrom returns the organization set by WithOrganization
+func OrganizationFrom(ctx context.Context) (schema.Organization, bool) {
+	org, ok := ctx.Value(organizationKey{}).(schema.Organization)
+	return org, ok
+}
+
+// OrganizationID returns the id of the organization in ctx, DefaultOrganizationID if there is none
+func OrganizationID(ctx context.Context) uuid.UUID {
+	if org, ok := OrganizationFrom(ctx); ok && org.Id != nil {
+		return *org.Id
+	}
+	return DefaultOrganizationID
+}
+
+// SlotInterval returns the length of availability slots in the organization in ctx
+func SlotInterval(ctx context.Context) time.Duration {
+	if org, ok := OrganizationFrom(ctx); ok && org.Settings != nil && org.Settings.SlotIntervalMinutes != nil {
+		return time.Duration(*org.Settings.SlotIntervalMinutes) * time.Minute
+	}
+	return GetAvailabilityInterval()
+}
+
+// tenantRole is what tenant transactions run as, the row level security policies on every tenant table
+// only show it the rows of the organization in app.organization_id
+const tenantRole = "reservation_tenant"
+
+// begin starts a transaction that can only read and write the rows of the organization in ctx. Rows it
+// inserts are given that organization by the column defaults.
+func (db *Database) begin(ctx context.Context) (*sql.Tx, error) {
+	tx, err := db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, `SELECT set_config('role', $1, true), set_config('app.organization_id', $2, true)`,
+		tenantRole, OrganizationID(ctx).String())
+	if err != nil {
+		db.rollback(tx)
+		return nil, err
+	}
+	return tx, nil
+}
+
+// inTenant runs fn in a transaction from begin, committing it if fn succeeds
+func (db *Database) inTenant(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.rollback(tx)
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// organizationQuery selects the columns scanOrganization reads
+const organizationQuery = `
+	SELECT o.id, o.name, o.slug, o.slot_interval_minutes, o.lead_time_minutes, o.max_active_holds,
+	  o.max_future_appointments, o.max_future_appointments_per_provider, o.allow_overlapping_appointments, o.max_no_shows,
+	  ARRAY(SELECT h.host FROM organization_hosts h WHERE h.organization_id = o.id ORDER BY h.host)
+	FROM organizations o
+`
+
+func scanOrganization(row rowScanner) (*schema.Organization, error) {
+	var id uuid.UUID
+	var name, slug string
+	var slotInterval, leadTime, maxHolds, maxFuture, maxPerProvider, maxNoShows sql.NullInt64
+	var allowOverlapping sql.NullBool
+	var hosts []string
+	if err := row.Scan(&id, &name, &slug, &slotInterval, &leadTime, &maxHolds, &maxFuture, &maxPerProvider,
+		&allowOverlapping, &maxNoShows, pq.Array(&hosts)); err != nil {
+		return nil, err
+	}
+
+	org := &schema.Organization{
+		Id:       (*types.UUID)(&id),
+		Name:     utils.Ptr(name),
+		Slug:     utils.Ptr(slug),
+		Hosts:    utils.Ptr(append([]string{}, hosts...)),
+		Settings: &schema.OrganizationSettings{},
+	}
+	if slotInterval.Valid {
+		org.Settings.SlotIntervalMinutes = utils.Ptr(int(slotInterval.Int64))
+	}
+	if leadTime.Valid {
+		org.Settings.LeadTimeMinutes = utils.Ptr(int(leadTime.Int64))
+	}
+	if maxHolds.Valid {
+		org.Settings.MaxActiveHolds = utils.Ptr(int(maxHolds.Int64))
+	}
+	if maxFuture.Valid {
+		org.Settings.MaxFutureAppointments = utils.Ptr(int(maxFuture.Int64))
+	}
+	if maxPerProvider.Valid {
+		org.Settings.MaxFutureAppointmentsPerProvider = utils.Ptr(int(maxPerProvider.Int64))
+	}
+	if allowOverlapping.Valid {
+		org.Settings.AllowOverlappingAppointments = utils.Ptr(allowOverlapping.Bool)
+	}
+	if maxNoShows.Valid {
+		org.Settings.MaxNoShows = utils.Ptr(int(maxNoShows.Int64))
+	}
+	return org, nil
+}
+
+// CreateOrganization adds an organization, returning ErrOrganizationExists if its slug, a host or its token is taken
+func (db *Database) CreateOrganization(ctx context.Context, org NewOrganization) (*schema.Organization, error) {
+	ctx, span := tracer.Start(ctx, "db.CreateOrganization")
+	defer span.End()
+
+	tx, err := db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer db.rollback(tx)
+
+	id := uuid.New()
+	now := db.Clock.Now()
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO organizations (id, name, slug, token_hash, slot_interval_minutes, lead_time_minutes, max_active_holds,
+	  max_future_appointments, max_future_appointments_per_provider, allow_overlapping_appointments, max_no_shows, created_at, updated_at)
+	VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $8, $9, $10, $11, $12, $7, $7)
+`, id, org.Name, org.Slug, org.TokenHash, org.Settings.SlotIntervalMinutes, org.Settings.LeadTimeMinutes, now,
+		org.Settings.MaxActiveHolds, org.Settings.MaxFutureAppointments, org.Settings.MaxFutureAppointmentsPerProvider,
+		org.Settings.AllowOverlappingAppointments, org.Settings.MaxNoShows)
+	if isPQError(err, uniqueViolation) {
+		return nil, ErrOrganizationExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, host := range org.Hosts {
+		_, err = tx.ExecContext(ctx, `INSERT INTO organization_hosts (host, organization_id) VALUES ($1, $2)`, host, id)
+		if isPQError(err, uniqueViolation) {
+			return nil, ErrOrganizationExists
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := scanOrganization(tx.QueryRowContext(ctx, organizationQuery+`WHERE o.id = $1`, id))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetOrganization returns an organization by id
+func (db *Database) GetOrganization(ctx context.Context, orgID types.UUID) (*schema.Organization, error) {
+	ctx, span := tracer.Start(ctx, "db.GetOrganization")
+	defer span.End()
+
+	return scanOrganization(db.Conn.QueryRowContext(ctx, organizationQuery+`WHERE o.id = $1`, orgID.String()))
+}
+
+// OrganizationByHost returns the organization serving host
+func (db *Database) OrganizationByHost(ctx context.Context, host string) (*schema.Organization, error) {
+	ctx, span := tracer.Start(ctx, "db.OrganizationByHost")
+	defer span.End()
+
+	return scanOrganization(db.Conn.QueryRowContext(ctx, organizationQuery+`
+	JOIN organization_hosts oh ON oh.organization_id = o.id
+	WHERE oh.host = $1
+`, host))
+}
+
+// OrganizationByToken returns the organization whose api token has the hash
+func (db *Database) OrganizationByToken(ctx context.Context, tokenHash string) (*schema.Organization, error) {
+	ctx, span := tracer.Start(ctx, "db.OrganizationByToken")
+	defer span.End()
+
+	return scanOrganization(db.Conn.QueryRowContext(ctx, organizationQuery+`WHERE o.token_hash = $1`, tokenHash))
+}
+
+// ListOrganizations returns every organization by slug, for work that runs in each of them
+func (db *Database) ListOrganizations(ctx context.Context) ([]schema.Organization, error) {
+	ctx, span := tracer.Start(ctx, "db.ListOrganizations")
+	defer span.End()
+
+	rows, err := db.Conn.QueryContext(ctx, organizationQuery+`ORDER BY o.slug`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orgs []schema.Organization
+	for rows.Next() {
+		org, err := scanOrganization(rows)
+		if err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, *org)
+	}
+	return orgs, rows.Err()
+}
+
+// UpdateOrganizationSettings replaces an organization's settings, nil settings fall back to the defaults
+func (db *Database) UpdateOrganizationSettings(ctx context.Context, orgID types.UUID, settings schema.OrganizationSettings) (*schema.Organization, error) {
+	ctx, span := tracer.Start(ctx, "db.UpdateOrganizationSettings")
+	defer span.End()
+
+	var id uuid.UUID
+	err := db.Conn.QueryRowContext(ctx, `
+	UPDATE organizations
+	SET slot_interval_minutes = $2, lead_time_minutes = $3, updated_at = $4, max_active_holds = $5,
+	  max_future_appointments = $6, max_future_appointments_per_provider = $7, allow_overlapping_appointments = $8,
+	  max_no_shows = $9
+	WHERE id = $1
+	RETURNING id
+`, orgID.String(), settings.SlotIntervalMinutes, settings.LeadTimeMinutes, db.Clock.Now(), settings.MaxActiveHolds,
+		settings.MaxFutureAppointments, settings.MaxFutureAppointmentsPerProvider, settings.AllowOverlappingAppointments, settings.MaxNoShows).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+	return db.GetOrganization(ctx, orgID)
+}
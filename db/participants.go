@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+)
+
+// seatOf matches the appointments appt that take a seat in provider's slot: the ones booked with the
+// provider and the ones the provider takes part in
+func seatOf(provider string) string {
+	return `(appt.provider_id = ` + provider + ` OR appt.id IN (
+	  SELECT ap.appointment_id FROM appointment_participants ap WHERE ap.provider_id = ` + provider + `
+	))`
+}
+
+// freeSlots counts the providers in the uuid array providers who have a slot with a seat left at startTime,
+// reservations made before holdCutoff have expired
+func freeSlots(providers, startTime, holdCutoff string) string {
+	return `(
+	  SELECT COUNT(*)
+	  FROM availability fa
+	  WHERE fa.provider_id = ANY(` + providers + `::uuid[])
+	    AND fa.start_time = ` + startTime + `
+	    AND (
+	      SELECT COUNT(*) FROM appointments appt
+	      WHERE ` + seatOf("fa.provider_id") + `
+	        AND appt.start_time = fa.start_time
+	        AND (
+	          appt.status = 'confirmed' OR
+	          (appt.status = 'reserved' AND appt.created_at > ` + holdCutoff + `)
+	        )
+	    ) < fa.capacity
+	)`
+}
+
+// uuidStrings converts ids for pq.Array
+func uuidStrings(ids []types.UUID) []string {
+	strs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strs = append(strs, id.String())
+	}
+	return strs
+}
+
+// uuidPtrs converts ids for bookable
+func uuidPtrs(ids []types.UUID) []*types.UUID {
+	ptrs := make([]*types.UUID, 0, len(ids))
+	for i := range ids {
+		ptrs = append(ptrs, &ids[i])
+	}
+	return ptrs
+}
+
+// seatsConflict reports why the slots of a provider and the participants at startTime can not all be
+// booked, checking the room with the provider's slot. Slots are locked in provider id order so bookings
+// that share providers wait for each other instead of deadlocking.
+func seatsConflict(ctx context.Context, tx *sql.Tx, providerID string, participantIDs []types.UUID, room *bookedRoom, startTime time.Time, excluded []string, now time.Time) (schema.OccurrenceConflictReason, error) {
+	providers := append([]string{providerID}, uuidStrings(participantIDs)...)
+	slices.Sort(providers)
+	for _, id := range providers {
+		var slotRoom *bookedRoom
+		if id == providerID {
+			slotRoom = room
+		}
+		reason, err := slotConflict(ctx, tx, id, slotRoom, startTime, excluded, now)
+		if err != nil || reason != "" {
+			return reason, err
+		}
+	}
+	return "", nil
+}
+
+// insertParticipants records the participants of a newly reserved appointment
+func insertParticipants(ctx context.Context, tx *sql.Tx, appointment *schema.Appointment, participantIDs []types.UUID, now time.Time) error {
+	if len(participantIDs) == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, `
+	INSERT INTO appointment_participants (appointment_id, provider_id, created_at)
+	SELECT $1::uuid, unnest($2::uuid[]), $3::timestamptz
+`, appointment.Id.String(), pq.Array(uuidStrings(participantIDs)), now)
+	if err != nil {
+		return err
+	}
+	// In the order they are read back in
+	participants := slices.Clone(participantIDs)
+	slices.SortFunc(participants, func(a, b types.UUID) int { return strings.Compare(a.String(), b.String()) })
+	appointment.ParticipantIds = &participants
+	return nil
+}
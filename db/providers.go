@@ -0,0 +1,203 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// ProviderQuery filters the provider directory, zero fields do not filter
+type ProviderQuery struct {
+	// Text holds words that must all appear in the provider's name or bio
+	Text      string
+	Specialty string
+	Language  string
+	// AvailableFrom and AvailableTo keep the providers with a bookable slot inside the range
+	AvailableFrom *time.Time
+	AvailableTo   *time.Time
+}
+
+// SearchTerms splits text into the lowercase words a search matches, the way the simple text search
+// configuration in postgres does
+func SearchTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// MatchesTerms reports whether every term is one of the words in texts
+func MatchesTerms(terms []string, texts ...string) bool {
+	words := map[string]bool{}
+	for _, text := range texts {
+		for _, word := range SearchTerms(text) {
+			words[word] = true
+		}
+	}
+	for _, term := range terms {
+		if !words[term] {
+			return false
+		}
+	}
+	return true
+}
+
+// providerColumns are the columns scanProvider reads, in order
+const providerColumns = `id, name, specialty, bio, languages, location`
+
+func scanProvider(row rowScanner) (*schema.Provider, error) {
+	var id uuid.UUID
+	var name string
+	var specialty, bio, location sql.NullString
+	var languages []string
+	if err := row.Scan(&id, &name, &specialty, &bio, pq.Array(&languages), &location); err != nil {
+		return nil, err
+	}
+
+	provider := &schema.Provider{
+		Id:   (*types.UUID)(&id),
+		Name: utils.Ptr(name),
+	}
+	if specialty.Valid {
+		provider.Specialty = &specialty.String
+	}
+	if bio.Valid {
+		provider.Bio = &bio.String
+	}
+	if len(languages) > 0 {
+		provider.Languages = &languages
+	}
+	if location.Valid {
+		provider.Location = &location.String
+	}
+	return provider, nil
+}
+
+// GetProvider returns the profile of an active provider
+func (db *Database) GetProvider(ctx context.Context, providerID types.UUID) (*schema.Provider, error) {
+	ctx, span := tracer.Start(ctx, "db.GetProvider")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.rollback(tx)
+
+	return scanProvider(tx.QueryRowContext(ctx, `
+	SELECT `+providerColumns+`
+	FROM users
+	WHERE id = $1
+	  AND role = 'provider'
+	  AND deactivated_at IS NULL
+`, providerID.String()))
+}
+
+// UpdateProviderProfile replaces a provider's profile, deactivated providers can still change theirs
+func (db *Database) UpdateProviderProfile(ctx context.Context, providerID types.UUID, profile schema.ProviderProfile) (*schema.Provider, error) {
+	ctx, span := tracer.Start(ctx, "db.UpdateProviderProfile")
+	defer span.End()
+
+	languages := []string{}
+	if profile.Languages != nil {
+		languages = *profile.Languages
+	}
+	var provider *schema.Provider
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		var err error
+		provider, err = scanProvider(tx.QueryRowContext(ctx, `
+		UPDATE users
+		SET specialty = $2, bio = $3, languages = $4, location = $5, updated_at = $6
+		WHERE id = $1
+		  AND role = 'provider'
+		RETURNING `+providerColumns+`
+	`, providerID.String(), profile.Specialty, profile.Bio, pq.Array(languages), profile.Location, db.Clock.Now()))
+		return err
+	})
+	return provider, err
+}
+
+// SearchProviders lists the active providers matching query. Text matches are ranked best first,
+// everything else is ordered by name.
+func (db *Database) SearchProviders(ctx context.Context, query ProviderQuery) ([]schema.Provider, error) {
+	ctx, span := tracer.Start(ctx, "db.SearchProviders")
+	defer span.End()
+
+	sqlQuery := `
+	SELECT ` + providerColumns + `
+	FROM users u
+	WHERE role = 'provider'
+	  AND deactivated_at IS NULL
+	`
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	order := "name, id"
+	if terms := SearchTerms(query.Text); len(terms) > 0 {
+		tsQuery := fmt.Sprintf("plainto_tsquery('simple', %s)", arg(strings.Join(terms, " ")))
+		sqlQuery += " AND search @@ " + tsQuery
+		order = fmt.Sprintf("ts_rank(search, %s) DESC, %s", tsQuery, order)
+	}
+	if query.Specialty != "" {
+		sqlQuery += " AND LOWER(specialty) = LOWER(" + arg(query.Specialty) + ")"
+	}
+	if query.Language != "" {
+		sqlQuery += " AND languages @> ARRAY[" + arg(query.Language) + "]::text[]"
+	}
+	if query.AvailableFrom != nil || query.AvailableTo != nil {
+		// Same seat count as GetAvailableAppointments
+		slots := `
+	  AND EXISTS (
+	    SELECT 1
+	    FROM availability a
+	    WHERE a.provider_id = u.id
+	      AND a.capacity > (
+	        SELECT COUNT(*)
+	        FROM appointments appt
+	        WHERE ` + seatOf("a.provider_id") + `
+	          AND appt.start_time = a.start_time
+	          AND (appt.status = 'confirmed' OR (appt.status = 'reserved' AND appt.created_at > ` + arg(holdCutoff(db.Clock.Now())) + `))
+	      )`
+		if query.AvailableFrom != nil {
+			slots += " AND a.start_time >= " + arg(*query.AvailableFrom)
+		}
+		if query.AvailableTo != nil {
+			slots += " AND a.end_time <= " + arg(*query.AvailableTo)
+		}
+		sqlQuery += slots + ")"
+	}
+	sqlQuery += " ORDER BY " + order
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.rollback(tx)
+
+	rows, err := tx.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	providers := []schema.Provider{}
+	for rows.Next() {
+		provider, err := scanProvider(rows)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, *provider)
+	}
+	return providers, rows.Err()
+}
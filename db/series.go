@@ -0,0 +1,459 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// ConflictError is returned when some occurrences of a series can not be booked
+type ConflictError struct {
+	Conflicts []schema.OccurrenceConflict
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%d occurrences are not available", len(e.Conflicts))
+}
+
+// SeriesRequest describes a recurring series of appointments to reserve
+type SeriesRequest struct {
+	ClientID   *types.UUID
+	ProviderID *types.UUID
+	// ParticipantIDs are the other providers who take part in every occurrence
+	ParticipantIDs []types.UUID
+	// RoomID is booked together with the provider for every occurrence, if it is not nil
+	RoomID     *types.UUID
+	Recurrence schema.Recurrence
+	StartTimes []time.Time
+	// AllowPartial reserves the free occurrences instead of failing when some conflict
+	AllowPartial bool
+	// Intake is stored with every occurrence, if it is not nil
+	Intake *Intake
+}
+
+// ReserveSeries reserves every occurrence of a recurring series in one transaction
+func (db *Database) ReserveSeries(ctx context.Context, req SeriesRequest) (*schema.AppointmentSeries, error) {
+	ctx, span := tracer.Start(ctx, "db.ReserveSeries")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.rollback(tx)
+
+	if err := lockClient(ctx, tx, req.ClientID); err != nil {
+		return nil, err
+	}
+	if err := bookable(ctx, tx, append([]*types.UUID{req.ClientID, req.ProviderID}, uuidPtrs(req.ParticipantIDs)...)...); err != nil {
+		return nil, err
+	}
+	room, err := lockRoom(ctx, tx, req.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := db.Clock.Now()
+	conflicts := []schema.OccurrenceConflict{}
+	var free []int
+	for i, startTime := range req.StartTimes {
+		reason, err := seatsConflict(ctx, tx, req.ProviderID.String(), req.ParticipantIDs, room, startTime, nil, now)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			conflicts = append(conflicts, schema.OccurrenceConflict{
+				Index:     utils.Ptr(i),
+				StartTime: utils.Ptr(startTime),
+				Reason:    &reason,
+			})
+			continue
+		}
+		free = append(free, i)
+	}
+	if len(free) == 0 || (len(conflicts) > 0 && !req.AllowPartial) {
+		return nil, &ConflictError{Conflicts: conflicts}
+	}
+
+	interval := 1
+	if req.Recurrence.Interval != nil {
+		interval = *req.Recurrence.Interval
+	}
+
+	seriesID := uuid.New()
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO appointment_series (id, client_id, provider_id, frequency, repeat_interval, occurrences, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+`, seriesID, req.ClientID.String(), req.ProviderID.String(), string(req.Recurrence.Frequency), interval, len(req.StartTimes), now)
+	if err != nil {
+		return nil, err
+	}
+
+	appointments := make([]schema.Appointment, 0, len(free))
+	for _, i := range free {
+		// The occurrences reserved before count against the client's limits too
+		if err := clientLimits(ctx, tx, req.ClientID, req.ProviderID, req.StartTimes[i], nil, now); err != nil {
+			return nil, err
+		}
+		appointment, err := insertReservedAppointment(ctx, tx, req.ClientID, req.ProviderID, req.RoomID, &req.StartTimes[i], &seriesLink{ID: seriesID, Index: i}, schema.StatusChangeSourceRequest, now)
+		if err != nil {
+			return nil, err
+		}
+		if err := insertParticipants(ctx, tx, appointment, req.ParticipantIDs, now); err != nil {
+			return nil, err
+		}
+		if err := saveIntake(ctx, tx, appointment, req.Intake); err != nil {
+			return nil, err
+		}
+		appointments = append(appointments, *appointment)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &schema.AppointmentSeries{
+		Id:           (*types.UUID)(&seriesID),
+		ClientId:     req.ClientID,
+		ProviderId:   req.ProviderID,
+		Recurrence:   &schema.Recurrence{Frequency: req.Recurrence.Frequency, Interval: &interval, Count: len(req.StartTimes)},
+		Appointments: &appointments,
+		Conflicts:    &conflicts,
+	}, nil
+}
+
+// GetSeries returns a series with all of its appointments, including cancelled ones
+func (db *Database) GetSeries(ctx context.Context, seriesID types.UUID) (*schema.AppointmentSeries, error) {
+	ctx, span := tracer.Start(ctx, "db.GetSeries")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.rollback(tx)
+
+	var id, clientID, providerID uuid.UUID
+	var frequency string
+	var interval, count int
+	err = tx.QueryRowContext(ctx, `
+	SELECT id, client_id, provider_id, frequency, repeat_interval, occurrences
+	FROM appointment_series
+	WHERE id = $1
+`, seriesID.String()).Scan(&id, &clientID, &providerID, &frequency, &interval, &count)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+	SELECT `+appointmentColumns+`
+	FROM appointments appt
+	WHERE appt.series_id = $1
+	ORDER BY appt.series_index
+`, seriesID.String())
+	if err != nil {
+		return nil, err
+	}
+	appointments, err := scanAppointments(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.AppointmentSeries{
+		Id:           (*types.UUID)(&id),
+		ClientId:     (*types.UUID)(&clientID),
+		ProviderId:   (*types.UUID)(&providerID),
+		Recurrence:   &schema.Recurrence{Frequency: schema.RecurrenceFrequency(frequency), Interval: &interval, Count: count},
+		Appointments: &appointments,
+	}, nil
+}
+
+// ConfirmSeries confirms every reservation in a series that has not expired and returns how many were
+func (db *Database) ConfirmSeries(ctx context.Context, seriesID types.UUID) (int64, error) {
+	ctx, span := tracer.Start(ctx, "db.ConfirmSeries")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer db.rollback(tx)
+
+	now := db.Clock.Now()
+	change := statusChange{status: schema.AppointmentStatusConfirmed, source: schema.StatusChangeSourceRequest, at: now}
+	rowsAffected, err := changeStatus(ctx, tx, change, `
+	UPDATE appointments
+	SET status = 'confirmed', updated_at = $2
+	WHERE series_id = $1
+	  AND status = ANY($4)
+	  AND NOT (status = 'reserved' AND created_at <= $3)
+	RETURNING id
+`, seriesID.String(), now, holdCutoff(now), changeableTo(schema.AppointmentStatusConfirmed))
+	if err != nil {
+		return 0, err
+	}
+	if rowsAffected == 0 {
+		return 0, sql.ErrNoRows
+	}
+	return rowsAffected, tx.Commit()
+}
+
+// CancelFollowingAppointments cancels an appointment and every later active appointment in its series. It
+// returns the error of TransitionFailure if the appointment can not be cancelled.
+func (db *Database) CancelFollowingAppointments(ctx context.Context, appointmentID types.UUID) error {
+	ctx, span := tracer.Start(ctx, "db.CancelFollowingAppointments")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.rollback(tx)
+
+	now := db.Clock.Now()
+	change := statusChange{status: schema.AppointmentStatusCancelled, source: schema.StatusChangeSourceRequest, at: now}
+	rowsAffected, err := changeStatus(ctx, tx, change, `
+	UPDATE appointments appt
+	SET status = 'cancelled', updated_at = $2
+	FROM appointments target
+	WHERE target.id = $1
+	  AND (
+	    appt.id = target.id OR
+	    (appt.series_id = target.series_id AND appt.series_index >= target.series_index)
+	  )
+	  AND appt.status = ANY($4)
+	  AND NOT (appt.status = 'reserved' AND appt.created_at <= $3)
+	RETURNING appt.id
+`, appointmentID.String(), now, holdCutoff(now), changeableTo(schema.AppointmentStatusCancelled))
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return transitionFailure(ctx, tx, appointmentID, schema.AppointmentStatusCancelled)
+	}
+	return tx.Commit()
+}
+
+// RescheduleAppointment moves an active appointment to a new start time. Appointments can be moved as long
+// as they can be cancelled, it returns the error of TransitionFailure for one that can not.
+func (db *Database) RescheduleAppointment(ctx context.Context, appointmentID types.UUID, newStart time.Time) ([]schema.Appointment, error) {
+	ctx, span := tracer.Start(ctx, "db.RescheduleAppointment")
+	defer span.End()
+
+	return db.reschedule(ctx, appointmentID, newStart, false)
+}
+
+// RescheduleFollowingAppointments moves an active appointment to a new start time and shifts every later
+// active appointment in its series by the same amount
+func (db *Database) RescheduleFollowingAppointments(ctx context.Context, appointmentID types.UUID, newStart time.Time) ([]schema.Appointment, error) {
+	ctx, span := tracer.Start(ctx, "db.RescheduleFollowingAppointments")
+	defer span.End()
+
+	return db.reschedule(ctx, appointmentID, newStart, true)
+}
+
+func (db *Database) reschedule(ctx context.Context, appointmentID types.UUID, newStart time.Time, following bool) ([]schema.Appointment, error) {
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.rollback(tx)
+
+	var clientID types.UUID
+	err = tx.QueryRowContext(ctx, `SELECT client_id FROM appointments WHERE id = $1`, appointmentID.String()).Scan(&clientID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err == nil {
+		if err := lockClient(ctx, tx, &clientID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := db.Clock.Now()
+	rows, err := tx.QueryContext(ctx, `
+	SELECT `+appointmentColumns+`
+	FROM appointments appt, appointments target
+	WHERE target.id = $1
+	  AND (
+	    appt.id = target.id OR
+	    ($2 AND appt.series_id = target.series_id AND appt.series_index >= target.series_index)
+	  )
+	  AND appt.status = ANY($4)
+	  AND NOT (appt.status = 'reserved' AND appt.created_at <= $3)
+	ORDER BY appt.start_time
+	FOR UPDATE OF appt
+`, appointmentID.String(), following, holdCutoff(now), changeableTo(schema.AppointmentStatusCancelled))
+	if err != nil {
+		return nil, err
+	}
+	moving, err := scanAppointments(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var shift time.Duration
+	found := false
+	ids := make([]string, 0, len(moving))
+	for _, appointment := range moving {
+		ids = append(ids, appointment.Id.String())
+		if *appointment.Id == appointmentID {
+			shift = newStart.Sub(*appointment.StartTime)
+			found = true
+		}
+	}
+	if !found {
+		return nil, transitionFailure(ctx, tx, appointmentID, schema.AppointmentStatusCancelled)
+	}
+
+	conflicts := []schema.OccurrenceConflict{}
+	for i, appointment := range moving {
+		// Appointments keep their room, which has to be free and at the location of the new slot, and
+		// their participants, who all need a free slot at the new time
+		room, err := lockRoom(ctx, tx, appointment.RoomId)
+		if err != nil {
+			return nil, err
+		}
+		var participantIDs []types.UUID
+		if appointment.ParticipantIds != nil {
+			participantIDs = *appointment.ParticipantIds
+		}
+		startTime := appointment.StartTime.Add(shift)
+		reason, err := seatsConflict(ctx, tx, appointment.ProviderId.String(), participantIDs, room, startTime, ids, now)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			index := i
+			if appointment.SeriesIndex != nil {
+				index = *appointment.SeriesIndex
+			}
+			conflicts = append(conflicts, schema.OccurrenceConflict{
+				Index:     &index,
+				StartTime: &startTime,
+				Reason:    &reason,
+			})
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, &ConflictError{Conflicts: conflicts}
+	}
+
+	for i := range moving {
+		startTime := moving[i].StartTime.Add(shift)
+		endTime := startTime.Add(SlotInterval(ctx))
+		var locationID uuid.NullUUID
+		err := tx.QueryRowContext(ctx, `
+		UPDATE appointments
+		SET start_time = $2, end_time = $3, updated_at = $4,
+		  location_id = (SELECT location_id FROM availability WHERE provider_id = appointments.provider_id AND start_time = $2)
+		WHERE id = $1
+		RETURNING location_id
+`, moving[i].Id.String(), startTime, endTime, now).Scan(&locationID)
+		if err != nil {
+			return nil, err
+		}
+		moving[i].StartTime = &startTime
+		moving[i].EndTime = &endTime
+		moving[i].LocationId = nullUUID(locationID)
+	}
+
+	// Each moved appointment is checked against the client's other ones at their new times
+	for _, appointment := range moving {
+		excluded := []string{appointment.Id.String()}
+		if err := clientLimits(ctx, tx, appointment.ClientId, appointment.ProviderId, *appointment.StartTime, excluded, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return moving, nil
+}
+
+const appointmentColumns = `appt.id, appt.client_id, appt.provider_id, appt.start_time, appt.end_time, appt.status, appt.series_id, appt.series_index, appt.location_id, appt.room_id,
+	  appt.status_changed_at, appt.status_changed_by, COALESCE(appt.reason, ''), appt.appointment_type_id, COALESCE(appt.intake_answers::text, ''),
+	  ARRAY(SELECT ap.provider_id::text FROM appointment_participants ap WHERE ap.appointment_id = appt.id ORDER BY ap.provider_id)`
+
+// nullUUID is the id, or nil if it is NULL
+func nullUUID(id uuid.NullUUID) *types.UUID {
+	if !id.Valid {
+		return nil
+	}
+	return (*types.UUID)(&id.UUID)
+}
+
+// scanAppointments reads appointmentColumns rows and closes them
+func scanAppointments(rows *sql.Rows) ([]schema.Appointment, error) {
+	defer rows.Close()
+
+	appointments := []schema.Appointment{}
+	for rows.Next() {
+		var id, clientID, providerID uuid.UUID
+		var startTime, endTime time.Time
+		var status string
+		var seriesID, locationID, roomID, changedBy, appointmentTypeID uuid.NullUUID
+		var seriesIndex sql.NullInt64
+		var changedAt sql.NullTime
+		var reason, answers string
+		var participants []string
+
+		err := rows.Scan(&id, &clientID, &providerID, &startTime, &endTime, &status, &seriesID, &seriesIndex, &locationID, &roomID,
+			&changedAt, &changedBy, &reason, &appointmentTypeID, &answers, pq.Array(&participants))
+		if err != nil {
+			return nil, err
+		}
+
+		appointmentStatus := schema.AppointmentStatus(status)
+		appointment := schema.Appointment{
+			Id:         (*types.UUID)(&id),
+			ClientId:   (*types.UUID)(&clientID),
+			ProviderId: (*types.UUID)(&providerID),
+			StartTime:  &startTime,
+			EndTime:    &endTime,
+			Status:     &appointmentStatus,
+			LocationId: nullUUID(locationID),
+			RoomId:     nullUUID(roomID),
+
+			StatusChangedBy: nullUUID(changedBy),
+		}
+		if changedAt.Valid {
+			appointment.StatusChangedAt = &changedAt.Time
+		}
+		if err := SetIntake(&appointment, reason, appointmentTypeID, answers); err != nil {
+			return nil, err
+		}
+		if seriesID.Valid {
+			appointment.SeriesId = (*types.UUID)(&seriesID.UUID)
+			appointment.SeriesIndex = utils.Ptr(int(seriesIndex.Int64))
+		}
+		if len(participants) > 0 {
+			participantIDs := make([]types.UUID, 0, len(participants))
+			for _, participant := range participants {
+				participantID, err := uuid.Parse(participant)
+				if err != nil {
+					return nil, err
+				}
+				participantIDs = append(participantIDs, participantID)
+			}
+			appointment.ParticipantIds = &participantIDs
+		}
+		appointments = append(appointments, appointment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return appointments, nil
+}
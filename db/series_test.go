@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/schema"
+)
+
+func weeklyStartTimes(first time.Time, count int) []time.Time {
+	var startTimes []time.Time
+	for i := 0; i < count; i++ {
+		startTimes = append(startTimes, first.AddDate(0, 0, 7*i))
+	}
+	return startTimes
+}
+
+func TestReserveSeries_AllOrNothing(t *testing.T) {
+	t.Parallel()
+	dbInstance := startTestDatabase(t)
+	defer dbInstance.Conn.Close()
+
+	providerID := createTestProvider(t, dbInstance)
+	clientID := createTestClient(t, dbInstance)
+	startTimes := weeklyStartTimes(time.Now().Add(48*time.Hour).Truncate(time.Minute), 4)
+
+	// The third week has no availability
+	addTestAvailability(t, dbInstance, providerID, []time.Time{startTimes[0], startTimes[1], startTimes[3]})
+
+	req := SeriesRequest{
+		ClientID:   clientID,
+		ProviderID: providerID,
+		Recurrence: schema.Recurrence{Frequency: schema.Weekly, Count: 4},
+		StartTimes: startTimes,
+	}
+	_, err := dbInstance.ReserveSeries(context.Background(), req)
+	var conflictErr *ConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Len(t, conflictErr.Conflicts, 1)
+	require.Equal(t, 2, *conflictErr.Conflicts[0].Index)
+	require.Equal(t, schema.NoAvailability, *conflictErr.Conflicts[0].Reason)
+
+	// Nothing was reserved
+	available, err := dbInstance.IsSlotAvailable(context.Background(), providerID, nil, &startTimes[0])
+	require.NoError(t, err)
+	require.True(t, available)
+
+	req.AllowPartial = true
+	series, err := dbInstance.ReserveSeries(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, *series.Appointments, 3)
+	require.Len(t, *series.Conflicts, 1)
+
+	confirmed, err := dbInstance.ConfirmSeries(context.Background(), *series.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), confirmed)
+
+	stored, err := dbInstance.GetSeries(context.Background(), *series.Id)
+	require.NoError(t, err)
+	require.Equal(t, 4, stored.Recurrence.Count)
+	for i, appointment := range *stored.Appointments {
+		require.Equal(t, schema.AppointmentStatusConfirmed, *appointment.Status)
+		require.Equal(t, series.Id.String(), appointment.SeriesId.String())
+		require.Equal(t, *(*series.Appointments)[i].SeriesIndex, *appointment.SeriesIndex)
+	}
+}
+
+func TestCancelFollowingAppointments(t *testing.T) {
+	t.Parallel()
+	dbInstance := startTestDatabase(t)
+	defer dbInstance.Conn.Close()
+
+	providerID := createTestProvider(t, dbInstance)
+	clientID := createTestClient(t, dbInstance)
+	startTimes := weeklyStartTimes(time.Now().Add(48*time.Hour).Truncate(time.Minute), 4)
+	addTestAvailability(t, dbInstance, providerID, startTimes)
+
+	series, err := dbInstance.ReserveSeries(context.Background(), SeriesRequest{
+		ClientID:   clientID,
+		ProviderID: providerID,
+		Recurrence: schema.Recurrence{Frequency: schema.Weekly, Count: 4},
+		StartTimes: startTimes,
+	})
+	require.NoError(t, err)
+
+	err = dbInstance.CancelFollowingAppointments(context.Background(), *(*series.Appointments)[2].Id)
+	require.NoError(t, err)
+
+	stored, err := dbInstance.GetSeries(context.Background(), *series.Id)
+	require.NoError(t, err)
+	var statuses []schema.AppointmentStatus
+	for _, appointment := range *stored.Appointments {
+		statuses = append(statuses, *appointment.Status)
+	}
+	require.Equal(t, []schema.AppointmentStatus{
+		schema.AppointmentStatusReserved,
+		schema.AppointmentStatusReserved,
+		schema.AppointmentStatusCancelled,
+		schema.AppointmentStatusCancelled,
+	}, statuses)
+}
+
+func TestRescheduleFollowingAppointments(t *testing.T) {
+	t.Parallel()
+	dbInstance := startTestDatabase(t)
+	defer dbInstance.Conn.Close()
+
+	providerID := createTestProvider(t, dbInstance)
+	clientID := createTestClient(t, dbInstance)
+	startTimes := weeklyStartTimes(time.Now().Add(48*time.Hour).Truncate(time.Minute), 3)
+	addTestAvailability(t, dbInstance, providerID, startTimes)
+
+	series, err := dbInstance.ReserveSeries(context.Background(), SeriesRequest{
+		ClientID:   clientID,
+		ProviderID: providerID,
+		Recurrence: schema.Recurrence{Frequency: schema.Weekly, Count: 3},
+		StartTimes: startTimes,
+	})
+	require.NoError(t, err)
+
+	// Move the second and third occurrences one hour later, the third has no availability yet
+	shift := time.Hour
+	addTestAvailability(t, dbInstance, providerID, []time.Time{startTimes[1].Add(shift)})
+	second := (*series.Appointments)[1]
+	_, err = dbInstance.RescheduleFollowingAppointments(context.Background(), *second.Id, startTimes[1].Add(shift))
+	var conflictErr *ConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Len(t, conflictErr.Conflicts, 1)
+	require.Equal(t, 2, *conflictErr.Conflicts[0].Index)
+
+	addTestAvailability(t, dbInstance, providerID, []time.Time{startTimes[2].Add(shift)})
+	moved, err := dbInstance.RescheduleFollowingAppointments(context.Background(), *second.Id, startTimes[1].Add(shift))
+	require.NoError(t, err)
+	require.Len(t, moved, 2)
+	require.True(t, startTimes[1].Add(shift).Equal(*moved[0].StartTime))
+	require.True(t, startTimes[2].Add(shift).Equal(*moved[1].StartTime))
+
+	// The original slots are free again and the first occurrence did not move
+	available, err := dbInstance.IsSlotAvailable(context.Background(), providerID, nil, &startTimes[1])
+	require.NoError(t, err)
+	require.True(t, available)
+	available, err = dbInstance.IsSlotAvailable(context.Background(), providerID, nil, &startTimes[0])
+	require.NoError(t, err)
+	require.False(t, available)
+}
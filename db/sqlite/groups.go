@@ -0,0 +1,258 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+const providerGroupColumns = `g.id, g.name, g.strategy, (
+	    SELECT json_group_array(provider_id) FROM (
+	      SELECT m.provider_id FROM provider_group_members m WHERE m.group_id = g.id ORDER BY m.position
+	    )
+	  )`
+
+// scanProviderGroup reads a row of providerGroupColumns
+func scanProviderGroup(row interface{ Scan(dest ...any) error }) (*schema.ProviderGroup, error) {
+	var id uuid.UUID
+	var name, members string
+	var strategy schema.GroupStrategy
+	if err := row.Scan(&id, &name, &strategy, &members); err != nil {
+		return nil, err
+	}
+	providerIDs := []types.UUID{}
+	if err := json.Unmarshal([]byte(members), &providerIDs); err != nil {
+		return nil, err
+	}
+	return &schema.ProviderGroup{Id: (*types.UUID)(&id), Name: &name, Strategy: &strategy, ProviderIds: &providerIDs}, nil
+}
+
+// CreateProviderGroup adds a group of providers in priority order. It returns sql.ErrNoRows if one of them
+// is not a provider in the organization and db.ErrUserInactive if one has been deactivated.
+func (s *Store) CreateProviderGroup(ctx context.Context, name string, strategy schema.GroupStrategy, providerIDs []types.UUID) (*schema.ProviderGroup, error) {
+	ctx, span := tracer.Start(ctx, "db.CreateProviderGroup")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer s.rollback(tx)
+
+	for _, providerID := range providerIDs {
+		if err := activeUserWithRole(ctx, tx, providerID, string(schema.UserRoleProvider)); err != nil {
+			return nil, err
+		}
+	}
+	groupID := uuid.New()
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO provider_groups (id, organization_id, name, strategy, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $5)
+`, groupID.String(), tenant(ctx), name, string(strategy), micros(s.Clock.Now()))
+	if err != nil {
+		return nil, err
+	}
+	for i, providerID := range providerIDs {
+		_, err = tx.ExecContext(ctx, `
+	INSERT INTO provider_group_members (group_id, provider_id, organization_id, position)
+	VALUES ($1, $2, $3, $4)
+`, groupID.String(), providerID.String(), tenant(ctx), i+1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &schema.ProviderGroup{Id: (*types.UUID)(&groupID), Name: utils.Ptr(name), Strategy: &strategy, ProviderIds: utils.Ptr(slices.Clone(providerIDs))}, nil
+}
+
+// ListProviderGroups returns the organization's provider groups by name
+func (s *Store) ListProviderGroups(ctx context.Context) ([]schema.ProviderGroup, error) {
+	ctx, span := tracer.Start(ctx, "db.ListProviderGroups")
+	defer span.End()
+
+	rows, err := s.Conn.QueryContext(ctx, `
+	SELECT `+providerGroupColumns+`
+	FROM provider_groups g
+	WHERE g.organization_id = $1
+	ORDER BY g.name, g.id
+`, tenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []schema.ProviderGroup{}
+	for rows.Next() {
+		group, err := scanProviderGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, *group)
+	}
+	return groups, rows.Err()
+}
+
+// GetProviderGroup returns a provider group, db.ErrGroupNotFound if it is not in the organization
+func (s *Store) GetProviderGroup(ctx context.Context, groupID types.UUID) (*schema.ProviderGroup, error) {
+	ctx, span := tracer.Start(ctx, "db.GetProviderGroup")
+	defer span.End()
+
+	group, err := scanProviderGroup(s.Conn.QueryRowContext(ctx, `
+	SELECT `+providerGroupColumns+`
+	FROM provider_groups g
+	WHERE g.id = $1
+	  AND g.organization_id = $2
+`, groupID.String(), tenant(ctx)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, db.ErrGroupNotFound
+	}
+	return group, err
+}
+
+// ReserveFromGroup holds a seat at startTime with the active member of a group its strategy ranks first
+// among the ones with a free slot, explaining the choice in the appointment's assignment. tx holds the
+// write lock so concurrent bookings from the group see each other's assignments. It returns
+// db.ErrGroupNotFound for a group that is not in the organization and db.ErrSlotUnavailable if no member
+// is free. Members the client has reached their per provider limit with are passed over, and the limit
+// is returned if that leaves none.
+func (s *Store) ReserveFromGroup(ctx context.Context, clientID, groupID types.UUID, startTime time.Time, intake *db.Intake) (*schema.Appointment, error) {
+	ctx, span := tracer.Start(ctx, "db.ReserveFromGroup")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer s.rollback(tx)
+
+	var strategy schema.GroupStrategy
+	err = tx.QueryRowContext(ctx, `
+	SELECT strategy
+	FROM provider_groups
+	WHERE id = $1
+	  AND organization_id = $2
+`, groupID.String(), tenant(ctx)).Scan(&strategy)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, db.ErrGroupNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := bookable(ctx, tx, clientID); err != nil {
+		return nil, err
+	}
+
+	now := s.Clock.Now()
+	weekStart, weekEnd := db.WeekOf(startTime)
+	rows, err := tx.QueryContext(ctx, `
+	SELECT m.provider_id, m.position, m.last_assignment,
+	  (
+	    SELECT COUNT(*) FROM appointments appt
+	    WHERE `+seatOf("m.provider_id")+`
+	      AND appt.start_time >= $2 AND appt.start_time < $4
+	      AND `+active+`
+	  ),
+	  `+freeProviders("json_array(m.provider_id)", "$5", "$3", "$6")+` > 0
+	FROM provider_group_members m
+	JOIN users u ON u.id = m.provider_id AND u.deactivated_at IS NULL
+	WHERE m.group_id = $1
+	ORDER BY m.position
+`, groupID.String(), micros(weekStart), holdCutoff(now), micros(weekEnd), micros(startTime), tenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := scanCandidates(rows)
+	if err != nil {
+		return nil, err
+	}
+	db.RankCandidates(strategy, candidates)
+
+	chosen := -1
+	var limited error
+	for i := range candidates {
+		if !*candidates[i].Available {
+			continue
+		}
+		// A client at their limit with one member can still be assigned another
+		err := clientLimits(ctx, tx, &clientID, candidates[i].ProviderId, startTime, nil, now)
+		if errors.Is(err, db.ErrProviderAppointmentLimit) {
+			candidates[i].Available = utils.Ptr(false)
+			limited = err
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		chosen = i
+		break
+	}
+	if chosen < 0 {
+		if limited != nil {
+			return nil, limited
+		}
+		return nil, db.ErrSlotUnavailable
+	}
+	providerID := *candidates[chosen].ProviderId
+	appointment, err := insertReservedAppointment(ctx, tx, &clientID, &providerID, nil, &startTime, nil, schema.StatusChangeSourceRequest, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := saveIntake(ctx, tx, appointment, intake); err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, `
+	UPDATE provider_groups SET assignments = assignments + 1, updated_at = $2 WHERE id = $1
+`, groupID.String(), micros(now))
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, `
+	UPDATE provider_group_members
+	SET last_assignment = (SELECT assignments FROM provider_groups WHERE id = $1)
+	WHERE group_id = $1 AND provider_id = $2
+`, groupID.String(), providerID.String())
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	appointment.Assignment = db.NewAssignment(groupID, strategy, candidates, chosen, startTime)
+	return appointment, nil
+}
+
+// scanCandidates reads provider id, position, last assignment, week count and availability rows and
+// closes them
+func scanCandidates(rows *sql.Rows) ([]schema.AssignmentCandidate, error) {
+	defer rows.Close()
+
+	candidates := []schema.AssignmentCandidate{}
+	for rows.Next() {
+		var providerID uuid.UUID
+		var priority, lastAssignment, booked int
+		var available bool
+		if err := rows.Scan(&providerID, &priority, &lastAssignment, &booked, &available); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, schema.AssignmentCandidate{
+			ProviderId:           (*types.UUID)(&providerID),
+			Priority:             &priority,
+			LastAssignment:       &lastAssignment,
+			AppointmentsThisWeek: &booked,
+			Available:            &available,
+		})
+	}
+	return candidates, rows.Err()
+}
@@ -0,0 +1,113 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// saveIntake checks intake against the questions of its appointment type and stores it with an appointment
+// tx just reserved, a nil intake leaves the appointment without one
+func saveIntake(ctx context.Context, tx *sql.Tx, appointment *schema.Appointment, intake *db.Intake) error {
+	if intake == nil {
+		return nil
+	}
+	var questions []schema.IntakeQuestion
+	var appointmentTypeID uuid.NullUUID
+	if intake.AppointmentTypeID != nil {
+		var raw string
+		err := tx.QueryRowContext(ctx, `
+		SELECT intake_questions
+		FROM appointment_types
+		WHERE id = $1
+		  AND organization_id = $2
+	`, intake.AppointmentTypeID.String(), tenant(ctx)).Scan(&raw)
+		if errors.Is(err, sql.ErrNoRows) {
+			return db.ErrAppointmentTypeNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(raw), &questions); err != nil {
+			return err
+		}
+		appointmentTypeID = uuid.NullUUID{UUID: *intake.AppointmentTypeID, Valid: true}
+	}
+	answers, err := db.EncodeAnswers(*intake, questions)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE appointments
+	SET reason = NULLIF($2, ''), appointment_type_id = $3, intake_answers = NULLIF($4, '')
+	WHERE id = $1
+`, appointment.Id.String(), intake.Reason, appointmentTypeID, answers)
+	if err != nil {
+		return err
+	}
+	return db.SetIntake(appointment, intake.Reason, appointmentTypeID, answers)
+}
+
+// CreateAppointmentType adds a kind of appointment with the intake questions clients booking one answer
+func (s *Store) CreateAppointmentType(ctx context.Context, name string, questions []schema.IntakeQuestion) (*schema.AppointmentType, error) {
+	ctx, span := tracer.Start(ctx, "db.CreateAppointmentType")
+	defer span.End()
+
+	if questions == nil {
+		questions = []schema.IntakeQuestion{}
+	}
+	raw, err := json.Marshal(questions)
+	if err != nil {
+		return nil, err
+	}
+	appointmentTypeID := uuid.New()
+	now := micros(s.Clock.Now())
+	_, err = s.Conn.ExecContext(ctx, `
+	INSERT INTO appointment_types (id, organization_id, name, intake_questions, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $5)
+`, appointmentTypeID.String(), tenant(ctx), name, string(raw), now)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.AppointmentType{Id: (*types.UUID)(&appointmentTypeID), Name: utils.Ptr(name), IntakeQuestions: &questions}, nil
+}
+
+// ListAppointmentTypes returns the organization's appointment types by name
+func (s *Store) ListAppointmentTypes(ctx context.Context) ([]schema.AppointmentType, error) {
+	ctx, span := tracer.Start(ctx, "db.ListAppointmentTypes")
+	defer span.End()
+
+	rows, err := s.Conn.QueryContext(ctx, `
+	SELECT id, name, intake_questions
+	FROM appointment_types
+	WHERE organization_id = $1
+	ORDER BY name, id
+`, tenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	appointmentTypes := []schema.AppointmentType{}
+	for rows.Next() {
+		var id uuid.UUID
+		var name, raw string
+		if err := rows.Scan(&id, &name, &raw); err != nil {
+			return nil, err
+		}
+		questions := []schema.IntakeQuestion{}
+		if err := json.Unmarshal([]byte(raw), &questions); err != nil {
+			return nil, err
+		}
+		appointmentTypes = append(appointmentTypes, schema.AppointmentType{Id: (*types.UUID)(&id), Name: utils.Ptr(name), IntakeQuestions: &questions})
+	}
+	return appointmentTypes, rows.Err()
+}
@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+)
+
+// clientLimits checks booking the client with the provider at startTime against the organization's limits.
+// The appointments in excluded are not counted. tx holds the write lock so concurrent bookings for the
+// client are counted one after the other.
+func clientLimits(ctx context.Context, tx *sql.Tx, clientID, providerID *types.UUID, startTime time.Time, excluded []string, now time.Time) error {
+	excludedIDs, err := excludedJSON(excluded)
+	if err != nil {
+		return err
+	}
+	var bookings db.ClientBookings
+	err = tx.QueryRowContext(ctx, `
+	SELECT
+	  COUNT(*) FILTER (WHERE appt.status = 'reserved'),
+	  COUNT(*) FILTER (WHERE appt.status <> 'no_show' AND appt.start_time > $2),
+	  COUNT(*) FILTER (WHERE appt.status <> 'no_show' AND appt.start_time > $2 AND appt.provider_id = $3),
+	  COUNT(*) FILTER (WHERE appt.status <> 'no_show' AND appt.start_time < $5 AND appt.end_time > $4),
+	  COUNT(*) FILTER (WHERE appt.status = 'no_show')
+	FROM appointments appt
+	WHERE appt.client_id = $1
+	  AND appt.organization_id = $7
+	  AND (
+	    appt.status = 'confirmed' OR
+	    (appt.status = 'reserved' AND appt.created_at > $6) OR
+	    (appt.status = 'no_show' AND appt.start_time > $8)
+	  )
+	  AND appt.id NOT IN (SELECT value FROM json_each($9))
+`, clientID.String(), micros(now), providerID.String(), micros(startTime), micros(startTime.Add(db.SlotInterval(ctx))),
+		holdCutoff(now), tenant(ctx), micros(now.Add(-db.NoShowWindow)), excludedIDs).Scan(
+		&bookings.Holds, &bookings.Future, &bookings.FutureWithProvider, &bookings.Overlapping, &bookings.NoShows)
+	if err != nil {
+		return err
+	}
+	return db.CheckClientLimits(ctx, bookings)
+}
@@ -0,0 +1,235 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// bookedRoom is a room a booking claims together with its provider
+type bookedRoom struct {
+	id         uuid.UUID
+	locationID uuid.UUID
+}
+
+// findRoom returns the room a booking claims, nil for a nil id and db.ErrRoomNotFound if the room is not
+// in the organization. tx holds the write lock so the room can not be booked by anything else meanwhile.
+func findRoom(ctx context.Context, tx *sql.Tx, roomID *types.UUID) (*bookedRoom, error) {
+	if roomID == nil {
+		return nil, nil
+	}
+	room := &bookedRoom{id: *roomID}
+	err := tx.QueryRowContext(ctx, `
+	SELECT location_id
+	FROM rooms
+	WHERE id = $1
+	  AND organization_id = $2
+`, roomID.String(), tenant(ctx)).Scan(&room.locationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, db.ErrRoomNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// roomConflict reports whether an active appointment other than the excluded ones has the room at an
+// overlapping time. The other seats of the same group session share its room.
+func roomConflict(ctx context.Context, tx *sql.Tx, room *bookedRoom, providerID string, startTime time.Time, excludedJSON string, now time.Time) (bool, error) {
+	var overlapping int
+	err := tx.QueryRowContext(ctx, `
+	SELECT COUNT(*)
+	FROM appointments appt
+	WHERE appt.room_id = $1
+	  AND appt.start_time < $4
+	  AND appt.end_time > $2
+	  AND NOT (appt.provider_id = $5 AND appt.start_time = $2)
+	  AND appt.id NOT IN (SELECT value FROM json_each($6))
+	  AND `+active+`
+`, room.id.String(), micros(startTime), holdCutoff(now), micros(startTime.Add(db.SlotInterval(ctx))), providerID, excludedJSON).Scan(&overlapping)
+	return overlapping > 0, err
+}
+
+// locationExists returns db.ErrLocationNotFound unless the location is in the organization
+func locationExists(ctx context.Context, conn queryer, locationID types.UUID) error {
+	var id string
+	err := conn.QueryRowContext(ctx, `
+	SELECT id
+	FROM locations
+	WHERE id = $1
+	  AND organization_id = $2
+`, locationID.String(), tenant(ctx)).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.ErrLocationNotFound
+	}
+	return err
+}
+
+// CreateLocation adds a location, an empty address is left out
+func (s *Store) CreateLocation(ctx context.Context, name, address string) (*schema.Location, error) {
+	ctx, span := tracer.Start(ctx, "db.CreateLocation")
+	defer span.End()
+
+	locationID := uuid.New()
+	now := micros(s.Clock.Now())
+	_, err := s.Conn.ExecContext(ctx, `
+	INSERT INTO locations (id, organization_id, name, address, created_at, updated_at)
+	VALUES ($1, $2, $3, NULLIF($4, ''), $5, $5)
+`, locationID.String(), tenant(ctx), name, address, now)
+	if err != nil {
+		return nil, err
+	}
+	return newLocation(locationID, name, address), nil
+}
+
+func newLocation(id uuid.UUID, name, address string) *schema.Location {
+	location := &schema.Location{Id: (*types.UUID)(&id), Name: utils.Ptr(name)}
+	if address != "" {
+		location.Address = utils.Ptr(address)
+	}
+	return location
+}
+
+// ListLocations returns the organization's locations by name
+func (s *Store) ListLocations(ctx context.Context) ([]schema.Location, error) {
+	ctx, span := tracer.Start(ctx, "db.ListLocations")
+	defer span.End()
+
+	rows, err := s.Conn.QueryContext(ctx, `
+	SELECT id, name, COALESCE(address, '')
+	FROM locations
+	WHERE organization_id = $1
+	ORDER BY name, id
+`, tenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := []schema.Location{}
+	for rows.Next() {
+		var id uuid.UUID
+		var name, address string
+		if err := rows.Scan(&id, &name, &address); err != nil {
+			return nil, err
+		}
+		locations = append(locations, *newLocation(id, name, address))
+	}
+	return locations, rows.Err()
+}
+
+// CreateRoom adds a room to a location, returning db.ErrLocationNotFound if it is not in the organization
+func (s *Store) CreateRoom(ctx context.Context, locationID types.UUID, name string) (*schema.Room, error) {
+	ctx, span := tracer.Start(ctx, "db.CreateRoom")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer s.rollback(tx)
+
+	// The foreign key would accept another organization's location
+	if err := locationExists(ctx, tx, locationID); err != nil {
+		return nil, err
+	}
+	roomID := uuid.New()
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO rooms (id, organization_id, location_id, name, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $5)
+`, roomID.String(), tenant(ctx), locationID.String(), name, micros(s.Clock.Now()))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &schema.Room{Id: (*types.UUID)(&roomID), LocationId: &locationID, Name: utils.Ptr(name)}, nil
+}
+
+// ListRooms returns the rooms at a location by name, db.ErrLocationNotFound if it is not in the organization
+func (s *Store) ListRooms(ctx context.Context, locationID types.UUID) ([]schema.Room, error) {
+	ctx, span := tracer.Start(ctx, "db.ListRooms")
+	defer span.End()
+
+	if err := locationExists(ctx, s.Conn, locationID); err != nil {
+		return nil, err
+	}
+	rows, err := s.Conn.QueryContext(ctx, `
+	SELECT id, name
+	FROM rooms
+	WHERE location_id = $1
+	ORDER BY name, id
+`, locationID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rooms := []schema.Room{}
+	for rows.Next() {
+		var id uuid.UUID
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, schema.Room{Id: (*types.UUID)(&id), LocationId: &locationID, Name: utils.Ptr(name)})
+	}
+	return rooms, rows.Err()
+}
+
+// GetRoomAppointments returns the active appointments in a room by start time, on date if it is given.
+// It returns db.ErrRoomNotFound if the room is not in the organization.
+func (s *Store) GetRoomAppointments(ctx context.Context, roomID types.UUID, date *types.Date) ([]schema.Appointment, error) {
+	ctx, span := tracer.Start(ctx, "db.GetRoomAppointments")
+	defer span.End()
+
+	var id string
+	err := s.Conn.QueryRowContext(ctx, `
+	SELECT id
+	FROM rooms
+	WHERE id = $1
+	  AND organization_id = $2
+`, roomID.String(), tenant(ctx)).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, db.ErrRoomNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var from, to int64
+	if date != nil {
+		from, to = micros(date.Time), micros(date.Time.Add(24*time.Hour))
+	}
+	rows, err := s.Conn.QueryContext(ctx, `
+	SELECT `+appointmentColumns+`
+	FROM appointments appt
+	WHERE appt.room_id = $1
+	  AND ($2 OR (appt.start_time >= $4 AND appt.start_time < $5))
+	  AND `+active+`
+	ORDER BY appt.start_time, appt.id
+`, roomID.String(), date == nil, holdCutoff(s.Clock.Now()), from, to)
+	if err != nil {
+		return nil, err
+	}
+	return scanAppointments(rows)
+}
+
+// excludedJSON encodes the ids json_each stands in for = ANY($n::uuid[]) with
+func excludedJSON(excluded []string) (string, error) {
+	if excluded == nil {
+		excluded = []string{}
+	}
+	b, err := json.Marshal(excluded)
+	return string(b), err
+}
@@ -0,0 +1,114 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// appointmentProvider returns sql.ErrNoRows if the appointment is not in the organization and
+// db.ErrNotAppointmentProvider unless the user is its provider or a participant
+func appointmentProvider(ctx context.Context, conn queryer, appointmentID, userID types.UUID) error {
+	var provider bool
+	err := conn.QueryRowContext(ctx, `
+	SELECT appt.provider_id = $2 OR EXISTS (
+	  SELECT 1 FROM appointment_participants ap WHERE ap.appointment_id = appt.id AND ap.provider_id = $2
+	)
+	FROM appointments appt
+	WHERE appt.id = $1
+	  AND appt.organization_id = $3
+`, appointmentID.String(), userID.String(), tenant(ctx)).Scan(&provider)
+	if err != nil {
+		return err
+	}
+	if !provider {
+		return db.ErrNotAppointmentProvider
+	}
+	return nil
+}
+
+func newAppointmentNote(appointmentID types.UUID, version int, body string, authorID types.UUID, createdAt int64) schema.AppointmentNote {
+	return schema.AppointmentNote{
+		AppointmentId: &appointmentID,
+		Version:       utils.Ptr(version),
+		Body:          utils.Ptr(body),
+		AuthorId:      &authorID,
+		CreatedAt:     utils.Ptr(fromMicros(createdAt)),
+	}
+}
+
+// SaveAppointmentNote saves the providers' notes on an appointment as its next version. It returns
+// sql.ErrNoRows if the appointment is not in the organization and db.ErrNotAppointmentProvider if the
+// author is not its provider or a participant.
+func (s *Store) SaveAppointmentNote(ctx context.Context, appointmentID, authorID types.UUID, body string) (*schema.AppointmentNote, error) {
+	ctx, span := tracer.Start(ctx, "db.SaveAppointmentNote")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer s.rollback(tx)
+
+	if err := appointmentProvider(ctx, tx, appointmentID, authorID); err != nil {
+		return nil, err
+	}
+	now := micros(s.Clock.Now())
+	var version int
+	err = tx.QueryRowContext(ctx, `
+	INSERT INTO appointment_notes (appointment_id, version, organization_id, body, author_id, created_at)
+	SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4, $5
+	FROM appointment_notes
+	WHERE appointment_id = $1
+	RETURNING version
+`, appointmentID.String(), tenant(ctx), body, authorID.String(), now).Scan(&version)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	note := newAppointmentNote(appointmentID, version, body, authorID, now)
+	return &note, nil
+}
+
+// ListAppointmentNotes returns every version of the providers' notes on an appointment, latest first. It
+// returns sql.ErrNoRows if the appointment is not in the organization.
+func (s *Store) ListAppointmentNotes(ctx context.Context, appointmentID types.UUID) ([]schema.AppointmentNote, error) {
+	ctx, span := tracer.Start(ctx, "db.ListAppointmentNotes")
+	defer span.End()
+
+	var id string
+	err := s.Conn.QueryRowContext(ctx, `SELECT id FROM appointments WHERE id = $1 AND organization_id = $2`,
+		appointmentID.String(), tenant(ctx)).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.Conn.QueryContext(ctx, `
+	SELECT version, body, author_id, created_at
+	FROM appointment_notes
+	WHERE appointment_id = $1
+	ORDER BY version DESC
+`, appointmentID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notes := []schema.AppointmentNote{}
+	for rows.Next() {
+		var version int
+		var body string
+		var authorID uuid.UUID
+		var createdAt int64
+		if err := rows.Scan(&version, &body, &authorID, &createdAt); err != nil {
+			return nil, err
+		}
+		notes = append(notes, newAppointmentNote(appointmentID, version, body, authorID, createdAt))
+	}
+	return notes, rows.Err()
+}
@@ -0,0 +1,192 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// tenant is the organization_id every query is filtered by. SQLite has no row level security, so unlike
+// postgres nothing stops a query that forgets it.
+func tenant(ctx context.Context) string {
+	return db.OrganizationID(ctx).String()
+}
+
+// organizationQuery selects the columns scanOrganization reads, the hosts are a json array
+const organizationQuery = `
+	SELECT o.id, o.name, o.slug, o.slot_interval_minutes, o.lead_time_minutes, o.max_active_holds,
+	  o.max_future_appointments, o.max_future_appointments_per_provider, o.allow_overlapping_appointments, o.max_no_shows,
+	  (SELECT json_group_array(host) FROM (SELECT h.host FROM organization_hosts h WHERE h.organization_id = o.id ORDER BY h.host))
+	FROM organizations o
+`
+
+func scanOrganization(row rowScanner) (*schema.Organization, error) {
+	var id uuid.UUID
+	var name, slug, hosts string
+	var slotInterval, leadTime, maxHolds, maxFuture, maxPerProvider, maxNoShows sql.NullInt64
+	var allowOverlapping sql.NullBool
+	if err := row.Scan(&id, &name, &slug, &slotInterval, &leadTime, &maxHolds, &maxFuture, &maxPerProvider,
+		&allowOverlapping, &maxNoShows, &hosts); err != nil {
+		return nil, err
+	}
+
+	hostList := []string{}
+	if err := json.Unmarshal([]byte(hosts), &hostList); err != nil {
+		return nil, err
+	}
+	org := &schema.Organization{
+		Id:       (*types.UUID)(&id),
+		Name:     utils.Ptr(name),
+		Slug:     utils.Ptr(slug),
+		Hosts:    &hostList,
+		Settings: &schema.OrganizationSettings{},
+	}
+	if slotInterval.Valid {
+		org.Settings.SlotIntervalMinutes = utils.Ptr(int(slotInterval.Int64))
+	}
+	if leadTime.Valid {
+		org.Settings.LeadTimeMinutes = utils.Ptr(int(leadTime.Int64))
+	}
+	if maxHolds.Valid {
+		org.Settings.MaxActiveHolds = utils.Ptr(int(maxHolds.Int64))
+	}
+	if maxFuture.Valid {
+		org.Settings.MaxFutureAppointments = utils.Ptr(int(maxFuture.Int64))
+	}
+	if maxPerProvider.Valid {
+		org.Settings.MaxFutureAppointmentsPerProvider = utils.Ptr(int(maxPerProvider.Int64))
+	}
+	if allowOverlapping.Valid {
+		org.Settings.AllowOverlappingAppointments = utils.Ptr(allowOverlapping.Bool)
+	}
+	if maxNoShows.Valid {
+		org.Settings.MaxNoShows = utils.Ptr(int(maxNoShows.Int64))
+	}
+	return org, nil
+}
+
+// isUniqueError reports whether err violates a UNIQUE or PRIMARY KEY constraint
+func isUniqueError(err error) bool {
+	return isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) || isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY)
+}
+
+// CreateOrganization adds an organization, returning db.ErrOrganizationExists if its slug, a host or its token is taken
+func (s *Store) CreateOrganization(ctx context.Context, org db.NewOrganization) (*schema.Organization, error) {
+	ctx, span := tracer.Start(ctx, "db.CreateOrganization")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer s.rollback(tx)
+
+	id := uuid.New()
+	now := micros(s.Clock.Now())
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO organizations (id, name, slug, token_hash, slot_interval_minutes, lead_time_minutes, max_active_holds,
+	  max_future_appointments, max_future_appointments_per_provider, allow_overlapping_appointments, max_no_shows, created_at, updated_at)
+	VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $8, $9, $10, $11, $12, $7, $7)
+`, id.String(), org.Name, org.Slug, org.TokenHash, org.Settings.SlotIntervalMinutes, org.Settings.LeadTimeMinutes, now,
+		org.Settings.MaxActiveHolds, org.Settings.MaxFutureAppointments, org.Settings.MaxFutureAppointmentsPerProvider,
+		org.Settings.AllowOverlappingAppointments, org.Settings.MaxNoShows)
+	if isUniqueError(err) {
+		return nil, db.ErrOrganizationExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, host := range org.Hosts {
+		_, err = tx.ExecContext(ctx, `INSERT INTO organization_hosts (host, organization_id) VALUES ($1, $2)`, host, id.String())
+		if isUniqueError(err) {
+			return nil, db.ErrOrganizationExists
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := scanOrganization(tx.QueryRowContext(ctx, organizationQuery+`WHERE o.id = $1`, id.String()))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetOrganization returns an organization by id
+func (s *Store) GetOrganization(ctx context.Context, orgID types.UUID) (*schema.Organization, error) {
+	ctx, span := tracer.Start(ctx, "db.GetOrganization")
+	defer span.End()
+
+	return scanOrganization(s.Conn.QueryRowContext(ctx, organizationQuery+`WHERE o.id = $1`, orgID.String()))
+}
+
+// OrganizationByHost returns the organization serving host
+func (s *Store) OrganizationByHost(ctx context.Context, host string) (*schema.Organization, error) {
+	ctx, span := tracer.Start(ctx, "db.OrganizationByHost")
+	defer span.End()
+
+	return scanOrganization(s.Conn.QueryRowContext(ctx, organizationQuery+`
+	JOIN organization_hosts oh ON oh.organization_id = o.id
+	WHERE oh.host = $1
+`, host))
+}
+
+// OrganizationByToken returns the organization whose api token has the hash
+func (s *Store) OrganizationByToken(ctx context.Context, tokenHash string) (*schema.Organization, error) {
+	ctx, span := tracer.Start(ctx, "db.OrganizationByToken")
+	defer span.End()
+
+	return scanOrganization(s.Conn.QueryRowContext(ctx, organizationQuery+`WHERE o.token_hash = $1`, tokenHash))
+}
+
+// ListOrganizations returns every organization by slug, for work that runs in each of them
+func (s *Store) ListOrganizations(ctx context.Context) ([]schema.Organization, error) {
+	ctx, span := tracer.Start(ctx, "db.ListOrganizations")
+	defer span.End()
+
+	rows, err := s.Conn.QueryContext(ctx, organizationQuery+`ORDER BY o.slug`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orgs []schema.Organization
+	for rows.Next() {
+		org, err := scanOrganization(rows)
+		if err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, *org)
+	}
+	return orgs, rows.Err()
+}
+
+// UpdateOrganizationSettings replaces an organization's settings, nil settings fall back to the defaults
+func (s *Store) UpdateOrganizationSettings(ctx context.Context, orgID types.UUID, settings schema.OrganizationSettings) (*schema.Organization, error) {
+	ctx, span := tracer.Start(ctx, "db.UpdateOrganizationSettings")
+	defer span.End()
+
+	result, err := s.Conn.ExecContext(ctx, `
+	UPDATE organizations
+	SET slot_interval_minutes = $2, lead_time_minutes = $3, updated_at = $4, max_active_holds = $5,
+	  max_future_appointments = $6, max_future_appointments_per_provider = $7, allow_overlapping_appointments = $8,
+	  max_no_shows = $9
+	WHERE id = $1
+`, orgID.String(), settings.SlotIntervalMinutes, settings.LeadTimeMinutes, micros(s.Clock.Now()), settings.MaxActiveHolds,
+		settings.MaxFutureAppointments, settings.MaxFutureAppointmentsPerProvider, settings.AllowOverlappingAppointments, settings.MaxNoShows)
+	if err := expectRows(result, err); err != nil {
+		return nil, err
+	}
+	return s.GetOrganization(ctx, orgID)
+}
@@ -0,0 +1,114 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+)
+
+// participantColumn reads the participants of appointment appt as a json array, sorted
+const participantColumn = `(
+	    SELECT json_group_array(provider_id) FROM (
+	      SELECT ap.provider_id FROM appointment_participants ap WHERE ap.appointment_id = appt.id ORDER BY ap.provider_id
+	    )
+	  )`
+
+// seatOf matches the appointments appt that take a seat in provider's slot: the ones booked with the
+// provider and the ones the provider takes part in
+func seatOf(provider string) string {
+	return `(appt.provider_id = ` + provider + ` OR appt.id IN (
+	  SELECT ap.appointment_id FROM appointment_participants ap WHERE ap.provider_id = ` + provider + `
+	))`
+}
+
+// freeProviders counts the providers in the json array providers who have a slot in organization with a
+// seat left at startTime, reservations made before holdCutoff have expired
+func freeProviders(providers, startTime, holdCutoff, organization string) string {
+	return `(
+	  SELECT COUNT(*)
+	  FROM availability fa
+	  WHERE fa.provider_id IN (SELECT value FROM json_each(` + providers + `))
+	    AND fa.start_time = ` + startTime + `
+	    AND fa.organization_id = ` + organization + `
+	    AND (
+	      SELECT COUNT(*) FROM appointments appt
+	      WHERE ` + seatOf("fa.provider_id") + `
+	        AND appt.start_time = fa.start_time
+	        AND (
+	          appt.status = 'confirmed' OR
+	          (appt.status = 'reserved' AND appt.created_at > ` + holdCutoff + `)
+	        )
+	    ) < fa.capacity
+	)`
+}
+
+// idsJSON encodes ids for json_each
+func idsJSON(ids []types.UUID) (string, error) {
+	strs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strs = append(strs, id.String())
+	}
+	return excludedJSON(strs)
+}
+
+// parseParticipants decodes participantColumn, nil if there are none
+func parseParticipants(participants string) (*[]types.UUID, error) {
+	var ids []types.UUID
+	if err := json.Unmarshal([]byte(participants), &ids); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return &ids, nil
+}
+
+// seatsConflict reports why the slots of a provider and the participants at startTime can not all be
+// booked, checking the room with the provider's slot. Providers are checked in the order the postgres
+// store locks them so both report the same reason.
+func seatsConflict(ctx context.Context, tx *sql.Tx, providerID string, participantIDs []types.UUID, room *bookedRoom, startTime time.Time, excluded []string, now time.Time) (schema.OccurrenceConflictReason, error) {
+	providers := []string{providerID}
+	for _, id := range participantIDs {
+		providers = append(providers, id.String())
+	}
+	slices.Sort(providers)
+	for _, id := range providers {
+		var slotRoom *bookedRoom
+		if id == providerID {
+			slotRoom = room
+		}
+		reason, err := slotConflict(ctx, tx, id, slotRoom, startTime, excluded, now)
+		if err != nil || reason != "" {
+			return reason, err
+		}
+	}
+	return "", nil
+}
+
+// insertParticipants records the participants of a newly reserved appointment
+func insertParticipants(ctx context.Context, tx *sql.Tx, appointment *schema.Appointment, participantIDs []types.UUID, now time.Time) error {
+	if len(participantIDs) == 0 {
+		return nil
+	}
+	for _, participantID := range participantIDs {
+		_, err := tx.ExecContext(ctx, `
+	INSERT INTO appointment_participants (appointment_id, provider_id, organization_id, created_at)
+	VALUES ($1, $2, $3, $4)
+`, appointment.Id.String(), participantID.String(), tenant(ctx), micros(now))
+		if err != nil {
+			return err
+		}
+	}
+	// In the order they are read back in
+	participants := slices.Clone(participantIDs)
+	slices.SortFunc(participants, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	appointment.ParticipantIds = &participants
+	return nil
+}
@@ -0,0 +1,165 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// providerColumns are the columns scanProvider reads, in order
+const providerColumns = `id, name, specialty, bio, languages, location`
+
+func scanProvider(row rowScanner) (*schema.Provider, error) {
+	var id uuid.UUID
+	var name, languages string
+	var specialty, bio, location sql.NullString
+	if err := row.Scan(&id, &name, &specialty, &bio, &languages, &location); err != nil {
+		return nil, err
+	}
+
+	provider := &schema.Provider{
+		Id:   (*types.UUID)(&id),
+		Name: utils.Ptr(name),
+	}
+	if specialty.Valid {
+		provider.Specialty = &specialty.String
+	}
+	if bio.Valid {
+		provider.Bio = &bio.String
+	}
+	var langs []string
+	if err := json.Unmarshal([]byte(languages), &langs); err != nil {
+		return nil, err
+	}
+	if len(langs) > 0 {
+		provider.Languages = &langs
+	}
+	if location.Valid {
+		provider.Location = &location.String
+	}
+	return provider, nil
+}
+
+// GetProvider returns the profile of an active provider
+func (s *Store) GetProvider(ctx context.Context, providerID types.UUID) (*schema.Provider, error) {
+	ctx, span := tracer.Start(ctx, "db.GetProvider")
+	defer span.End()
+
+	return scanProvider(s.Conn.QueryRowContext(ctx, `
+	SELECT `+providerColumns+`
+	FROM users
+	WHERE id = $1
+	  AND role = 'provider'
+	  AND deactivated_at IS NULL
+	  AND organization_id = $2
+`, providerID.String(), tenant(ctx)))
+}
+
+// UpdateProviderProfile replaces a provider's profile, deactivated providers can still change theirs
+func (s *Store) UpdateProviderProfile(ctx context.Context, providerID types.UUID, profile schema.ProviderProfile) (*schema.Provider, error) {
+	ctx, span := tracer.Start(ctx, "db.UpdateProviderProfile")
+	defer span.End()
+
+	languages := []string{}
+	if profile.Languages != nil {
+		languages = *profile.Languages
+	}
+	encoded, err := json.Marshal(languages)
+	if err != nil {
+		return nil, err
+	}
+	return scanProvider(s.Conn.QueryRowContext(ctx, `
+	UPDATE users
+	SET specialty = $2, bio = $3, languages = $4, location = $5, updated_at = $6
+	WHERE id = $1
+	  AND role = 'provider'
+	  AND organization_id = $7
+	RETURNING `+providerColumns+`
+`, providerID.String(), profile.Specialty, profile.Bio, string(encoded), profile.Location, micros(s.Clock.Now()), tenant(ctx)))
+}
+
+// SearchProviders lists the active providers matching query by name. There is no full text index, the
+// words of a search are matched against each candidate and nothing is ranked.
+func (s *Store) SearchProviders(ctx context.Context, query db.ProviderQuery) ([]schema.Provider, error) {
+	ctx, span := tracer.Start(ctx, "db.SearchProviders")
+	defer span.End()
+
+	sqlQuery := `
+	SELECT ` + providerColumns + `
+	FROM users u
+	WHERE role = 'provider'
+	  AND deactivated_at IS NULL
+	`
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	sqlQuery += " AND organization_id = " + arg(tenant(ctx))
+
+	if query.Specialty != "" {
+		sqlQuery += " AND LOWER(specialty) = LOWER(" + arg(query.Specialty) + ")"
+	}
+	if query.Language != "" {
+		sqlQuery += " AND EXISTS (SELECT 1 FROM json_each(u.languages) WHERE value = " + arg(query.Language) + ")"
+	}
+	if query.AvailableFrom != nil || query.AvailableTo != nil {
+		slots := `
+	  AND EXISTS (
+	    SELECT 1
+	    FROM availability a
+	    WHERE a.provider_id = u.id
+	      AND a.capacity > (
+	        SELECT COUNT(*)
+	        FROM appointments appt
+	        WHERE ` + seatOf("a.provider_id") + `
+	          AND appt.start_time = a.start_time
+	          AND (appt.status = 'confirmed' OR (appt.status = 'reserved' AND appt.created_at > ` + arg(holdCutoff(s.Clock.Now())) + `))
+	      )`
+		if query.AvailableFrom != nil {
+			slots += " AND a.start_time >= " + arg(micros(*query.AvailableFrom))
+		}
+		if query.AvailableTo != nil {
+			slots += " AND a.end_time <= " + arg(micros(*query.AvailableTo))
+		}
+		sqlQuery += slots + ")"
+	}
+	sqlQuery += " ORDER BY name, id"
+
+	rows, err := s.Conn.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	terms := db.SearchTerms(query.Text)
+	providers := []schema.Provider{}
+	for rows.Next() {
+		provider, err := scanProvider(rows)
+		if err != nil {
+			return nil, err
+		}
+		bio := ""
+		if provider.Bio != nil {
+			bio = *provider.Bio
+		}
+		if !db.MatchesTerms(terms, *provider.Name, bio) {
+			continue
+		}
+		providers = append(providers, *provider)
+	}
+	return providers, rows.Err()
+}
@@ -0,0 +1,410 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// ReserveSeries reserves every occurrence of a recurring series in one transaction
+func (s *Store) ReserveSeries(ctx context.Context, req db.SeriesRequest) (*schema.AppointmentSeries, error) {
+	ctx, span := tracer.Start(ctx, "db.ReserveSeries")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer s.rollback(tx)
+
+	if err := bookable(ctx, tx, append([]types.UUID{*req.ClientID, *req.ProviderID}, req.ParticipantIDs...)...); err != nil {
+		return nil, err
+	}
+	room, err := findRoom(ctx, tx, req.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.Clock.Now()
+	conflicts := []schema.OccurrenceConflict{}
+	var free []int
+	for i, startTime := range req.StartTimes {
+		reason, err := seatsConflict(ctx, tx, req.ProviderID.String(), req.ParticipantIDs, room, startTime, nil, now)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			conflicts = append(conflicts, schema.OccurrenceConflict{
+				Index:     utils.Ptr(i),
+				StartTime: utils.Ptr(startTime),
+				Reason:    &reason,
+			})
+			continue
+		}
+		free = append(free, i)
+	}
+	if len(free) == 0 || (len(conflicts) > 0 && !req.AllowPartial) {
+		return nil, &db.ConflictError{Conflicts: conflicts}
+	}
+
+	interval := 1
+	if req.Recurrence.Interval != nil {
+		interval = *req.Recurrence.Interval
+	}
+
+	seriesID := uuid.New()
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO appointment_series (id, client_id, provider_id, frequency, repeat_interval, occurrences, created_at, updated_at, organization_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $7, $8)
+`, seriesID.String(), req.ClientID.String(), req.ProviderID.String(), string(req.Recurrence.Frequency), interval, len(req.StartTimes), micros(now), tenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	appointments := make([]schema.Appointment, 0, len(free))
+	for _, i := range free {
+		// The occurrences reserved before count against the client's limits too
+		if err := clientLimits(ctx, tx, req.ClientID, req.ProviderID, req.StartTimes[i], nil, now); err != nil {
+			return nil, err
+		}
+		appointment, err := insertReservedAppointment(ctx, tx, req.ClientID, req.ProviderID, req.RoomID, &req.StartTimes[i], &seriesLink{ID: seriesID, Index: i}, schema.StatusChangeSourceRequest, now)
+		if err != nil {
+			return nil, err
+		}
+		if err := insertParticipants(ctx, tx, appointment, req.ParticipantIDs, now); err != nil {
+			return nil, err
+		}
+		if err := saveIntake(ctx, tx, appointment, req.Intake); err != nil {
+			return nil, err
+		}
+		appointments = append(appointments, *appointment)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &schema.AppointmentSeries{
+		Id:           (*types.UUID)(&seriesID),
+		ClientId:     req.ClientID,
+		ProviderId:   req.ProviderID,
+		Recurrence:   &schema.Recurrence{Frequency: req.Recurrence.Frequency, Interval: &interval, Count: len(req.StartTimes)},
+		Appointments: &appointments,
+		Conflicts:    &conflicts,
+	}, nil
+}
+
+// GetSeries returns a series with all of its appointments, including cancelled ones
+func (s *Store) GetSeries(ctx context.Context, seriesID types.UUID) (*schema.AppointmentSeries, error) {
+	ctx, span := tracer.Start(ctx, "db.GetSeries")
+	defer span.End()
+
+	var id, clientID, providerID uuid.UUID
+	var frequency string
+	var interval, count int
+	err := s.Conn.QueryRowContext(ctx, `
+	SELECT id, client_id, provider_id, frequency, repeat_interval, occurrences
+	FROM appointment_series
+	WHERE id = $1
+	  AND organization_id = $2
+`, seriesID.String(), tenant(ctx)).Scan(&id, &clientID, &providerID, &frequency, &interval, &count)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Conn.QueryContext(ctx, `
+	SELECT `+appointmentColumns+`
+	FROM appointments appt
+	WHERE appt.series_id = $1
+	ORDER BY appt.series_index
+`, seriesID.String())
+	if err != nil {
+		return nil, err
+	}
+	appointments, err := scanAppointments(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.AppointmentSeries{
+		Id:           (*types.UUID)(&id),
+		ClientId:     (*types.UUID)(&clientID),
+		ProviderId:   (*types.UUID)(&providerID),
+		Recurrence:   &schema.Recurrence{Frequency: schema.RecurrenceFrequency(frequency), Interval: &interval, Count: count},
+		Appointments: &appointments,
+	}, nil
+}
+
+// ConfirmSeries confirms every reservation in a series that has not expired and returns how many were
+func (s *Store) ConfirmSeries(ctx context.Context, seriesID types.UUID) (int64, error) {
+	ctx, span := tracer.Start(ctx, "db.ConfirmSeries")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer s.rollback(tx)
+
+	now := s.Clock.Now()
+	change := statusChange{status: schema.AppointmentStatusConfirmed, source: schema.StatusChangeSourceRequest, at: now}
+	rowsAffected, err := changeStatus(ctx, tx, change, `
+	UPDATE appointments
+	SET status = 'confirmed', updated_at = $2
+	WHERE series_id = $1
+	  AND status IN (SELECT value FROM json_each($5))
+	  AND NOT (status = 'reserved' AND created_at <= $3)
+	  AND organization_id = $4
+	RETURNING id
+`, seriesID.String(), micros(now), holdCutoff(now), tenant(ctx), changeableTo(schema.AppointmentStatusConfirmed))
+	if err != nil {
+		return 0, err
+	}
+	if rowsAffected == 0 {
+		return 0, sql.ErrNoRows
+	}
+	return rowsAffected, tx.Commit()
+}
+
+// following selects the appointments moved or cancelled together with target $1 in organization $5, which
+// are the appointment itself and, when $4 is true, every later one in its series. Only those whose status
+// is in $6, the statuses that can change to cancelled, and whose hold did not run out by $3 are active.
+const following = `
+	SELECT appt.id
+	FROM appointments appt, appointments target
+	WHERE target.id = $1
+	  AND target.organization_id = $5
+	  AND (
+	    appt.id = target.id OR
+	    ($4 AND appt.series_id = target.series_id AND appt.series_index >= target.series_index)
+	  )
+	  AND appt.status IN (SELECT value FROM json_each($6))
+	  AND NOT (appt.status = 'reserved' AND appt.created_at <= $3)`
+
+// CancelFollowingAppointments cancels an appointment and every later active appointment in its series. It
+// returns the error of db.TransitionFailure if the appointment can not be cancelled.
+func (s *Store) CancelFollowingAppointments(ctx context.Context, appointmentID types.UUID) error {
+	ctx, span := tracer.Start(ctx, "db.CancelFollowingAppointments")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer s.rollback(tx)
+
+	now := s.Clock.Now()
+	change := statusChange{status: schema.AppointmentStatusCancelled, source: schema.StatusChangeSourceRequest, at: now}
+	cancelled, err := changeStatus(ctx, tx, change, `
+	UPDATE appointments
+	SET status = 'cancelled', updated_at = $2
+	WHERE id IN (`+following+`)
+	RETURNING id
+`, appointmentID.String(), micros(now), holdCutoff(now), true, tenant(ctx), changeableTo(schema.AppointmentStatusCancelled))
+	if err != nil {
+		return err
+	}
+	if cancelled > 0 {
+		return tx.Commit()
+	}
+	return transitionFailure(ctx, tx, appointmentID, schema.AppointmentStatusCancelled)
+}
+
+// RescheduleAppointment moves an active appointment to a new start time. Appointments can be moved as long
+// as they can be cancelled, it returns the error of db.TransitionFailure for one that can not.
+func (s *Store) RescheduleAppointment(ctx context.Context, appointmentID types.UUID, newStart time.Time) ([]schema.Appointment, error) {
+	ctx, span := tracer.Start(ctx, "db.RescheduleAppointment")
+	defer span.End()
+
+	return s.reschedule(ctx, appointmentID, newStart, false)
+}
+
+// RescheduleFollowingAppointments moves an active appointment to a new start time and shifts every later
+// active appointment in its series by the same amount
+func (s *Store) RescheduleFollowingAppointments(ctx context.Context, appointmentID types.UUID, newStart time.Time) ([]schema.Appointment, error) {
+	ctx, span := tracer.Start(ctx, "db.RescheduleFollowingAppointments")
+	defer span.End()
+
+	return s.reschedule(ctx, appointmentID, newStart, true)
+}
+
+func (s *Store) reschedule(ctx context.Context, appointmentID types.UUID, newStart time.Time, withSeries bool) ([]schema.Appointment, error) {
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer s.rollback(tx)
+
+	now := s.Clock.Now()
+	rows, err := tx.QueryContext(ctx, `
+	SELECT `+appointmentColumns+`
+	FROM appointments appt
+	WHERE appt.id IN (`+following+`)
+	ORDER BY appt.start_time
+`, appointmentID.String(), micros(now), holdCutoff(now), withSeries, tenant(ctx), changeableTo(schema.AppointmentStatusCancelled))
+	if err != nil {
+		return nil, err
+	}
+	moving, err := scanAppointments(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var shift time.Duration
+	found := false
+	ids := make([]string, 0, len(moving))
+	for _, appointment := range moving {
+		ids = append(ids, appointment.Id.String())
+		if *appointment.Id == appointmentID {
+			shift = newStart.Sub(*appointment.StartTime)
+			found = true
+		}
+	}
+	if !found {
+		return nil, transitionFailure(ctx, tx, appointmentID, schema.AppointmentStatusCancelled)
+	}
+
+	conflicts := []schema.OccurrenceConflict{}
+	for i, appointment := range moving {
+		// Appointments keep their room, which has to be free and at the location of the new slot, and
+		// their participants, who all need a free slot at the new time
+		room, err := findRoom(ctx, tx, appointment.RoomId)
+		if err != nil {
+			return nil, err
+		}
+		var participantIDs []types.UUID
+		if appointment.ParticipantIds != nil {
+			participantIDs = *appointment.ParticipantIds
+		}
+		startTime := appointment.StartTime.Add(shift)
+		reason, err := seatsConflict(ctx, tx, appointment.ProviderId.String(), participantIDs, room, startTime, ids, now)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			index := i
+			if appointment.SeriesIndex != nil {
+				index = *appointment.SeriesIndex
+			}
+			conflicts = append(conflicts, schema.OccurrenceConflict{
+				Index:     &index,
+				StartTime: &startTime,
+				Reason:    &reason,
+			})
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, &db.ConflictError{Conflicts: conflicts}
+	}
+
+	for i := range moving {
+		startTime := moving[i].StartTime.Add(shift)
+		endTime := startTime.Add(db.SlotInterval(ctx))
+		var locationID uuid.NullUUID
+		err := tx.QueryRowContext(ctx, `
+		UPDATE appointments
+		SET start_time = $2, end_time = $3, updated_at = $4,
+		  location_id = (SELECT location_id FROM availability WHERE provider_id = appointments.provider_id AND start_time = $2)
+		WHERE id = $1
+		RETURNING location_id
+`, moving[i].Id.String(), micros(startTime), micros(endTime), micros(now)).Scan(&locationID)
+		if err != nil {
+			return nil, err
+		}
+		moving[i].StartTime = &startTime
+		moving[i].EndTime = &endTime
+		moving[i].LocationId = nullUUID(locationID)
+	}
+
+	// Each moved appointment is checked against the client's other ones at their new times
+	for _, appointment := range moving {
+		excluded := []string{appointment.Id.String()}
+		if err := clientLimits(ctx, tx, appointment.ClientId, appointment.ProviderId, *appointment.StartTime, excluded, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return moving, nil
+}
+
+const appointmentColumns = `appt.id, appt.client_id, appt.provider_id, appt.start_time, appt.end_time, appt.status, appt.series_id, appt.series_index, appt.location_id, appt.room_id,
+	  appt.status_changed_at, appt.status_changed_by, COALESCE(appt.reason, ''), appt.appointment_type_id, COALESCE(appt.intake_answers, ''),
+	  ` + participantColumn
+
+// nullUUID is the id, or nil if it is NULL
+func nullUUID(id uuid.NullUUID) *types.UUID {
+	if !id.Valid {
+		return nil
+	}
+	return (*types.UUID)(&id.UUID)
+}
+
+// scanAppointments reads appointmentColumns rows and closes them
+func scanAppointments(rows *sql.Rows) ([]schema.Appointment, error) {
+	defer rows.Close()
+
+	appointments := []schema.Appointment{}
+	for rows.Next() {
+		var id, clientID, providerID uuid.UUID
+		var startTime, endTime int64
+		var status string
+		var seriesID uuid.NullUUID
+		var seriesIndex sql.NullInt64
+		var locationID, roomID, changedBy, appointmentTypeID uuid.NullUUID
+		var changedAt sql.NullInt64
+		var reason, answers string
+		var participants string
+
+		err := rows.Scan(&id, &clientID, &providerID, &startTime, &endTime, &status, &seriesID, &seriesIndex, &locationID, &roomID,
+			&changedAt, &changedBy, &reason, &appointmentTypeID, &answers, &participants)
+		if err != nil {
+			return nil, err
+		}
+		participantIDs, err := parseParticipants(participants)
+		if err != nil {
+			return nil, err
+		}
+
+		appointmentStatus := schema.AppointmentStatus(status)
+		appointment := schema.Appointment{
+			Id:             (*types.UUID)(&id),
+			ClientId:       (*types.UUID)(&clientID),
+			ProviderId:     (*types.UUID)(&providerID),
+			StartTime:      utils.Ptr(fromMicros(startTime)),
+			EndTime:        utils.Ptr(fromMicros(endTime)),
+			Status:         &appointmentStatus,
+			LocationId:     nullUUID(locationID),
+			RoomId:         nullUUID(roomID),
+			ParticipantIds: participantIDs,
+
+			StatusChangedBy: nullUUID(changedBy),
+		}
+		if changedAt.Valid {
+			appointment.StatusChangedAt = utils.Ptr(fromMicros(changedAt.Int64))
+		}
+		if err := db.SetIntake(&appointment, reason, appointmentTypeID, answers); err != nil {
+			return nil, err
+		}
+		if seriesID.Valid {
+			appointment.SeriesId = (*types.UUID)(&seriesID.UUID)
+			appointment.SeriesIndex = utils.Ptr(int(seriesIndex.Int64))
+		}
+		appointments = append(appointments, appointment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return appointments, nil
+}
@@ -0,0 +1,622 @@
+// Package sqlite keeps the data in a single sqlite file, for small deployments that run without postgres.
+// It translates the queries in package db: times are microseconds since the unix epoch and rows are not
+// locked with FOR UPDATE because every transaction holds the write lock.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/XSAM/otelsql"
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/clock"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	_ "modernc.org/sqlite" // SQLite driver
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// pragmas are set on every connection. Transactions begin immediately so they take the write lock before
+// reading, which is what makes checking for a free seat and taking it atomic like the row locks in postgres.
+// Writers queue behind the busy timeout instead of failing, WAL lets readers carry on meanwhile.
+const pragmas = "_pragma=foreign_keys(1)&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_txlock=immediate"
+
+// active matches the appointments that hold a seat, $3 is the creation time before which holds expired
+const active = `(
+	    appt.status = 'confirmed' OR
+	    (appt.status = 'reserved' AND appt.created_at > $3)
+	  )`
+
+// tracer records a span for every Store method under the same names as package db
+var tracer = otel.Tracer("github.com/tateexon/reservation/db/sqlite")
+
+// Store keeps everything in a sqlite database
+type Store struct {
+	Conn   *sql.DB
+	Logger *slog.Logger
+	// Clock is the time queries run at
+	Clock clock.Clock
+}
+
+// Ensure that Store implements db.Store
+var _ db.Store = (*Store)(nil)
+
+// New opens the sqlite database at path, creating the file if it does not exist. A nil logger uses the default logger.
+func New(path string, logger *slog.Logger) (*Store, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	// Every statement is traced as a child of the span in its context
+	conn, err := otelsql.Open("sqlite", "file:"+path+"?"+pragmas, otelsql.WithAttributes(semconv.DBSystemSqlite))
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	logger.Info("Opened database", slog.String("path", path))
+	return &Store{Conn: conn, Logger: logger, Clock: clock.System{}}, nil
+}
+
+// micros converts a time to how it is stored
+func micros(t time.Time) int64 {
+	return t.UnixMicro()
+}
+
+// fromMicros converts a stored time back
+func fromMicros(v int64) time.Time {
+	return time.UnixMicro(v).UTC()
+}
+
+// holdCutoff is the creation time before which reservations made by now have expired
+func holdCutoff(now time.Time) int64 {
+	return micros(now.Add(-db.ReservationHoldDuration))
+}
+
+// rollback ends a transaction that was not committed, it is a no-op after a commit
+func (s *Store) rollback(tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		s.Logger.Error("Failed to roll back transaction", slog.Any("error", err))
+	}
+}
+
+// Ping checks that the database can be opened
+func (s *Store) Ping(ctx context.Context) error {
+	return s.Conn.PingContext(ctx)
+}
+
+// SchemaVersion returns the version of the newest migration applied to the database
+func (s *Store) SchemaVersion(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "db.SchemaVersion")
+	defer span.End()
+
+	var version int
+	err := s.Conn.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	return version, err
+}
+
+// GetAvailableAppointments lists the slots with a seat left. With participants only the slots at times when
+// every participant has a free slot too are listed.
+func (s *Store) GetAvailableAppointments(ctx context.Context, providerID *types.UUID, participantIDs []types.UUID, locationID *types.UUID, date *types.Date) ([]schema.Appointment, error) {
+	ctx, span := tracer.Start(ctx, "db.GetAvailableAppointments")
+	defer span.End()
+
+	appointments := []schema.Appointment{}
+
+	query := `
+    SELECT a.id, a.provider_id, a.start_time, a.end_time, a.capacity, a.capacity - COUNT(appt.id), a.location_id
+    FROM availability a
+    JOIN users u ON u.id = a.provider_id AND u.deactivated_at IS NULL
+    LEFT JOIN appointments appt ON ` + seatOf("a.provider_id") + ` AND a.start_time = appt.start_time
+      AND appt.status IN ('reserved', 'confirmed')
+      AND (
+        appt.status = 'confirmed' OR
+        (appt.status = 'reserved' AND appt.created_at > $1)
+      )
+    WHERE a.organization_id = $2
+    `
+
+	args := []any{holdCutoff(s.Clock.Now()), tenant(ctx)}
+	argIndex := 3
+
+	if providerID != nil {
+		query += fmt.Sprintf(" AND a.provider_id = $%d", argIndex)
+		args = append(args, providerID.String())
+		argIndex++
+	}
+
+	if len(participantIDs) > 0 {
+		participants, err := idsJSON(participantIDs)
+		if err != nil {
+			return nil, err
+		}
+		// Deactivated participants can not be booked, so they are never free
+		query += fmt.Sprintf(" AND %s = $%d", freeProviders(fmt.Sprintf("$%d", argIndex), "a.start_time", "$1", "$2"), argIndex+1)
+		query += fmt.Sprintf(" AND NOT EXISTS (SELECT 1 FROM users pu WHERE pu.id IN (SELECT value FROM json_each($%d)) AND pu.deactivated_at IS NOT NULL)", argIndex)
+		args = append(args, participants, len(participantIDs))
+		argIndex += 2
+	}
+
+	if locationID != nil {
+		query += fmt.Sprintf(" AND a.location_id = $%d", argIndex)
+		args = append(args, locationID.String())
+		argIndex++
+	}
+
+	if date != nil {
+		startOfDay := date.Time
+		endOfDay := startOfDay.Add(24 * time.Hour)
+
+		query += fmt.Sprintf(" AND a.start_time >= $%d AND a.start_time < $%d", argIndex, argIndex+1)
+		args = append(args, micros(startOfDay), micros(endOfDay))
+	}
+
+	// Only slots with seats left are available
+	query += " GROUP BY a.id HAVING COUNT(appt.id) < a.capacity ORDER BY a.start_time"
+
+	rows, err := s.Conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, providerID uuid.UUID
+		var startTime, endTime int64
+		var capacity, seatsRemaining int
+		var locationID uuid.NullUUID
+
+		if err := rows.Scan(&id, &providerID, &startTime, &endTime, &capacity, &seatsRemaining, &locationID); err != nil {
+			return nil, err
+		}
+
+		appointments = append(appointments, schema.Appointment{
+			Id:             (*types.UUID)(&id),
+			ProviderId:     (*types.UUID)(&providerID),
+			StartTime:      utils.Ptr(fromMicros(startTime)),
+			EndTime:        utils.Ptr(fromMicros(endTime)),
+			Capacity:       &capacity,
+			SeatsRemaining: &seatsRemaining,
+			LocationId:     nullUUID(locationID),
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return appointments, nil
+}
+
+func (s *Store) GetAppointmentStartTime(ctx context.Context, availabilityID *types.UUID) (time.Time, error) {
+	ctx, span := tracer.Start(ctx, "db.GetAppointmentStartTime")
+	defer span.End()
+
+	var startTime int64
+	err := s.Conn.QueryRowContext(ctx, `
+	SELECT start_time
+	FROM availability
+	WHERE id = $1
+	  AND organization_id = $2
+`, availabilityID.String(), tenant(ctx)).Scan(&startTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return fromMicros(startTime), nil
+}
+
+// IsSlotAvailable reports whether the provider and every participant have a slot with a seat left at startTime
+func (s *Store) IsSlotAvailable(ctx context.Context, providerID *types.UUID, participantIDs []types.UUID, startTime *time.Time) (bool, error) {
+	ctx, span := tracer.Start(ctx, "db.IsSlotAvailable")
+	defer span.End()
+
+	providers := append([]types.UUID{*providerID}, participantIDs...)
+	providersJSON, err := idsJSON(providers)
+	if err != nil {
+		return false, err
+	}
+	var count int
+	err = s.Conn.QueryRowContext(ctx, `
+        SELECT `+freeProviders("$1", "$2", "$3", "$4")+`
+    `, providersJSON, micros(*startTime), holdCutoff(s.Clock.Now()), tenant(ctx)).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count == len(providers), nil
+}
+
+// ReserveAppointment holds a seat in a provider's slot, in the slot of every participant at the same time
+// and the room if roomID is not nil. Any of them being taken fails the whole reservation, and so does an
+// intake that does not fit its appointment type.
+func (s *Store) ReserveAppointment(ctx context.Context, clientID, providerID *types.UUID, participantIDs []types.UUID, roomID *types.UUID, startTime *time.Time, intake *db.Intake) (*schema.Appointment, error) {
+	ctx, span := tracer.Start(ctx, "db.ReserveAppointment")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer s.rollback(tx)
+
+	if err := bookable(ctx, tx, append([]types.UUID{*clientID, *providerID}, participantIDs...)...); err != nil {
+		return nil, err
+	}
+	now := s.Clock.Now()
+	if err := clientLimits(ctx, tx, clientID, providerID, *startTime, nil, now); err != nil {
+		return nil, err
+	}
+	room, err := findRoom(ctx, tx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	reason, err := seatsConflict(ctx, tx, providerID.String(), participantIDs, room, *startTime, nil, now)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		return nil, db.SlotError(reason)
+	}
+
+	appointment, err := insertReservedAppointment(ctx, tx, clientID, providerID, roomID, startTime, nil, schema.StatusChangeSourceRequest, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := insertParticipants(ctx, tx, appointment, participantIDs, now); err != nil {
+		return nil, err
+	}
+	if err := saveIntake(ctx, tx, appointment, intake); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return appointment, nil
+}
+
+// slotConflict reports why a provider's slot, and room if it is not nil, can not be booked at now, ignoring
+// the appointments in excluded. tx holds the write lock so nothing else can take a seat before it ends.
+func slotConflict(ctx context.Context, tx *sql.Tx, providerID string, room *bookedRoom, startTime time.Time, excluded []string, now time.Time) (schema.OccurrenceConflictReason, error) {
+	var capacity int
+	var locationID uuid.NullUUID
+	err := tx.QueryRowContext(ctx, `
+	SELECT capacity, location_id
+	FROM availability
+	WHERE provider_id = $1 AND start_time = $2
+`, providerID, micros(startTime)).Scan(&capacity, &locationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return schema.NoAvailability, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	excludedIDs, err := excludedJSON(excluded)
+	if err != nil {
+		return "", err
+	}
+
+	var booked int
+	err = tx.QueryRowContext(ctx, `
+	SELECT COUNT(*)
+	FROM appointments appt
+	WHERE `+seatOf("$1")+`
+	  AND appt.start_time = $2
+	  AND appt.id NOT IN (SELECT value FROM json_each($4))
+	  AND `+active+`
+`, providerID, micros(startTime), holdCutoff(now), excludedIDs).Scan(&booked)
+	if err != nil {
+		return "", err
+	}
+	if booked >= capacity {
+		return schema.Booked, nil
+	}
+
+	if room == nil {
+		return "", nil
+	}
+	if locationID.UUID != room.locationID {
+		return schema.RoomElsewhere, nil
+	}
+	taken, err := roomConflict(ctx, tx, room, providerID, startTime, excludedIDs, now)
+	if err != nil {
+		return "", err
+	}
+	if taken {
+		return schema.RoomBooked, nil
+	}
+	return "", nil
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// seriesLink places an appointment in a recurring series
+type seriesLink struct {
+	ID    uuid.UUID
+	Index int
+}
+
+// insertReservedAppointment holds a seat in a slot, the appointment takes the location of the slot. Its
+// history starts with the reservation, made by source.
+func insertReservedAppointment(ctx context.Context, conn queryer, clientID, providerID, roomID *types.UUID, startTime *time.Time, series *seriesLink, source schema.StatusChangeSource, now time.Time) (*schema.Appointment, error) {
+	endTime := startTime.Add(db.SlotInterval(ctx))
+	appointmentID := uuid.New()
+
+	var seriesID uuid.NullUUID
+	var seriesIndex sql.NullInt64
+	if series != nil {
+		seriesID = uuid.NullUUID{UUID: series.ID, Valid: true}
+		seriesIndex = sql.NullInt64{Int64: int64(series.Index), Valid: true}
+	}
+
+	var room, locationID uuid.NullUUID
+	if roomID != nil {
+		room = uuid.NullUUID{UUID: *roomID, Valid: true}
+	}
+
+	err := conn.QueryRowContext(ctx, `
+		INSERT INTO appointments (id, client_id, provider_id, start_time, end_time, status, series_id, series_index, room_id, location_id, created_at, updated_at, organization_id)
+		VALUES ($1, $2, $3, $4, $5, 'reserved', $6, $7, $8,
+		  (SELECT location_id FROM availability WHERE provider_id = $3 AND start_time = $4), $9, $9, $10)
+		RETURNING location_id
+		`, appointmentID.String(), clientID.String(), providerID.String(), micros(*startTime), micros(endTime), seriesID, seriesIndex, room, micros(now), tenant(ctx)).Scan(&locationID)
+	if err != nil {
+		return nil, err
+	}
+	status := schema.AppointmentStatusReserved
+	if err := recordStatus(ctx, conn, appointmentID.String(), statusChange{status: status, source: source, at: now}); err != nil {
+		return nil, err
+	}
+	appointment := &schema.Appointment{
+		Id:         (*types.UUID)(&appointmentID),
+		ClientId:   clientID,
+		ProviderId: providerID,
+		StartTime:  startTime,
+		EndTime:    &endTime,
+		Status:     &status,
+		LocationId: nullUUID(locationID),
+		RoomId:     roomID,
+	}
+	if series != nil {
+		appointment.SeriesId = (*types.UUID)(&series.ID)
+		appointment.SeriesIndex = utils.Ptr(series.Index)
+	}
+	return appointment, nil
+}
+
+func (s *Store) ConfirmAppointment(ctx context.Context, appointmentID types.UUID) error {
+	ctx, span := tracer.Start(ctx, "db.ConfirmAppointment")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer s.rollback(tx)
+
+	now := s.Clock.Now()
+	change := statusChange{status: schema.AppointmentStatusConfirmed, source: schema.StatusChangeSourceRequest, at: now}
+	confirmed, err := changeStatus(ctx, tx, change, `
+	UPDATE appointments
+	SET status = 'confirmed', updated_at = $2
+	WHERE id = $1
+	  AND status IN (SELECT value FROM json_each($5))
+	  AND NOT (status = 'reserved' AND created_at <= $3)
+	  AND organization_id = $4
+	RETURNING id
+`, appointmentID.String(), micros(now), holdCutoff(now), tenant(ctx), changeableTo(schema.AppointmentStatusConfirmed))
+	if err != nil {
+		return err
+	}
+	if confirmed > 0 {
+		return tx.Commit()
+	}
+
+	// Tell a hold that ran out from an appointment that is missing or can not be confirmed
+	var status schema.AppointmentStatus
+	var expired bool
+	err = tx.QueryRowContext(ctx, `
+	SELECT status, status = 'expired' OR (status = 'reserved' AND created_at <= $2)
+	FROM appointments
+	WHERE id = $1
+	  AND organization_id = $3
+`, appointmentID.String(), holdCutoff(now), tenant(ctx)).Scan(&status, &expired)
+	if err != nil {
+		return err
+	}
+	if expired {
+		return db.ErrHoldExpired
+	}
+	return db.TransitionFailure(status, schema.AppointmentStatusConfirmed)
+}
+
+// ExpireReservations marks reservations that were not confirmed in time as expired and returns how many were.
+// Queries already treat these holds as expired, this only makes the status explicit.
+func (s *Store) ExpireReservations(ctx context.Context) (int64, error) {
+	ctx, span := tracer.Start(ctx, "db.ExpireReservations")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer s.rollback(tx)
+
+	now := s.Clock.Now()
+	change := statusChange{status: schema.AppointmentStatusExpired, source: schema.StatusChangeSourceHoldExpiry, at: now}
+	expired, err := changeStatus(ctx, tx, change, `
+	UPDATE appointments
+	SET status = 'expired', updated_at = $1
+	WHERE status IN (SELECT value FROM json_each($4))
+	  AND created_at <= $2
+	  AND organization_id = $3
+	RETURNING id
+`, micros(now), holdCutoff(now), tenant(ctx), changeableTo(schema.AppointmentStatusExpired))
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	if expired > 0 {
+		s.Logger.Debug("Expired reservations", slog.Int64("count", expired))
+	}
+	return expired, nil
+}
+
+// CancelAppointment cancels an active reservation or a confirmed appointment, freeing its slot. It returns
+// the error of db.TransitionFailure if the appointment can not be cancelled.
+func (s *Store) CancelAppointment(ctx context.Context, appointmentID types.UUID) error {
+	ctx, span := tracer.Start(ctx, "db.CancelAppointment")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer s.rollback(tx)
+
+	now := s.Clock.Now()
+	change := statusChange{status: schema.AppointmentStatusCancelled, source: schema.StatusChangeSourceRequest, at: now}
+	cancelled, err := changeStatus(ctx, tx, change, `
+	UPDATE appointments
+	SET status = 'cancelled', updated_at = $2
+	WHERE id = $1
+	  AND organization_id = $4
+	  AND status IN (SELECT value FROM json_each($5))
+	  AND NOT (status = 'reserved' AND created_at <= $3)
+	RETURNING id
+`, appointmentID.String(), micros(now), holdCutoff(now), tenant(ctx), changeableTo(schema.AppointmentStatusCancelled))
+	if err != nil {
+		return err
+	}
+	if cancelled > 0 {
+		return tx.Commit()
+	}
+	return transitionFailure(ctx, tx, appointmentID, schema.AppointmentStatusCancelled)
+}
+
+// expectRows turns an update that matched nothing into sql.ErrNoRows
+func expectRows(result sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// AddAvailability adds slots that up to capacity clients can book, at a location if locationID is not nil
+func (s *Store) AddAvailability(ctx context.Context, providerID types.UUID, locationID *types.UUID, slots []time.Time, capacity int) error {
+	ctx, span := tracer.Start(ctx, "db.AddAvailability")
+	defer span.End()
+
+	if err := activeUserWithRole(ctx, s.Conn, providerID, string(schema.UserRoleProvider)); err != nil {
+		return err
+	}
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer s.rollback(tx)
+
+	var location uuid.NullUUID
+	if locationID != nil {
+		// The foreign key would accept another organization's location
+		if err := locationExists(ctx, tx, *locationID); err != nil {
+			return err
+		}
+		location = uuid.NullUUID{UUID: *locationID, Valid: true}
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+	INSERT INTO availability (id, provider_id, start_time, end_time, capacity, created_at, updated_at, organization_id, location_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $8)
+	ON CONFLICT (provider_id, start_time) DO NOTHING
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	now := micros(s.Clock.Now())
+	interval := db.SlotInterval(ctx)
+	for _, startTime := range slots {
+		endTime := startTime.Add(interval)
+		_, err := stmt.ExecContext(ctx, uuid.NewString(), providerID.String(), micros(startTime), micros(endTime), capacity, now, tenant(ctx), location)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// userWithRoleExists returns sql.ErrNoRows unless there is a user with the id and role
+func (s *Store) userWithRoleExists(ctx context.Context, userID types.UUID, role string) error {
+	var id string
+	return s.Conn.QueryRowContext(ctx, `
+	SELECT id
+	FROM users
+	WHERE id = $1
+	AND role = $2
+	AND organization_id = $3
+`, userID.String(), role, tenant(ctx)).Scan(&id)
+}
+
+func (s *Store) CreateUser(ctx context.Context, name, email, role string) (*schema.User, error) {
+	ctx, span := tracer.Start(ctx, "db.CreateUser")
+	defer span.End()
+
+	userID := uuid.New()
+	_, err := s.Conn.ExecContext(ctx, `
+        INSERT INTO users (id, name, email, role, created_at, updated_at, organization_id)
+        VALUES ($1, $2, $3, $4, $5, $5, $6)
+    `, userID.String(), name, email, role, micros(s.Clock.Now()), tenant(ctx))
+	if isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) {
+		return nil, db.ErrEmailTaken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	userRole := schema.UserRole(role)
+	return &schema.User{
+		Id:    (*types.UUID)(&userID),
+		Name:  utils.Ptr(name),
+		Email: utils.Ptr(email),
+		Role:  &userRole,
+	}, nil
+}
+
+func (s *Store) GetUser(ctx context.Context, userID types.UUID) (*schema.User, error) {
+	ctx, span := tracer.Start(ctx, "db.GetUser")
+	defer span.End()
+
+	return scanUser(s.Conn.QueryRowContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE id = $1
+		  AND organization_id = $2
+	`, userID.String(), tenant(ctx)))
+}
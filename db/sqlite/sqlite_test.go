@@ -0,0 +1,31 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/clock"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/db/sqlite"
+	"github.com/tateexon/reservation/db/storetest"
+	"github.com/tateexon/reservation/migrations"
+)
+
+func TestConformance(t *testing.T) {
+	t.Parallel()
+
+	storetest.Run(t, storetest.Harness{
+		New: func(t *testing.T, clock clock.Clock) db.Store {
+			store, err := sqlite.New(filepath.Join(t.TempDir(), "reservation.db"), nil)
+			require.NoError(t, err)
+			t.Cleanup(func() { store.Conn.Close() })
+			store.Clock = clock
+
+			_, err = migrations.NewRunner(store.Conn, migrations.SQLite, nil).Up(context.Background())
+			require.NoError(t, err)
+			return store
+		},
+	})
+}
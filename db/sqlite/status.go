@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+)
+
+// changeableTo is db.ChangeableTo encoded for json_each
+func changeableTo(status schema.AppointmentStatus) string {
+	statuses, _ := json.Marshal(db.ChangeableTo(status)) // a list of strings always encodes
+	return string(statuses)
+}
+
+// transitionFailure looks up the status of an appointment a change to status was not made to and returns
+// db.TransitionFailure for it, sql.ErrNoRows if it is not in the organization
+func transitionFailure(ctx context.Context, conn queryer, appointmentID types.UUID, to schema.AppointmentStatus) error {
+	var from schema.AppointmentStatus
+	err := conn.QueryRowContext(ctx, `SELECT status FROM appointments WHERE id = $1 AND organization_id = $2`,
+		appointmentID.String(), tenant(ctx)).Scan(&from)
+	if err != nil {
+		return err
+	}
+	return db.TransitionFailure(from, to)
+}
+
+// UpdateAppointmentStatus moves an appointment along its lifecycle, recording the provider who did and when.
+// It returns sql.ErrNoRows if the appointment is not in the organization, db.ErrNotAppointmentProvider if
+// changedBy is not its provider or a participant and the error of db.CheckTransition if the change is not
+// allowed.
+func (s *Store) UpdateAppointmentStatus(ctx context.Context, appointmentID types.UUID, status schema.AppointmentStatus, changedBy types.UUID) (*schema.Appointment, error) {
+	ctx, span := tracer.Start(ctx, "db.UpdateAppointmentStatus")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer s.rollback(tx)
+
+	var from schema.AppointmentStatus
+	var createdAt, startTime int64
+	var providerID uuid.UUID
+	var participant bool
+	err = tx.QueryRowContext(ctx, `
+	SELECT appt.status, appt.created_at, appt.start_time, appt.provider_id, EXISTS (
+	  SELECT 1 FROM appointment_participants ap WHERE ap.appointment_id = appt.id AND ap.provider_id = $2
+	)
+	FROM appointments appt
+	WHERE appt.id = $1
+	  AND appt.organization_id = $3
+`, appointmentID.String(), changedBy.String(), tenant(ctx)).Scan(&from, &createdAt, &startTime, &providerID, &participant)
+	if err != nil {
+		return nil, err
+	}
+	if providerID != changedBy && !participant {
+		return nil, db.ErrNotAppointmentProvider
+	}
+	now := s.Clock.Now()
+	if err := db.CheckTransition(from, status, fromMicros(createdAt), fromMicros(startTime), now); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE appointments
+	SET status = $2, status_changed_at = $3, status_changed_by = $4, updated_at = $3
+	WHERE id = $1
+`, appointmentID.String(), string(status), micros(now), changedBy.String())
+	if err != nil {
+		return nil, err
+	}
+	change := statusChange{status: status, source: schema.StatusChangeSourceProvider, changedBy: &changedBy, at: now}
+	if err := recordStatus(ctx, tx, appointmentID.String(), change); err != nil {
+		return nil, err
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT `+appointmentColumns+` FROM appointments appt WHERE appt.id = $1`, appointmentID.String())
+	if err != nil {
+		return nil, err
+	}
+	appointments, err := scanAppointments(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &appointments[0], nil
+}
@@ -0,0 +1,192 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+	sqlitedriver "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// isConstraintError reports whether err is the sqlite constraint violation with the extended code
+func isConstraintError(err error, code int) bool {
+	var sqliteErr *sqlitedriver.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == code
+}
+
+// userColumns are the columns scanUser reads, in order
+const userColumns = `id, name, email, role, deactivated_at`
+
+func scanUser(row *sql.Row) (*schema.User, error) {
+	var id uuid.UUID
+	var name, email, role string
+	var deactivatedAt sql.NullInt64
+	if err := row.Scan(&id, &name, &email, &role, &deactivatedAt); err != nil {
+		return nil, err
+	}
+
+	userRole := schema.UserRole(role)
+	user := &schema.User{
+		Id:    (*types.UUID)(&id),
+		Name:  utils.Ptr(name),
+		Email: utils.Ptr(email),
+		Role:  &userRole,
+	}
+	if deactivatedAt.Valid {
+		user.DeactivatedAt = utils.Ptr(fromMicros(deactivatedAt.Int64))
+	}
+	return user, nil
+}
+
+// activeUserWithRole returns sql.ErrNoRows unless there is a user with the id and role, and
+// db.ErrUserInactive if they have been deactivated
+func activeUserWithRole(ctx context.Context, conn queryer, userID types.UUID, role string) error {
+	var deactivatedAt sql.NullInt64
+	err := conn.QueryRowContext(ctx, `
+	SELECT deactivated_at
+	FROM users
+	WHERE id = $1
+	AND role = $2
+	AND organization_id = $3
+`, userID.String(), role, tenant(ctx)).Scan(&deactivatedAt)
+	if err != nil {
+		return err
+	}
+	if deactivatedAt.Valid {
+		return db.ErrUserInactive
+	}
+	return nil
+}
+
+// bookable returns sql.ErrNoRows if any of the users is not in the organization and db.ErrUserInactive
+// if any has been deactivated. tx holds the write lock so they can not be deactivated before it ends.
+func bookable(ctx context.Context, tx *sql.Tx, userIDs ...types.UUID) error {
+	ids, err := idsJSON(userIDs)
+	if err != nil {
+		return err
+	}
+	var found, deactivated int
+	err = tx.QueryRowContext(ctx, `
+	SELECT COUNT(*), COUNT(deactivated_at)
+	FROM users
+	WHERE id IN (SELECT value FROM json_each($1))
+	  AND organization_id = $2
+`, ids, tenant(ctx)).Scan(&found, &deactivated)
+	if err != nil {
+		return err
+	}
+	if deactivated > 0 {
+		return db.ErrUserInactive
+	}
+	distinct := map[types.UUID]bool{}
+	for _, id := range userIDs {
+		distinct[id] = true
+	}
+	if found < len(distinct) {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// UpdateUser changes a user's name or email, returning db.ErrEmailTaken if another user has the email
+func (s *Store) UpdateUser(ctx context.Context, userID types.UUID, update db.UserUpdate) (*schema.User, error) {
+	ctx, span := tracer.Start(ctx, "db.UpdateUser")
+	defer span.End()
+
+	user, err := scanUser(s.Conn.QueryRowContext(ctx, `
+	UPDATE users
+	SET name = COALESCE($2, name), email = COALESCE($3, email), updated_at = $4
+	WHERE id = $1
+	  AND organization_id = $5
+	RETURNING `+userColumns+`
+`, userID.String(), update.Name, update.Email, micros(s.Clock.Now()), tenant(ctx)))
+	if isConstraintError(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE) {
+		return nil, db.ErrEmailTaken
+	}
+	return user, err
+}
+
+// DeactivateUser stops a user from booking or being booked and cancels the waitlist entries still
+// waiting for them. Their appointments are kept. Deactivating a user again keeps the first time.
+func (s *Store) DeactivateUser(ctx context.Context, userID types.UUID) (*schema.User, error) {
+	ctx, span := tracer.Start(ctx, "db.DeactivateUser")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer s.rollback(tx)
+
+	now := micros(s.Clock.Now())
+	user, err := scanUser(tx.QueryRowContext(ctx, `
+	UPDATE users
+	SET deactivated_at = COALESCE(deactivated_at, $2), updated_at = $2
+	WHERE id = $1
+	  AND organization_id = $3
+	RETURNING `+userColumns+`
+`, userID.String(), now, tenant(ctx)))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE waitlist_entries
+	SET status = 'cancelled', updated_at = $2
+	WHERE status = 'waiting'
+	  AND (client_id = $1 OR provider_id = $1)
+`, userID.String(), now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// DeleteUser removes a user with their availability and waitlist entries. A user who has appointments,
+// even cancelled ones, is not deleted and db.ErrUserHasAppointments is returned so they can be
+// deactivated instead. The foreign keys here still cascade, so this check is all that keeps the history.
+func (s *Store) DeleteUser(ctx context.Context, userID types.UUID) error {
+	ctx, span := tracer.Start(ctx, "db.DeleteUser")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer s.rollback(tx)
+
+	var hasAppointments bool
+	err = tx.QueryRowContext(ctx, `
+	SELECT EXISTS (
+	  SELECT 1 FROM appointments
+	  WHERE (client_id = $1 OR provider_id = $1)
+	    AND organization_id = $2
+	  UNION ALL
+	  SELECT 1 FROM appointment_participants
+	  WHERE provider_id = $1
+	    AND organization_id = $2
+	)
+`, userID.String(), tenant(ctx)).Scan(&hasAppointments)
+	if err != nil {
+		return err
+	}
+	if hasAppointments {
+		return db.ErrUserHasAppointments
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1 AND organization_id = $2`, userID.String(), tenant(ctx))
+	if err := expectRows(result, err); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,339 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// freeSlots selects the start times of a provider's slots with a free seat in the window from $2 to $4
+// that start at or after $5, earliest first
+var freeSlots = `
+	SELECT a.start_time
+	FROM availability a
+	WHERE a.provider_id = $1
+	  AND a.start_time >= $2 AND a.start_time >= $5 AND a.end_time <= $4
+	  AND (
+	    SELECT COUNT(*) FROM appointments appt
+	    WHERE ` + seatOf("a.provider_id") + `
+	      AND appt.start_time = a.start_time
+	      AND ` + active + `
+	  ) < a.capacity
+	ORDER BY a.start_time`
+
+// JoinWaitlist adds a client to a provider's waitlist for a time window. Only slots starting at or
+// after earliestStart are considered bookable.
+func (s *Store) JoinWaitlist(ctx context.Context, clientID, providerID types.UUID, windowStart, windowEnd, earliestStart time.Time) (*schema.WaitlistEntry, error) {
+	ctx, span := tracer.Start(ctx, "db.JoinWaitlist")
+	defer span.End()
+
+	if err := activeUserWithRole(ctx, s.Conn, providerID, string(schema.UserRoleProvider)); err != nil {
+		return nil, err
+	}
+	if err := activeUserWithRole(ctx, s.Conn, clientID, string(schema.UserRoleClient)); err != nil {
+		return nil, err
+	}
+
+	now := s.Clock.Now()
+	var count int
+	err := s.Conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM (`+freeSlots+`)`,
+		providerID.String(), micros(windowStart), holdCutoff(now), micros(windowEnd), micros(earliestStart)).Scan(&count)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, db.ErrSlotsAvailable
+	}
+
+	entryID := uuid.New()
+	createdAt := fromMicros(micros(now))
+	_, err = s.Conn.ExecContext(ctx, `
+	INSERT INTO waitlist_entries (id, client_id, provider_id, window_start, window_end, status, created_at, updated_at, organization_id)
+	VALUES ($1, $2, $3, $4, $5, 'waiting', $6, $6, $7)
+`, entryID.String(), clientID.String(), providerID.String(), micros(windowStart), micros(windowEnd), micros(createdAt), tenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	var position int
+	err = s.Conn.QueryRowContext(ctx, `
+	SELECT COUNT(*)
+	FROM waitlist_entries
+	WHERE provider_id = $1
+	  AND status = 'waiting'
+	  AND (created_at, id) <= ($2, $3)
+`, providerID.String(), micros(createdAt), entryID.String()).Scan(&position)
+	if err != nil {
+		return nil, err
+	}
+
+	status := schema.WaitlistEntryStatusWaiting
+	return &schema.WaitlistEntry{
+		Id:          (*types.UUID)(&entryID),
+		ClientId:    &clientID,
+		ProviderId:  &providerID,
+		WindowStart: &windowStart,
+		WindowEnd:   &windowEnd,
+		Status:      &status,
+		Position:    &position,
+		CreatedAt:   &createdAt,
+	}, nil
+}
+
+// LeaveWaitlist removes a waiting client from the waitlist
+func (s *Store) LeaveWaitlist(ctx context.Context, entryID types.UUID) error {
+	ctx, span := tracer.Start(ctx, "db.LeaveWaitlist")
+	defer span.End()
+
+	result, err := s.Conn.ExecContext(ctx, `
+	UPDATE waitlist_entries
+	SET status = 'cancelled', updated_at = $2
+	WHERE id = $1
+	  AND status = 'waiting'
+	  AND organization_id = $3
+`, entryID.String(), micros(s.Clock.Now()), tenant(ctx))
+	return expectRows(result, err)
+}
+
+// GetProviderWaitlist returns the offered and waiting entries for a provider in the order they are served
+func (s *Store) GetProviderWaitlist(ctx context.Context, providerID types.UUID) ([]schema.WaitlistEntry, error) {
+	ctx, span := tracer.Start(ctx, "db.GetProviderWaitlist")
+	defer span.End()
+
+	if err := s.userWithRoleExists(ctx, providerID, string(schema.UserRoleProvider)); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Conn.QueryContext(ctx, `
+	SELECT w.id, w.client_id, w.provider_id, w.window_start, w.window_end, w.status, w.appointment_id, appt.created_at,
+	  ROW_NUMBER() OVER (PARTITION BY w.status ORDER BY w.created_at, w.id),
+	  w.created_at
+	FROM waitlist_entries w
+	LEFT JOIN appointments appt ON appt.id = w.appointment_id
+	WHERE w.provider_id = $1
+	  AND w.status IN ('offered', 'waiting')
+	ORDER BY w.status = 'waiting', w.created_at, w.id
+`, providerID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []schema.WaitlistEntry{}
+	for rows.Next() {
+		var id, clientID, pID uuid.UUID
+		var windowStart, windowEnd, createdAt int64
+		var status string
+		var appointmentID uuid.NullUUID
+		var heldAt sql.NullInt64
+		var position int
+
+		err := rows.Scan(&id, &clientID, &pID, &windowStart, &windowEnd, &status, &appointmentID, &heldAt, &position, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		entryStatus := schema.WaitlistEntryStatus(status)
+		entry := schema.WaitlistEntry{
+			Id:          (*types.UUID)(&id),
+			ClientId:    (*types.UUID)(&clientID),
+			ProviderId:  (*types.UUID)(&pID),
+			WindowStart: utils.Ptr(fromMicros(windowStart)),
+			WindowEnd:   utils.Ptr(fromMicros(windowEnd)),
+			Status:      &entryStatus,
+			CreatedAt:   utils.Ptr(fromMicros(createdAt)),
+		}
+		if entryStatus == schema.WaitlistEntryStatusWaiting {
+			entry.Position = utils.Ptr(position)
+		}
+		if appointmentID.Valid {
+			entry.AppointmentId = (*types.UUID)(&appointmentID.UUID)
+		}
+		if heldAt.Valid {
+			entry.HoldExpiresAt = utils.Ptr(fromMicros(heldAt.Int64).Add(db.ReservationHoldDuration))
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// PromoteWaitlist settles earlier offers and then walks every waiting entry first-come-first-served,
+// placing a hold on the earliest free slot in its window that starts at or after earliestStart.
+func (s *Store) PromoteWaitlist(ctx context.Context, earliestStart time.Time) ([]db.WaitlistOffer, error) {
+	ctx, span := tracer.Start(ctx, "db.PromoteWaitlist")
+	defer span.End()
+
+	tx, err := s.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer s.rollback(tx)
+
+	now := s.Clock.Now()
+
+	// Offers are done once their hold is confirmed, cancelled or expired
+	_, err = tx.ExecContext(ctx, `
+	UPDATE waitlist_entries AS w
+	SET status = CASE appt.status
+	    WHEN 'confirmed' THEN 'fulfilled'
+	    WHEN 'cancelled' THEN 'cancelled'
+	    ELSE 'expired'
+	  END,
+	  updated_at = $1
+	FROM appointments appt
+	WHERE w.appointment_id = appt.id
+	  AND w.status = 'offered'
+	  AND w.organization_id = $3
+	  AND NOT (appt.status = 'reserved' AND appt.created_at > $2)
+`, micros(now), holdCutoff(now), tenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	// Windows that can no longer be booked will never be served
+	_, err = tx.ExecContext(ctx, `
+	UPDATE waitlist_entries
+	SET status = 'expired', updated_at = $2
+	WHERE status = 'waiting'
+	  AND window_end <= $1
+	  AND organization_id = $3
+`, micros(earliestStart), micros(now), tenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+	SELECT id, client_id, provider_id, window_start, window_end, created_at
+	FROM waitlist_entries
+	WHERE status = 'waiting'
+	  AND organization_id = $1
+	ORDER BY created_at, id
+`, tenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+	var waiting []schema.WaitlistEntry
+	for rows.Next() {
+		var id, clientID, providerID uuid.UUID
+		var windowStart, windowEnd, createdAt int64
+		if err := rows.Scan(&id, &clientID, &providerID, &windowStart, &windowEnd, &createdAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		waiting = append(waiting, schema.WaitlistEntry{
+			Id:          (*types.UUID)(&id),
+			ClientId:    (*types.UUID)(&clientID),
+			ProviderId:  (*types.UUID)(&providerID),
+			WindowStart: utils.Ptr(fromMicros(windowStart)),
+			WindowEnd:   utils.Ptr(fromMicros(windowEnd)),
+			CreatedAt:   utils.Ptr(fromMicros(createdAt)),
+		})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var offers []db.WaitlistOffer
+	for _, entry := range waiting {
+		// Entries of deactivated users keep waiting
+		err := bookable(ctx, tx, *entry.ClientId, *entry.ProviderId)
+		if errors.Is(err, db.ErrUserInactive) || errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		// tx holds the write lock, so the first free slot is still free when it is held
+		startTime, found, err := firstFreeSlot(ctx, tx, entry, earliestStart, now)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+
+		appointment, err := insertReservedAppointment(ctx, tx, entry.ClientId, entry.ProviderId, nil, &startTime, nil, schema.StatusChangeSourceWaitlist, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hold slot for waitlist entry %s: %w", entry.Id, err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+		UPDATE waitlist_entries
+		SET status = 'offered', appointment_id = $2, updated_at = $3
+		WHERE id = $1
+`, entry.Id.String(), appointment.Id.String(), micros(now))
+		if err != nil {
+			return nil, err
+		}
+
+		entry.Status = utils.Ptr(schema.WaitlistEntryStatusOffered)
+		entry.AppointmentId = appointment.Id
+		entry.HoldExpiresAt = utils.Ptr(now.Add(db.ReservationHoldDuration))
+		offers = append(offers, db.WaitlistOffer{Entry: entry, Appointment: *appointment})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	for _, offer := range offers {
+		s.Logger.Info("Offered slot to waitlisted client",
+			slog.String("waitlist_entry_id", offer.Entry.Id.String()),
+			slog.String("appointment_id", offer.Appointment.Id.String()),
+			slog.Time("start_time", *offer.Appointment.StartTime),
+		)
+	}
+
+	return offers, nil
+}
+
+// firstFreeSlot finds the earliest slot with a free seat at now in a waitlist entry's window that the client
+// can hold within their limits
+func firstFreeSlot(ctx context.Context, tx *sql.Tx, entry schema.WaitlistEntry, earliestStart, now time.Time) (time.Time, bool, error) {
+	rows, err := tx.QueryContext(ctx, freeSlots,
+		entry.ProviderId.String(), micros(*entry.WindowStart), holdCutoff(now), micros(*entry.WindowEnd), micros(earliestStart))
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	var candidates []time.Time
+	for rows.Next() {
+		var startMicros int64
+		if err := rows.Scan(&startMicros); err != nil {
+			rows.Close()
+			return time.Time{}, false, err
+		}
+		candidates = append(candidates, fromMicros(startMicros))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return time.Time{}, false, err
+	}
+
+	for _, startTime := range candidates {
+		// A slot overlapping another of the client's appointments is passed over, a later one may not
+		err := clientLimits(ctx, tx, entry.ClientId, entry.ProviderId, startTime, nil, now)
+		if db.IsLimitError(err) {
+			continue
+		}
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		return startTime, true, nil
+	}
+	return time.Time{}, false, nil
+}
@@ -0,0 +1,176 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+)
+
+var (
+	// ErrInvalidTransition is returned when an appointment can not change from its status to another
+	ErrInvalidTransition = errors.New("appointment can not change to that status")
+	// ErrAppointmentNotStarted is returned when an appointment is checked in, completed or marked a
+	// no-show before it starts
+	ErrAppointmentNotStarted = errors.New("appointment has not started")
+	// ErrNotAppointmentProvider is returned when someone other than the provider or participants of an
+	// appointment changes its status or reads or writes its notes
+	ErrNotAppointmentProvider = errors.New("user is not a provider of the appointment")
+)
+
+// appointmentTransitions is the appointment lifecycle, the statuses each status can change to. Statuses
+// that are not keys are final.
+var appointmentTransitions = map[schema.AppointmentStatus][]schema.AppointmentStatus{
+	schema.AppointmentStatusReserved:  {schema.AppointmentStatusConfirmed, schema.AppointmentStatusCancelled, schema.AppointmentStatusExpired},
+	schema.AppointmentStatusConfirmed: {schema.AppointmentStatusCancelled, schema.AppointmentStatusCheckedIn, schema.AppointmentStatusNoShow},
+	schema.AppointmentStatusCheckedIn: {schema.AppointmentStatusCompleted},
+}
+
+// startedStatuses can only be reached once an appointment has started
+var startedStatuses = []schema.AppointmentStatus{
+	schema.AppointmentStatusCheckedIn,
+	schema.AppointmentStatusCompleted,
+	schema.AppointmentStatusNoShow,
+}
+
+// TransitionError tells which change of status is not allowed, it is also ErrInvalidTransition
+type TransitionError struct {
+	From, To schema.AppointmentStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("appointment can not change from %s to %s", e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// CheckTransition returns a TransitionError if an appointment can not change from one status to another,
+// ErrHoldExpired if it is a reservation made at createdAt whose hold ran out, which can only expire, and
+// ErrAppointmentNotStarted if the change has to wait for startTime
+func CheckTransition(from, to schema.AppointmentStatus, createdAt, startTime, now time.Time) error {
+	if !slices.Contains(appointmentTransitions[from], to) {
+		return &TransitionError{From: from, To: to}
+	}
+	if from == schema.AppointmentStatusReserved && to != schema.AppointmentStatusExpired && !createdAt.After(holdCutoff(now)) {
+		return ErrHoldExpired
+	}
+	if slices.Contains(startedStatuses, to) && now.Before(startTime) {
+		return ErrAppointmentNotStarted
+	}
+	return nil
+}
+
+// ChangeableTo lists the statuses an appointment can change to status from. Changes made to many appointments
+// at once are guarded by it, so every change of status follows appointmentTransitions.
+func ChangeableTo(status schema.AppointmentStatus) []schema.AppointmentStatus {
+	var from []schema.AppointmentStatus
+	for candidate, to := range appointmentTransitions {
+		if slices.Contains(to, status) {
+			from = append(from, candidate)
+		}
+	}
+	slices.Sort(from)
+	return from
+}
+
+// TransitionFailure tells why an appointment in status from did not change to status: a TransitionError if
+// it has started, as one with an outcome keeps it, and sql.ErrNoRows otherwise, as one that was cancelled or
+// whose hold ran out is no longer active
+func TransitionFailure(from, to schema.AppointmentStatus) error {
+	if slices.Contains(startedStatuses, from) && !slices.Contains(appointmentTransitions[from], to) {
+		return &TransitionError{From: from, To: to}
+	}
+	return sql.ErrNoRows
+}
+
+// changeableTo is ChangeableTo as a query parameter
+func changeableTo(status schema.AppointmentStatus) interface{} {
+	from := ChangeableTo(status)
+	statuses := make([]string, 0, len(from))
+	for _, s := range from {
+		statuses = append(statuses, string(s))
+	}
+	return pq.Array(statuses)
+}
+
+// transitionFailure looks up the status of an appointment a change to status was not made to and returns
+// TransitionFailure for it, sql.ErrNoRows if it is not in the organization
+func transitionFailure(ctx context.Context, tx *sql.Tx, appointmentID types.UUID, to schema.AppointmentStatus) error {
+	var from schema.AppointmentStatus
+	if err := tx.QueryRowContext(ctx, `SELECT status FROM appointments WHERE id = $1`, appointmentID.String()).Scan(&from); err != nil {
+		return err
+	}
+	return TransitionFailure(from, to)
+}
+
+// UpdateAppointmentStatus moves an appointment along its lifecycle, recording the provider who did and when.
+// It returns sql.ErrNoRows if the appointment is not in the organization, ErrNotAppointmentProvider if
+// changedBy is not its provider or a participant and the error of CheckTransition if the change is not
+// allowed.
+func (db *Database) UpdateAppointmentStatus(ctx context.Context, appointmentID types.UUID, status schema.AppointmentStatus, changedBy types.UUID) (*schema.Appointment, error) {
+	ctx, span := tracer.Start(ctx, "db.UpdateAppointmentStatus")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.rollback(tx)
+
+	var from schema.AppointmentStatus
+	var createdAt, startTime time.Time
+	var providerID uuid.UUID
+	var participant bool
+	err = tx.QueryRowContext(ctx, `
+	SELECT appt.status, appt.created_at, appt.start_time, appt.provider_id, EXISTS (
+	  SELECT 1 FROM appointment_participants ap WHERE ap.appointment_id = appt.id AND ap.provider_id = $2
+	)
+	FROM appointments appt
+	WHERE appt.id = $1
+	FOR UPDATE
+`, appointmentID.String(), changedBy.String()).Scan(&from, &createdAt, &startTime, &providerID, &participant)
+	if err != nil {
+		return nil, err
+	}
+	if providerID != changedBy && !participant {
+		return nil, ErrNotAppointmentProvider
+	}
+	now := db.Clock.Now()
+	if err := CheckTransition(from, status, createdAt, startTime, now); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE appointments
+	SET status = $2, status_changed_at = $3, status_changed_by = $4, updated_at = $3
+	WHERE id = $1
+`, appointmentID.String(), string(status), now, changedBy.String())
+	if err != nil {
+		return nil, err
+	}
+	change := statusChange{status: status, source: schema.StatusChangeSourceProvider, changedBy: &changedBy, at: now}
+	if err := recordStatus(ctx, tx, appointmentID.String(), change); err != nil {
+		return nil, err
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT `+appointmentColumns+` FROM appointments appt WHERE appt.id = $1`, appointmentID.String())
+	if err != nil {
+		return nil, err
+	}
+	appointments, err := scanAppointments(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &appointments[0], nil
+}
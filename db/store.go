@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+)
+
+// Store is everything the api needs from storage. Database keeps it in postgres, other implementations
+// have to pass the conformance suite in db/storetest so handlers behave the same on top of any of them.
+// Every method except the organization ones works in the organization of its context, see WithOrganization.
+type Store interface {
+	// Ping checks that the store can be reached
+	Ping(ctx context.Context) error
+	// SchemaVersion returns the version of the newest migration applied to the store
+	SchemaVersion(ctx context.Context) (int, error)
+
+	CreateUser(ctx context.Context, name, email, role string) (*schema.User, error)
+	GetUser(ctx context.Context, userID types.UUID) (*schema.User, error)
+	UpdateUser(ctx context.Context, userID types.UUID, update UserUpdate) (*schema.User, error)
+	DeactivateUser(ctx context.Context, userID types.UUID) (*schema.User, error)
+	DeleteUser(ctx context.Context, userID types.UUID) error
+
+	GetProvider(ctx context.Context, providerID types.UUID) (*schema.Provider, error)
+	UpdateProviderProfile(ctx context.Context, providerID types.UUID, profile schema.ProviderProfile) (*schema.Provider, error)
+	SearchProviders(ctx context.Context, query ProviderQuery) ([]schema.Provider, error)
+
+	CreateLocation(ctx context.Context, name, address string) (*schema.Location, error)
+	ListLocations(ctx context.Context) ([]schema.Location, error)
+	CreateRoom(ctx context.Context, locationID types.UUID, name string) (*schema.Room, error)
+	ListRooms(ctx context.Context, locationID types.UUID) ([]schema.Room, error)
+	GetRoomAppointments(ctx context.Context, roomID types.UUID, date *types.Date) ([]schema.Appointment, error)
+
+	CreateProviderGroup(ctx context.Context, name string, strategy schema.GroupStrategy, providerIDs []types.UUID) (*schema.ProviderGroup, error)
+	ListProviderGroups(ctx context.Context) ([]schema.ProviderGroup, error)
+	GetProviderGroup(ctx context.Context, groupID types.UUID) (*schema.ProviderGroup, error)
+	ReserveFromGroup(ctx context.Context, clientID, groupID types.UUID, startTime time.Time, intake *Intake) (*schema.Appointment, error)
+
+	CreateAppointmentType(ctx context.Context, name string, questions []schema.IntakeQuestion) (*schema.AppointmentType, error)
+	ListAppointmentTypes(ctx context.Context) ([]schema.AppointmentType, error)
+
+	AddAvailability(ctx context.Context, providerID types.UUID, locationID *types.UUID, slots []time.Time, capacity int) error
+	GetAvailableAppointments(ctx context.Context, providerID *types.UUID, participantIDs []types.UUID, locationID *types.UUID, date *types.Date) ([]schema.Appointment, error)
+	GetAppointmentStartTime(ctx context.Context, availabilityID *types.UUID) (time.Time, error)
+	IsSlotAvailable(ctx context.Context, providerID *types.UUID, participantIDs []types.UUID, startTime *time.Time) (bool, error)
+
+	ReserveAppointment(ctx context.Context, clientID, providerID *types.UUID, participantIDs []types.UUID, roomID *types.UUID, startTime *time.Time, intake *Intake) (*schema.Appointment, error)
+	ConfirmAppointment(ctx context.Context, appointmentID types.UUID) error
+	CancelAppointment(ctx context.Context, appointmentID types.UUID) error
+	ExpireReservations(ctx context.Context) (int64, error)
+	UpdateAppointmentStatus(ctx context.Context, appointmentID types.UUID, status schema.AppointmentStatus, changedBy types.UUID) (*schema.Appointment, error)
+	ListStatusHistory(ctx context.Context, appointmentID types.UUID) ([]schema.StatusChange, error)
+	SaveAppointmentNote(ctx context.Context, appointmentID, authorID types.UUID, body string) (*schema.AppointmentNote, error)
+	ListAppointmentNotes(ctx context.Context, appointmentID types.UUID) ([]schema.AppointmentNote, error)
+
+	ReserveSeries(ctx context.Context, req SeriesRequest) (*schema.AppointmentSeries, error)
+	GetSeries(ctx context.Context, seriesID types.UUID) (*schema.AppointmentSeries, error)
+	ConfirmSeries(ctx context.Context, seriesID types.UUID) (int64, error)
+	CancelFollowingAppointments(ctx context.Context, appointmentID types.UUID) error
+	RescheduleAppointment(ctx context.Context, appointmentID types.UUID, newStart time.Time) ([]schema.Appointment, error)
+	RescheduleFollowingAppointments(ctx context.Context, appointmentID types.UUID, newStart time.Time) ([]schema.Appointment, error)
+
+	JoinWaitlist(ctx context.Context, clientID, providerID types.UUID, windowStart, windowEnd, earliestStart time.Time) (*schema.WaitlistEntry, error)
+	LeaveWaitlist(ctx context.Context, entryID types.UUID) error
+	GetProviderWaitlist(ctx context.Context, providerID types.UUID) ([]schema.WaitlistEntry, error)
+	PromoteWaitlist(ctx context.Context, earliestStart time.Time) ([]WaitlistOffer, error)
+
+	CreateOrganization(ctx context.Context, org NewOrganization) (*schema.Organization, error)
+	GetOrganization(ctx context.Context, orgID types.UUID) (*schema.Organization, error)
+	OrganizationByHost(ctx context.Context, host string) (*schema.Organization, error)
+	OrganizationByToken(ctx context.Context, tokenHash string) (*schema.Organization, error)
+	ListOrganizations(ctx context.Context) ([]schema.Organization, error)
+	UpdateOrganizationSettings(ctx context.Context, orgID types.UUID, settings schema.OrganizationSettings) (*schema.Organization, error)
+}
+
+// Ensure that Database implements Store
+var _ Store = (*Database)(nil)
@@ -0,0 +1,33 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/clock"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/db/storetest"
+	"github.com/tateexon/reservation/utils"
+)
+
+func TestConformance(t *testing.T) {
+	t.Parallel()
+
+	storetest.Run(t, storetest.Harness{
+		New: func(t *testing.T, clock clock.Clock) db.Store {
+			ctx := context.Background()
+			ctr := utils.StartTestPostgres(ctx, t, "yourdb", "youruser", "yourpassword")
+
+			// explicitly set sslmode=disable because the container is not configured to use TLS
+			connStr, err := ctr.ConnectionString(ctx, "sslmode=disable")
+			require.NoError(t, err)
+
+			dbInstance, err := db.NewDatabase(connStr, nil)
+			require.NoError(t, err, "Failed to connect to the database")
+			t.Cleanup(func() { dbInstance.Conn.Close() })
+			dbInstance.Clock = clock
+			return dbInstance
+		},
+	})
+}
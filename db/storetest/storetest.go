@@ -0,0 +1,2172 @@
+// Package storetest is the conformance suite for db.Store implementations. Every implementation runs it
+// from its own tests so they all keep the semantics the handlers rely on.
+package storetest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/clock"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/migrations"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// Harness gives the suite access to a store implementation
+type Harness struct {
+	// New returns an empty store at the newest schema version that reads the time from clock
+	New func(t *testing.T, clock clock.Clock) db.Store
+}
+
+// epoch is when every test starts, holds are made to expire by moving the clock past it
+var epoch = time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC)
+
+// newStore returns a store and the fake clock it reads the time from
+func newStore(t *testing.T, h Harness) (db.Store, *clock.Fake) {
+	clk := clock.NewFake(epoch)
+	return h.New(t, clk), clk
+}
+
+// Run runs every conformance test against the store h creates
+func Run(t *testing.T, h Harness) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, h Harness)
+	}{
+		{"SchemaVersion", testSchemaVersion},
+		{"Users", testUsers},
+		{"UpdateUser", testUpdateUser},
+		{"DeactivateUser", testDeactivateUser},
+		{"DeleteUser", testDeleteUser},
+		{"ProviderDirectory", testProviderDirectory},
+		{"AddAvailability", testAddAvailability},
+		{"GetAvailableAppointments", testGetAvailableAppointments},
+		{"ReserveAppointment", testReserveAppointment},
+		{"GroupCapacity", testGroupCapacity},
+		{"ConcurrentSeats", testConcurrentSeats},
+		{"ConfirmAppointment", testConfirmAppointment},
+		{"HoldExpiry", testHoldExpiry},
+		{"CancelAppointment", testCancelAppointment},
+		{"ReserveSeries", testReserveSeries},
+		{"CancelFollowingAppointments", testCancelFollowingAppointments},
+		{"RescheduleAppointment", testRescheduleAppointment},
+		{"RescheduleFollowingAppointments", testRescheduleFollowingAppointments},
+		{"JoinWaitlist", testJoinWaitlist},
+		{"PromoteWaitlist", testPromoteWaitlist},
+		{"PromoteWaitlist_SettlesOffers", testPromoteWaitlistSettlesOffers},
+		{"PromoteWaitlist_ClientLimits", testPromoteWaitlistClientLimits},
+		{"Organizations", testOrganizations},
+		{"TenantIsolation", testTenantIsolation},
+		{"Locations", testLocations},
+		{"RoomBooking", testRoomBooking},
+		{"RescheduleWithRoom", testRescheduleWithRoom},
+		{"Participants", testParticipants},
+		{"RescheduleWithParticipants", testRescheduleWithParticipants},
+		{"ProviderGroups", testProviderGroups},
+		{"GroupAssignment", testGroupAssignment},
+		{"ConcurrentGroupBookings", testConcurrentGroupBookings},
+		{"ClientLimits", testClientLimits},
+		{"ConcurrentClientLimits", testConcurrentClientLimits},
+		{"SeriesClientLimits", testSeriesClientLimits},
+		{"RescheduleClientLimits", testRescheduleClientLimits},
+		{"AppointmentOutcomes", testAppointmentOutcomes},
+		{"NoShowLimit", testNoShowLimit},
+		{"StartedAppointmentsKeepTheirStatus", testStartedAppointmentsKeepTheirStatus},
+		{"AppointmentIntake", testAppointmentIntake},
+		{"AppointmentNotes", testAppointmentNotes},
+		{"StatusHistory", testStatusHistory},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.test(t, h)
+		})
+	}
+}
+
+func createProvider(t *testing.T, store db.Store) *types.UUID {
+	return createUser(t, store, "provider")
+}
+
+func createClient(t *testing.T, store db.Store) *types.UUID {
+	return createUser(t, store, "client")
+}
+
+func createUser(t *testing.T, store db.Store, role string) *types.UUID {
+	email := fmt.Sprintf("%s-%s@example.com", role, uuid.NewString())
+	user, err := store.CreateUser(context.Background(), "Test "+role, email, role)
+	require.NoError(t, err)
+	return user.Id
+}
+
+func addAvailability(t *testing.T, store db.Store, providerID *types.UUID, slots ...time.Time) {
+	err := store.AddAvailability(context.Background(), *providerID, nil, slots, 1)
+	require.NoError(t, err)
+}
+
+func weeklyStartTimes(first time.Time, count int) []time.Time {
+	var startTimes []time.Time
+	for i := 0; i < count; i++ {
+		startTimes = append(startTimes, first.AddDate(0, 0, 7*i))
+	}
+	return startTimes
+}
+
+func statuses(t *testing.T, store db.Store, seriesID types.UUID) []schema.AppointmentStatus {
+	series, err := store.GetSeries(context.Background(), seriesID)
+	require.NoError(t, err)
+	var result []schema.AppointmentStatus
+	for _, appointment := range *series.Appointments {
+		result = append(result, *appointment.Status)
+	}
+	return result
+}
+
+func testSchemaVersion(t *testing.T, h Harness) {
+	store, _ := newStore(t, h)
+	ctx := context.Background()
+
+	require.NoError(t, store.Ping(ctx))
+	latest, err := migrations.Latest()
+	require.NoError(t, err)
+	version, err := store.SchemaVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, latest, version)
+}
+
+func testUsers(t *testing.T, h Harness) {
+	store, _ := newStore(t, h)
+	ctx := context.Background()
+
+	created, err := store.CreateUser(ctx, "Dr. Jekyll", "jekyll@example.com", "provider")
+	require.NoError(t, err)
+	user, err := store.GetUser(ctx, *created.Id)
+	require.NoError(t, err)
+	require.Equal(t, created, user)
+
+	// Emails are unique
+	_, err = store.CreateUser(ctx, "Mr. Hyde", "jekyll@example.com", "client")
+	require.ErrorIs(t, err, db.ErrEmailTaken)
+
+	_, err = store.CreateUser(ctx, "Mr. Hyde", "hyde@example.com", "admin")
+	require.Error(t, err)
+
+	_, err = store.GetUser(ctx, uuid.New())
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testUpdateUser(t *testing.T, h Harness) {
+	store, _ := newStore(t, h)
+	ctx := context.Background()
+
+	created, err := store.CreateUser(ctx, "Dr. Jekyll", "jekyll@example.com", "provider")
+	require.NoError(t, err)
+	_, err = store.CreateUser(ctx, "Mr. Hyde", "hyde@example.com", "client")
+	require.NoError(t, err)
+
+	// Fields that are not given are kept
+	updated, err := store.UpdateUser(ctx, *created.Id, db.UserUpdate{Name: utils.Ptr("Henry Jekyll")})
+	require.NoError(t, err)
+	require.Equal(t, "Henry Jekyll", *updated.Name)
+	require.Equal(t, "jekyll@example.com", *updated.Email)
+	require.Equal(t, schema.UserRoleProvider, *updated.Role)
+
+	updated, err = store.UpdateUser(ctx, *created.Id, db.UserUpdate{Email: utils.Ptr("henry@example.com")})
+	require.NoError(t, err)
+	user, err := store.GetUser(ctx, *created.Id)
+	require.NoError(t, err)
+	require.Equal(t, updated, user)
+	require.Equal(t, "henry@example.com", *user.Email)
+
+	// The old email is free again, another user's is not
+	_, err = store.CreateUser(ctx, "Edward Hyde", "jekyll@example.com", "client")
+	require.NoError(t, err)
+	_, err = store.UpdateUser(ctx, *created.Id, db.UserUpdate{Email: utils.Ptr("hyde@example.com")})
+	require.ErrorIs(t, err, db.ErrEmailTaken)
+
+	_, err = store.UpdateUser(ctx, uuid.New(), db.UserUpdate{Name: utils.Ptr("Nobody")})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testDeactivateUser(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	earliestStart := clk.Now().Add(24 * time.Hour)
+	addAvailability(t, store, providerID, startTime, startTime.Add(time.Hour))
+
+	booked, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+	waitingID := createClient(t, store)
+	entry, err := store.JoinWaitlist(ctx, *waitingID, *providerID, startTime, startTime.Add(15*time.Minute), earliestStart)
+	require.NoError(t, err)
+
+	deactivated, err := store.DeactivateUser(ctx, *providerID)
+	require.NoError(t, err)
+	require.NotNil(t, deactivated.DeactivatedAt)
+	require.True(t, clk.Now().Equal(*deactivated.DeactivatedAt))
+	user, err := store.GetUser(ctx, *providerID)
+	require.NoError(t, err)
+	require.Equal(t, deactivated, user)
+
+	// Deactivating again keeps the first time
+	clk.Advance(time.Minute)
+	again, err := store.DeactivateUser(ctx, *providerID)
+	require.NoError(t, err)
+	require.Equal(t, deactivated.DeactivatedAt, again.DeactivatedAt)
+
+	// Their slots are no longer offered and can not be booked
+	slots, err := store.GetAvailableAppointments(ctx, providerID, nil, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, slots)
+	later := startTime.Add(time.Hour)
+	_, err = store.ReserveAppointment(ctx, createClient(t, store), providerID, nil, nil, &later, nil)
+	require.ErrorIs(t, err, db.ErrUserInactive)
+	_, err = store.ReserveSeries(ctx, db.SeriesRequest{
+		ClientID:   createClient(t, store),
+		ProviderID: providerID,
+		Recurrence: schema.Recurrence{Frequency: schema.Weekly, Count: 2},
+		StartTimes: weeklyStartTimes(later, 2),
+	})
+	require.ErrorIs(t, err, db.ErrUserInactive)
+	err = store.AddAvailability(ctx, *providerID, nil, []time.Time{startTime.Add(2 * time.Hour)}, 1)
+	require.ErrorIs(t, err, db.ErrUserInactive)
+	_, err = store.JoinWaitlist(ctx, *createClient(t, store), *providerID, startTime, startTime.Add(time.Hour), earliestStart)
+	require.ErrorIs(t, err, db.ErrUserInactive)
+
+	// Their history is kept and nobody is left waiting for them
+	require.NoError(t, store.ConfirmAppointment(ctx, *booked.Id))
+	require.ErrorIs(t, store.LeaveWaitlist(ctx, *entry.Id), sql.ErrNoRows)
+
+	// A deactivated client can not book either
+	otherProviderID := createProvider(t, store)
+	addAvailability(t, store, otherProviderID, startTime)
+	_, err = store.DeactivateUser(ctx, *clientID)
+	require.NoError(t, err)
+	_, err = store.ReserveAppointment(ctx, clientID, otherProviderID, nil, nil, &startTime, nil)
+	require.ErrorIs(t, err, db.ErrUserInactive)
+
+	_, err = store.DeactivateUser(ctx, uuid.New())
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testDeleteUser(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	addAvailability(t, store, providerID, startTime)
+
+	// A cancelled appointment is still history
+	appointment, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.CancelAppointment(ctx, *appointment.Id))
+	require.ErrorIs(t, store.DeleteUser(ctx, *providerID), db.ErrUserHasAppointments)
+	require.ErrorIs(t, store.DeleteUser(ctx, *clientID), db.ErrUserHasAppointments)
+
+	// A user without appointments goes with their availability and waitlist entries
+	otherProviderID := createProvider(t, store)
+	addAvailability(t, store, otherProviderID, startTime)
+	_, err = store.JoinWaitlist(ctx, *clientID, *otherProviderID, startTime.Add(time.Hour), startTime.Add(2*time.Hour), startTime)
+	require.NoError(t, err)
+	require.NoError(t, store.DeleteUser(ctx, *otherProviderID))
+
+	_, err = store.GetUser(ctx, *otherProviderID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	slots, err := store.GetAvailableAppointments(ctx, otherProviderID, nil, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, slots)
+	_, err = store.GetProviderWaitlist(ctx, *otherProviderID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	require.ErrorIs(t, store.DeleteUser(ctx, *otherProviderID), sql.ErrNoRows)
+}
+
+func testProviderDirectory(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	newProvider := func(name string, profile schema.ProviderProfile) types.UUID {
+		user, err := store.CreateUser(ctx, name, uuid.NewString()+"@example.com", "provider")
+		require.NoError(t, err)
+		provider, err := store.UpdateProviderProfile(ctx, *user.Id, profile)
+		require.NoError(t, err)
+		require.Equal(t, name, *provider.Name)
+		return *user.Id
+	}
+	search := func(query db.ProviderQuery) []types.UUID {
+		providers, err := store.SearchProviders(ctx, query)
+		require.NoError(t, err)
+		ids := []types.UUID{}
+		for _, provider := range providers {
+			ids = append(ids, *provider.Id)
+		}
+		return ids
+	}
+
+	adams := newProvider("Dr. Adams", schema.ProviderProfile{
+		Specialty: utils.Ptr("Physiotherapy"),
+		Bio:       utils.Ptr("Sports injuries and knee rehabilitation"),
+		Languages: &[]string{"en", "es"},
+		Location:  utils.Ptr("Denver"),
+	})
+	baker := newProvider("Dr. Baker", schema.ProviderProfile{
+		Specialty: utils.Ptr("Dermatology"),
+		Bio:       utils.Ptr("Skin care for athletes"),
+		Languages: &[]string{"en"},
+	})
+	carter := newProvider("Dr. Carter", schema.ProviderProfile{Specialty: utils.Ptr("Physiotherapy")})
+	createClient(t, store)
+
+	provider, err := store.GetProvider(ctx, adams)
+	require.NoError(t, err)
+	require.Equal(t, "Physiotherapy", *provider.Specialty)
+	require.Equal(t, []string{"en", "es"}, *provider.Languages)
+	require.Equal(t, "Denver", *provider.Location)
+	provider, err = store.GetProvider(ctx, carter)
+	require.NoError(t, err)
+	require.Nil(t, provider.Bio)
+	require.Nil(t, provider.Languages)
+
+	// Only providers are listed, by name
+	require.Equal(t, []types.UUID{adams, baker, carter}, search(db.ProviderQuery{}))
+	require.Equal(t, []types.UUID{adams, carter}, search(db.ProviderQuery{Specialty: "physiotherapy"}))
+	require.Equal(t, []types.UUID{adams}, search(db.ProviderQuery{Language: "es"}))
+
+	// Every word has to be in the name or bio
+	require.Equal(t, []types.UUID{adams}, search(db.ProviderQuery{Text: "knee INJURIES"}))
+	require.Equal(t, []types.UUID{baker}, search(db.ProviderQuery{Text: "baker"}))
+	require.Empty(t, search(db.ProviderQuery{Text: "knee skin"}))
+	require.Equal(t, []types.UUID{adams, baker, carter}, search(db.ProviderQuery{Text: " - "}))
+
+	// A slot is only offered while it has a seat left
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	addAvailability(t, store, &baker, startTime)
+	addAvailability(t, store, &carter, startTime.Add(24*time.Hour))
+	day := db.ProviderQuery{AvailableFrom: &startTime, AvailableTo: utils.Ptr(startTime.Add(24 * time.Hour))}
+	require.Equal(t, []types.UUID{baker}, search(day))
+	require.Equal(t, []types.UUID{carter}, search(db.ProviderQuery{AvailableFrom: utils.Ptr(startTime.Add(time.Minute))}))
+	require.Equal(t, []types.UUID{baker}, search(db.ProviderQuery{AvailableTo: utils.Ptr(startTime.Add(time.Hour))}))
+	_, err = store.ReserveAppointment(ctx, createClient(t, store), &baker, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+	require.Empty(t, search(day))
+
+	// Deactivated providers leave the directory but can still be updated
+	_, err = store.DeactivateUser(ctx, adams)
+	require.NoError(t, err)
+	require.Equal(t, []types.UUID{baker, carter}, search(db.ProviderQuery{}))
+	_, err = store.GetProvider(ctx, adams)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	provider, err = store.UpdateProviderProfile(ctx, adams, schema.ProviderProfile{})
+	require.NoError(t, err)
+	require.Nil(t, provider.Specialty)
+	require.Nil(t, provider.Languages)
+
+	// Clients have no profile
+	_, err = store.UpdateProviderProfile(ctx, *createClient(t, store), schema.ProviderProfile{})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = store.GetProvider(ctx, uuid.New())
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testAddAvailability(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	startTime := clk.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	slots := utils.GenerateTimeSlots(startTime, startTime.Add(2*time.Hour), db.GetAvailabilityInterval())
+	require.NoError(t, store.AddAvailability(ctx, *providerID, nil, slots, 1))
+
+	appointments, err := store.GetAvailableAppointments(ctx, providerID, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, appointments, len(slots))
+
+	// Slots are unique per provider and start time, adding them again keeps the existing ones
+	require.NoError(t, store.AddAvailability(ctx, *providerID, nil, slots, 3))
+	again, err := store.GetAvailableAppointments(ctx, providerID, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, again, len(slots))
+	require.Equal(t, appointments[0].Id.String(), again[0].Id.String())
+	require.Equal(t, 1, *again[0].Capacity)
+
+	startTime, err = store.GetAppointmentStartTime(ctx, appointments[0].Id)
+	require.NoError(t, err)
+	require.True(t, slots[0].Equal(startTime))
+	_, err = store.GetAppointmentStartTime(ctx, utils.Ptr(uuid.New()))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Only providers have availability
+	require.Error(t, store.AddAvailability(ctx, uuid.New(), nil, slots, 1))
+	require.Error(t, store.AddAvailability(ctx, *createClient(t, store), nil, slots, 1))
+}
+
+func testGetAvailableAppointments(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	otherID := createProvider(t, store)
+	day := clk.Now().Add(72 * time.Hour).UTC().Truncate(24 * time.Hour)
+	// Added out of order, they are listed by start time
+	addAvailability(t, store, providerID, day.Add(10*time.Hour), day.Add(9*time.Hour), day.Add(33*time.Hour))
+	addAvailability(t, store, otherID, day.Add(9*time.Hour))
+
+	appointments, err := store.GetAvailableAppointments(ctx, providerID, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, appointments, 3)
+	require.True(t, day.Add(9*time.Hour).Equal(*appointments[0].StartTime))
+	require.True(t, day.Add(10*time.Hour).Equal(*appointments[1].StartTime))
+	require.True(t, day.Add(9*time.Hour+db.GetAvailabilityInterval()).Equal(*appointments[0].EndTime))
+	require.Equal(t, providerID.String(), appointments[0].ProviderId.String())
+
+	appointments, err = store.GetAvailableAppointments(ctx, providerID, nil, nil, &types.Date{Time: day})
+	require.NoError(t, err)
+	require.Len(t, appointments, 2)
+
+	appointments, err = store.GetAvailableAppointments(ctx, nil, nil, nil, &types.Date{Time: day})
+	require.NoError(t, err)
+	require.Len(t, appointments, 3)
+
+	appointments, err = store.GetAvailableAppointments(ctx, utils.Ptr(uuid.New()), nil, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, appointments)
+}
+
+func testReserveAppointment(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTime := clk.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	addAvailability(t, store, providerID, startTime)
+
+	available, err := store.IsSlotAvailable(ctx, providerID, nil, &startTime)
+	require.NoError(t, err)
+	require.True(t, available)
+
+	appointment, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+	require.Equal(t, schema.AppointmentStatusReserved, *appointment.Status)
+	require.Equal(t, clientID.String(), appointment.ClientId.String())
+	require.True(t, startTime.Equal(*appointment.StartTime))
+	require.True(t, startTime.Add(db.GetAvailabilityInterval()).Equal(*appointment.EndTime))
+
+	available, err = store.IsSlotAvailable(ctx, providerID, nil, &startTime)
+	require.NoError(t, err)
+	require.False(t, available)
+
+	_, err = store.ReserveAppointment(ctx, createClient(t, store), providerID, nil, nil, &startTime, nil)
+	require.ErrorIs(t, err, db.ErrSlotUnavailable)
+
+	// A slot that was never offered can not be reserved
+	otherTime := startTime.Add(time.Hour)
+	available, err = store.IsSlotAvailable(ctx, providerID, nil, &otherTime)
+	require.NoError(t, err)
+	require.False(t, available)
+	_, err = store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &otherTime, nil)
+	require.ErrorIs(t, err, db.ErrSlotUnavailable)
+}
+
+func testGroupCapacity(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	require.NoError(t, store.AddAvailability(ctx, *providerID, nil, []time.Time{startTime}, 3))
+
+	for seatsRemaining := 3; seatsRemaining > 0; seatsRemaining-- {
+		appointments, err := store.GetAvailableAppointments(ctx, providerID, nil, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, appointments, 1)
+		require.Equal(t, 3, *appointments[0].Capacity)
+		require.Equal(t, seatsRemaining, *appointments[0].SeatsRemaining)
+
+		_, err = store.ReserveAppointment(ctx, createClient(t, store), providerID, nil, nil, &startTime, nil)
+		require.NoError(t, err)
+	}
+
+	appointments, err := store.GetAvailableAppointments(ctx, providerID, nil, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, appointments)
+
+	_, err = store.ReserveAppointment(ctx, createClient(t, store), providerID, nil, nil, &startTime, nil)
+	require.ErrorIs(t, err, db.ErrSlotUnavailable)
+
+	require.Error(t, store.AddAvailability(ctx, *providerID, nil, []time.Time{startTime.Add(time.Hour)}, 0))
+}
+
+func testConcurrentSeats(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	require.NoError(t, store.AddAvailability(ctx, *providerID, nil, []time.Time{startTime}, 3))
+
+	clients := make([]*types.UUID, 10)
+	for i := range clients {
+		clients[i] = createClient(t, store)
+	}
+
+	var wg sync.WaitGroup
+	var reserved atomic.Int32
+	for _, clientID := range clients {
+		wg.Add(1)
+		go func(clientID *types.UUID) {
+			defer wg.Done()
+			_, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+			if err == nil {
+				reserved.Add(1)
+				return
+			}
+			assert.ErrorIs(t, err, db.ErrSlotUnavailable)
+		}(clientID)
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(3), reserved.Load())
+}
+
+func testConfirmAppointment(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTime := clk.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	addAvailability(t, store, providerID, startTime)
+
+	appointment, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.ConfirmAppointment(ctx, *appointment.Id))
+
+	// Only reservations can be confirmed
+	err = store.ConfirmAppointment(ctx, *appointment.Id)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	require.NotErrorIs(t, err, db.ErrHoldExpired)
+	err = store.ConfirmAppointment(ctx, uuid.New())
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	require.NotErrorIs(t, err, db.ErrHoldExpired)
+
+	// Confirmed appointments do not expire
+	clk.Advance(time.Hour)
+	expired, err := store.ExpireReservations(ctx)
+	require.NoError(t, err)
+	require.Zero(t, expired)
+	available, err := store.IsSlotAvailable(ctx, providerID, nil, &startTime)
+	require.NoError(t, err)
+	require.False(t, available)
+}
+
+func testHoldExpiry(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTime := clk.Now().Add(25 * time.Hour).Truncate(time.Minute)
+	slots := []time.Time{startTime, startTime.Add(db.GetAvailabilityInterval())}
+	addAvailability(t, store, providerID, slots...)
+
+	stale, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &slots[0], nil)
+	require.NoError(t, err)
+	clk.Advance(2 * time.Minute)
+	fresh, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &slots[1], nil)
+	require.NoError(t, err)
+
+	// A hold placed 29 minutes ago still holds its seat
+	clk.Advance(29 * time.Minute)
+	available, err := store.IsSlotAvailable(ctx, providerID, nil, &slots[1])
+	require.NoError(t, err)
+	require.False(t, available)
+
+	// One placed 31 minutes ago does not
+	available, err = store.IsSlotAvailable(ctx, providerID, nil, &slots[0])
+	require.NoError(t, err)
+	require.True(t, available, "Slot should be available after reservation has expired")
+	appointments, err := store.GetAvailableAppointments(ctx, providerID, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, appointments, 1)
+
+	// An expired hold can not be confirmed or cancelled, and confirming says why
+	require.ErrorIs(t, store.ConfirmAppointment(ctx, *stale.Id), db.ErrHoldExpired)
+	require.ErrorIs(t, store.CancelAppointment(ctx, *stale.Id), sql.ErrNoRows)
+
+	expired, err := store.ExpireReservations(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), expired)
+	require.ErrorIs(t, store.ConfirmAppointment(ctx, *stale.Id), db.ErrHoldExpired)
+
+	// Already expired holds are not counted twice
+	expired, err = store.ExpireReservations(ctx)
+	require.NoError(t, err)
+	require.Zero(t, expired)
+
+	rebooked, err := store.ReserveAppointment(ctx, createClient(t, store), providerID, nil, nil, &slots[0], nil)
+	require.NoError(t, err)
+	require.True(t, stale.StartTime.Equal(*rebooked.StartTime))
+	require.NoError(t, store.ConfirmAppointment(ctx, *fresh.Id))
+}
+
+func testCancelAppointment(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTime := clk.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	addAvailability(t, store, providerID, startTime)
+
+	reserved, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.CancelAppointment(ctx, *reserved.Id))
+	require.ErrorIs(t, store.CancelAppointment(ctx, *reserved.Id), sql.ErrNoRows)
+	require.ErrorIs(t, store.ConfirmAppointment(ctx, *reserved.Id), sql.ErrNoRows)
+
+	// Confirmed appointments can be cancelled too, either way the seat is free again
+	confirmed, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.ConfirmAppointment(ctx, *confirmed.Id))
+	require.NoError(t, store.CancelAppointment(ctx, *confirmed.Id))
+
+	available, err := store.IsSlotAvailable(ctx, providerID, nil, &startTime)
+	require.NoError(t, err)
+	require.True(t, available)
+	require.ErrorIs(t, store.CancelAppointment(ctx, uuid.New()), sql.ErrNoRows)
+}
+
+func testReserveSeries(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTimes := weeklyStartTimes(clk.Now().Add(48*time.Hour).Truncate(time.Minute), 4)
+
+	// The third week has no availability
+	addAvailability(t, store, providerID, startTimes[0], startTimes[1], startTimes[3])
+
+	req := db.SeriesRequest{
+		ClientID:   clientID,
+		ProviderID: providerID,
+		Recurrence: schema.Recurrence{Frequency: schema.Weekly, Count: 4},
+		StartTimes: startTimes,
+	}
+	_, err := store.ReserveSeries(ctx, req)
+	var conflictErr *db.ConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Len(t, conflictErr.Conflicts, 1)
+	require.Equal(t, 2, *conflictErr.Conflicts[0].Index)
+	require.Equal(t, schema.NoAvailability, *conflictErr.Conflicts[0].Reason)
+
+	// Nothing was reserved
+	available, err := store.IsSlotAvailable(ctx, providerID, nil, &startTimes[0])
+	require.NoError(t, err)
+	require.True(t, available)
+
+	req.AllowPartial = true
+	series, err := store.ReserveSeries(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, *series.Appointments, 3)
+	require.Len(t, *series.Conflicts, 1)
+	require.Equal(t, 1, *series.Recurrence.Interval)
+
+	// The booked weeks now conflict
+	_, err = store.ReserveSeries(ctx, req)
+	require.ErrorAs(t, err, &conflictErr)
+	require.Len(t, conflictErr.Conflicts, 4)
+	require.Equal(t, schema.Booked, *conflictErr.Conflicts[0].Reason)
+
+	confirmed, err := store.ConfirmSeries(ctx, *series.Id)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), confirmed)
+	_, err = store.ConfirmSeries(ctx, *series.Id)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	stored, err := store.GetSeries(ctx, *series.Id)
+	require.NoError(t, err)
+	require.Equal(t, 4, stored.Recurrence.Count)
+	require.Equal(t, schema.Weekly, stored.Recurrence.Frequency)
+	require.Equal(t, clientID.String(), stored.ClientId.String())
+	for i, appointment := range *stored.Appointments {
+		require.Equal(t, schema.AppointmentStatusConfirmed, *appointment.Status)
+		require.Equal(t, series.Id.String(), appointment.SeriesId.String())
+		require.Equal(t, *(*series.Appointments)[i].SeriesIndex, *appointment.SeriesIndex)
+		require.Equal(t, []statusChange{
+			{status: schema.AppointmentStatusReserved, source: schema.StatusChangeSourceRequest},
+			{status: schema.AppointmentStatusConfirmed, source: schema.StatusChangeSourceRequest},
+		}, history(t, store, *appointment.Id))
+	}
+
+	_, err = store.GetSeries(ctx, uuid.New())
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testCancelFollowingAppointments(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTimes := weeklyStartTimes(clk.Now().Add(48*time.Hour).Truncate(time.Minute), 4)
+	addAvailability(t, store, providerID, startTimes...)
+
+	series, err := store.ReserveSeries(ctx, db.SeriesRequest{
+		ClientID:   clientID,
+		ProviderID: providerID,
+		Recurrence: schema.Recurrence{Frequency: schema.Weekly, Count: 4},
+		StartTimes: startTimes,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, store.CancelFollowingAppointments(ctx, *(*series.Appointments)[2].Id))
+	require.Equal(t, []schema.AppointmentStatus{
+		schema.AppointmentStatusReserved,
+		schema.AppointmentStatusReserved,
+		schema.AppointmentStatusCancelled,
+		schema.AppointmentStatusCancelled,
+	}, statuses(t, store, *series.Id))
+	for _, appointment := range (*series.Appointments)[2:] {
+		require.Equal(t, []statusChange{
+			{status: schema.AppointmentStatusReserved, source: schema.StatusChangeSourceRequest},
+			{status: schema.AppointmentStatusCancelled, source: schema.StatusChangeSourceRequest},
+		}, history(t, store, *appointment.Id))
+	}
+
+	// Without a series only the appointment itself is cancelled
+	otherTime := startTimes[0].Add(time.Hour)
+	addAvailability(t, store, providerID, otherTime)
+	single, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &otherTime, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.CancelFollowingAppointments(ctx, *single.Id))
+	require.ErrorIs(t, store.CancelFollowingAppointments(ctx, *single.Id), sql.ErrNoRows)
+	require.Equal(t, schema.AppointmentStatusReserved, statuses(t, store, *series.Id)[0])
+}
+
+func testRescheduleAppointment(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	newStart := startTime.Add(time.Hour)
+	addAvailability(t, store, providerID, startTime)
+
+	appointment, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+
+	_, err = store.RescheduleAppointment(ctx, *appointment.Id, newStart)
+	var conflictErr *db.ConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Equal(t, schema.NoAvailability, *conflictErr.Conflicts[0].Reason)
+
+	addAvailability(t, store, providerID, newStart)
+	moved, err := store.RescheduleAppointment(ctx, *appointment.Id, newStart)
+	require.NoError(t, err)
+	require.Len(t, moved, 1)
+	require.True(t, newStart.Equal(*moved[0].StartTime))
+	require.True(t, newStart.Add(db.GetAvailabilityInterval()).Equal(*moved[0].EndTime))
+	require.Equal(t, schema.AppointmentStatusReserved, *moved[0].Status)
+
+	available, err := store.IsSlotAvailable(ctx, providerID, nil, &startTime)
+	require.NoError(t, err)
+	require.True(t, available)
+	available, err = store.IsSlotAvailable(ctx, providerID, nil, &newStart)
+	require.NoError(t, err)
+	require.False(t, available)
+
+	// Moving onto its own slot does not conflict with itself
+	_, err = store.RescheduleAppointment(ctx, *appointment.Id, newStart)
+	require.NoError(t, err)
+
+	_, err = store.RescheduleAppointment(ctx, uuid.New(), newStart)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testRescheduleFollowingAppointments(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTimes := weeklyStartTimes(clk.Now().Add(48*time.Hour).Truncate(time.Minute), 3)
+	addAvailability(t, store, providerID, startTimes...)
+
+	series, err := store.ReserveSeries(ctx, db.SeriesRequest{
+		ClientID:   clientID,
+		ProviderID: providerID,
+		Recurrence: schema.Recurrence{Frequency: schema.Weekly, Count: 3},
+		StartTimes: startTimes,
+	})
+	require.NoError(t, err)
+
+	// Move the second and third occurrences one hour later, the third has no availability yet
+	shift := time.Hour
+	addAvailability(t, store, providerID, startTimes[1].Add(shift))
+	second := (*series.Appointments)[1]
+	_, err = store.RescheduleFollowingAppointments(ctx, *second.Id, startTimes[1].Add(shift))
+	var conflictErr *db.ConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Len(t, conflictErr.Conflicts, 1)
+	require.Equal(t, 2, *conflictErr.Conflicts[0].Index)
+
+	addAvailability(t, store, providerID, startTimes[2].Add(shift))
+	moved, err := store.RescheduleFollowingAppointments(ctx, *second.Id, startTimes[1].Add(shift))
+	require.NoError(t, err)
+	require.Len(t, moved, 2)
+	require.True(t, startTimes[1].Add(shift).Equal(*moved[0].StartTime))
+	require.True(t, startTimes[2].Add(shift).Equal(*moved[1].StartTime))
+
+	// The original slots are free again and the first occurrence did not move
+	available, err := store.IsSlotAvailable(ctx, providerID, nil, &startTimes[1])
+	require.NoError(t, err)
+	require.True(t, available)
+	available, err = store.IsSlotAvailable(ctx, providerID, nil, &startTimes[0])
+	require.NoError(t, err)
+	require.False(t, available)
+}
+
+func testJoinWaitlist(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	earliestStart := clk.Now().Add(24 * time.Hour)
+	addAvailability(t, store, providerID, startTime)
+
+	_, err := store.JoinWaitlist(ctx, *clientID, *providerID, startTime, startTime.Add(time.Hour), earliestStart)
+	require.ErrorIs(t, err, db.ErrSlotsAvailable)
+
+	// Slots before earliestStart do not count as available
+	entry, err := store.JoinWaitlist(ctx, *clientID, *providerID, startTime, startTime.Add(time.Hour), startTime.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, schema.WaitlistEntryStatusWaiting, *entry.Status)
+	require.Equal(t, 1, *entry.Position)
+	require.NotNil(t, entry.CreatedAt)
+
+	_, err = store.JoinWaitlist(ctx, *providerID, *providerID, startTime, startTime.Add(time.Hour), startTime.Add(time.Minute))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = store.JoinWaitlist(ctx, *clientID, uuid.New(), startTime, startTime.Add(time.Hour), startTime.Add(time.Minute))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	require.NoError(t, store.LeaveWaitlist(ctx, *entry.Id))
+	require.ErrorIs(t, store.LeaveWaitlist(ctx, *entry.Id), sql.ErrNoRows)
+
+	waitlist, err := store.GetProviderWaitlist(ctx, *providerID)
+	require.NoError(t, err)
+	require.Empty(t, waitlist)
+	_, err = store.GetProviderWaitlist(ctx, *clientID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testPromoteWaitlist(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	earliestStart := clk.Now().Add(24 * time.Hour)
+	addAvailability(t, store, providerID, startTime)
+
+	appointment, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+
+	// Two clients join the waitlist, the first to join must be served first
+	firstID := createClient(t, store)
+	secondID := createClient(t, store)
+	first, err := store.JoinWaitlist(ctx, *firstID, *providerID, startTime, startTime.Add(time.Hour), earliestStart)
+	require.NoError(t, err)
+	require.Equal(t, 1, *first.Position)
+	clk.Advance(time.Minute)
+	second, err := store.JoinWaitlist(ctx, *secondID, *providerID, startTime, startTime.Add(time.Hour), earliestStart)
+	require.NoError(t, err)
+	require.Equal(t, 2, *second.Position)
+
+	// Nothing is free yet
+	offers, err := store.PromoteWaitlist(ctx, earliestStart)
+	require.NoError(t, err)
+	require.Empty(t, offers)
+
+	require.NoError(t, store.CancelAppointment(ctx, *appointment.Id))
+
+	offers, err = store.PromoteWaitlist(ctx, earliestStart)
+	require.NoError(t, err)
+	require.Len(t, offers, 1)
+	require.Equal(t, first.Id.String(), offers[0].Entry.Id.String())
+	require.Equal(t, schema.WaitlistEntryStatusOffered, *offers[0].Entry.Status)
+	require.Equal(t, offers[0].Appointment.Id.String(), offers[0].Entry.AppointmentId.String())
+	require.NotNil(t, offers[0].Entry.HoldExpiresAt)
+	require.Equal(t, firstID.String(), offers[0].Appointment.ClientId.String())
+	require.Equal(t, schema.AppointmentStatusReserved, *offers[0].Appointment.Status)
+	require.True(t, startTime.Equal(*offers[0].Appointment.StartTime))
+
+	available, err := store.IsSlotAvailable(ctx, providerID, nil, &startTime)
+	require.NoError(t, err)
+	require.False(t, available)
+
+	waitlist, err := store.GetProviderWaitlist(ctx, *providerID)
+	require.NoError(t, err)
+	require.Len(t, waitlist, 2)
+	require.Equal(t, schema.WaitlistEntryStatusOffered, *waitlist[0].Status)
+	require.Equal(t, offers[0].Appointment.Id.String(), waitlist[0].AppointmentId.String())
+	require.NotNil(t, waitlist[0].HoldExpiresAt)
+	require.Nil(t, waitlist[0].Position)
+	require.Equal(t, second.Id.String(), waitlist[1].Id.String())
+	require.Equal(t, 1, *waitlist[1].Position)
+}
+
+func testPromoteWaitlistSettlesOffers(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	earliestStart := clk.Now().Add(24 * time.Hour)
+
+	firstID := createClient(t, store)
+	secondID := createClient(t, store)
+	thirdID := createClient(t, store)
+	_, err := store.JoinWaitlist(ctx, *firstID, *providerID, startTime, startTime.Add(time.Hour), earliestStart)
+	require.NoError(t, err)
+	clk.Advance(time.Minute)
+	_, err = store.JoinWaitlist(ctx, *secondID, *providerID, startTime, startTime.Add(time.Hour), earliestStart)
+	require.NoError(t, err)
+	// A window that closes before the lead time runs out can never be served
+	_, err = store.JoinWaitlist(ctx, *thirdID, *providerID, startTime.Add(-48*time.Hour), startTime.Add(-47*time.Hour), time.Time{})
+	require.NoError(t, err)
+
+	// New availability goes to the first client
+	addAvailability(t, store, providerID, startTime)
+	offers, err := store.PromoteWaitlist(ctx, earliestStart)
+	require.NoError(t, err)
+	require.Len(t, offers, 1)
+	require.Equal(t, firstID.String(), offers[0].Entry.ClientId.String())
+
+	// The first client lets their hold expire so the slot goes to the second
+	clk.Advance(31 * time.Minute)
+	offers, err = store.PromoteWaitlist(ctx, earliestStart)
+	require.NoError(t, err)
+	require.Len(t, offers, 1)
+	require.Equal(t, secondID.String(), offers[0].Entry.ClientId.String())
+
+	// The second client confirms, which fulfils their entry
+	require.NoError(t, store.ConfirmAppointment(ctx, *offers[0].Appointment.Id))
+	offers, err = store.PromoteWaitlist(ctx, earliestStart)
+	require.NoError(t, err)
+	require.Empty(t, offers)
+
+	waitlist, err := store.GetProviderWaitlist(ctx, *providerID)
+	require.NoError(t, err)
+	require.Empty(t, waitlist)
+}
+
+func testOrganizations(t *testing.T, h Harness) {
+	store, _ := newStore(t, h)
+	ctx := context.Background()
+
+	// The default organization always exists
+	defaultOrg, err := store.GetOrganization(ctx, db.DefaultOrganizationID)
+	require.NoError(t, err)
+	require.Equal(t, "default", *defaultOrg.Slug)
+
+	created, err := store.CreateOrganization(ctx, db.NewOrganization{
+		Name:      "Northside Clinic",
+		Slug:      "northside",
+		Hosts:     []string{"northside.example.com", "book.northside.example.com"},
+		TokenHash: db.HashToken("northside-token"),
+		Settings:  schema.OrganizationSettings{SlotIntervalMinutes: utils.Ptr(15)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"book.northside.example.com", "northside.example.com"}, *created.Hosts)
+	require.Equal(t, 15, *created.Settings.SlotIntervalMinutes)
+	require.Nil(t, created.Settings.LeadTimeMinutes)
+
+	org, err := store.OrganizationByHost(ctx, "northside.example.com")
+	require.NoError(t, err)
+	require.Equal(t, created, org)
+	org, err = store.OrganizationByToken(ctx, db.HashToken("northside-token"))
+	require.NoError(t, err)
+	require.Equal(t, created, org)
+	_, err = store.OrganizationByHost(ctx, "unknown.example.com")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = store.OrganizationByToken(ctx, db.HashToken("wrong-token"))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Slugs, hosts and tokens belong to one organization
+	for _, taken := range []db.NewOrganization{
+		{Name: "Copy", Slug: "northside"},
+		{Name: "Copy", Slug: "copy-host", Hosts: []string{"northside.example.com"}},
+		{Name: "Copy", Slug: "copy-token", TokenHash: db.HashToken("northside-token")},
+	} {
+		_, err = store.CreateOrganization(ctx, taken)
+		require.ErrorIs(t, err, db.ErrOrganizationExists, taken.Slug)
+	}
+
+	orgs, err := store.ListOrganizations(ctx)
+	require.NoError(t, err)
+	require.Len(t, orgs, 2)
+	require.Equal(t, "default", *orgs[0].Slug)
+	require.Equal(t, "northside", *orgs[1].Slug)
+
+	updated, err := store.UpdateOrganizationSettings(ctx, *created.Id, schema.OrganizationSettings{LeadTimeMinutes: utils.Ptr(60)})
+	require.NoError(t, err)
+	require.Nil(t, updated.Settings.SlotIntervalMinutes)
+	require.Equal(t, 60, *updated.Settings.LeadTimeMinutes)
+	limits := schema.OrganizationSettings{
+		MaxActiveHolds:                   utils.Ptr(2),
+		MaxFutureAppointments:            utils.Ptr(10),
+		MaxFutureAppointmentsPerProvider: utils.Ptr(3),
+		AllowOverlappingAppointments:     utils.Ptr(true),
+		MaxNoShows:                       utils.Ptr(3),
+	}
+	updated, err = store.UpdateOrganizationSettings(ctx, *created.Id, limits)
+	require.NoError(t, err)
+	require.Equal(t, limits, *updated.Settings)
+	_, err = store.UpdateOrganizationSettings(ctx, uuid.New(), schema.OrganizationSettings{})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testTenantIsolation(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	org, err := store.CreateOrganization(ctx, db.NewOrganization{
+		Name:     "Northside Clinic",
+		Slug:     "northside",
+		Settings: schema.OrganizationSettings{SlotIntervalMinutes: utils.Ptr(15)},
+	})
+	require.NoError(t, err)
+	tenantCtx := db.WithOrganization(ctx, *org)
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	tenantProvider, err := store.CreateUser(tenantCtx, "Dr. North", "north@example.com", "provider")
+	require.NoError(t, err)
+	tenantClient, err := store.CreateUser(tenantCtx, "Nora", "nora@example.com", "client")
+	require.NoError(t, err)
+
+	// Slots are as long as the organization's interval
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	require.NoError(t, store.AddAvailability(tenantCtx, *tenantProvider.Id, nil, []time.Time{startTime}, 1))
+	addAvailability(t, store, providerID, startTime)
+	appointment, err := store.ReserveAppointment(tenantCtx, tenantClient.Id, tenantProvider.Id, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+	require.Equal(t, startTime.Add(15*time.Minute), *appointment.EndTime)
+
+	// Nothing of one organization is visible from another
+	_, err = store.GetUser(ctx, *tenantProvider.Id)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = store.GetUser(tenantCtx, *providerID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = store.UpdateUser(ctx, *tenantClient.Id, db.UserUpdate{Name: utils.Ptr("Mallory")})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	require.ErrorIs(t, store.DeleteUser(ctx, *tenantClient.Id), sql.ErrNoRows)
+	require.ErrorIs(t, store.ConfirmAppointment(ctx, *appointment.Id), sql.ErrNoRows)
+	require.ErrorIs(t, store.CancelAppointment(ctx, *appointment.Id), sql.ErrNoRows)
+
+	slots, err := store.GetAvailableAppointments(ctx, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, slots, 1)
+	require.Equal(t, *providerID, *slots[0].ProviderId)
+	providers, err := store.SearchProviders(tenantCtx, db.ProviderQuery{})
+	require.NoError(t, err)
+	require.Len(t, providers, 1)
+	require.Equal(t, *tenantProvider.Id, *providers[0].Id)
+
+	// Users of another organization can not be booked or waitlisted
+	_, err = store.ReserveAppointment(ctx, clientID, tenantProvider.Id, nil, nil, &startTime, nil)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = store.ReserveAppointment(tenantCtx, clientID, tenantProvider.Id, nil, nil, &startTime, nil)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = store.JoinWaitlist(ctx, *clientID, *tenantProvider.Id, startTime, startTime.Add(time.Hour), clk.Now())
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	require.ErrorIs(t, store.AddAvailability(ctx, *tenantProvider.Id, nil, []time.Time{startTime}, 1), sql.ErrNoRows)
+
+	// Emails are still unique within an organization
+	_, err = store.CreateUser(tenantCtx, "Nora Again", "nora@example.com", "client")
+	require.ErrorIs(t, err, db.ErrEmailTaken)
+
+	// Holds expire per organization
+	clk.Advance(db.ReservationHoldDuration + time.Minute)
+	expired, err := store.ExpireReservations(ctx)
+	require.NoError(t, err)
+	require.Zero(t, expired)
+	expired, err = store.ExpireReservations(tenantCtx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), expired)
+}
+
+func testLocations(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	north, err := store.CreateLocation(ctx, "Northside", "1 North St")
+	require.NoError(t, err)
+	require.Equal(t, "1 North St", *north.Address)
+	south, err := store.CreateLocation(ctx, "Southside", "")
+	require.NoError(t, err)
+	require.Nil(t, south.Address)
+
+	locations, err := store.ListLocations(ctx)
+	require.NoError(t, err)
+	require.Len(t, locations, 2)
+	require.Equal(t, *north.Id, *locations[0].Id)
+	require.Nil(t, locations[1].Address)
+
+	_, err = store.CreateRoom(ctx, *north.Id, "Room 2")
+	require.NoError(t, err)
+	room, err := store.CreateRoom(ctx, *north.Id, "Room 1")
+	require.NoError(t, err)
+	require.Equal(t, *north.Id, *room.LocationId)
+	rooms, err := store.ListRooms(ctx, *north.Id)
+	require.NoError(t, err)
+	require.Len(t, rooms, 2)
+	require.Equal(t, *room.Id, *rooms[0].Id)
+	rooms, err = store.ListRooms(ctx, *south.Id)
+	require.NoError(t, err)
+	require.Empty(t, rooms)
+
+	_, err = store.CreateRoom(ctx, uuid.New(), "Room 1")
+	require.ErrorIs(t, err, db.ErrLocationNotFound)
+	_, err = store.ListRooms(ctx, uuid.New())
+	require.ErrorIs(t, err, db.ErrLocationNotFound)
+	_, err = store.GetRoomAppointments(ctx, uuid.New(), nil)
+	require.ErrorIs(t, err, db.ErrRoomNotFound)
+
+	// Slots are listed by the location they are offered at
+	providerID := createProvider(t, store)
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	require.NoError(t, store.AddAvailability(ctx, *providerID, north.Id, []time.Time{startTime}, 1))
+	require.NoError(t, store.AddAvailability(ctx, *providerID, south.Id, []time.Time{startTime.Add(time.Hour)}, 1))
+	addAvailability(t, store, providerID, startTime.Add(2*time.Hour))
+	require.ErrorIs(t, store.AddAvailability(ctx, *providerID, (*types.UUID)(utils.Ptr(uuid.New())), []time.Time{startTime}, 1), db.ErrLocationNotFound)
+
+	slots, err := store.GetAvailableAppointments(ctx, providerID, nil, north.Id, nil)
+	require.NoError(t, err)
+	require.Len(t, slots, 1)
+	require.True(t, startTime.Equal(*slots[0].StartTime))
+	require.Equal(t, *north.Id, *slots[0].LocationId)
+	slots, err = store.GetAvailableAppointments(ctx, providerID, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, slots, 3)
+	require.Nil(t, slots[2].LocationId)
+
+	// Locations belong to an organization
+	org, err := store.CreateOrganization(ctx, db.NewOrganization{Name: "Westside Clinic", Slug: "westside"})
+	require.NoError(t, err)
+	tenantCtx := db.WithOrganization(ctx, *org)
+	locations, err = store.ListLocations(tenantCtx)
+	require.NoError(t, err)
+	require.Empty(t, locations)
+	_, err = store.CreateRoom(tenantCtx, *north.Id, "Room 3")
+	require.ErrorIs(t, err, db.ErrLocationNotFound)
+	_, err = store.GetRoomAppointments(tenantCtx, *room.Id, nil)
+	require.ErrorIs(t, err, db.ErrRoomNotFound)
+}
+
+func testRoomBooking(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	north, err := store.CreateLocation(ctx, "Northside", "")
+	require.NoError(t, err)
+	south, err := store.CreateLocation(ctx, "Southside", "")
+	require.NoError(t, err)
+	room, err := store.CreateRoom(ctx, *north.Id, "Procedure room")
+	require.NoError(t, err)
+
+	smith := createProvider(t, store)
+	jones := createProvider(t, store)
+	group := createProvider(t, store)
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	require.NoError(t, store.AddAvailability(ctx, *smith, north.Id, []time.Time{startTime, startTime.Add(time.Hour)}, 1))
+	require.NoError(t, store.AddAvailability(ctx, *jones, north.Id, []time.Time{startTime, startTime.Add(db.GetAvailabilityInterval() / 2)}, 1))
+	require.NoError(t, store.AddAvailability(ctx, *jones, south.Id, []time.Time{startTime.Add(time.Hour)}, 1))
+	require.NoError(t, store.AddAvailability(ctx, *group, north.Id, []time.Time{startTime.Add(2 * time.Hour)}, 2))
+	addAvailability(t, store, jones, startTime.Add(3*time.Hour))
+
+	appointment, err := store.ReserveAppointment(ctx, createClient(t, store), smith, nil, room.Id, &startTime, nil)
+	require.NoError(t, err)
+	require.Equal(t, *room.Id, *appointment.RoomId)
+	require.Equal(t, *north.Id, *appointment.LocationId)
+
+	// The room is taken even though the other provider is free, and neither is claimed
+	_, err = store.ReserveAppointment(ctx, createClient(t, store), jones, nil, room.Id, &startTime, nil)
+	require.ErrorIs(t, err, db.ErrRoomUnavailable)
+	overlapping := startTime.Add(db.GetAvailabilityInterval() / 2)
+	_, err = store.ReserveAppointment(ctx, createClient(t, store), jones, nil, room.Id, &overlapping, nil)
+	require.ErrorIs(t, err, db.ErrRoomUnavailable)
+	available, err := store.IsSlotAvailable(ctx, jones, nil, &startTime)
+	require.NoError(t, err)
+	require.True(t, available)
+
+	// Without the room the other provider can still be booked
+	_, err = store.ReserveAppointment(ctx, createClient(t, store), jones, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+
+	// Rooms are only booked at their own location
+	elsewhere := startTime.Add(time.Hour)
+	_, err = store.ReserveAppointment(ctx, createClient(t, store), jones, nil, room.Id, &elsewhere, nil)
+	require.ErrorIs(t, err, db.ErrRoomElsewhere)
+	nowhere := startTime.Add(3 * time.Hour)
+	_, err = store.ReserveAppointment(ctx, createClient(t, store), jones, nil, room.Id, &nowhere, nil)
+	require.ErrorIs(t, err, db.ErrRoomElsewhere)
+	_, err = store.ReserveAppointment(ctx, createClient(t, store), jones, nil, (*types.UUID)(utils.Ptr(uuid.New())), &nowhere, nil)
+	require.ErrorIs(t, err, db.ErrRoomNotFound)
+
+	// The seats of a group session share its room
+	session := startTime.Add(2 * time.Hour)
+	for i := 0; i < 2; i++ {
+		_, err = store.ReserveAppointment(ctx, createClient(t, store), group, nil, room.Id, &session, nil)
+		require.NoError(t, err)
+	}
+
+	calendar, err := store.GetRoomAppointments(ctx, *room.Id, nil)
+	require.NoError(t, err)
+	require.Len(t, calendar, 3)
+	require.Equal(t, *appointment.Id, *calendar[0].Id)
+	calendar, err = store.GetRoomAppointments(ctx, *room.Id, &types.Date{Time: startTime.AddDate(0, 0, 1).Truncate(24 * time.Hour)})
+	require.NoError(t, err)
+	require.Empty(t, calendar)
+
+	// Cancelling frees the room
+	require.NoError(t, store.CancelAppointment(ctx, *appointment.Id))
+	_, err = store.ReserveAppointment(ctx, createClient(t, store), smith, nil, room.Id, &startTime, nil)
+	require.NoError(t, err)
+
+	// A series needs the room for every occurrence
+	series, err := store.ReserveSeries(ctx, db.SeriesRequest{
+		ClientID:   createClient(t, store),
+		ProviderID: jones,
+		RoomID:     room.Id,
+		Recurrence: schema.Recurrence{Frequency: schema.Weekly, Count: 2},
+		StartTimes: []time.Time{overlapping, overlapping.AddDate(0, 0, 7)},
+	})
+	require.Nil(t, series)
+	var conflictErr *db.ConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Equal(t, schema.RoomBooked, *conflictErr.Conflicts[0].Reason)
+	require.Equal(t, schema.NoAvailability, *conflictErr.Conflicts[1].Reason)
+}
+
+func testRescheduleWithRoom(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	north, err := store.CreateLocation(ctx, "Northside", "")
+	require.NoError(t, err)
+	south, err := store.CreateLocation(ctx, "Southside", "")
+	require.NoError(t, err)
+	room, err := store.CreateRoom(ctx, *north.Id, "Procedure room")
+	require.NoError(t, err)
+
+	providerID := createProvider(t, store)
+	otherID := createProvider(t, store)
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	require.NoError(t, store.AddAvailability(ctx, *providerID, north.Id, []time.Time{startTime, startTime.Add(time.Hour), startTime.Add(2 * time.Hour)}, 1))
+	require.NoError(t, store.AddAvailability(ctx, *providerID, south.Id, []time.Time{startTime.Add(3 * time.Hour)}, 1))
+	require.NoError(t, store.AddAvailability(ctx, *otherID, north.Id, []time.Time{startTime.Add(time.Hour)}, 1))
+
+	appointment, err := store.ReserveAppointment(ctx, createClient(t, store), providerID, nil, room.Id, &startTime, nil)
+	require.NoError(t, err)
+	_, err = store.ReserveAppointment(ctx, createClient(t, store), otherID, nil, room.Id, utils.Ptr(startTime.Add(time.Hour)), nil)
+	require.NoError(t, err)
+
+	// The appointment keeps its room, so it can only move where the room is free
+	_, err = store.RescheduleAppointment(ctx, *appointment.Id, startTime.Add(time.Hour))
+	var conflictErr *db.ConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Equal(t, schema.RoomBooked, *conflictErr.Conflicts[0].Reason)
+	_, err = store.RescheduleAppointment(ctx, *appointment.Id, startTime.Add(3*time.Hour))
+	require.ErrorAs(t, err, &conflictErr)
+	require.Equal(t, schema.RoomElsewhere, *conflictErr.Conflicts[0].Reason)
+
+	moved, err := store.RescheduleAppointment(ctx, *appointment.Id, startTime.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, *room.Id, *moved[0].RoomId)
+	require.Equal(t, *north.Id, *moved[0].LocationId)
+}
+
+func testParticipants(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	surgeon := createProvider(t, store)
+	anesthetist := createProvider(t, store)
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	addAvailability(t, store, surgeon, startTime, startTime.Add(time.Hour), startTime.Add(2*time.Hour))
+	addAvailability(t, store, anesthetist, startTime, startTime.Add(time.Hour))
+	busy := startTime.Add(time.Hour)
+	_, err := store.ReserveAppointment(ctx, createClient(t, store), anesthetist, nil, nil, &busy, nil)
+	require.NoError(t, err)
+
+	// Only the times when both are free are listed
+	team := []types.UUID{*anesthetist}
+	slots, err := store.GetAvailableAppointments(ctx, surgeon, team, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, slots, 1)
+	require.True(t, startTime.Equal(*slots[0].StartTime))
+	available, err := store.IsSlotAvailable(ctx, surgeon, team, &busy)
+	require.NoError(t, err)
+	require.False(t, available)
+
+	// One taken slot fails the whole reservation and neither seat is held
+	_, err = store.ReserveAppointment(ctx, createClient(t, store), surgeon, team, nil, &busy, nil)
+	require.ErrorIs(t, err, db.ErrSlotUnavailable)
+	available, err = store.IsSlotAvailable(ctx, surgeon, nil, &busy)
+	require.NoError(t, err)
+	require.True(t, available)
+	_, err = store.ReserveAppointment(ctx, createClient(t, store), surgeon, []types.UUID{uuid.New()}, nil, &startTime, nil)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	appointment, err := store.ReserveAppointment(ctx, createClient(t, store), surgeon, team, nil, &startTime, nil)
+	require.NoError(t, err)
+	require.Equal(t, team, *appointment.ParticipantIds)
+
+	// The appointment holds the participant's seat too
+	slots, err = store.GetAvailableAppointments(ctx, anesthetist, nil, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, slots)
+	require.ErrorIs(t, store.DeleteUser(ctx, *anesthetist), db.ErrUserHasAppointments)
+
+	// Cancelling frees both
+	require.NoError(t, store.CancelAppointment(ctx, *appointment.Id))
+	available, err = store.IsSlotAvailable(ctx, surgeon, team, &startTime)
+	require.NoError(t, err)
+	require.True(t, available)
+
+	// Deactivated participants are never free
+	interpreter := createProvider(t, store)
+	addAvailability(t, store, interpreter, startTime)
+	_, err = store.DeactivateUser(ctx, *interpreter)
+	require.NoError(t, err)
+	slots, err = store.GetAvailableAppointments(ctx, surgeon, []types.UUID{*interpreter}, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, slots)
+	_, err = store.ReserveAppointment(ctx, createClient(t, store), surgeon, []types.UUID{*interpreter}, nil, &startTime, nil)
+	require.ErrorIs(t, err, db.ErrUserInactive)
+
+	// A series needs the participants at every occurrence
+	series, err := store.ReserveSeries(ctx, db.SeriesRequest{
+		ClientID:       createClient(t, store),
+		ProviderID:     surgeon,
+		ParticipantIDs: team,
+		Recurrence:     schema.Recurrence{Frequency: schema.Daily, Count: 3},
+		StartTimes:     []time.Time{startTime, busy, startTime.Add(2 * time.Hour)},
+		AllowPartial:   true,
+	})
+	require.NoError(t, err)
+	require.Len(t, *series.Appointments, 1)
+	require.Equal(t, team, *(*series.Appointments)[0].ParticipantIds)
+	require.Equal(t, schema.Booked, *(*series.Conflicts)[0].Reason)
+	require.Equal(t, schema.NoAvailability, *(*series.Conflicts)[1].Reason)
+	fetched, err := store.GetSeries(ctx, *series.Id)
+	require.NoError(t, err)
+	require.Equal(t, team, *(*fetched.Appointments)[0].ParticipantIds)
+}
+
+func testRescheduleWithParticipants(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	doctor := createProvider(t, store)
+	interpreter := createProvider(t, store)
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	addAvailability(t, store, doctor, startTime, startTime.Add(time.Hour), startTime.Add(2*time.Hour))
+	addAvailability(t, store, interpreter, startTime, startTime.Add(time.Hour))
+
+	appointment, err := store.ReserveAppointment(ctx, createClient(t, store), doctor, []types.UUID{*interpreter}, nil, &startTime, nil)
+	require.NoError(t, err)
+
+	// The participant has to be free at the new time as well
+	_, err = store.RescheduleAppointment(ctx, *appointment.Id, startTime.Add(2*time.Hour))
+	var conflictErr *db.ConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Equal(t, schema.NoAvailability, *conflictErr.Conflicts[0].Reason)
+
+	moved, err := store.RescheduleAppointment(ctx, *appointment.Id, startTime.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []types.UUID{*interpreter}, *moved[0].ParticipantIds)
+
+	// Both seats moved with it
+	available, err := store.IsSlotAvailable(ctx, interpreter, nil, &startTime)
+	require.NoError(t, err)
+	require.True(t, available)
+	available, err = store.IsSlotAvailable(ctx, interpreter, nil, utils.Ptr(startTime.Add(time.Hour)))
+	require.NoError(t, err)
+	require.False(t, available)
+}
+
+func testProviderGroups(t *testing.T, h Harness) {
+	store, _ := newStore(t, h)
+	ctx := context.Background()
+
+	smith := createProvider(t, store)
+	jones := createProvider(t, store)
+	_, err := store.CreateProviderGroup(ctx, "Cardiology", schema.RoundRobin, []types.UUID{*smith, *createClient(t, store)})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	retired := createProvider(t, store)
+	_, err = store.DeactivateUser(ctx, *retired)
+	require.NoError(t, err)
+	_, err = store.CreateProviderGroup(ctx, "Cardiology", schema.RoundRobin, []types.UUID{*smith, *retired})
+	require.ErrorIs(t, err, db.ErrUserInactive)
+
+	group, err := store.CreateProviderGroup(ctx, "Cardiology", schema.LeastBooked, []types.UUID{*jones, *smith})
+	require.NoError(t, err)
+	require.Equal(t, schema.LeastBooked, *group.Strategy)
+	_, err = store.CreateProviderGroup(ctx, "Anesthesia", schema.Priority, []types.UUID{*smith})
+	require.NoError(t, err)
+
+	fetched, err := store.GetProviderGroup(ctx, *group.Id)
+	require.NoError(t, err)
+	require.Equal(t, group, fetched)
+	groups, err := store.ListProviderGroups(ctx)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	require.Equal(t, "Anesthesia", *groups[0].Name)
+	_, err = store.GetProviderGroup(ctx, uuid.New())
+	require.ErrorIs(t, err, db.ErrGroupNotFound)
+
+	// Deleted providers leave their groups
+	require.NoError(t, store.DeleteUser(ctx, *jones))
+	fetched, err = store.GetProviderGroup(ctx, *group.Id)
+	require.NoError(t, err)
+	require.Equal(t, []types.UUID{*smith}, *fetched.ProviderIds)
+}
+
+func testGroupAssignment(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	a, b, c := createProvider(t, store), createProvider(t, store), createProvider(t, store)
+	first := clk.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	second, third := first.Add(time.Hour), first.Add(2*time.Hour)
+	for _, providerID := range []*types.UUID{a, b, c} {
+		addAvailability(t, store, providerID, first, second, third)
+	}
+	reserve := func(groupID types.UUID, startTime time.Time) *schema.Appointment {
+		appointment, err := store.ReserveFromGroup(ctx, *createClient(t, store), groupID, startTime, nil)
+		require.NoError(t, err)
+		require.Equal(t, groupID, *appointment.Assignment.GroupId)
+		require.NotEmpty(t, *appointment.Assignment.Explanation)
+		return appointment
+	}
+
+	// Round robin goes through the members in order and comes back to the one assigned least recently
+	roundRobin, err := store.CreateProviderGroup(ctx, "Front desk", schema.RoundRobin, []types.UUID{*a, *b, *c})
+	require.NoError(t, err)
+	var assigned []types.UUID
+	for i := 0; i < 3; i++ {
+		assigned = append(assigned, *reserve(*roundRobin.Id, first).ProviderId)
+	}
+	require.Equal(t, []types.UUID{*a, *b, *c}, assigned)
+	_, err = store.ReserveFromGroup(ctx, *createClient(t, store), *roundRobin.Id, first, nil)
+	require.ErrorIs(t, err, db.ErrSlotUnavailable)
+	appointment := reserve(*roundRobin.Id, second)
+	require.Equal(t, *a, *appointment.ProviderId)
+	require.Equal(t, 1, *(*appointment.Assignment.Candidates)[0].LastAssignment)
+
+	// Least booked counts the appointments in the week, ties go to the earlier member
+	leastBooked, err := store.CreateProviderGroup(ctx, "Cardiology", schema.LeastBooked, []types.UUID{*a, *c, *b})
+	require.NoError(t, err)
+	appointment = reserve(*leastBooked.Id, third)
+	require.Equal(t, *c, *appointment.ProviderId)
+	candidates := *appointment.Assignment.Candidates
+	require.Equal(t, 1, *candidates[0].AppointmentsThisWeek)
+	require.Equal(t, *a, *candidates[2].ProviderId)
+	require.Equal(t, 2, *candidates[2].AppointmentsThisWeek)
+
+	// Priority takes the first member with a free slot
+	priority, err := store.CreateProviderGroup(ctx, "Surgery", schema.Priority, []types.UUID{*c, *b, *a})
+	require.NoError(t, err)
+	appointment = reserve(*priority.Id, third)
+	require.Equal(t, *b, *appointment.ProviderId)
+	candidates = *appointment.Assignment.Candidates
+	require.Equal(t, *c, *candidates[0].ProviderId)
+	require.False(t, *candidates[0].Available)
+	require.True(t, *candidates[1].Available)
+
+	// Deactivated members are never assigned
+	_, err = store.DeactivateUser(ctx, *a)
+	require.NoError(t, err)
+	_, err = store.ReserveFromGroup(ctx, *createClient(t, store), *priority.Id, third, nil)
+	require.ErrorIs(t, err, db.ErrSlotUnavailable)
+
+	_, err = store.ReserveFromGroup(ctx, *createClient(t, store), uuid.New(), third, nil)
+	require.ErrorIs(t, err, db.ErrGroupNotFound)
+	_, err = store.ReserveFromGroup(ctx, uuid.New(), *priority.Id, third, nil)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testConcurrentGroupBookings(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	var members []types.UUID
+	for i := 0; i < 3; i++ {
+		providerID := createProvider(t, store)
+		addAvailability(t, store, providerID, startTime)
+		members = append(members, *providerID)
+	}
+	group, err := store.CreateProviderGroup(ctx, "Front desk", schema.RoundRobin, members)
+	require.NoError(t, err)
+
+	clients := make([]*types.UUID, 10)
+	for i := range clients {
+		clients[i] = createClient(t, store)
+	}
+
+	// Every member is assigned once, none twice
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	assigned := map[types.UUID]int{}
+	for _, clientID := range clients {
+		wg.Add(1)
+		go func(clientID *types.UUID) {
+			defer wg.Done()
+			appointment, err := store.ReserveFromGroup(ctx, *clientID, *group.Id, startTime, nil)
+			if err == nil {
+				mu.Lock()
+				assigned[*appointment.ProviderId]++
+				mu.Unlock()
+				return
+			}
+			assert.ErrorIs(t, err, db.ErrSlotUnavailable)
+		}(clientID)
+	}
+	wg.Wait()
+
+	require.Equal(t, map[types.UUID]int{members[0]: 1, members[1]: 1, members[2]: 1}, assigned)
+}
+
+// withSettings returns a context in the default organization with settings
+func withSettings(settings schema.OrganizationSettings) context.Context {
+	return db.WithOrganization(context.Background(), schema.Organization{Settings: &settings})
+}
+
+func testClientLimits(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	a, b := createProvider(t, store), createProvider(t, store)
+	first := clk.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	slots := make([]time.Time, 4)
+	for i := range slots {
+		slots[i] = first.Add(time.Duration(i) * time.Hour)
+	}
+	for _, providerID := range []*types.UUID{a, b} {
+		require.NoError(t, store.AddAvailability(ctx, *providerID, nil, append(slots, first.Add(db.GetAvailabilityInterval())), 5))
+	}
+	reserve := func(ctx context.Context, clientID, providerID *types.UUID, startTime time.Time) error {
+		_, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+		return err
+	}
+
+	// A client can not be in two places at once unless the organization allows it
+	clientID := createClient(t, store)
+	require.NoError(t, reserve(ctx, clientID, a, first))
+	require.ErrorIs(t, reserve(ctx, clientID, b, first), db.ErrClientDoubleBooked)
+	require.NoError(t, reserve(ctx, clientID, b, first.Add(db.GetAvailabilityInterval())))
+	require.NoError(t, reserve(withSettings(schema.OrganizationSettings{AllowOverlappingAppointments: utils.Ptr(true)}), clientID, b, first))
+
+	// Confirmed appointments and expired holds do not count as holds
+	holds := withSettings(schema.OrganizationSettings{MaxActiveHolds: utils.Ptr(1)})
+	clientID = createClient(t, store)
+	appointment, err := store.ReserveAppointment(holds, clientID, a, nil, nil, &slots[1], nil)
+	require.NoError(t, err)
+	require.ErrorIs(t, reserve(holds, clientID, a, slots[2]), db.ErrHoldLimit)
+	require.NoError(t, store.ConfirmAppointment(ctx, *appointment.Id))
+	require.NoError(t, reserve(holds, clientID, a, slots[2]))
+	clk.Advance(db.ReservationHoldDuration + time.Minute)
+	require.NoError(t, reserve(holds, clientID, a, slots[3]))
+
+	// Upcoming appointments count overall and with each provider
+	future := withSettings(schema.OrganizationSettings{MaxFutureAppointments: utils.Ptr(2), MaxFutureAppointmentsPerProvider: utils.Ptr(1)})
+	clientID = createClient(t, store)
+	require.NoError(t, reserve(future, clientID, a, slots[1]))
+	require.ErrorIs(t, reserve(future, clientID, a, slots[2]), db.ErrProviderAppointmentLimit)
+	require.NoError(t, reserve(future, clientID, b, slots[2]))
+	require.ErrorIs(t, reserve(future, clientID, b, slots[3]), db.ErrAppointmentLimit)
+
+	// Group bookings pass over the members the client has reached their limit with
+	perProvider := withSettings(schema.OrganizationSettings{MaxFutureAppointmentsPerProvider: utils.Ptr(1)})
+	group, err := store.CreateProviderGroup(ctx, "Front desk", schema.Priority, []types.UUID{*a, *b})
+	require.NoError(t, err)
+	clientID = createClient(t, store)
+	require.NoError(t, reserve(perProvider, clientID, a, slots[1]))
+	appointment, err = store.ReserveFromGroup(perProvider, *clientID, *group.Id, slots[2], nil)
+	require.NoError(t, err)
+	require.Equal(t, *b, *appointment.ProviderId)
+	_, err = store.ReserveFromGroup(perProvider, *clientID, *group.Id, slots[3], nil)
+	require.ErrorIs(t, err, db.ErrProviderAppointmentLimit)
+	_, err = store.ReserveFromGroup(perProvider, *clientID, *group.Id, slots[1], nil)
+	require.ErrorIs(t, err, db.ErrClientDoubleBooked)
+}
+
+func testConcurrentClientLimits(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := withSettings(schema.OrganizationSettings{MaxActiveHolds: utils.Ptr(2)})
+
+	// Every request is for a different provider so only the client's limit can turn them away
+	startTime := clk.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	providers := make([]*types.UUID, 10)
+	for i := range providers {
+		providers[i] = createProvider(t, store)
+		addAvailability(t, store, providers[i], startTime.Add(time.Duration(i)*time.Hour))
+	}
+	clientID := createClient(t, store)
+
+	var wg sync.WaitGroup
+	var reserved atomic.Int32
+	for i, providerID := range providers {
+		wg.Add(1)
+		go func(providerID *types.UUID, startTime time.Time) {
+			defer wg.Done()
+			_, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+			if err == nil {
+				reserved.Add(1)
+				return
+			}
+			assert.ErrorIs(t, err, db.ErrHoldLimit)
+		}(providerID, startTime.Add(time.Duration(i)*time.Hour))
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(2), reserved.Load())
+}
+
+func testSeriesClientLimits(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	a, b := createProvider(t, store), createProvider(t, store)
+	startTimes := weeklyStartTimes(clk.Now().Add(48*time.Hour).Truncate(time.Minute), 3)
+	addAvailability(t, store, a, startTimes...)
+	addAvailability(t, store, b, startTimes...)
+	req := db.SeriesRequest{
+		ClientID:   createClient(t, store),
+		ProviderID: a,
+		Recurrence: schema.Recurrence{Frequency: schema.Weekly, Count: 3},
+		StartTimes: startTimes,
+	}
+
+	// Every occurrence counts against the client's limits, together with the ones before it
+	_, err := store.ReserveSeries(withSettings(schema.OrganizationSettings{MaxActiveHolds: utils.Ptr(2)}), req)
+	require.ErrorIs(t, err, db.ErrHoldLimit)
+	available, err := store.IsSlotAvailable(ctx, a, nil, &startTimes[0])
+	require.NoError(t, err)
+	require.True(t, available)
+
+	// An occurrence can not overlap another appointment of the client
+	_, err = store.ReserveAppointment(ctx, req.ClientID, b, nil, nil, &startTimes[1], nil)
+	require.NoError(t, err)
+	_, err = store.ReserveSeries(ctx, req)
+	require.ErrorIs(t, err, db.ErrClientDoubleBooked)
+	available, err = store.IsSlotAvailable(ctx, a, nil, &startTimes[0])
+	require.NoError(t, err)
+	require.True(t, available)
+
+	_, err = store.ReserveSeries(withSettings(schema.OrganizationSettings{AllowOverlappingAppointments: utils.Ptr(true)}), req)
+	require.NoError(t, err)
+}
+
+func testRescheduleClientLimits(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	a, b := createProvider(t, store), createProvider(t, store)
+	first := clk.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	slots := []time.Time{first, first.Add(time.Hour), first.Add(2 * time.Hour)}
+	addAvailability(t, store, a, slots...)
+	addAvailability(t, store, b, slots...)
+	clientID := createClient(t, store)
+	appointment, err := store.ReserveAppointment(ctx, clientID, a, nil, nil, &slots[0], nil)
+	require.NoError(t, err)
+	_, err = store.ReserveAppointment(ctx, clientID, b, nil, nil, &slots[1], nil)
+	require.NoError(t, err)
+
+	// The client already has an appointment at the new time, so nothing moves
+	_, err = store.RescheduleAppointment(ctx, *appointment.Id, slots[1])
+	require.ErrorIs(t, err, db.ErrClientDoubleBooked)
+	available, err := store.IsSlotAvailable(ctx, a, nil, &slots[1])
+	require.NoError(t, err)
+	require.True(t, available)
+
+	// The appointment being moved does not count against itself
+	full := withSettings(schema.OrganizationSettings{MaxActiveHolds: utils.Ptr(2), MaxFutureAppointmentsPerProvider: utils.Ptr(1)})
+	moved, err := store.RescheduleAppointment(full, *appointment.Id, slots[2])
+	require.NoError(t, err)
+	require.Equal(t, slots[2].UTC(), moved[0].StartTime.UTC())
+}
+
+func testAppointmentOutcomes(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTime := clk.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	addAvailability(t, store, providerID, startTime)
+	reserved, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+
+	// Reservations have to be confirmed first and nothing happens before the appointment starts
+	_, err = store.UpdateAppointmentStatus(ctx, *reserved.Id, schema.AppointmentStatusCheckedIn, *providerID)
+	var transition *db.TransitionError
+	require.ErrorAs(t, err, &transition)
+	require.Equal(t, db.TransitionError{From: schema.AppointmentStatusReserved, To: schema.AppointmentStatusCheckedIn}, *transition)
+	require.NoError(t, store.ConfirmAppointment(ctx, *reserved.Id))
+	_, err = store.UpdateAppointmentStatus(ctx, *reserved.Id, schema.AppointmentStatusCheckedIn, *providerID)
+	require.ErrorIs(t, err, db.ErrAppointmentNotStarted)
+
+	// Only the provider changes the status, and who did and when is recorded
+	clk.Advance(24 * time.Hour)
+	_, err = store.UpdateAppointmentStatus(ctx, *reserved.Id, schema.AppointmentStatusCheckedIn, *clientID)
+	require.ErrorIs(t, err, db.ErrNotAppointmentProvider)
+	_, err = store.UpdateAppointmentStatus(ctx, *reserved.Id, schema.AppointmentStatusCompleted, *providerID)
+	require.ErrorIs(t, err, db.ErrInvalidTransition)
+	checkedIn, err := store.UpdateAppointmentStatus(ctx, *reserved.Id, schema.AppointmentStatusCheckedIn, *providerID)
+	require.NoError(t, err)
+	require.Equal(t, schema.AppointmentStatusCheckedIn, *checkedIn.Status)
+	require.Equal(t, *providerID, *checkedIn.StatusChangedBy)
+	require.True(t, clk.Now().Equal(*checkedIn.StatusChangedAt))
+
+	clk.Advance(time.Hour)
+	_, err = store.UpdateAppointmentStatus(ctx, *reserved.Id, schema.AppointmentStatusNoShow, *providerID)
+	require.ErrorIs(t, err, db.ErrInvalidTransition)
+	completed, err := store.UpdateAppointmentStatus(ctx, *reserved.Id, schema.AppointmentStatusCompleted, *providerID)
+	require.NoError(t, err)
+	require.Equal(t, schema.AppointmentStatusCompleted, *completed.Status)
+	require.True(t, clk.Now().Equal(*completed.StatusChangedAt))
+
+	// Completed appointments are final
+	_, err = store.UpdateAppointmentStatus(ctx, *reserved.Id, schema.AppointmentStatusCheckedIn, *providerID)
+	require.ErrorIs(t, err, db.ErrInvalidTransition)
+	require.ErrorIs(t, store.CancelAppointment(ctx, *reserved.Id), db.ErrInvalidTransition)
+	_, err = store.UpdateAppointmentStatus(ctx, uuid.New(), schema.AppointmentStatusCheckedIn, *providerID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// The other providers of an appointment can mark it too
+	secondID := createProvider(t, store)
+	startTime = clk.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	addAvailability(t, store, providerID, startTime)
+	addAvailability(t, store, secondID, startTime)
+	appointment, err := store.ReserveAppointment(ctx, clientID, providerID, []types.UUID{*secondID}, nil, &startTime, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.ConfirmAppointment(ctx, *appointment.Id))
+	clk.Advance(25 * time.Hour)
+	noShow, err := store.UpdateAppointmentStatus(ctx, *appointment.Id, schema.AppointmentStatusNoShow, *secondID)
+	require.NoError(t, err)
+	require.Equal(t, schema.AppointmentStatusNoShow, *noShow.Status)
+	require.Equal(t, *secondID, *noShow.StatusChangedBy)
+}
+
+func testNoShowLimit(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := withSettings(schema.OrganizationSettings{MaxNoShows: utils.Ptr(2)})
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	missAppointment := func() {
+		startTime := clk.Now().Add(24 * time.Hour).Truncate(time.Minute)
+		addAvailability(t, store, providerID, startTime)
+		appointment, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+		require.NoError(t, err)
+		require.NoError(t, store.ConfirmAppointment(ctx, *appointment.Id))
+		clk.Advance(25 * time.Hour)
+		_, err = store.UpdateAppointmentStatus(ctx, *appointment.Id, schema.AppointmentStatusNoShow, *providerID)
+		require.NoError(t, err)
+	}
+	missAppointment()
+	missAppointment()
+
+	startTime := clk.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	addAvailability(t, store, providerID, startTime)
+	_, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.ErrorIs(t, err, db.ErrNoShowLimit)
+
+	// Organizations without the limit still take the booking
+	_, err = store.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+
+	// No-shows stop counting once they are old enough
+	clk.Advance(db.NoShowWindow - 24*time.Hour)
+	startTime = clk.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	addAvailability(t, store, providerID, startTime)
+	_, err = store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+}
+
+func testAppointmentIntake(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	checkup, err := store.CreateAppointmentType(ctx, "Checkup", []schema.IntakeQuestion{
+		{Key: "symptoms", Label: "What brings you in?", Type: schema.Text, Required: utils.Ptr(true)},
+		{Key: "age", Label: "Age", Type: schema.Number},
+		{Key: "insured", Label: "Are you insured?", Type: schema.Boolean},
+		{Key: "visit", Label: "Visit", Type: schema.Choice, Options: &[]string{"in person", "video"}},
+	})
+	require.NoError(t, err)
+	_, err = store.CreateAppointmentType(ctx, "Blood test", nil)
+	require.NoError(t, err)
+	appointmentTypes, err := store.ListAppointmentTypes(ctx)
+	require.NoError(t, err)
+	require.Len(t, appointmentTypes, 2)
+	require.Equal(t, "Blood test", *appointmentTypes[0].Name)
+	require.Empty(t, *appointmentTypes[0].IntakeQuestions)
+	require.Equal(t, *checkup, appointmentTypes[1])
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTime := clk.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	addAvailability(t, store, providerID, startTime)
+	reserve := func(intake *db.Intake) (*schema.Appointment, error) {
+		return store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, intake)
+	}
+
+	// Answers have to fit the questions of the appointment type
+	_, err = reserve(&db.Intake{AppointmentTypeID: utils.Ptr(uuid.New())})
+	require.ErrorIs(t, err, db.ErrAppointmentTypeNotFound)
+	_, err = reserve(&db.Intake{Answers: map[string]interface{}{"symptoms": "Cough"}})
+	var intakeErr *db.IntakeError
+	require.ErrorAs(t, err, &intakeErr)
+	require.Equal(t, []schema.FieldError{{Field: "intake_answers", Message: "need an appointment_type_id"}}, intakeErr.Errors)
+	_, err = reserve(&db.Intake{AppointmentTypeID: checkup.Id, Answers: map[string]interface{}{
+		"age":     "forty",
+		"insured": "yes",
+		"visit":   "phone",
+		"pets":    2,
+	}})
+	require.ErrorAs(t, err, &intakeErr)
+	require.Equal(t, []schema.FieldError{
+		{Field: "intake_answers.symptoms", Message: "is required"},
+		{Field: "intake_answers.age", Message: "must be a number"},
+		{Field: "intake_answers.insured", Message: "must be true or false"},
+		{Field: "intake_answers.visit", Message: "must be one of in person, video"},
+		{Field: "intake_answers.pets", Message: "is not an intake question of the appointment type"},
+	}, intakeErr.Errors)
+
+	// Nothing was reserved by the failed attempts
+	available, err := store.IsSlotAvailable(ctx, providerID, nil, &startTime)
+	require.NoError(t, err)
+	require.True(t, available)
+
+	appointment, err := reserve(&db.Intake{
+		Reason:            "Persistent cough",
+		AppointmentTypeID: checkup.Id,
+		Answers:           map[string]interface{}{"symptoms": "Cough for two weeks", "age": 42, "insured": true, "visit": "video"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Persistent cough", *appointment.Reason)
+	require.Equal(t, *checkup.Id, *appointment.AppointmentTypeId)
+	answers := map[string]interface{}{"symptoms": "Cough for two weeks", "age": 42.0, "insured": true, "visit": "video"}
+	require.Equal(t, answers, *appointment.IntakeAnswers)
+
+	// The intake is read back with the appointment and kept when it moves
+	later := startTime.Add(time.Hour)
+	addAvailability(t, store, providerID, later)
+	moved, err := store.RescheduleAppointment(ctx, *appointment.Id, later)
+	require.NoError(t, err)
+	require.Equal(t, "Persistent cough", *moved[0].Reason)
+	require.Equal(t, answers, *moved[0].IntakeAnswers)
+
+	// A reason needs no appointment type, and other organizations can not use this one's types
+	plain, err := reserve(&db.Intake{Reason: "Follow up"})
+	require.NoError(t, err)
+	require.Equal(t, "Follow up", *plain.Reason)
+	require.Nil(t, plain.AppointmentTypeId)
+	require.Nil(t, plain.IntakeAnswers)
+
+	other, err := store.CreateOrganization(ctx, db.NewOrganization{Name: "Elsewhere", Slug: "elsewhere"})
+	require.NoError(t, err)
+	otherCtx := db.WithOrganization(ctx, *other)
+	otherTypes, err := store.ListAppointmentTypes(otherCtx)
+	require.NoError(t, err)
+	require.Empty(t, otherTypes)
+}
+
+func testAppointmentNotes(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID, participantID := createProvider(t, store), createProvider(t, store)
+	clientID := createClient(t, store)
+	startTime := clk.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	addAvailability(t, store, providerID, startTime)
+	addAvailability(t, store, participantID, startTime)
+	appointment, err := store.ReserveAppointment(ctx, clientID, providerID, []types.UUID{*participantID}, nil, &startTime, nil)
+	require.NoError(t, err)
+
+	notes, err := store.ListAppointmentNotes(ctx, *appointment.Id)
+	require.NoError(t, err)
+	require.Empty(t, notes)
+
+	// Every save is a new version, by whichever provider of the appointment made it
+	first, err := store.SaveAppointmentNote(ctx, *appointment.Id, *providerID, "Bring previous x-rays")
+	require.NoError(t, err)
+	require.Equal(t, 1, *first.Version)
+	require.Equal(t, *providerID, *first.AuthorId)
+	clk.Advance(time.Minute)
+	second, err := store.SaveAppointmentNote(ctx, *appointment.Id, *participantID, "Bring previous x-rays and blood work")
+	require.NoError(t, err)
+	require.Equal(t, 2, *second.Version)
+	require.True(t, clk.Now().Equal(*second.CreatedAt))
+
+	notes, err = store.ListAppointmentNotes(ctx, *appointment.Id)
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	require.Equal(t, "Bring previous x-rays and blood work", *notes[0].Body)
+	require.Equal(t, *participantID, *notes[0].AuthorId)
+	require.Equal(t, "Bring previous x-rays", *notes[1].Body)
+	require.Equal(t, 1, *notes[1].Version)
+	require.True(t, first.CreatedAt.Equal(*notes[1].CreatedAt))
+
+	// Notes are attributed to the appointment's providers, not the client or other providers
+	_, err = store.SaveAppointmentNote(ctx, *appointment.Id, *clientID, "Not mine")
+	require.ErrorIs(t, err, db.ErrNotAppointmentProvider)
+	_, err = store.SaveAppointmentNote(ctx, *appointment.Id, *createProvider(t, store), "Not mine")
+	require.ErrorIs(t, err, db.ErrNotAppointmentProvider)
+	_, err = store.ListAppointmentNotes(ctx, uuid.New())
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = store.SaveAppointmentNote(ctx, uuid.New(), *providerID, "Lost")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Another organization can not read them
+	other, err := store.CreateOrganization(ctx, db.NewOrganization{Name: "Elsewhere", Slug: "elsewhere"})
+	require.NoError(t, err)
+	_, err = store.ListAppointmentNotes(db.WithOrganization(ctx, *other), *appointment.Id)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testPromoteWaitlistClientLimits(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID, otherID := createProvider(t, store), createProvider(t, store)
+	first := clk.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	second := first.Add(db.GetAvailabilityInterval())
+	later := first.Add(24 * time.Hour)
+	earliestStart := clk.Now().Add(24 * time.Hour)
+	addAvailability(t, store, providerID, first, second)
+	addAvailability(t, store, otherID, first, later)
+
+	// The provider is fully booked
+	var blockers []*schema.Appointment
+	for _, startTime := range []time.Time{first, second} {
+		appointment, err := store.ReserveAppointment(ctx, createClient(t, store), providerID, nil, nil, &startTime, nil)
+		require.NoError(t, err)
+		blockers = append(blockers, appointment)
+	}
+
+	// The first client to join already sees the other provider at the first slot's time, the second holds
+	// as many reservations as allowed and the third is free
+	overlapping, held, free := createClient(t, store), createClient(t, store), createClient(t, store)
+	appointment, err := store.ReserveAppointment(ctx, overlapping, otherID, nil, nil, &first, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.ConfirmAppointment(ctx, *appointment.Id))
+	_, err = store.ReserveAppointment(ctx, held, otherID, nil, nil, &later, nil)
+	require.NoError(t, err)
+	var entries []*schema.WaitlistEntry
+	for _, clientID := range []*types.UUID{overlapping, held, free} {
+		entry, err := store.JoinWaitlist(ctx, *clientID, *providerID, first, second.Add(db.GetAvailabilityInterval()), earliestStart)
+		require.NoError(t, err)
+		entries = append(entries, entry)
+		clk.Advance(time.Minute)
+	}
+
+	for _, blocker := range blockers {
+		require.NoError(t, store.CancelAppointment(ctx, *blocker.Id))
+	}
+	holds := withSettings(schema.OrganizationSettings{MaxActiveHolds: utils.Ptr(1)})
+	offers, err := store.PromoteWaitlist(holds, earliestStart)
+	require.NoError(t, err)
+	require.Len(t, offers, 2)
+	require.Equal(t, *entries[0].Id, *offers[0].Entry.Id)
+	require.True(t, second.Equal(*offers[0].Appointment.StartTime))
+	require.Equal(t, *entries[2].Id, *offers[1].Entry.Id)
+	require.True(t, first.Equal(*offers[1].Appointment.StartTime))
+
+	// The client at their limit keeps waiting
+	waitlist, err := store.GetProviderWaitlist(ctx, *providerID)
+	require.NoError(t, err)
+	require.Len(t, waitlist, 3)
+	require.Equal(t, *entries[1].Id, *waitlist[2].Id)
+	require.Equal(t, schema.WaitlistEntryStatusWaiting, *waitlist[2].Status)
+}
+
+func testStartedAppointmentsKeepTheirStatus(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTime := clk.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	later := startTime.Add(48 * time.Hour)
+	addAvailability(t, store, providerID, startTime, later)
+	appointment, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.ConfirmAppointment(ctx, *appointment.Id))
+	clk.Advance(25 * time.Hour)
+	_, err = store.UpdateAppointmentStatus(ctx, *appointment.Id, schema.AppointmentStatusCheckedIn, *providerID)
+	require.NoError(t, err)
+
+	// Nothing but completing changes a checked in appointment
+	rejected := func(from, to schema.AppointmentStatus, err error) {
+		t.Helper()
+		var transition *db.TransitionError
+		require.ErrorAs(t, err, &transition)
+		require.Equal(t, db.TransitionError{From: from, To: to}, *transition)
+	}
+	checkedIn, cancelled := schema.AppointmentStatusCheckedIn, schema.AppointmentStatusCancelled
+	rejected(checkedIn, cancelled, store.CancelAppointment(ctx, *appointment.Id))
+	rejected(checkedIn, cancelled, store.CancelFollowingAppointments(ctx, *appointment.Id))
+	rejected(checkedIn, schema.AppointmentStatusConfirmed, store.ConfirmAppointment(ctx, *appointment.Id))
+	_, err = store.RescheduleAppointment(ctx, *appointment.Id, later)
+	rejected(checkedIn, cancelled, err)
+	clk.Advance(db.ReservationHoldDuration)
+	expired, err := store.ExpireReservations(ctx)
+	require.NoError(t, err)
+	require.Zero(t, expired)
+
+	completed, err := store.UpdateAppointmentStatus(ctx, *appointment.Id, schema.AppointmentStatusCompleted, *providerID)
+	require.NoError(t, err)
+	require.Equal(t, schema.AppointmentStatusCompleted, *completed.Status)
+
+	// And nothing changes a completed one
+	rejected(schema.AppointmentStatusCompleted, cancelled, store.CancelAppointment(ctx, *appointment.Id))
+	_, err = store.RescheduleFollowingAppointments(ctx, *appointment.Id, later)
+	rejected(schema.AppointmentStatusCompleted, cancelled, err)
+	expired, err = store.ExpireReservations(ctx)
+	require.NoError(t, err)
+	require.Zero(t, expired)
+	_, err = store.UpdateAppointmentStatus(ctx, *appointment.Id, schema.AppointmentStatusNoShow, *providerID)
+	rejected(schema.AppointmentStatusCompleted, schema.AppointmentStatusNoShow, err)
+}
+
+// statusChange is a change of status without its time, to compare histories by
+type statusChange struct {
+	status    schema.AppointmentStatus
+	source    schema.StatusChangeSource
+	changedBy *types.UUID
+}
+
+func history(t *testing.T, store db.Store, appointmentID types.UUID) []statusChange {
+	t.Helper()
+	changes, err := store.ListStatusHistory(context.Background(), appointmentID)
+	require.NoError(t, err)
+	history := make([]statusChange, 0, len(changes))
+	for _, change := range changes {
+		history = append(history, statusChange{status: *change.Status, source: *change.Source, changedBy: change.ChangedBy})
+	}
+	return history
+}
+
+func testStatusHistory(t *testing.T, h Harness) {
+	store, clk := newStore(t, h)
+	ctx := context.Background()
+
+	providerID := createProvider(t, store)
+	clientID := createClient(t, store)
+	startTime := clk.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	addAvailability(t, store, providerID, startTime)
+
+	// Every change is recorded, with the provider who made it and when
+	appointment, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+	reservedAt := clk.Now()
+	clk.Advance(time.Minute)
+	require.NoError(t, store.ConfirmAppointment(ctx, *appointment.Id))
+	confirmedAt := clk.Now()
+	clk.Advance(24 * time.Hour)
+	_, err = store.UpdateAppointmentStatus(ctx, *appointment.Id, schema.AppointmentStatusCheckedIn, *providerID)
+	require.NoError(t, err)
+	clk.Advance(time.Hour)
+	_, err = store.UpdateAppointmentStatus(ctx, *appointment.Id, schema.AppointmentStatusCompleted, *providerID)
+	require.NoError(t, err)
+
+	require.Equal(t, []statusChange{
+		{status: schema.AppointmentStatusReserved, source: schema.StatusChangeSourceRequest},
+		{status: schema.AppointmentStatusConfirmed, source: schema.StatusChangeSourceRequest},
+		{status: schema.AppointmentStatusCheckedIn, source: schema.StatusChangeSourceProvider, changedBy: providerID},
+		{status: schema.AppointmentStatusCompleted, source: schema.StatusChangeSourceProvider, changedBy: providerID},
+	}, history(t, store, *appointment.Id))
+	changes, err := store.ListStatusHistory(ctx, *appointment.Id)
+	require.NoError(t, err)
+	require.True(t, reservedAt.Equal(*changes[0].ChangedAt))
+	require.True(t, confirmedAt.Equal(*changes[1].ChangedAt))
+	require.True(t, clk.Now().Equal(*changes[3].ChangedAt))
+
+	// Failed changes are not recorded
+	require.ErrorIs(t, store.CancelAppointment(ctx, *appointment.Id), db.ErrInvalidTransition)
+	require.Len(t, history(t, store, *appointment.Id), 4)
+
+	// Cancellations and expiry are recorded too
+	startTime = clk.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	addAvailability(t, store, providerID, startTime)
+	cancelled, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.CancelAppointment(ctx, *cancelled.Id))
+	require.Equal(t, []statusChange{
+		{status: schema.AppointmentStatusReserved, source: schema.StatusChangeSourceRequest},
+		{status: schema.AppointmentStatusCancelled, source: schema.StatusChangeSourceRequest},
+	}, history(t, store, *cancelled.Id))
+
+	stale, err := store.ReserveAppointment(ctx, clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+	waitingID := createClient(t, store)
+	_, err = store.JoinWaitlist(ctx, *waitingID, *providerID, startTime, startTime.Add(time.Hour), clk.Now())
+	require.NoError(t, err)
+	clk.Advance(31 * time.Minute)
+
+	// A hold that ran out can not be confirmed by any path, only expired
+	_, err = store.UpdateAppointmentStatus(ctx, *stale.Id, schema.AppointmentStatusConfirmed, *providerID)
+	require.ErrorIs(t, err, db.ErrHoldExpired)
+	require.ErrorIs(t, store.ConfirmAppointment(ctx, *stale.Id), db.ErrHoldExpired)
+	expired, err := store.ExpireReservations(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), expired)
+	require.Equal(t, []statusChange{
+		{status: schema.AppointmentStatusReserved, source: schema.StatusChangeSourceRequest},
+		{status: schema.AppointmentStatusExpired, source: schema.StatusChangeSourceHoldExpiry},
+	}, history(t, store, *stale.Id))
+
+	// Offers made from the waitlist say so
+	offers, err := store.PromoteWaitlist(ctx, clk.Now())
+	require.NoError(t, err)
+	require.Len(t, offers, 1)
+	require.Equal(t, []statusChange{
+		{status: schema.AppointmentStatusReserved, source: schema.StatusChangeSourceWaitlist},
+	}, history(t, store, *offers[0].Appointment.Id))
+
+	// The history belongs to the organization of the appointment
+	_, err = store.ListStatusHistory(ctx, uuid.New())
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	other, err := store.CreateOrganization(ctx, db.NewOrganization{Name: "Elsewhere", Slug: "elsewhere"})
+	require.NoError(t, err)
+	_, err = store.ListStatusHistory(db.WithOrganization(ctx, *other), *appointment.Id)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
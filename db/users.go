@@ -0,0 +1,223 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+var (
+	// ErrEmailTaken is returned when another user already has the email
+	ErrEmailTaken = errors.New("email is already in use")
+	// ErrUserInactive is returned when booking with a client or provider who has been deactivated
+	ErrUserInactive = errors.New("user has been deactivated")
+	// ErrUserHasAppointments is returned when deleting a user whose appointments have to be kept
+	ErrUserHasAppointments = errors.New("user has appointments")
+)
+
+// UserUpdate holds the user fields to change, nil fields are kept
+type UserUpdate struct {
+	Name  *string
+	Email *string
+}
+
+// postgres error codes, https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+// isPQError reports whether err is a postgres error with code
+func isPQError(err error, code pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == code
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// userColumns are the columns scanUser reads, in order
+const userColumns = `id, name, email, role, deactivated_at`
+
+func scanUser(row rowScanner) (*schema.User, error) {
+	var id uuid.UUID
+	var name, email, role string
+	var deactivatedAt sql.NullTime
+	if err := row.Scan(&id, &name, &email, &role, &deactivatedAt); err != nil {
+		return nil, err
+	}
+
+	userRole := schema.UserRole(role)
+	user := &schema.User{
+		Id:    (*types.UUID)(&id),
+		Name:  utils.Ptr(name),
+		Email: utils.Ptr(email),
+		Role:  &userRole,
+	}
+	if deactivatedAt.Valid {
+		user.DeactivatedAt = &deactivatedAt.Time
+	}
+	return user, nil
+}
+
+// UpdateUser changes a user's name or email, returning ErrEmailTaken if another user has the email
+func (db *Database) UpdateUser(ctx context.Context, userID types.UUID, update UserUpdate) (*schema.User, error) {
+	ctx, span := tracer.Start(ctx, "db.UpdateUser")
+	defer span.End()
+
+	var user *schema.User
+	err := db.inTenant(ctx, func(tx *sql.Tx) error {
+		var err error
+		user, err = scanUser(tx.QueryRowContext(ctx, `
+		UPDATE users
+		SET name = COALESCE($2, name), email = COALESCE($3, email), updated_at = $4
+		WHERE id = $1
+		RETURNING `+userColumns+`
+	`, userID.String(), update.Name, update.Email, db.Clock.Now()))
+		return err
+	})
+	if isPQError(err, uniqueViolation) {
+		return nil, ErrEmailTaken
+	}
+	return user, err
+}
+
+// DeactivateUser stops a user from booking or being booked and cancels the waitlist entries still
+// waiting for them. Their appointments are kept. Deactivating a user again keeps the first time.
+func (db *Database) DeactivateUser(ctx context.Context, userID types.UUID) (*schema.User, error) {
+	ctx, span := tracer.Start(ctx, "db.DeactivateUser")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.rollback(tx)
+
+	now := db.Clock.Now()
+	user, err := scanUser(tx.QueryRowContext(ctx, `
+	UPDATE users
+	SET deactivated_at = COALESCE(deactivated_at, $2), updated_at = $2
+	WHERE id = $1
+	RETURNING `+userColumns+`
+`, userID.String(), now))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+	UPDATE waitlist_entries
+	SET status = 'cancelled', updated_at = $2
+	WHERE status = 'waiting'
+	  AND (client_id = $1 OR provider_id = $1)
+`, userID.String(), now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// DeleteUser removes a user with their availability and waitlist entries. A user who has appointments,
+// even cancelled ones, is not deleted and ErrUserHasAppointments is returned so they can be deactivated instead.
+func (db *Database) DeleteUser(ctx context.Context, userID types.UUID) error {
+	ctx, span := tracer.Start(ctx, "db.DeleteUser")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.rollback(tx)
+
+	// Lock the user so nobody books with them until they are gone
+	var id uuid.UUID
+	err = tx.QueryRowContext(ctx, `
+	SELECT id
+	FROM users
+	WHERE id = $1
+	FOR UPDATE
+`, userID.String()).Scan(&id)
+	if err != nil {
+		return err
+	}
+
+	var hasAppointments bool
+	err = tx.QueryRowContext(ctx, `
+	SELECT EXISTS (
+	  SELECT 1 FROM appointments
+	  WHERE client_id = $1 OR provider_id = $1
+	  UNION ALL
+	  SELECT 1 FROM appointment_participants
+	  WHERE provider_id = $1
+	)
+`, userID.String()).Scan(&hasAppointments)
+	if err != nil {
+		return err
+	}
+	if hasAppointments {
+		return ErrUserHasAppointments
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID.String())
+	if isPQError(err, foreignKeyViolation) {
+		return ErrUserHasAppointments
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// bookable returns sql.ErrNoRows if any of the users is not in the organization and ErrUserInactive if any
+// has been deactivated. Their rows stay locked until tx ends so they can not be deactivated while they
+// are being booked.
+func bookable(ctx context.Context, tx *sql.Tx, userIDs ...*types.UUID) error {
+	ids := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		ids = append(ids, id.String())
+	}
+	rows, err := tx.QueryContext(ctx, `
+	SELECT id, deactivated_at
+	FROM users
+	WHERE id = ANY($1::uuid[])
+	FOR SHARE
+`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	found := map[uuid.UUID]bool{}
+	for rows.Next() {
+		var id uuid.UUID
+		var deactivatedAt sql.NullTime
+		if err := rows.Scan(&id, &deactivatedAt); err != nil {
+			return err
+		}
+		if deactivatedAt.Valid {
+			return ErrUserInactive
+		}
+		found[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, id := range userIDs {
+		if !found[*id] {
+			return sql.ErrNoRows
+		}
+	}
+	return nil
+}
@@ -0,0 +1,403 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/tateexon/reservation/schema"
+	"github.com/tateexon/reservation/utils"
+)
+
+// ErrSlotsAvailable is returned when joining a waitlist for a window that still has open slots
+var ErrSlotsAvailable = errors.New("slots are available in the requested window")
+
+// WaitlistOffer is a hold placed on behalf of a waitlisted client
+type WaitlistOffer struct {
+	Entry       schema.WaitlistEntry
+	Appointment schema.Appointment
+}
+
+// JoinWaitlist adds a client to a provider's waitlist for a time window. Only slots starting at or
+// after earliestStart are considered bookable.
+func (db *Database) JoinWaitlist(ctx context.Context, clientID, providerID types.UUID, windowStart, windowEnd, earliestStart time.Time) (*schema.WaitlistEntry, error) {
+	ctx, span := tracer.Start(ctx, "db.JoinWaitlist")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.rollback(tx)
+
+	if err := activeUserWithRole(ctx, tx, providerID, "provider"); err != nil {
+		return nil, err
+	}
+	if err := activeUserWithRole(ctx, tx, clientID, "client"); err != nil {
+		return nil, err
+	}
+
+	now := db.Clock.Now()
+	var count int
+	err = tx.QueryRowContext(ctx, `
+	SELECT COUNT(*)
+	FROM availability a
+	WHERE a.provider_id = $1
+	  AND a.start_time >= $2 AND a.start_time >= $3 AND a.end_time <= $4
+	  AND (
+	    SELECT COUNT(*) FROM appointments appt
+	    WHERE `+seatOf("a.provider_id")+`
+	      AND appt.start_time = a.start_time
+	      AND (
+	        appt.status = 'confirmed' OR
+	        (appt.status = 'reserved' AND appt.created_at > $5)
+	      )
+	  ) < a.capacity
+`, providerID.String(), windowStart, earliestStart, windowEnd, holdCutoff(now)).Scan(&count)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, ErrSlotsAvailable
+	}
+
+	entryID := uuid.New()
+	var createdAt time.Time
+	err = tx.QueryRowContext(ctx, `
+	INSERT INTO waitlist_entries (id, client_id, provider_id, window_start, window_end, status, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, 'waiting', $6, $6)
+	RETURNING created_at
+`, entryID, clientID.String(), providerID.String(), windowStart, windowEnd, now).Scan(&createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var position int
+	err = tx.QueryRowContext(ctx, `
+	SELECT COUNT(*)
+	FROM waitlist_entries
+	WHERE provider_id = $1
+	  AND status = 'waiting'
+	  AND (created_at, id) <= ($2, $3)
+`, providerID.String(), createdAt, entryID).Scan(&position)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	status := schema.WaitlistEntryStatusWaiting
+	return &schema.WaitlistEntry{
+		Id:          (*types.UUID)(&entryID),
+		ClientId:    &clientID,
+		ProviderId:  &providerID,
+		WindowStart: &windowStart,
+		WindowEnd:   &windowEnd,
+		Status:      &status,
+		Position:    &position,
+		CreatedAt:   &createdAt,
+	}, nil
+}
+
+// LeaveWaitlist removes a waiting client from the waitlist
+func (db *Database) LeaveWaitlist(ctx context.Context, entryID types.UUID) error {
+	ctx, span := tracer.Start(ctx, "db.LeaveWaitlist")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.rollback(tx)
+
+	result, err := tx.ExecContext(ctx, `
+	UPDATE waitlist_entries
+	SET status = 'cancelled', updated_at = $2
+	WHERE id = $1
+	  AND status = 'waiting'
+`, entryID.String(), db.Clock.Now())
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return tx.Commit()
+}
+
+// GetProviderWaitlist returns the offered and waiting entries for a provider in the order they are served
+func (db *Database) GetProviderWaitlist(ctx context.Context, providerID types.UUID) ([]schema.WaitlistEntry, error) {
+	ctx, span := tracer.Start(ctx, "db.GetProviderWaitlist")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.rollback(tx)
+
+	if _, err := providerExists(ctx, tx, providerID); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+	SELECT w.id, w.client_id, w.provider_id, w.window_start, w.window_end, w.status, w.appointment_id, appt.created_at,
+	  ROW_NUMBER() OVER (PARTITION BY w.status ORDER BY w.created_at, w.id),
+	  w.created_at
+	FROM waitlist_entries w
+	LEFT JOIN appointments appt ON appt.id = w.appointment_id
+	WHERE w.provider_id = $1
+	  AND w.status IN ('offered', 'waiting')
+	ORDER BY w.status = 'waiting', w.created_at, w.id
+`, providerID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []schema.WaitlistEntry{}
+	for rows.Next() {
+		var id, clientID, pID uuid.UUID
+		var windowStart, windowEnd, createdAt time.Time
+		var status string
+		var appointmentID uuid.NullUUID
+		var heldAt sql.NullTime
+		var position int
+
+		err := rows.Scan(&id, &clientID, &pID, &windowStart, &windowEnd, &status, &appointmentID, &heldAt, &position, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		entryStatus := schema.WaitlistEntryStatus(status)
+		entry := schema.WaitlistEntry{
+			Id:          (*types.UUID)(&id),
+			ClientId:    (*types.UUID)(&clientID),
+			ProviderId:  (*types.UUID)(&pID),
+			WindowStart: &windowStart,
+			WindowEnd:   &windowEnd,
+			Status:      &entryStatus,
+			CreatedAt:   &createdAt,
+		}
+		if entryStatus == schema.WaitlistEntryStatusWaiting {
+			entry.Position = utils.Ptr(position)
+		}
+		if appointmentID.Valid {
+			entry.AppointmentId = (*types.UUID)(&appointmentID.UUID)
+		}
+		if heldAt.Valid {
+			entry.HoldExpiresAt = utils.Ptr(heldAt.Time.Add(ReservationHoldDuration))
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// PromoteWaitlist settles earlier offers and then walks every waiting entry first-come-first-served,
+// placing a hold on the earliest free slot in its window that starts at or after earliestStart.
+func (db *Database) PromoteWaitlist(ctx context.Context, earliestStart time.Time) ([]WaitlistOffer, error) {
+	ctx, span := tracer.Start(ctx, "db.PromoteWaitlist")
+	defer span.End()
+
+	tx, err := db.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.rollback(tx)
+
+	now := db.Clock.Now()
+
+	// Offers are done once their hold is confirmed, cancelled or expired
+	_, err = tx.ExecContext(ctx, `
+	UPDATE waitlist_entries w
+	SET status = CASE appt.status
+	    WHEN 'confirmed' THEN 'fulfilled'
+	    WHEN 'cancelled' THEN 'cancelled'
+	    ELSE 'expired'
+	  END,
+	  updated_at = $1
+	FROM appointments appt
+	WHERE w.appointment_id = appt.id
+	  AND w.status = 'offered'
+	  AND NOT (appt.status = 'reserved' AND appt.created_at > $2)
+`, now, holdCutoff(now))
+	if err != nil {
+		return nil, err
+	}
+
+	// Windows that can no longer be booked will never be served
+	_, err = tx.ExecContext(ctx, `
+	UPDATE waitlist_entries
+	SET status = 'expired', updated_at = $2
+	WHERE status = 'waiting'
+	  AND window_end <= $1
+`, earliestStart, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lock the waiting entries so concurrent promotions serve each one only once
+	rows, err := tx.QueryContext(ctx, `
+	SELECT id, client_id, provider_id, window_start, window_end, created_at
+	FROM waitlist_entries
+	WHERE status = 'waiting'
+	ORDER BY created_at, id
+	FOR UPDATE
+`)
+	if err != nil {
+		return nil, err
+	}
+	var waiting []schema.WaitlistEntry
+	for rows.Next() {
+		var id, clientID, providerID uuid.UUID
+		var windowStart, windowEnd, createdAt time.Time
+		if err := rows.Scan(&id, &clientID, &providerID, &windowStart, &windowEnd, &createdAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		waiting = append(waiting, schema.WaitlistEntry{
+			Id:          (*types.UUID)(&id),
+			ClientId:    (*types.UUID)(&clientID),
+			ProviderId:  (*types.UUID)(&providerID),
+			WindowStart: &windowStart,
+			WindowEnd:   &windowEnd,
+			CreatedAt:   &createdAt,
+		})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Lock the waiting clients before any slot, like a booking does, so the limits of concurrent bookings for
+	// them count the holds placed here
+	for _, entry := range waiting {
+		if err := lockClient(ctx, tx, entry.ClientId); err != nil {
+			return nil, err
+		}
+	}
+
+	var offers []WaitlistOffer
+	for _, entry := range waiting {
+		// Entries of deactivated users keep waiting
+		err := bookable(ctx, tx, entry.ClientId, entry.ProviderId)
+		if errors.Is(err, ErrUserInactive) || errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		startTime, found, err := firstFreeSlot(ctx, tx, entry, earliestStart, now)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+
+		appointment, err := insertReservedAppointment(ctx, tx, entry.ClientId, entry.ProviderId, nil, &startTime, nil, schema.StatusChangeSourceWaitlist, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hold slot for waitlist entry %s: %w", entry.Id, err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+		UPDATE waitlist_entries
+		SET status = 'offered', appointment_id = $2, updated_at = $3
+		WHERE id = $1
+`, entry.Id.String(), appointment.Id.String(), now)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.Status = utils.Ptr(schema.WaitlistEntryStatusOffered)
+		entry.AppointmentId = appointment.Id
+		entry.HoldExpiresAt = utils.Ptr(now.Add(ReservationHoldDuration))
+		offers = append(offers, WaitlistOffer{Entry: entry, Appointment: *appointment})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	for _, offer := range offers {
+		db.Logger.Info("Offered slot to waitlisted client",
+			slog.String("waitlist_entry_id", offer.Entry.Id.String()),
+			slog.String("appointment_id", offer.Appointment.Id.String()),
+			slog.Time("start_time", *offer.Appointment.StartTime),
+		)
+	}
+
+	return offers, nil
+}
+
+// firstFreeSlot finds and locks the earliest slot with a free seat at now in a waitlist entry's window that
+// the client can hold within their limits. The client has to be locked by lockClient.
+func firstFreeSlot(ctx context.Context, tx *sql.Tx, entry schema.WaitlistEntry, earliestStart, now time.Time) (time.Time, bool, error) {
+	rows, err := tx.QueryContext(ctx, `
+	SELECT a.start_time
+	FROM availability a
+	WHERE a.provider_id = $1
+	  AND a.start_time >= $2 AND a.start_time >= $3 AND a.end_time <= $4
+	  AND (
+	    SELECT COUNT(*) FROM appointments appt
+	    WHERE `+seatOf("a.provider_id")+`
+	      AND appt.start_time = a.start_time
+	      AND (
+	        appt.status = 'confirmed' OR
+	        (appt.status = 'reserved' AND appt.created_at > $5)
+	      )
+	  ) < a.capacity
+	ORDER BY a.start_time
+`, entry.ProviderId.String(), *entry.WindowStart, earliestStart, *entry.WindowEnd, holdCutoff(now))
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	var candidates []time.Time
+	for rows.Next() {
+		var startTime time.Time
+		if err := rows.Scan(&startTime); err != nil {
+			rows.Close()
+			return time.Time{}, false, err
+		}
+		candidates = append(candidates, startTime)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return time.Time{}, false, err
+	}
+
+	// A concurrent reservation may take the last seat between the query and the lock
+	for _, startTime := range candidates {
+		// A slot overlapping another of the client's appointments is passed over, a later one may not
+		err := clientLimits(ctx, tx, entry.ClientId, entry.ProviderId, startTime, nil, now)
+		if IsLimitError(err) {
+			continue
+		}
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		reason, err := slotConflict(ctx, tx, entry.ProviderId.String(), nil, startTime, nil, now)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		if reason == "" {
+			return startTime, true, nil
+		}
+	}
+	return time.Time{}, false, nil
+}
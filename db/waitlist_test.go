@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/clock"
+	"github.com/tateexon/reservation/schema"
+)
+
+func TestJoinWaitlist_SlotsAvailable(t *testing.T) {
+	t.Parallel()
+	dbInstance := startTestDatabase(t)
+	defer dbInstance.Conn.Close()
+
+	providerID := createTestProvider(t, dbInstance)
+	clientID := createTestClient(t, dbInstance)
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	addTestAvailability(t, dbInstance, providerID, []time.Time{startTime})
+
+	_, err := dbInstance.JoinWaitlist(context.Background(), *clientID, *providerID, startTime, startTime.Add(time.Hour), time.Now().Add(24*time.Hour))
+	require.ErrorIs(t, err, ErrSlotsAvailable)
+}
+
+func TestPromoteWaitlist(t *testing.T) {
+	t.Parallel()
+	dbInstance := startTestDatabase(t)
+	defer dbInstance.Conn.Close()
+
+	providerID := createTestProvider(t, dbInstance)
+	clientID := createTestClient(t, dbInstance)
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	earliestStart := time.Now().Add(24 * time.Hour)
+	addTestAvailability(t, dbInstance, providerID, []time.Time{startTime})
+
+	appointment, err := dbInstance.ReserveAppointment(context.Background(), clientID, providerID, nil, nil, &startTime, nil)
+	require.NoError(t, err)
+
+	// Two clients join the waitlist, the first to join must be served first
+	firstID := createTestClient(t, dbInstance)
+	secondID := createTestClient(t, dbInstance)
+	first, err := dbInstance.JoinWaitlist(context.Background(), *firstID, *providerID, startTime, startTime.Add(time.Hour), earliestStart)
+	require.NoError(t, err)
+	require.Equal(t, 1, *first.Position)
+	second, err := dbInstance.JoinWaitlist(context.Background(), *secondID, *providerID, startTime, startTime.Add(time.Hour), earliestStart)
+	require.NoError(t, err)
+	require.Equal(t, 2, *second.Position)
+
+	// Nothing is free yet
+	offers, err := dbInstance.PromoteWaitlist(context.Background(), earliestStart)
+	require.NoError(t, err)
+	require.Empty(t, offers)
+
+	require.NoError(t, dbInstance.CancelAppointment(context.Background(), *appointment.Id))
+
+	offers, err = dbInstance.PromoteWaitlist(context.Background(), earliestStart)
+	require.NoError(t, err)
+	require.Len(t, offers, 1)
+	require.Equal(t, first.Id.String(), offers[0].Entry.Id.String())
+	require.Equal(t, firstID.String(), offers[0].Appointment.ClientId.String())
+	require.Equal(t, schema.AppointmentStatus("reserved"), *offers[0].Appointment.Status)
+
+	available, err := dbInstance.IsSlotAvailable(context.Background(), providerID, nil, &startTime)
+	require.NoError(t, err)
+	require.False(t, available)
+
+	waitlist, err := dbInstance.GetProviderWaitlist(context.Background(), *providerID)
+	require.NoError(t, err)
+	require.Len(t, waitlist, 2)
+	require.Equal(t, schema.WaitlistEntryStatusOffered, *waitlist[0].Status)
+	require.NotNil(t, waitlist[0].HoldExpiresAt)
+	require.Equal(t, second.Id.String(), waitlist[1].Id.String())
+	require.Equal(t, 1, *waitlist[1].Position)
+}
+
+func TestPromoteWaitlist_ExpiredOffer(t *testing.T) {
+	t.Parallel()
+	dbInstance := startTestDatabase(t)
+	defer dbInstance.Conn.Close()
+	clk := clock.NewFake(time.Now())
+	dbInstance.Clock = clk
+
+	providerID := createTestProvider(t, dbInstance)
+	startTime := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+	earliestStart := time.Now().Add(24 * time.Hour)
+
+	firstID := createTestClient(t, dbInstance)
+	secondID := createTestClient(t, dbInstance)
+	first, err := dbInstance.JoinWaitlist(context.Background(), *firstID, *providerID, startTime, startTime.Add(time.Hour), earliestStart)
+	require.NoError(t, err)
+	clk.Advance(time.Minute)
+	_, err = dbInstance.JoinWaitlist(context.Background(), *secondID, *providerID, startTime, startTime.Add(time.Hour), earliestStart)
+	require.NoError(t, err)
+
+	// New availability goes to the first client
+	addTestAvailability(t, dbInstance, providerID, []time.Time{startTime})
+	offers, err := dbInstance.PromoteWaitlist(context.Background(), earliestStart)
+	require.NoError(t, err)
+	require.Len(t, offers, 1)
+	require.Equal(t, firstID.String(), offers[0].Entry.ClientId.String())
+
+	// The first client lets their hold expire
+	clk.Advance(31 * time.Minute)
+
+	offers, err = dbInstance.PromoteWaitlist(context.Background(), earliestStart)
+	require.NoError(t, err)
+	require.Len(t, offers, 1)
+	require.Equal(t, secondID.String(), offers[0].Entry.ClientId.String())
+
+	var status string
+	err = dbInstance.Conn.QueryRow(`
+        SELECT status FROM waitlist_entries WHERE id = $1
+    `, first.Id.String()).Scan(&status)
+	require.NoError(t, err)
+	require.Equal(t, "expired", status)
+}
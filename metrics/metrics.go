@@ -0,0 +1,151 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "reservation"
+
+// Reasons a reservation is rejected
+const (
+	RejectedInvalidRequest         = "invalid_request"
+	RejectedInvalidAvailability    = "invalid_availability"
+	RejectedLeadTime               = "lead_time"
+	RejectedConflict               = "conflict"
+	RejectedUserInactive           = "user_inactive"
+	RejectedUnknownUser            = "unknown_user"
+	RejectedUnknownRoom            = "unknown_room"
+	RejectedUnknownGroup           = "unknown_group"
+	RejectedUnknownAppointmentType = "unknown_appointment_type"
+	RejectedBookingLimit           = "booking_limit"
+	RejectedDoubleBooked           = "double_booked"
+	RejectedNoShowLimit            = "no_show_limit"
+)
+
+// unmatchedOperation labels requests that did not match any route
+const unmatchedOperation = "unmatched"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by OpenAPI operation id, method and status code.",
+	}, []string{"operation", "method", "status"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by OpenAPI operation id and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "method"})
+
+	// ReservationsCreated counts reservations placed by clients, each occurrence of a series counts once
+	ReservationsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reservations_created_total",
+		Help:      "Reservations placed by clients.",
+	})
+
+	// ReservationsConfirmed counts reservations confirmed before they expired
+	ReservationsConfirmed = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reservations_confirmed_total",
+		Help:      "Reservations confirmed before they expired.",
+	})
+
+	// ReservationsExpired counts reservations that were never confirmed
+	ReservationsExpired = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reservations_expired_total",
+		Help:      "Reservations that expired without being confirmed.",
+	})
+
+	// ReservationsRejected counts reservation attempts that were turned away, by reason
+	ReservationsRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reservations_rejected_total",
+		Help:      "Reservation attempts that were rejected, by reason.",
+	}, []string{"reason"})
+
+	// AppointmentOutcomes counts appointments checked in, completed or marked a no-show, by status
+	AppointmentOutcomes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "appointment_outcomes_total",
+		Help:      "Appointments checked in, completed or marked a no-show, by status.",
+	}, []string{"status"})
+
+	// WaitlistOffers counts holds placed automatically for waitlisted clients
+	WaitlistOffers = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "waitlist_offers_total",
+		Help:      "Holds placed automatically for waitlisted clients.",
+	})
+)
+
+// RegisterDB exposes the connection pool stats of a database
+func RegisterDB(db *sql.DB, name string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Middleware records the count and latency of every request labelled by the OpenAPI operation id of the
+// route it matched, so path parameters do not create a series per id. Routes outside the spec are labelled
+// by their route template.
+func Middleware(spec *openapi3.T) gin.HandlerFunc {
+	operations := OperationIDs(spec)
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		method := c.Request.Method
+		operation, ok := operations[method+" "+c.FullPath()]
+		if !ok {
+			operation = c.FullPath()
+		}
+		if operation == "" {
+			operation = unmatchedOperation
+		}
+
+		httpRequests.WithLabelValues(operation, method, strconv.Itoa(c.Writer.Status())).Inc()
+		httpDuration.WithLabelValues(operation, method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// OperationIDs maps "METHOD /gin/:route" keys to the operation ids in the spec
+func OperationIDs(spec *openapi3.T) map[string]string {
+	operations := map[string]string{}
+	for path, item := range spec.Paths.Map() {
+		route := GinRoute(path)
+		for method, operation := range item.Operations() {
+			operations[method+" "+route] = operation.OperationID
+		}
+	}
+	return operations
+}
+
+// GinRoute converts an OpenAPI path template like /users/{userId} to the gin route /users/:userId
+func GinRoute(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = ":" + strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "}")
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/schema"
+)
+
+func TestOperationIDs(t *testing.T) {
+	t.Parallel()
+
+	spec, err := schema.GetSwagger()
+	require.NoError(t, err)
+
+	operations := OperationIDs(spec)
+	require.Equal(t, "PostAppointments", operations["POST /appointments"])
+	require.Equal(t, "PostAppointmentsAppointmentIdConfirm", operations["POST /appointments/:appointmentId/confirm"])
+	require.Equal(t, "GetUsersUserId", operations["GET /users/:userId"])
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	spec, err := schema.GetSwagger()
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware(spec))
+	router.GET("/users/:userId", func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+	})
+
+	before := testutil.ToFloat64(httpRequests.WithLabelValues("GetUsersUserId", http.MethodGet, "404"))
+	for _, id := range []string{"a", "b"} {
+		req := httptest.NewRequest(http.MethodGet, "/users/"+id, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	after := testutil.ToFloat64(httpRequests.WithLabelValues("GetUsersUserId", http.MethodGet, "404"))
+	require.Equal(t, float64(2), after-before)
+
+	// The metrics handler speaks the Prometheus text format
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.True(t, strings.Contains(w.Body.String(), `reservation_http_requests_total{method="GET",operation="GetUsersUserId",status="404"}`))
+}
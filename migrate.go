@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/tateexon/reservation/config"
+	"github.com/tateexon/reservation/migrations"
+)
+
+var errMigrateUsage = errors.New("usage: reservation migrate up | down [steps] | status")
+
+// runMigrate implements the migrate command, down reverts one migration unless told how many
+func runMigrate(cfg *config.Config, logger *slog.Logger, args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	steps := 1
+	switch {
+	case args[0] != "up" && args[0] != "down" && args[0] != "status":
+		return errMigrateUsage
+	case args[0] == "down" && len(args) == 2:
+		var err error
+		steps, err = strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid number of steps %q: %w", args[1], err)
+		}
+	case len(args) != 1:
+		return errMigrateUsage
+	}
+
+	if cfg.Database.Driver == config.DriverMemory {
+		return fmt.Errorf("the %s driver has no migrations", cfg.Database.Driver)
+	}
+
+	_, conn, dialect, err := openDatabase(cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+	runner := migrations.NewRunner(conn, dialect, logger)
+	switch args[0] {
+	case "up":
+		applied, err := runner.Up(ctx)
+		if err != nil {
+			return err
+		}
+		logger.Info("Database is up to date", slog.Int("applied", applied))
+	case "down":
+		reverted, err := runner.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		logger.Info("Reverted migrations", slog.Int("reverted", reverted))
+	case "status":
+		statuses, err := runner.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return printMigrationStatus(statuses)
+	}
+	return nil
+}
+
+func printMigrationStatus(statuses []migrations.Status) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+		}
+		fmt.Fprintf(w, "%03d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,9 @@
+-- 001_initial_schema.sql
+
+DROP TABLE IF EXISTS appointments;
+DROP TABLE IF EXISTS availability;
+DROP TABLE IF EXISTS users;
+
+DROP FUNCTION IF EXISTS update_updated_at_column();
+
+DROP EXTENSION IF EXISTS "uuid-ossp";
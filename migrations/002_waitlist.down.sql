@@ -0,0 +1,8 @@
+-- 002_waitlist.sql
+
+DROP TABLE IF EXISTS waitlist_entries;
+
+DELETE FROM appointments WHERE status = 'cancelled';
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_status_check;
+ALTER TABLE appointments
+ADD CONSTRAINT appointments_status_check CHECK (status IN ('reserved', 'confirmed'));
@@ -0,0 +1,31 @@
+-- 002_waitlist.sql
+
+-- Allow appointments to be cancelled so their slot can be offered to the waitlist
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_status_check;
+ALTER TABLE appointments
+ADD CONSTRAINT appointments_status_check CHECK (status IN ('reserved', 'confirmed', 'cancelled'));
+
+-- Create waitlist table
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    client_id UUID NOT NULL,
+    provider_id UUID NOT NULL,
+    window_start TIMESTAMPTZ NOT NULL,
+    window_end TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting' CHECK (status IN ('waiting', 'offered', 'fulfilled', 'expired', 'cancelled')),
+    appointment_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_waitlist_window CHECK (window_start < window_end),
+    CONSTRAINT fk_waitlist_client FOREIGN KEY (client_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_waitlist_provider FOREIGN KEY (provider_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_waitlist_appointment FOREIGN KEY (appointment_id) REFERENCES appointments(id) ON DELETE SET NULL
+);
+
+-- Apply trigger to waitlist table
+CREATE TRIGGER update_waitlist_entries_updated_at BEFORE UPDATE
+ON waitlist_entries FOR EACH ROW EXECUTE PROCEDURE update_updated_at_column();
+
+-- Waitlist entries are served first-come-first-served per provider
+CREATE INDEX IF NOT EXISTS idx_waitlist_provider_status_created_at
+ON waitlist_entries (provider_id, status, created_at);
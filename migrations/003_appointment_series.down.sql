@@ -0,0 +1,6 @@
+-- 003_appointment_series.sql
+
+ALTER TABLE appointments DROP COLUMN IF EXISTS series_index;
+ALTER TABLE appointments DROP COLUMN IF EXISTS series_id;
+
+DROP TABLE IF EXISTS appointment_series;
@@ -0,0 +1,28 @@
+-- 003_appointment_series.sql
+
+-- Create appointment series table
+CREATE TABLE IF NOT EXISTS appointment_series (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    client_id UUID NOT NULL,
+    provider_id UUID NOT NULL,
+    frequency VARCHAR(20) NOT NULL CHECK (frequency IN ('daily', 'weekly')),
+    repeat_interval INT NOT NULL DEFAULT 1 CHECK (repeat_interval > 0),
+    occurrences INT NOT NULL CHECK (occurrences > 1),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_series_client FOREIGN KEY (client_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_series_provider FOREIGN KEY (provider_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Apply trigger to appointment series table
+CREATE TRIGGER update_appointment_series_updated_at BEFORE UPDATE
+ON appointment_series FOR EACH ROW EXECUTE PROCEDURE update_updated_at_column();
+
+-- Link appointments to the series they were booked in
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS series_id UUID;
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS series_index INT;
+ALTER TABLE appointments
+ADD CONSTRAINT fk_appointment_series FOREIGN KEY (series_id) REFERENCES appointment_series(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_appointments_series_index
+ON appointments (series_id, series_index);
@@ -0,0 +1,6 @@
+-- 004_slot_capacity.sql
+
+-- Number of clients that can book the same availability slot, group sessions have more than one seat
+ALTER TABLE availability ADD COLUMN IF NOT EXISTS capacity INT NOT NULL DEFAULT 1;
+ALTER TABLE availability
+ADD CONSTRAINT chk_capacity_positive CHECK (capacity > 0);
@@ -0,0 +1,6 @@
+-- 005_expired_status.sql
+
+UPDATE appointments SET status = 'reserved' WHERE status = 'expired';
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_status_check;
+ALTER TABLE appointments
+ADD CONSTRAINT appointments_status_check CHECK (status IN ('reserved', 'confirmed', 'cancelled'));
@@ -0,0 +1,6 @@
+-- 005_expired_status.sql
+
+-- Holds that were not confirmed in time are swept to 'expired' so expiries can be counted
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_status_check;
+ALTER TABLE appointments
+ADD CONSTRAINT appointments_status_check CHECK (status IN ('reserved', 'confirmed', 'cancelled', 'expired'));
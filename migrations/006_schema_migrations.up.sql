@@ -0,0 +1,14 @@
+-- 006_schema_migrations.sql
+
+-- Records the applied migrations so the api can tell whether the schema is current. The migration runner
+-- creates this table itself, this records the versions of databases set up by the postgres init scripts.
+-- The versions are fixed on purpose: init scripts ran every script up to this one, which was the newest
+-- until the runner replaced them, and the runner only applies this one after 1 to 5 and records it itself.
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INT PRIMARY KEY,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO schema_migrations (version)
+SELECT generate_series(1, 6)
+ON CONFLICT (version) DO NOTHING;
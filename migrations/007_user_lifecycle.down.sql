@@ -0,0 +1,17 @@
+-- 007_user_lifecycle.sql
+
+ALTER TABLE appointment_series DROP CONSTRAINT IF EXISTS fk_series_provider;
+ALTER TABLE appointment_series
+ADD CONSTRAINT fk_series_provider FOREIGN KEY (provider_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE appointment_series DROP CONSTRAINT IF EXISTS fk_series_client;
+ALTER TABLE appointment_series
+ADD CONSTRAINT fk_series_client FOREIGN KEY (client_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS fk_appointment_provider;
+ALTER TABLE appointments
+ADD CONSTRAINT fk_appointment_provider FOREIGN KEY (provider_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS fk_appointment_client;
+ALTER TABLE appointments
+ADD CONSTRAINT fk_appointment_client FOREIGN KEY (client_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE users DROP COLUMN IF EXISTS deactivated_at;
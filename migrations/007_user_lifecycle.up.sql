@@ -0,0 +1,19 @@
+-- 007_user_lifecycle.sql
+
+-- Deactivated users keep their history but can no longer book or be booked
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMPTZ;
+
+-- Appointments are history, deleting a user who has any fails instead of wiping them
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS fk_appointment_client;
+ALTER TABLE appointments
+ADD CONSTRAINT fk_appointment_client FOREIGN KEY (client_id) REFERENCES users(id) ON DELETE RESTRICT;
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS fk_appointment_provider;
+ALTER TABLE appointments
+ADD CONSTRAINT fk_appointment_provider FOREIGN KEY (provider_id) REFERENCES users(id) ON DELETE RESTRICT;
+
+ALTER TABLE appointment_series DROP CONSTRAINT IF EXISTS fk_series_client;
+ALTER TABLE appointment_series
+ADD CONSTRAINT fk_series_client FOREIGN KEY (client_id) REFERENCES users(id) ON DELETE RESTRICT;
+ALTER TABLE appointment_series DROP CONSTRAINT IF EXISTS fk_series_provider;
+ALTER TABLE appointment_series
+ADD CONSTRAINT fk_series_provider FOREIGN KEY (provider_id) REFERENCES users(id) ON DELETE RESTRICT;
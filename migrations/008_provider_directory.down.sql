@@ -0,0 +1,12 @@
+-- 008_provider_directory.sql
+
+DROP INDEX IF EXISTS idx_availability_start_time;
+DROP INDEX IF EXISTS idx_users_specialty;
+DROP INDEX IF EXISTS idx_users_languages;
+DROP INDEX IF EXISTS idx_users_search;
+
+ALTER TABLE users DROP COLUMN IF EXISTS search;
+ALTER TABLE users DROP COLUMN IF EXISTS location;
+ALTER TABLE users DROP COLUMN IF EXISTS languages;
+ALTER TABLE users DROP COLUMN IF EXISTS bio;
+ALTER TABLE users DROP COLUMN IF EXISTS specialty;
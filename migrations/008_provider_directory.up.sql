@@ -0,0 +1,19 @@
+-- 008_provider_directory.sql
+
+-- Provider profiles for the directory, clients never have one
+ALTER TABLE users ADD COLUMN IF NOT EXISTS specialty VARCHAR(255);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS bio TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS languages TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS location VARCHAR(255);
+
+-- Full text search over the name and bio. The simple configuration does not stem or drop stop words, so
+-- every word of a search has to appear as it was written.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS search TSVECTOR
+GENERATED ALWAYS AS (to_tsvector('simple', name || ' ' || COALESCE(bio, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_users_search ON users USING GIN (search);
+CREATE INDEX IF NOT EXISTS idx_users_languages ON users USING GIN (languages);
+CREATE INDEX IF NOT EXISTS idx_users_specialty ON users (LOWER(specialty));
+
+-- Directory searches for slots in a time range across every provider
+CREATE INDEX IF NOT EXISTS idx_availability_start_time ON availability (start_time);
@@ -0,0 +1,39 @@
+-- 009_organizations.sql
+
+DROP POLICY IF EXISTS tenant_isolation ON waitlist_entries;
+DROP POLICY IF EXISTS tenant_isolation ON appointment_series;
+DROP POLICY IF EXISTS tenant_isolation ON appointments;
+DROP POLICY IF EXISTS tenant_isolation ON availability;
+DROP POLICY IF EXISTS tenant_isolation ON users;
+
+ALTER TABLE waitlist_entries DISABLE ROW LEVEL SECURITY;
+ALTER TABLE appointment_series DISABLE ROW LEVEL SECURITY;
+ALTER TABLE appointments DISABLE ROW LEVEL SECURITY;
+ALTER TABLE availability DISABLE ROW LEVEL SECURITY;
+ALTER TABLE users DISABLE ROW LEVEL SECURITY;
+
+-- The role may be used by other databases on the server, so it is kept
+REVOKE ALL ON users, availability, appointments, appointment_series, waitlist_entries,
+  organizations, organization_hosts
+FROM reservation_tenant;
+
+-- Other organizations' users may share an email with the default organization's, those can not be kept
+DELETE FROM organizations WHERE id <> '00000000-0000-0000-0000-000000000001';
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_organization_email_key;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+DROP INDEX IF EXISTS idx_waitlist_entries_organization;
+DROP INDEX IF EXISTS idx_appointment_series_organization;
+DROP INDEX IF EXISTS idx_appointments_organization;
+DROP INDEX IF EXISTS idx_availability_organization;
+
+ALTER TABLE waitlist_entries DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE appointment_series DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE appointments DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE availability DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE users DROP COLUMN IF EXISTS organization_id;
+
+DROP FUNCTION IF EXISTS current_organization_id();
+DROP TABLE IF EXISTS organization_hosts;
+DROP TABLE IF EXISTS organizations;
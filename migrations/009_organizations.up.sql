@@ -0,0 +1,103 @@
+-- 009_organizations.sql
+
+-- Organizations (clinics) own their users, availability, appointments and waitlists. Settings left NULL
+-- fall back to the server's defaults.
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(63) UNIQUE NOT NULL,
+    token_hash CHAR(64) UNIQUE,
+    slot_interval_minutes INT CHECK (slot_interval_minutes > 0),
+    lead_time_minutes INT CHECK (lead_time_minutes >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_organizations_updated_at BEFORE UPDATE
+ON organizations FOR EACH ROW EXECUTE PROCEDURE update_updated_at_column();
+
+-- Hosts requests without a token are served for
+CREATE TABLE IF NOT EXISTS organization_hosts (
+    host VARCHAR(255) PRIMARY KEY,
+    organization_id UUID NOT NULL,
+    CONSTRAINT fk_host_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_hosts_organization
+ON organization_hosts (organization_id);
+
+-- Everything that existed before organizations belongs to the default one
+INSERT INTO organizations (id, name, slug)
+VALUES ('00000000-0000-0000-0000-000000000001', 'Default', 'default')
+ON CONFLICT (id) DO NOTHING;
+
+-- The organization a transaction works in, set by the store with set_config. NULL when it is not set,
+-- which the policies below treat as no organization at all.
+CREATE OR REPLACE FUNCTION current_organization_id() RETURNS UUID AS $$
+    SELECT NULLIF(current_setting('app.organization_id', true), '')::uuid
+$$ LANGUAGE sql STABLE;
+
+-- Rows are inserted into the transaction's organization, connections that did not set one use the default
+ALTER TABLE users ADD COLUMN IF NOT EXISTS organization_id UUID NOT NULL
+DEFAULT COALESCE(current_organization_id(), '00000000-0000-0000-0000-000000000001');
+ALTER TABLE availability ADD COLUMN IF NOT EXISTS organization_id UUID NOT NULL
+DEFAULT COALESCE(current_organization_id(), '00000000-0000-0000-0000-000000000001');
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS organization_id UUID NOT NULL
+DEFAULT COALESCE(current_organization_id(), '00000000-0000-0000-0000-000000000001');
+ALTER TABLE appointment_series ADD COLUMN IF NOT EXISTS organization_id UUID NOT NULL
+DEFAULT COALESCE(current_organization_id(), '00000000-0000-0000-0000-000000000001');
+ALTER TABLE waitlist_entries ADD COLUMN IF NOT EXISTS organization_id UUID NOT NULL
+DEFAULT COALESCE(current_organization_id(), '00000000-0000-0000-0000-000000000001');
+
+ALTER TABLE users
+ADD CONSTRAINT fk_user_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
+ALTER TABLE availability
+ADD CONSTRAINT fk_availability_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
+ALTER TABLE appointments
+ADD CONSTRAINT fk_appointment_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
+ALTER TABLE appointment_series
+ADD CONSTRAINT fk_series_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
+ALTER TABLE waitlist_entries
+ADD CONSTRAINT fk_waitlist_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
+
+-- Emails only have to be unique within an organization
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+ALTER TABLE users ADD CONSTRAINT users_organization_email_key UNIQUE (organization_id, email);
+
+CREATE INDEX IF NOT EXISTS idx_availability_organization ON availability (organization_id);
+CREATE INDEX IF NOT EXISTS idx_appointments_organization ON appointments (organization_id);
+CREATE INDEX IF NOT EXISTS idx_appointment_series_organization ON appointment_series (organization_id);
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_organization ON waitlist_entries (organization_id);
+
+-- The store switches to this role in every tenant transaction. Table owners and superusers bypass row
+-- level security, the role does not, so it only ever sees its organization's rows. Creating it needs the
+-- CREATEROLE privilege, roles are shared by every database on the server.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'reservation_tenant') THEN
+        CREATE ROLE reservation_tenant NOLOGIN;
+    END IF;
+END
+$$;
+
+GRANT reservation_tenant TO CURRENT_USER;
+GRANT SELECT, INSERT, UPDATE, DELETE ON users, availability, appointments, appointment_series, waitlist_entries
+TO reservation_tenant;
+GRANT SELECT ON organizations, organization_hosts TO reservation_tenant;
+
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE availability ENABLE ROW LEVEL SECURITY;
+ALTER TABLE appointments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE appointment_series ENABLE ROW LEVEL SECURITY;
+ALTER TABLE waitlist_entries ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation ON users TO reservation_tenant
+USING (organization_id = current_organization_id());
+CREATE POLICY tenant_isolation ON availability TO reservation_tenant
+USING (organization_id = current_organization_id());
+CREATE POLICY tenant_isolation ON appointments TO reservation_tenant
+USING (organization_id = current_organization_id());
+CREATE POLICY tenant_isolation ON appointment_series TO reservation_tenant
+USING (organization_id = current_organization_id());
+CREATE POLICY tenant_isolation ON waitlist_entries TO reservation_tenant
+USING (organization_id = current_organization_id());
@@ -0,0 +1,11 @@
+-- 010_locations.sql
+
+DROP INDEX IF EXISTS idx_appointments_room_start_time;
+DROP INDEX IF EXISTS idx_availability_location_start_time;
+
+ALTER TABLE appointments DROP COLUMN IF EXISTS room_id;
+ALTER TABLE appointments DROP COLUMN IF EXISTS location_id;
+ALTER TABLE availability DROP COLUMN IF EXISTS location_id;
+
+DROP TABLE IF EXISTS rooms;
+DROP TABLE IF EXISTS locations;
@@ -0,0 +1,54 @@
+-- 010_locations.sql
+
+-- Sites an organization sees clients at
+CREATE TABLE IF NOT EXISTS locations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL DEFAULT COALESCE(current_organization_id(), '00000000-0000-0000-0000-000000000001'),
+    name VARCHAR(255) NOT NULL,
+    address TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_location_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+CREATE TRIGGER update_locations_updated_at BEFORE UPDATE
+ON locations FOR EACH ROW EXECUTE PROCEDURE update_updated_at_column();
+
+-- Rooms are booked together with a provider, bookings lock the room row so two can not overlap
+CREATE TABLE IF NOT EXISTS rooms (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL DEFAULT COALESCE(current_organization_id(), '00000000-0000-0000-0000-000000000001'),
+    location_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_room_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_room_location FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE CASCADE
+);
+
+CREATE TRIGGER update_rooms_updated_at BEFORE UPDATE
+ON rooms FOR EACH ROW EXECUTE PROCEDURE update_updated_at_column();
+
+CREATE INDEX IF NOT EXISTS idx_locations_organization ON locations (organization_id);
+CREATE INDEX IF NOT EXISTS idx_rooms_location ON rooms (location_id);
+
+-- Slots are offered at a location, appointments keep the location of their slot and the room they booked
+ALTER TABLE availability ADD COLUMN IF NOT EXISTS location_id UUID
+REFERENCES locations(id) ON DELETE CASCADE;
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS location_id UUID
+REFERENCES locations(id) ON DELETE CASCADE;
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS room_id UUID
+REFERENCES rooms(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_availability_location_start_time ON availability (location_id, start_time);
+CREATE INDEX IF NOT EXISTS idx_appointments_room_start_time ON appointments (room_id, start_time);
+
+GRANT SELECT, INSERT, UPDATE, DELETE ON locations, rooms TO reservation_tenant;
+
+ALTER TABLE locations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE rooms ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation ON locations TO reservation_tenant
+USING (organization_id = current_organization_id());
+CREATE POLICY tenant_isolation ON rooms TO reservation_tenant
+USING (organization_id = current_organization_id());
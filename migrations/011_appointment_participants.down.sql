@@ -0,0 +1,3 @@
+-- 011_appointment_participants.sql
+
+DROP TABLE IF EXISTS appointment_participants;
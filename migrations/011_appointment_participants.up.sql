@@ -0,0 +1,23 @@
+-- 011_appointment_participants.sql
+
+-- Providers who take part in an appointment besides its provider. Each holds a seat in their own slot at
+-- the appointment's start time for as long as the appointment is active.
+CREATE TABLE IF NOT EXISTS appointment_participants (
+    appointment_id UUID NOT NULL,
+    provider_id UUID NOT NULL,
+    organization_id UUID NOT NULL DEFAULT COALESCE(current_organization_id(), '00000000-0000-0000-0000-000000000001'),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (appointment_id, provider_id),
+    CONSTRAINT fk_participant_appointment FOREIGN KEY (appointment_id) REFERENCES appointments(id) ON DELETE CASCADE,
+    CONSTRAINT fk_participant_provider FOREIGN KEY (provider_id) REFERENCES users(id),
+    CONSTRAINT fk_participant_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_appointment_participants_provider ON appointment_participants (provider_id);
+
+GRANT SELECT, INSERT, UPDATE, DELETE ON appointment_participants TO reservation_tenant;
+
+ALTER TABLE appointment_participants ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation ON appointment_participants TO reservation_tenant
+USING (organization_id = current_organization_id());
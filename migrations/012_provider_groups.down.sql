@@ -0,0 +1,4 @@
+-- 012_provider_groups.sql
+
+DROP TABLE IF EXISTS provider_group_members;
+DROP TABLE IF EXISTS provider_groups;
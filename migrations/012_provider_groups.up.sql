@@ -0,0 +1,43 @@
+-- 012_provider_groups.sql
+
+-- Providers that can stand in for each other. Group bookings lock the group row, so assignments from a
+-- group happen one at a time and assignments counts them.
+CREATE TABLE IF NOT EXISTS provider_groups (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL DEFAULT COALESCE(current_organization_id(), '00000000-0000-0000-0000-000000000001'),
+    name VARCHAR(255) NOT NULL,
+    strategy VARCHAR(20) NOT NULL DEFAULT 'round_robin' CHECK (strategy IN ('round_robin', 'least_booked', 'priority')),
+    assignments INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_provider_group_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+CREATE TRIGGER update_provider_groups_updated_at BEFORE UPDATE
+ON provider_groups FOR EACH ROW EXECUTE PROCEDURE update_updated_at_column();
+
+-- Members in priority order, last_assignment is the number of the group's assignment they last got
+CREATE TABLE IF NOT EXISTS provider_group_members (
+    group_id UUID NOT NULL,
+    provider_id UUID NOT NULL,
+    organization_id UUID NOT NULL DEFAULT COALESCE(current_organization_id(), '00000000-0000-0000-0000-000000000001'),
+    position INTEGER NOT NULL,
+    last_assignment INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (group_id, provider_id),
+    CONSTRAINT fk_group_member_group FOREIGN KEY (group_id) REFERENCES provider_groups(id) ON DELETE CASCADE,
+    CONSTRAINT fk_group_member_provider FOREIGN KEY (provider_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_group_member_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_provider_groups_organization ON provider_groups (organization_id);
+CREATE INDEX IF NOT EXISTS idx_provider_group_members_provider ON provider_group_members (provider_id);
+
+GRANT SELECT, INSERT, UPDATE, DELETE ON provider_groups, provider_group_members TO reservation_tenant;
+
+ALTER TABLE provider_groups ENABLE ROW LEVEL SECURITY;
+ALTER TABLE provider_group_members ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation ON provider_groups TO reservation_tenant
+USING (organization_id = current_organization_id());
+CREATE POLICY tenant_isolation ON provider_group_members TO reservation_tenant
+USING (organization_id = current_organization_id());
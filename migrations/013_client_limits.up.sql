@@ -0,0 +1,12 @@
+-- 013_client_limits.sql
+
+-- Per organization limits on what one client may book, NULL leaves a client unlimited
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS max_active_holds INT CHECK (max_active_holds > 0);
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS max_future_appointments INT CHECK (max_future_appointments > 0);
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS max_future_appointments_per_provider INT
+CHECK (max_future_appointments_per_provider > 0);
+-- NULL keeps a client from holding appointments at overlapping times
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS allow_overlapping_appointments BOOLEAN;
+
+-- The limits count a client's active appointments
+CREATE INDEX IF NOT EXISTS idx_appointments_client_start_time ON appointments (client_id, start_time);
@@ -0,0 +1,11 @@
+-- 014_appointment_outcomes.sql
+
+ALTER TABLE organizations DROP COLUMN IF EXISTS max_no_shows;
+
+ALTER TABLE appointments DROP COLUMN IF EXISTS status_changed_by;
+ALTER TABLE appointments DROP COLUMN IF EXISTS status_changed_at;
+
+UPDATE appointments SET status = 'confirmed' WHERE status IN ('checked_in', 'completed', 'no_show');
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_status_check;
+ALTER TABLE appointments
+ADD CONSTRAINT appointments_status_check CHECK (status IN ('reserved', 'confirmed', 'cancelled', 'expired'));
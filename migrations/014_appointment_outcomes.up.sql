@@ -0,0 +1,14 @@
+-- 014_appointment_outcomes.sql
+
+-- Once an appointment has started it is checked in and completed, or marked a no-show
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_status_check;
+ALTER TABLE appointments
+ADD CONSTRAINT appointments_status_check
+CHECK (status IN ('reserved', 'confirmed', 'cancelled', 'expired', 'checked_in', 'completed', 'no_show'));
+
+-- The provider who last changed the appointment to one of those and when
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMPTZ;
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS status_changed_by UUID REFERENCES users(id);
+
+-- Clients with this many recent no-shows can not book, NULL leaves them unlimited
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS max_no_shows INT CHECK (max_no_shows > 0);
@@ -0,0 +1,9 @@
+-- 015_appointment_intake.sql
+
+DROP TABLE IF EXISTS appointment_notes;
+
+ALTER TABLE appointments DROP COLUMN IF EXISTS intake_answers;
+ALTER TABLE appointments DROP COLUMN IF EXISTS appointment_type_id;
+ALTER TABLE appointments DROP COLUMN IF EXISTS reason;
+
+DROP TABLE IF EXISTS appointment_types;
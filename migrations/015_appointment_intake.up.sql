@@ -0,0 +1,49 @@
+-- 015_appointment_intake.sql
+
+-- Kinds of appointment, clients booking one answer its intake questions
+CREATE TABLE IF NOT EXISTS appointment_types (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL DEFAULT COALESCE(current_organization_id(), '00000000-0000-0000-0000-000000000001'),
+    name VARCHAR(255) NOT NULL,
+    intake_questions JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_appointment_type_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+CREATE TRIGGER update_appointment_types_updated_at BEFORE UPDATE
+ON appointment_types FOR EACH ROW EXECUTE PROCEDURE update_updated_at_column();
+
+-- Why the client booked and their answers to the intake questions of the appointment type
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS reason TEXT;
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS appointment_type_id UUID REFERENCES appointment_types(id);
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS intake_answers JSONB;
+
+-- The providers' private notes on an appointment. Every save adds a version, the tenant role can not
+-- change or remove one.
+CREATE TABLE IF NOT EXISTS appointment_notes (
+    appointment_id UUID NOT NULL,
+    version INTEGER NOT NULL CHECK (version > 0),
+    organization_id UUID NOT NULL DEFAULT COALESCE(current_organization_id(), '00000000-0000-0000-0000-000000000001'),
+    body TEXT NOT NULL,
+    author_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (appointment_id, version),
+    CONSTRAINT fk_appointment_note_appointment FOREIGN KEY (appointment_id) REFERENCES appointments(id) ON DELETE CASCADE,
+    CONSTRAINT fk_appointment_note_author FOREIGN KEY (author_id) REFERENCES users(id),
+    CONSTRAINT fk_appointment_note_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_appointment_types_organization ON appointment_types (organization_id);
+CREATE INDEX IF NOT EXISTS idx_appointment_notes_author ON appointment_notes (author_id);
+
+GRANT SELECT, INSERT, UPDATE, DELETE ON appointment_types TO reservation_tenant;
+GRANT SELECT, INSERT ON appointment_notes TO reservation_tenant;
+
+ALTER TABLE appointment_types ENABLE ROW LEVEL SECURITY;
+ALTER TABLE appointment_notes ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY tenant_isolation ON appointment_types TO reservation_tenant
+USING (organization_id = current_organization_id());
+CREATE POLICY tenant_isolation ON appointment_notes TO reservation_tenant
+USING (organization_id = current_organization_id());
@@ -0,0 +1,3 @@
+-- 016_status_history.sql
+
+DROP TABLE IF EXISTS appointment_status_history;
@@ -0,0 +1,130 @@
+// Package migrations embeds the sql migrations and applies them to a database
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FS holds the postgres migration scripts, named NNN_description.up.sql and NNN_description.down.sql
+//
+//go:embed *.sql
+var FS embed.FS
+
+// sqliteFS holds the same migrations written for sqlite, every version in FS has a counterpart in sqlite/
+//
+//go:embed sqlite/*.sql
+var sqliteFS embed.FS
+
+// Dialect is the database a set of migrations is written for
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+// scripts returns the directory holding the dialect's migrations
+func (d Dialect) scripts() (fs.FS, error) {
+	switch d {
+	case Postgres:
+		return FS, nil
+	case SQLite:
+		return fs.Sub(sqliteFS, "sqlite")
+	default:
+		return nil, fmt.Errorf("unknown migration dialect %q", d)
+	}
+}
+
+// Migration is a versioned schema change and the script that reverts it
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Load reads a dialect's embedded migrations in version order. Every version needs both an up and a down script.
+func Load(dialect Dialect) ([]Migration, error) {
+	fsys, err := dialect.scripts()
+	if err != nil {
+		return nil, err
+	}
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, name := range names {
+		base, direction, found := cutDirection(name)
+		if !found {
+			return nil, fmt.Errorf("migration %s must end in .up.sql or .down.sql", name)
+		}
+		prefix, description, found := strings.Cut(base, "_")
+		if !found {
+			return nil, fmt.Errorf("migration %s is missing a version prefix", name)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has an invalid version: %w", name, err)
+		}
+
+		script, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: description}
+			byVersion[version] = m
+		}
+		if m.Name != description {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, m.Name, description)
+		}
+		if direction == "up" {
+			m.Up = string(script)
+		} else {
+			m.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d %s needs a non-empty up and down script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+func cutDirection(name string) (string, string, bool) {
+	if base, found := strings.CutSuffix(name, ".up.sql"); found {
+		return base, "up", true
+	}
+	if base, found := strings.CutSuffix(name, ".down.sql"); found {
+		return base, "down", true
+	}
+	return "", "", false
+}
+
+// Latest returns the version of the newest migration, which is the same in every dialect
+func Latest() (int, error) {
+	migrations, err := Load(Postgres)
+	if err != nil {
+		return 0, err
+	}
+	if len(migrations) == 0 {
+		return 0, nil
+	}
+	return migrations[len(migrations)-1].Version, nil
+}
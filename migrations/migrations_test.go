@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatest(t *testing.T) {
+	t.Parallel()
+
+	latest, err := Latest()
+	require.NoError(t, err)
+	require.Positive(t, latest)
+
+	// Versions have no gaps, every migration can be rolled back and every dialect has the same ones
+	postgres, err := Load(Postgres)
+	require.NoError(t, err)
+	for _, dialect := range []Dialect{Postgres, SQLite} {
+		fsys, err := dialect.scripts()
+		require.NoError(t, err)
+		for version := 1; version <= latest; version++ {
+			for _, direction := range []string{"up", "down"} {
+				matches, err := fs.Glob(fsys, fmt.Sprintf("%03d_*.%s.sql", version, direction))
+				require.NoError(t, err)
+				require.Len(t, matches, 1, "%s version %d %s", dialect, version, direction)
+			}
+		}
+
+		loaded, err := Load(dialect)
+		require.NoError(t, err)
+		require.Len(t, loaded, len(postgres), dialect)
+		for i, m := range loaded {
+			require.Equal(t, postgres[i].Name, m.Name, "%s version %d", dialect, m.Version)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// lockID is the postgres advisory lock key held while migrating so concurrent runners, such as several
+// replicas starting at once, apply each migration only once
+const lockID int64 = 7_318_204_955
+
+var createTrackingTable = map[Dialect]string{
+	Postgres: `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INT PRIMARY KEY,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)`,
+	SQLite: `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`,
+}
+
+// Runner applies the embedded migrations to a database and records them in schema_migrations
+type Runner struct {
+	DB      *sql.DB
+	Dialect Dialect
+	Logger  *slog.Logger
+}
+
+// NewRunner creates a runner for a db of the given dialect, a nil logger uses the default logger.
+// SQLite databases have to be opened with immediate transactions, as db/sqlite does.
+func NewRunner(db *sql.DB, dialect Dialect, logger *slog.Logger) *Runner {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Runner{DB: db, Dialect: dialect, Logger: logger}
+}
+
+// Status is a migration and when it was applied, AppliedAt is nil while it is pending
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Up applies every pending migration in version order and returns how many were applied
+func (r *Runner) Up(ctx context.Context) (int, error) {
+	migrations, err := Load(r.Dialect)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = r.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, m := range migrations {
+			if _, ok := applied[m.Version]; ok {
+				continue
+			}
+			done, err := r.apply(ctx, conn, m, upStep(m))
+			if err != nil {
+				return err
+			}
+			if done {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down reverts the newest steps applied migrations and returns how many were reverted
+func (r *Runner) Down(ctx context.Context, steps int) (int, error) {
+	if steps < 1 {
+		return 0, fmt.Errorf("steps must be at least 1, got %d", steps)
+	}
+	migrations, err := Load(r.Dialect)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = r.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(migrations) - 1; i >= 0 && count < steps; i-- {
+			m := migrations[i]
+			if _, ok := applied[m.Version]; !ok {
+				continue
+			}
+			done, err := r.apply(ctx, conn, m, downStep(m))
+			if err != nil {
+				return err
+			}
+			if done {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Status lists every migration with when it was applied
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	migrations, err := Load(r.Dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []Status
+	err = r.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, m := range migrations {
+			status := Status{Migration: m}
+			if appliedAt, ok := applied[m.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// withLock runs fn on a single connection holding the advisory lock, after making sure the tracking table exists.
+// Session level advisory locks belong to a connection so everything has to run on the same one.
+// SQLite has no advisory locks, there apply takes the write lock and skips steps another runner already made.
+func (r *Runner) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	createTable, ok := createTrackingTable[r.Dialect]
+	if !ok {
+		return fmt.Errorf("unknown migration dialect %q", r.Dialect)
+	}
+
+	conn, err := r.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if r.Dialect == Postgres {
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+			return fmt.Errorf("failed to take the migration lock: %w", err)
+		}
+		defer func() {
+			// Unlock even if ctx was cancelled, the connection goes back to the pool still holding the lock otherwise
+			if _, err := conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, lockID); err != nil {
+				r.Logger.Error("Failed to release the migration lock", slog.Any("error", err))
+			}
+		}()
+	}
+
+	if _, err := conn.ExecContext(ctx, createTable); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// step is one direction of a migration and how it is recorded
+type step struct {
+	direction string
+	script    string
+	record    string
+	// recorded is whether the migration is in schema_migrations once the step is done
+	recorded bool
+}
+
+func upStep(m Migration) step {
+	return step{
+		direction: "up",
+		script:    m.Up,
+		record:    `INSERT INTO schema_migrations (version) VALUES ($1) ON CONFLICT (version) DO NOTHING`,
+		recorded:  true,
+	}
+}
+
+func downStep(m Migration) step {
+	return step{
+		direction: "down",
+		script:    m.Down,
+		record:    `DELETE FROM schema_migrations WHERE version = $1`,
+	}
+}
+
+// apply runs a step's script and records it in one transaction, so a failed migration leaves no trace.
+// It reports false without running the script when another runner made the step first.
+func (r *Runner) apply(ctx context.Context, conn *sql.Conn, m Migration, s step) (bool, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			r.Logger.Error("Failed to roll back migration", slog.Int("version", m.Version), slog.Any("error", err))
+		}
+	}()
+
+	var recorded bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, m.Version).Scan(&recorded)
+	if err != nil {
+		return false, err
+	}
+	if recorded == s.recorded {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, s.script); err != nil {
+		return false, fmt.Errorf("migration %d %s %s failed: %w", m.Version, m.Name, s.direction, err)
+	}
+	if _, err := tx.ExecContext(ctx, s.record, m.Version); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	r.Logger.Info("Applied migration",
+		slog.Int("version", m.Version),
+		slog.String("name", m.Name),
+		slog.String("direction", s.direction),
+	)
+	return true, nil
+}
@@ -0,0 +1,124 @@
+package migrations_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/db/sqlite"
+	"github.com/tateexon/reservation/migrations"
+	"github.com/tateexon/reservation/utils"
+)
+
+func TestRunner_UpDown(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	ctr := utils.StartEmptyTestPostgres(ctx, t, "yourdb", "youruser", "yourpassword")
+	connStr, err := ctr.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+	conn, err := sql.Open("postgres", connStr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	all, err := migrations.Load(migrations.Postgres)
+	require.NoError(t, err)
+	runner := migrations.NewRunner(conn, migrations.Postgres, nil)
+
+	// Replicas starting together apply every migration exactly once
+	var wg sync.WaitGroup
+	counts := make([]int, 3)
+	for i := range counts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			count, err := runner.Up(ctx)
+			require.NoError(t, err)
+			counts[i] = count
+		}()
+	}
+	wg.Wait()
+	require.ElementsMatch(t, []int{len(all), 0, 0}, counts)
+
+	statuses, err := runner.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, len(all))
+	for _, status := range statuses {
+		require.NotNil(t, status.AppliedAt, "migration %d", status.Version)
+	}
+
+	// Every down script reverts its up script
+	reverted, err := runner.Down(ctx, len(all))
+	require.NoError(t, err)
+	require.Equal(t, len(all), reverted)
+
+	var tables int
+	require.NoError(t, conn.QueryRow(`
+	SELECT COUNT(*) FROM information_schema.tables
+	WHERE table_schema = 'public' AND table_name <> 'schema_migrations'
+`).Scan(&tables))
+	require.Zero(t, tables)
+
+	applied, err := runner.Up(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(all), applied)
+}
+
+func TestRunner_UpDown_SQLite(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	store, err := sqlite.New(filepath.Join(t.TempDir(), "reservation.db"), nil)
+	require.NoError(t, err)
+	defer store.Conn.Close()
+	conn := store.Conn
+
+	all, err := migrations.Load(migrations.SQLite)
+	require.NoError(t, err)
+	runner := migrations.NewRunner(conn, migrations.SQLite, nil)
+
+	// Without an advisory lock concurrent runners still apply every migration exactly once
+	var wg sync.WaitGroup
+	counts := make([]int, 3)
+	for i := range counts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			count, err := runner.Up(ctx)
+			require.NoError(t, err)
+			counts[i] = count
+		}()
+	}
+	wg.Wait()
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	require.Equal(t, len(all), total)
+
+	statuses, err := runner.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, len(all))
+	for _, status := range statuses {
+		require.NotNil(t, status.AppliedAt, "migration %d", status.Version)
+	}
+
+	// Every down script reverts its up script
+	reverted, err := runner.Down(ctx, len(all))
+	require.NoError(t, err)
+	require.Equal(t, len(all), reverted)
+
+	var tables int
+	require.NoError(t, conn.QueryRow(`
+	SELECT COUNT(*) FROM sqlite_master
+	WHERE type = 'table' AND name <> 'schema_migrations'
+`).Scan(&tables))
+	require.Zero(t, tables)
+
+	applied, err := runner.Up(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(all), applied)
+}
@@ -0,0 +1,6 @@
+-- 001_initial_schema.sql
+
+DROP TABLE IF EXISTS appointments;
+DROP TABLE IF EXISTS appointment_statuses;
+DROP TABLE IF EXISTS availability;
+DROP TABLE IF EXISTS users;
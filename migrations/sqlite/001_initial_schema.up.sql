@@ -0,0 +1,58 @@
+-- 001_initial_schema.sql
+
+-- SQLite has no timestamp type, times are stored as microseconds since the unix epoch in UTC which keeps
+-- the precision of postgres and compares correctly. The store sets created_at and updated_at itself.
+
+-- Create users table
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    email TEXT UNIQUE NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('provider', 'client')),
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+-- Create availability table
+CREATE TABLE IF NOT EXISTS availability (
+    id TEXT PRIMARY KEY,
+    provider_id TEXT NOT NULL,
+    start_time INTEGER NOT NULL,
+    end_time INTEGER NOT NULL,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    CONSTRAINT chk_start_before_end CHECK (start_time < end_time),
+    CONSTRAINT unique_provider_start_time UNIQUE (provider_id, start_time),
+    CONSTRAINT fk_provider FOREIGN KEY (provider_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- SQLite can not alter a CHECK constraint, so the statuses an appointment can have are rows that later
+-- migrations add to instead
+CREATE TABLE IF NOT EXISTS appointment_statuses (
+    status TEXT PRIMARY KEY
+);
+
+INSERT INTO appointment_statuses (status) VALUES ('reserved'), ('confirmed');
+
+-- Create appointments table
+CREATE TABLE IF NOT EXISTS appointments (
+    id TEXT PRIMARY KEY,
+    client_id TEXT NOT NULL,
+    provider_id TEXT NOT NULL,
+    start_time INTEGER NOT NULL,
+    end_time INTEGER NOT NULL,
+    status TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    CONSTRAINT chk_appointment_start_before_end CHECK (start_time < end_time),
+    CONSTRAINT fk_appointment_status FOREIGN KEY (status) REFERENCES appointment_statuses(status),
+    CONSTRAINT fk_appointment_client FOREIGN KEY (client_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_appointment_provider FOREIGN KEY (provider_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Indexes for efficient querying
+CREATE INDEX IF NOT EXISTS idx_appointments_provider_start_time
+ON appointments (provider_id, start_time);
+
+CREATE INDEX IF NOT EXISTS idx_appointments_status_created_at
+ON appointments (status, created_at);
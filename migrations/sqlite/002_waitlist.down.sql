@@ -0,0 +1,6 @@
+-- 002_waitlist.sql
+
+DROP TABLE IF EXISTS waitlist_entries;
+
+DELETE FROM appointments WHERE status = 'cancelled';
+DELETE FROM appointment_statuses WHERE status = 'cancelled';
@@ -0,0 +1,25 @@
+-- 002_waitlist.sql
+
+-- Allow appointments to be cancelled so their slot can be offered to the waitlist
+INSERT INTO appointment_statuses (status) VALUES ('cancelled');
+
+-- Create waitlist table
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id TEXT PRIMARY KEY,
+    client_id TEXT NOT NULL,
+    provider_id TEXT NOT NULL,
+    window_start INTEGER NOT NULL,
+    window_end INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'waiting' CHECK (status IN ('waiting', 'offered', 'fulfilled', 'expired', 'cancelled')),
+    appointment_id TEXT,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    CONSTRAINT chk_waitlist_window CHECK (window_start < window_end),
+    CONSTRAINT fk_waitlist_client FOREIGN KEY (client_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_waitlist_provider FOREIGN KEY (provider_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_waitlist_appointment FOREIGN KEY (appointment_id) REFERENCES appointments(id) ON DELETE SET NULL
+);
+
+-- Waitlist entries are served first-come-first-served per provider
+CREATE INDEX IF NOT EXISTS idx_waitlist_provider_status_created_at
+ON waitlist_entries (provider_id, status, created_at);
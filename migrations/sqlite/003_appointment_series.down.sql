@@ -0,0 +1,7 @@
+-- 003_appointment_series.sql
+
+DROP INDEX IF EXISTS idx_appointments_series_index;
+ALTER TABLE appointments DROP COLUMN series_index;
+ALTER TABLE appointments DROP COLUMN series_id;
+
+DROP TABLE IF EXISTS appointment_series;
@@ -0,0 +1,22 @@
+-- 003_appointment_series.sql
+
+-- Create appointment series table
+CREATE TABLE IF NOT EXISTS appointment_series (
+    id TEXT PRIMARY KEY,
+    client_id TEXT NOT NULL,
+    provider_id TEXT NOT NULL,
+    frequency TEXT NOT NULL CHECK (frequency IN ('daily', 'weekly')),
+    repeat_interval INTEGER NOT NULL DEFAULT 1 CHECK (repeat_interval > 0),
+    occurrences INTEGER NOT NULL CHECK (occurrences > 1),
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    CONSTRAINT fk_series_client FOREIGN KEY (client_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_series_provider FOREIGN KEY (provider_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Link appointments to the series they were booked in
+ALTER TABLE appointments ADD COLUMN series_id TEXT REFERENCES appointment_series(id) ON DELETE CASCADE;
+ALTER TABLE appointments ADD COLUMN series_index INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_appointments_series_index
+ON appointments (series_id, series_index);
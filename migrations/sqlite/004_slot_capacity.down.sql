@@ -0,0 +1,3 @@
+-- 004_slot_capacity.sql
+
+ALTER TABLE availability DROP COLUMN capacity;
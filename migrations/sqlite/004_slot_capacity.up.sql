@@ -0,0 +1,4 @@
+-- 004_slot_capacity.sql
+
+-- Number of clients that can book the same availability slot, group sessions have more than one seat
+ALTER TABLE availability ADD COLUMN capacity INTEGER NOT NULL DEFAULT 1 CONSTRAINT chk_capacity_positive CHECK (capacity > 0);
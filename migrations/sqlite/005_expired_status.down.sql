@@ -0,0 +1,4 @@
+-- 005_expired_status.sql
+
+UPDATE appointments SET status = 'reserved' WHERE status = 'expired';
+DELETE FROM appointment_statuses WHERE status = 'expired';
@@ -0,0 +1,4 @@
+-- 005_expired_status.sql
+
+-- Holds that were not confirmed in time are swept to 'expired' so expiries can be counted
+INSERT INTO appointment_statuses (status) VALUES ('expired');
@@ -0,0 +1,4 @@
+-- 006_schema_migrations.sql
+
+-- schema_migrations is kept, the migration runner records versions in it
+SELECT 1;
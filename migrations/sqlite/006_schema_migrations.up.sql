@@ -0,0 +1,8 @@
+-- 006_schema_migrations.sql
+
+-- Records the applied migrations so the api can tell whether the schema is current. The migration runner
+-- creates this table itself, the version exists to keep sqlite in step with postgres.
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
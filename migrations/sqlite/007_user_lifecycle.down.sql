@@ -0,0 +1,3 @@
+-- 007_user_lifecycle.sql
+
+ALTER TABLE users DROP COLUMN deactivated_at;
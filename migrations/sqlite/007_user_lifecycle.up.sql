@@ -0,0 +1,7 @@
+-- 007_user_lifecycle.sql
+
+-- Deactivated users keep their history but can no longer book or be booked
+ALTER TABLE users ADD COLUMN deactivated_at INTEGER;
+
+-- SQLite can not change the ON DELETE CASCADE of the appointment foreign keys without rebuilding the
+-- tables, the store refuses to delete a user who has appointments instead
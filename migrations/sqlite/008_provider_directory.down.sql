@@ -0,0 +1,9 @@
+-- 008_provider_directory.sql
+
+DROP INDEX IF EXISTS idx_availability_start_time;
+DROP INDEX IF EXISTS idx_users_specialty;
+
+ALTER TABLE users DROP COLUMN location;
+ALTER TABLE users DROP COLUMN languages;
+ALTER TABLE users DROP COLUMN bio;
+ALTER TABLE users DROP COLUMN specialty;
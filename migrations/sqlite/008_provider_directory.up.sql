@@ -0,0 +1,13 @@
+-- 008_provider_directory.sql
+
+-- Provider profiles for the directory, clients never have one. Languages are a json array.
+ALTER TABLE users ADD COLUMN specialty TEXT;
+ALTER TABLE users ADD COLUMN bio TEXT;
+ALTER TABLE users ADD COLUMN languages TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE users ADD COLUMN location TEXT;
+
+-- SQLite has no full text index here, the store matches the words of a search itself
+CREATE INDEX IF NOT EXISTS idx_users_specialty ON users (LOWER(specialty));
+
+-- Directory searches for slots in a time range across every provider
+CREATE INDEX IF NOT EXISTS idx_availability_start_time ON availability (start_time);
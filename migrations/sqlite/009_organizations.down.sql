@@ -0,0 +1,16 @@
+-- 009_organizations.sql
+
+DROP INDEX IF EXISTS idx_waitlist_entries_organization;
+DROP INDEX IF EXISTS idx_appointment_series_organization;
+DROP INDEX IF EXISTS idx_appointments_organization;
+DROP INDEX IF EXISTS idx_availability_organization;
+DROP INDEX IF EXISTS idx_users_organization;
+
+ALTER TABLE waitlist_entries DROP COLUMN organization_id;
+ALTER TABLE appointment_series DROP COLUMN organization_id;
+ALTER TABLE appointments DROP COLUMN organization_id;
+ALTER TABLE availability DROP COLUMN organization_id;
+ALTER TABLE users DROP COLUMN organization_id;
+
+DROP TABLE IF EXISTS organization_hosts;
+DROP TABLE IF EXISTS organizations;
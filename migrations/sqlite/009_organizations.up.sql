@@ -0,0 +1,41 @@
+-- 009_organizations.sql
+
+-- Organizations (clinics) own their users, availability, appointments and waitlists. Settings left NULL
+-- fall back to the server's defaults.
+CREATE TABLE IF NOT EXISTS organizations (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    slug TEXT UNIQUE NOT NULL,
+    token_hash TEXT UNIQUE,
+    slot_interval_minutes INTEGER CHECK (slot_interval_minutes > 0),
+    lead_time_minutes INTEGER CHECK (lead_time_minutes >= 0),
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+-- Hosts requests without a token are served for
+CREATE TABLE IF NOT EXISTS organization_hosts (
+    host TEXT PRIMARY KEY,
+    organization_id TEXT NOT NULL,
+    CONSTRAINT fk_host_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+-- Everything that existed before organizations belongs to the default one
+INSERT OR IGNORE INTO organizations (id, name, slug, created_at, updated_at)
+VALUES ('00000000-0000-0000-0000-000000000001', 'Default', 'default', 0, 0);
+
+-- SQLite can not add a foreign key with a default without rebuilding the tables, and has no row level
+-- security, the store filters every query by organization itself
+ALTER TABLE users ADD COLUMN organization_id TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001';
+ALTER TABLE availability ADD COLUMN organization_id TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001';
+ALTER TABLE appointments ADD COLUMN organization_id TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001';
+ALTER TABLE appointment_series ADD COLUMN organization_id TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001';
+ALTER TABLE waitlist_entries ADD COLUMN organization_id TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001';
+
+-- The UNIQUE on users.email can not be dropped without rebuilding the table either, so emails stay unique
+-- across every organization here
+CREATE INDEX IF NOT EXISTS idx_users_organization ON users (organization_id);
+CREATE INDEX IF NOT EXISTS idx_availability_organization ON availability (organization_id);
+CREATE INDEX IF NOT EXISTS idx_appointments_organization ON appointments (organization_id);
+CREATE INDEX IF NOT EXISTS idx_appointment_series_organization ON appointment_series (organization_id);
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_organization ON waitlist_entries (organization_id);
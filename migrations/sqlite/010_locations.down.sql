@@ -0,0 +1,14 @@
+-- 010_locations.sql
+
+DROP INDEX IF EXISTS idx_appointments_room_start_time;
+DROP INDEX IF EXISTS idx_availability_location_start_time;
+
+ALTER TABLE appointments DROP COLUMN room_id;
+ALTER TABLE appointments DROP COLUMN location_id;
+ALTER TABLE availability DROP COLUMN location_id;
+
+DROP INDEX IF EXISTS idx_rooms_location;
+DROP INDEX IF EXISTS idx_locations_organization;
+
+DROP TABLE IF EXISTS rooms;
+DROP TABLE IF EXISTS locations;
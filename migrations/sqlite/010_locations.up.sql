@@ -0,0 +1,35 @@
+-- 010_locations.sql
+
+-- Sites an organization sees clients at
+CREATE TABLE IF NOT EXISTS locations (
+    id TEXT PRIMARY KEY,
+    organization_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    address TEXT,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    CONSTRAINT fk_location_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+-- Rooms are booked together with a provider
+CREATE TABLE IF NOT EXISTS rooms (
+    id TEXT PRIMARY KEY,
+    organization_id TEXT NOT NULL,
+    location_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    CONSTRAINT fk_room_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_room_location FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_locations_organization ON locations (organization_id);
+CREATE INDEX IF NOT EXISTS idx_rooms_location ON rooms (location_id);
+
+-- Slots are offered at a location, appointments keep the location of their slot and the room they booked
+ALTER TABLE availability ADD COLUMN location_id TEXT REFERENCES locations(id) ON DELETE CASCADE;
+ALTER TABLE appointments ADD COLUMN location_id TEXT REFERENCES locations(id) ON DELETE CASCADE;
+ALTER TABLE appointments ADD COLUMN room_id TEXT REFERENCES rooms(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_availability_location_start_time ON availability (location_id, start_time);
+CREATE INDEX IF NOT EXISTS idx_appointments_room_start_time ON appointments (room_id, start_time);
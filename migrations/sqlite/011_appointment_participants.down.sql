@@ -0,0 +1,4 @@
+-- 011_appointment_participants.sql
+
+DROP INDEX IF EXISTS idx_appointment_participants_provider;
+DROP TABLE IF EXISTS appointment_participants;
@@ -0,0 +1,16 @@
+-- 011_appointment_participants.sql
+
+-- Providers who take part in an appointment besides its provider. Each holds a seat in their own slot at
+-- the appointment's start time for as long as the appointment is active.
+CREATE TABLE IF NOT EXISTS appointment_participants (
+    appointment_id TEXT NOT NULL,
+    provider_id TEXT NOT NULL,
+    organization_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (appointment_id, provider_id),
+    CONSTRAINT fk_participant_appointment FOREIGN KEY (appointment_id) REFERENCES appointments(id) ON DELETE CASCADE,
+    CONSTRAINT fk_participant_provider FOREIGN KEY (provider_id) REFERENCES users(id),
+    CONSTRAINT fk_participant_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_appointment_participants_provider ON appointment_participants (provider_id);
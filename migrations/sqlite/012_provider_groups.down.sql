@@ -0,0 +1,6 @@
+-- 012_provider_groups.sql
+
+DROP INDEX IF EXISTS idx_provider_group_members_provider;
+DROP INDEX IF EXISTS idx_provider_groups_organization;
+DROP TABLE IF EXISTS provider_group_members;
+DROP TABLE IF EXISTS provider_groups;
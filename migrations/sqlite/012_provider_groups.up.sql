@@ -0,0 +1,29 @@
+-- 012_provider_groups.sql
+
+-- Providers that can stand in for each other, assignments counts the providers assigned from the group
+CREATE TABLE IF NOT EXISTS provider_groups (
+    id TEXT PRIMARY KEY,
+    organization_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    strategy TEXT NOT NULL DEFAULT 'round_robin' CHECK (strategy IN ('round_robin', 'least_booked', 'priority')),
+    assignments INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    CONSTRAINT fk_provider_group_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+-- Members in priority order, last_assignment is the number of the group's assignment they last got
+CREATE TABLE IF NOT EXISTS provider_group_members (
+    group_id TEXT NOT NULL,
+    provider_id TEXT NOT NULL,
+    organization_id TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    last_assignment INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (group_id, provider_id),
+    CONSTRAINT fk_group_member_group FOREIGN KEY (group_id) REFERENCES provider_groups(id) ON DELETE CASCADE,
+    CONSTRAINT fk_group_member_provider FOREIGN KEY (provider_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_group_member_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_provider_groups_organization ON provider_groups (organization_id);
+CREATE INDEX IF NOT EXISTS idx_provider_group_members_provider ON provider_group_members (provider_id);
@@ -0,0 +1,8 @@
+-- 013_client_limits.sql
+
+DROP INDEX IF EXISTS idx_appointments_client_start_time;
+
+ALTER TABLE organizations DROP COLUMN allow_overlapping_appointments;
+ALTER TABLE organizations DROP COLUMN max_future_appointments_per_provider;
+ALTER TABLE organizations DROP COLUMN max_future_appointments;
+ALTER TABLE organizations DROP COLUMN max_active_holds;
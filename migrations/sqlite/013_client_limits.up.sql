@@ -0,0 +1,12 @@
+-- 013_client_limits.sql
+
+-- Per organization limits on what one client may book, NULL leaves a client unlimited
+ALTER TABLE organizations ADD COLUMN max_active_holds INTEGER CHECK (max_active_holds > 0);
+ALTER TABLE organizations ADD COLUMN max_future_appointments INTEGER CHECK (max_future_appointments > 0);
+ALTER TABLE organizations ADD COLUMN max_future_appointments_per_provider INTEGER
+CHECK (max_future_appointments_per_provider > 0);
+-- NULL keeps a client from holding appointments at overlapping times
+ALTER TABLE organizations ADD COLUMN allow_overlapping_appointments INTEGER;
+
+-- The limits count a client's active appointments
+CREATE INDEX IF NOT EXISTS idx_appointments_client_start_time ON appointments (client_id, start_time);
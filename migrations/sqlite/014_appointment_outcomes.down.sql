@@ -0,0 +1,9 @@
+-- 014_appointment_outcomes.sql
+
+ALTER TABLE organizations DROP COLUMN max_no_shows;
+
+ALTER TABLE appointments DROP COLUMN status_changed_by;
+ALTER TABLE appointments DROP COLUMN status_changed_at;
+
+UPDATE appointments SET status = 'confirmed' WHERE status IN ('checked_in', 'completed', 'no_show');
+DELETE FROM appointment_statuses WHERE status IN ('checked_in', 'completed', 'no_show');
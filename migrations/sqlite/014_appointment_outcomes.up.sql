@@ -0,0 +1,11 @@
+-- 014_appointment_outcomes.sql
+
+-- Once an appointment has started it is checked in and completed, or marked a no-show
+INSERT INTO appointment_statuses (status) VALUES ('checked_in'), ('completed'), ('no_show');
+
+-- The provider who last changed the appointment to one of those and when
+ALTER TABLE appointments ADD COLUMN status_changed_at INTEGER;
+ALTER TABLE appointments ADD COLUMN status_changed_by TEXT REFERENCES users(id);
+
+-- Clients with this many recent no-shows can not book, NULL leaves them unlimited
+ALTER TABLE organizations ADD COLUMN max_no_shows INTEGER CHECK (max_no_shows > 0);
@@ -0,0 +1,12 @@
+-- 015_appointment_intake.sql
+
+DROP INDEX IF EXISTS idx_appointment_notes_author;
+DROP INDEX IF EXISTS idx_appointment_types_organization;
+
+DROP TABLE IF EXISTS appointment_notes;
+
+ALTER TABLE appointments DROP COLUMN intake_answers;
+ALTER TABLE appointments DROP COLUMN appointment_type_id;
+ALTER TABLE appointments DROP COLUMN reason;
+
+DROP TABLE IF EXISTS appointment_types;
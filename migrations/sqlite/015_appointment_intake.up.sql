@@ -0,0 +1,34 @@
+-- 015_appointment_intake.sql
+
+-- Kinds of appointment, clients booking one answer its intake questions, a json array
+CREATE TABLE IF NOT EXISTS appointment_types (
+    id TEXT PRIMARY KEY,
+    organization_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    intake_questions TEXT NOT NULL DEFAULT '[]' CHECK (json_valid(intake_questions)),
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    CONSTRAINT fk_appointment_type_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+-- Why the client booked and their answers to the intake questions of the appointment type, a json object
+ALTER TABLE appointments ADD COLUMN reason TEXT;
+ALTER TABLE appointments ADD COLUMN appointment_type_id TEXT REFERENCES appointment_types(id);
+ALTER TABLE appointments ADD COLUMN intake_answers TEXT CHECK (json_valid(intake_answers));
+
+-- The providers' private notes on an appointment, every save adds a version
+CREATE TABLE IF NOT EXISTS appointment_notes (
+    appointment_id TEXT NOT NULL,
+    version INTEGER NOT NULL CHECK (version > 0),
+    organization_id TEXT NOT NULL,
+    body TEXT NOT NULL,
+    author_id TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (appointment_id, version),
+    CONSTRAINT fk_appointment_note_appointment FOREIGN KEY (appointment_id) REFERENCES appointments(id) ON DELETE CASCADE,
+    CONSTRAINT fk_appointment_note_author FOREIGN KEY (author_id) REFERENCES users(id),
+    CONSTRAINT fk_appointment_note_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_appointment_types_organization ON appointment_types (organization_id);
+CREATE INDEX IF NOT EXISTS idx_appointment_notes_author ON appointment_notes (author_id);
@@ -0,0 +1,24 @@
+-- 016_status_history.sql
+
+-- Every status an appointment has had, from its reservation on, rows are only ever added. changed_by is the
+-- provider who recorded an outcome, the api has no user accounts to attribute the other changes to, so
+-- source tells what made them.
+CREATE TABLE IF NOT EXISTS appointment_status_history (
+    appointment_id TEXT NOT NULL,
+    seq INTEGER NOT NULL CHECK (seq > 0),
+    organization_id TEXT NOT NULL,
+    status TEXT NOT NULL,
+    changed_at INTEGER NOT NULL,
+    changed_by TEXT,
+    source TEXT NOT NULL CHECK (source IN ('request', 'provider', 'waitlist', 'hold_expiry', 'migration')),
+    PRIMARY KEY (appointment_id, seq),
+    CONSTRAINT fk_status_history_appointment FOREIGN KEY (appointment_id) REFERENCES appointments(id) ON DELETE CASCADE,
+    CONSTRAINT fk_status_history_status FOREIGN KEY (status) REFERENCES appointment_statuses(status),
+    CONSTRAINT fk_status_history_changed_by FOREIGN KEY (changed_by) REFERENCES users(id),
+    CONSTRAINT fk_status_history_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+
+-- Appointments booked before keep the status they had as the start of their history
+INSERT INTO appointment_status_history (appointment_id, seq, organization_id, status, changed_at, changed_by, source)
+SELECT id, 1, organization_id, status, COALESCE(status_changed_at, updated_at), status_changed_by, 'migration'
+FROM appointments;
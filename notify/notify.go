@@ -0,0 +1,101 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Notification types
+const (
+	WaitlistOffer = "waitlist_offer"
+)
+
+// Notification is a message to a user about one of their appointments
+type Notification struct {
+	Type          string    `json:"type"`
+	UserID        uuid.UUID `json:"user_id"`
+	AppointmentID uuid.UUID `json:"appointment_id"`
+	StartTime     time.Time `json:"start_time"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	Message       string    `json:"message"`
+	// TraceID is the trace of the request that caused the notification, set by the notifier
+	TraceID string `json:"trace_id,omitempty"`
+}
+
+// Notifier delivers notifications to users
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// LogNotifier logs notifications instead of delivering them, useful for local development
+type LogNotifier struct {
+	Logger *slog.Logger
+}
+
+func (l LogNotifier) Notify(ctx context.Context, n Notification) error {
+	logger := l.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.InfoContext(ctx, "notification",
+		slog.String("type", n.Type),
+		slog.String("user_id", n.UserID.String()),
+		slog.String("appointment_id", n.AppointmentID.String()),
+		slog.String("message", n.Message),
+	)
+	return nil
+}
+
+// WebhookNotifier posts notifications as json to an http endpoint. The trace context is sent in the
+// traceparent header so the receiver can continue the trace.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		URL: url,
+		Client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: otelhttp.NewTransport(http.DefaultTransport, otelhttp.WithPropagators(propagation.TraceContext{})),
+		},
+	}
+}
+
+func (w *WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		n.TraceID = sc.TraceID().String()
+	}
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	t.Parallel()
+
+	var received Notification
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	n := Notification{
+		Type:          WaitlistOffer,
+		UserID:        uuid.New(),
+		AppointmentID: uuid.New(),
+		StartTime:     time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second),
+		ExpiresAt:     time.Now().Add(30 * time.Minute).UTC().Truncate(time.Second),
+		Message:       "A slot opened up",
+	}
+	err := NewWebhookNotifier(srv.URL).Notify(context.Background(), n)
+	require.NoError(t, err)
+	require.Equal(t, n, received)
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	err := NewWebhookNotifier(srv.URL).Notify(context.Background(), Notification{Type: WaitlistOffer})
+	require.Error(t, err)
+}
+
+func TestWebhookNotifier_TraceContext(t *testing.T) {
+	t.Parallel()
+
+	var received Notification
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	traceID := trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	err := NewWebhookNotifier(srv.URL).Notify(ctx, Notification{Type: WaitlistOffer})
+	require.NoError(t, err)
+	require.Equal(t, traceID.String(), received.TraceID)
+	require.Contains(t, traceparent, traceID.String())
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/tateexon/reservation/config"
+	"github.com/tateexon/reservation/db"
+	"github.com/tateexon/reservation/schema"
+)
+
+var errOrganizationUsage = errors.New("usage: reservation organization create --name NAME --slug SLUG [--host HOST]... [SETTINGS] | list | settings SLUG [SETTINGS], the settings are --slot-interval MINUTES --lead-time MINUTES --max-holds N --max-appointments N --max-provider-appointments N --allow-overlap --max-no-shows N")
+
+// runOrganization implements the organization command. It works on the database directly rather than
+// through the api, since organizations are what api requests are scoped to.
+func runOrganization(cfg *config.Config, logger *slog.Logger, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errOrganizationUsage
+	}
+	if cfg.Database.Driver == config.DriverMemory {
+		return fmt.Errorf("the %s driver forgets organizations when the server stops", cfg.Database.Driver)
+	}
+
+	store, conn, _, err := openDatabase(cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+	switch args[0] {
+	case "create":
+		return createOrganization(ctx, store, args[1:], out)
+	case "list":
+		if len(args) != 1 {
+			return errOrganizationUsage
+		}
+		orgs, err := store.ListOrganizations(ctx)
+		if err != nil {
+			return err
+		}
+		return printOrganizations(out, orgs)
+	case "settings":
+		return updateOrganizationSettings(ctx, store, args[1:], out)
+	default:
+		return errOrganizationUsage
+	}
+}
+
+// settingFlags are the organization settings, zero leaves a setting at its default
+type settingFlags struct {
+	slotInterval, leadTime                             *int
+	maxHolds, maxAppointments, maxProviderAppointments *int
+	maxNoShows                                         *int
+	allowOverlap                                       *bool
+}
+
+// settingsFlags registers the organization settings
+func settingsFlags(f *flag.FlagSet) settingFlags {
+	return settingFlags{
+		slotInterval:            f.Int("slot-interval", 0, "minutes in an availability slot, 0 for the server's interval"),
+		leadTime:                f.Int("lead-time", 0, "minutes in advance reservations must be made, 0 for a day"),
+		maxHolds:                f.Int("max-holds", 0, "unconfirmed reservations a client may hold at once, 0 for no limit"),
+		maxAppointments:         f.Int("max-appointments", 0, "upcoming appointments a client may have, 0 for no limit"),
+		maxProviderAppointments: f.Int("max-provider-appointments", 0, "upcoming appointments a client may have with one provider, 0 for no limit"),
+		allowOverlap:            f.Bool("allow-overlap", false, "let a client hold appointments at overlapping times"),
+		maxNoShows:              f.Int("max-no-shows", 0, "no-shows in the last 90 days that stop a client from booking, 0 for no limit"),
+	}
+}
+
+func (flags settingFlags) settings() (schema.OrganizationSettings, error) {
+	var settings schema.OrganizationSettings
+	for _, setting := range []struct {
+		value  int
+		target **int
+	}{
+		{*flags.slotInterval, &settings.SlotIntervalMinutes},
+		{*flags.leadTime, &settings.LeadTimeMinutes},
+		{*flags.maxHolds, &settings.MaxActiveHolds},
+		{*flags.maxAppointments, &settings.MaxFutureAppointments},
+		{*flags.maxProviderAppointments, &settings.MaxFutureAppointmentsPerProvider},
+		{*flags.maxNoShows, &settings.MaxNoShows},
+	} {
+		if setting.value < 0 {
+			return settings, errors.New("settings can not be negative")
+		}
+		if setting.value > 0 {
+			*setting.target = &setting.value
+		}
+	}
+	if *flags.allowOverlap {
+		settings.AllowOverlappingAppointments = flags.allowOverlap
+	}
+	return settings, nil
+}
+
+func createOrganization(ctx context.Context, store db.Store, args []string, out io.Writer) error {
+	f := flag.NewFlagSet("organization create", flag.ContinueOnError)
+	name := f.String("name", "", "name of the organization")
+	slug := f.String("slug", "", "short unique name of the organization")
+	var hosts hostFlag
+	f.Var(&hosts, "host", "host the organization is served on, repeatable")
+	flags := settingsFlags(f)
+	if err := f.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" || *slug == "" || f.NArg() != 0 {
+		return errOrganizationUsage
+	}
+	settings, err := flags.settings()
+	if err != nil {
+		return err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+	org, err := store.CreateOrganization(ctx, db.NewOrganization{
+		Name:      *name,
+		Slug:      *slug,
+		Hosts:     hosts,
+		TokenHash: db.HashToken(token),
+		Settings:  settings,
+	})
+	if err != nil {
+		return err
+	}
+	if err := printOrganizations(out, []schema.Organization{*org}); err != nil {
+		return err
+	}
+	// Only the hash is stored, so this is the one chance to see the token
+	fmt.Fprintf(out, "\nAPI token: %s\n", token)
+	return nil
+}
+
+func updateOrganizationSettings(ctx context.Context, store db.Store, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errOrganizationUsage
+	}
+	f := flag.NewFlagSet("organization settings", flag.ContinueOnError)
+	flags := settingsFlags(f)
+	if err := f.Parse(args[1:]); err != nil {
+		return err
+	}
+	if f.NArg() != 0 {
+		return errOrganizationUsage
+	}
+	settings, err := flags.settings()
+	if err != nil {
+		return err
+	}
+
+	orgs, err := store.ListOrganizations(ctx)
+	if err != nil {
+		return err
+	}
+	for _, org := range orgs {
+		if *org.Slug != args[0] {
+			continue
+		}
+		updated, err := store.UpdateOrganizationSettings(ctx, *org.Id, settings)
+		if err != nil {
+			return err
+		}
+		return printOrganizations(out, []schema.Organization{*updated})
+	}
+	return fmt.Errorf("no organization has the slug %q", args[0])
+}
+
+// newToken returns a random api token
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func printOrganizations(out io.Writer, orgs []schema.Organization) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSLUG\tNAME\tHOSTS\tSLOT INTERVAL\tLEAD TIME\tMAX HOLDS\tMAX APPOINTMENTS\tPER PROVIDER\tOVERLAP\tMAX NO-SHOWS")
+	for _, org := range orgs {
+		overlap := "no"
+		if org.Settings.AllowOverlappingAppointments != nil && *org.Settings.AllowOverlappingAppointments {
+			overlap = "yes"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", org.Id, *org.Slug, *org.Name, strings.Join(*org.Hosts, ","),
+			minutesSetting(org.Settings.SlotIntervalMinutes), minutesSetting(org.Settings.LeadTimeMinutes),
+			limitSetting(org.Settings.MaxActiveHolds), limitSetting(org.Settings.MaxFutureAppointments),
+			limitSetting(org.Settings.MaxFutureAppointmentsPerProvider), overlap, limitSetting(org.Settings.MaxNoShows))
+	}
+	return w.Flush()
+}
+
+func limitSetting(limit *int) string {
+	if limit == nil {
+		return "none"
+	}
+	return fmt.Sprint(*limit)
+}
+
+func minutesSetting(minutes *int) string {
+	if minutes == nil {
+		return "default"
+	}
+	return fmt.Sprintf("%dm", *minutes)
+}
+
+// hostFlag collects every --host, lowercased like the hosts requests are matched by
+type hostFlag []string
+
+func (h *hostFlag) String() string {
+	return strings.Join(*h, ",")
+}
+
+func (h *hostFlag) Set(host string) error {
+	*h = append(*h, strings.ToLower(host))
+	return nil
+}
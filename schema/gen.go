@@ -4,19 +4,35 @@
 package schema
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"strings"
 	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
 	"github.com/oapi-codegen/runtime"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
 // Defines values for AppointmentStatus.
 const (
-	Confirmed AppointmentStatus = "confirmed"
-	Reserved  AppointmentStatus = "reserved"
+	AppointmentStatusCancelled AppointmentStatus = "cancelled"
+	AppointmentStatusCheckedIn AppointmentStatus = "checked_in"
+	AppointmentStatusCompleted AppointmentStatus = "completed"
+	AppointmentStatusConfirmed AppointmentStatus = "confirmed"
+	AppointmentStatusExpired   AppointmentStatus = "expired"
+	AppointmentStatusNoShow    AppointmentStatus = "no_show"
+	AppointmentStatusReserved  AppointmentStatus = "reserved"
 )
 
 // Defines values for CreateUserRequestRole.
@@ -25,36 +41,254 @@ const (
 	CreateUserRequestRoleProvider CreateUserRequestRole = "provider"
 )
 
+// Defines values for GroupStrategy.
+const (
+	LeastBooked GroupStrategy = "least_booked"
+	Priority    GroupStrategy = "priority"
+	RoundRobin  GroupStrategy = "round_robin"
+)
+
+// Defines values for IntakeQuestionType.
+const (
+	Boolean IntakeQuestionType = "boolean"
+	Choice  IntakeQuestionType = "choice"
+	Number  IntakeQuestionType = "number"
+	Text    IntakeQuestionType = "text"
+)
+
+// Defines values for OccurrenceConflictReason.
+const (
+	Booked         OccurrenceConflictReason = "booked"
+	NoAvailability OccurrenceConflictReason = "no_availability"
+	RoomBooked     OccurrenceConflictReason = "room_booked"
+	RoomElsewhere  OccurrenceConflictReason = "room_elsewhere"
+)
+
+// Defines values for OccurrenceScope.
+const (
+	Following OccurrenceScope = "following"
+	This      OccurrenceScope = "this"
+)
+
+// Defines values for ProblemCode.
+const (
+	ProblemCodeAppointmentLimitReached         ProblemCode = "appointment_limit_reached"
+	ProblemCodeAppointmentNotFound             ProblemCode = "appointment_not_found"
+	ProblemCodeAppointmentNotStarted           ProblemCode = "appointment_not_started"
+	ProblemCodeAppointmentTypeNotFound         ProblemCode = "appointment_type_not_found"
+	ProblemCodeAvailabilityNotFound            ProblemCode = "availability_not_found"
+	ProblemCodeClientDoubleBooked              ProblemCode = "client_double_booked"
+	ProblemCodeEmailTaken                      ProblemCode = "email_taken"
+	ProblemCodeGroupNotFound                   ProblemCode = "group_not_found"
+	ProblemCodeHoldExpired                     ProblemCode = "hold_expired"
+	ProblemCodeHoldLimitReached                ProblemCode = "hold_limit_reached"
+	ProblemCodeInternalError                   ProblemCode = "internal_error"
+	ProblemCodeInvalidRequest                  ProblemCode = "invalid_request"
+	ProblemCodeInvalidStatusTransition         ProblemCode = "invalid_status_transition"
+	ProblemCodeInvalidToken                    ProblemCode = "invalid_token"
+	ProblemCodeLeadTimeViolation               ProblemCode = "lead_time_violation"
+	ProblemCodeLocationNotFound                ProblemCode = "location_not_found"
+	ProblemCodeNoShowLimitReached              ProblemCode = "no_show_limit_reached"
+	ProblemCodeProviderAppointmentLimitReached ProblemCode = "provider_appointment_limit_reached"
+	ProblemCodeProviderNotFound                ProblemCode = "provider_not_found"
+	ProblemCodeRoomNotFound                    ProblemCode = "room_not_found"
+	ProblemCodeRoomUnavailable                 ProblemCode = "room_unavailable"
+	ProblemCodeSeriesNotFound                  ProblemCode = "series_not_found"
+	ProblemCodeServiceUnavailable              ProblemCode = "service_unavailable"
+	ProblemCodeSlotUnavailable                 ProblemCode = "slot_unavailable"
+	ProblemCodeSlotsAvailable                  ProblemCode = "slots_available"
+	ProblemCodeTokenRequired                   ProblemCode = "token_required"
+	ProblemCodeUserHasAppointments             ProblemCode = "user_has_appointments"
+	ProblemCodeUserInactive                    ProblemCode = "user_inactive"
+	ProblemCodeUserNotFound                    ProblemCode = "user_not_found"
+	ProblemCodeValidationFailed                ProblemCode = "validation_failed"
+	ProblemCodeWaitlistEntryNotFound           ProblemCode = "waitlist_entry_not_found"
+)
+
+// Defines values for RecurrenceFrequency.
+const (
+	Daily  RecurrenceFrequency = "daily"
+	Weekly RecurrenceFrequency = "weekly"
+)
+
+// Defines values for StatusChangeSource.
+const (
+	StatusChangeSourceHoldExpiry StatusChangeSource = "hold_expiry"
+	StatusChangeSourceMigration  StatusChangeSource = "migration"
+	StatusChangeSourceProvider   StatusChangeSource = "provider"
+	StatusChangeSourceRequest    StatusChangeSource = "request"
+	StatusChangeSourceWaitlist   StatusChangeSource = "waitlist"
+)
+
 // Defines values for UserRole.
 const (
 	UserRoleClient   UserRole = "client"
 	UserRoleProvider UserRole = "provider"
 )
 
+// Defines values for WaitlistEntryStatus.
+const (
+	WaitlistEntryStatusCancelled WaitlistEntryStatus = "cancelled"
+	WaitlistEntryStatusExpired   WaitlistEntryStatus = "expired"
+	WaitlistEntryStatusFulfilled WaitlistEntryStatus = "fulfilled"
+	WaitlistEntryStatusOffered   WaitlistEntryStatus = "offered"
+	WaitlistEntryStatusWaiting   WaitlistEntryStatus = "waiting"
+)
+
 // Appointment defines model for Appointment.
 type Appointment struct {
-	ClientId   *openapi_types.UUID `json:"client_id,omitempty"`
-	EndTime    *time.Time          `json:"end_time,omitempty"`
-	Id         *openapi_types.UUID `json:"id,omitempty"`
-	ProviderId *openapi_types.UUID `json:"provider_id,omitempty"`
-	StartTime  *time.Time          `json:"start_time,omitempty"`
-	Status     *AppointmentStatus  `json:"status,omitempty"`
+	// AppointmentTypeId The kind of appointment booked, whose intake questions intake_answers answers
+	AppointmentTypeId *openapi_types.UUID `json:"appointment_type_id,omitempty"`
+
+	// Assignment How a group booking picked its provider, only set on the response to one
+	Assignment *Assignment `json:"assignment,omitempty"`
+
+	// Capacity Number of clients that can book this slot, only set in slot listings
+	Capacity *int                `json:"capacity,omitempty"`
+	ClientId *openapi_types.UUID `json:"client_id,omitempty"`
+	EndTime  *time.Time          `json:"end_time,omitempty"`
+	Id       *openapi_types.UUID `json:"id,omitempty"`
+
+	// IntakeAnswers The client's answers to the intake questions of the appointment type by question key
+	IntakeAnswers *map[string]interface{} `json:"intake_answers,omitempty"`
+
+	// LocationId Where the slot is, if its availability was given a location
+	LocationId *openapi_types.UUID `json:"location_id,omitempty"`
+
+	// ParticipantIds The other providers who take part in the appointment, each holding a seat in their own slot at the same time
+	ParticipantIds *[]openapi_types.UUID `json:"participant_ids,omitempty"`
+	ProviderId     *openapi_types.UUID   `json:"provider_id,omitempty"`
+
+	// Reason Why the client booked, if they said
+	Reason *string `json:"reason,omitempty"`
+
+	// RoomId The room booked together with the provider, if any
+	RoomId *openapi_types.UUID `json:"room_id,omitempty"`
+
+	// SeatsRemaining Number of seats in this slot that can still be booked, only set in slot listings
+	SeatsRemaining *int `json:"seats_remaining,omitempty"`
+
+	// SeriesId The recurring series this appointment was booked in, if any
+	SeriesId *openapi_types.UUID `json:"series_id,omitempty"`
+
+	// SeriesIndex Zero based position of this appointment in its series
+	SeriesIndex *int               `json:"series_index,omitempty"`
+	StartTime   *time.Time         `json:"start_time,omitempty"`
+	Status      *AppointmentStatus `json:"status,omitempty"`
+
+	// StatusChangedAt When the appointment was last checked in, completed or marked a no-show
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+
+	// StatusChangedBy The provider who last checked the appointment in, completed it or marked it a no-show
+	StatusChangedBy *openapi_types.UUID `json:"status_changed_by,omitempty"`
 }
 
 // AppointmentStatus defines model for Appointment.Status.
 type AppointmentStatus string
 
+// AppointmentNote One version of the providers' notes on an appointment, saving them adds a version
+type AppointmentNote struct {
+	AppointmentId *openapi_types.UUID `json:"appointment_id,omitempty"`
+
+	// AuthorId The author_id given when this version was saved
+	AuthorId  *openapi_types.UUID `json:"author_id,omitempty"`
+	Body      *string             `json:"body,omitempty"`
+	CreatedAt *time.Time          `json:"created_at,omitempty"`
+
+	// Version Starts at 1 and goes up with every save
+	Version *int `json:"version,omitempty"`
+}
+
+// AppointmentSeries defines model for AppointmentSeries.
+type AppointmentSeries struct {
+	Appointments *[]Appointment      `json:"appointments,omitempty"`
+	ClientId     *openapi_types.UUID `json:"client_id,omitempty"`
+
+	// Conflicts Occurrences that could not be reserved when partial series are allowed
+	Conflicts  *[]OccurrenceConflict `json:"conflicts,omitempty"`
+	Id         *openapi_types.UUID   `json:"id,omitempty"`
+	ProviderId *openapi_types.UUID   `json:"provider_id,omitempty"`
+	Recurrence *Recurrence           `json:"recurrence,omitempty"`
+}
+
+// AppointmentType A kind of appointment, clients booking one answer its intake questions
+type AppointmentType struct {
+	Id              *openapi_types.UUID `json:"id,omitempty"`
+	IntakeQuestions *[]IntakeQuestion   `json:"intake_questions,omitempty"`
+	Name            *string             `json:"name,omitempty"`
+}
+
+// Assignment How a group booking picked its provider, only set on the response to one
+type Assignment struct {
+	// Candidates The active members in the order the strategy ranked them
+	Candidates  *[]AssignmentCandidate `json:"candidates,omitempty"`
+	Explanation *string                `json:"explanation,omitempty"`
+	GroupId     *openapi_types.UUID    `json:"group_id,omitempty"`
+
+	// Strategy How a group booking picks among the providers free at the time. round_robin takes the one assigned least recently, least_booked the one with the fewest appointments in the week of the booking and priority the first in the group's order. Ties go to the earlier provider in the group.
+	Strategy *GroupStrategy `json:"strategy,omitempty"`
+}
+
+// AssignmentCandidate defines model for AssignmentCandidate.
+type AssignmentCandidate struct {
+	// AppointmentsThisWeek Reserved and confirmed appointments the provider has in the week of the booking, Monday to Sunday UTC
+	AppointmentsThisWeek *int `json:"appointments_this_week,omitempty"`
+
+	// Available Whether the provider had a free slot at the time
+	Available *bool `json:"available,omitempty"`
+
+	// LastAssignment Number of the group's assignment the provider last got, 0 if they never got one
+	LastAssignment *int `json:"last_assignment,omitempty"`
+
+	// Priority One based position in the group
+	Priority   *int                `json:"priority,omitempty"`
+	ProviderId *openapi_types.UUID `json:"provider_id,omitempty"`
+}
+
 // Availability defines model for Availability.
 type Availability struct {
-	EndTime    *time.Time          `json:"end_time,omitempty"`
-	Id         *openapi_types.UUID `json:"id,omitempty"`
+	// Capacity Number of clients that can book each slot, more than one for group sessions
+	Capacity *int                `json:"capacity,omitempty"`
+	EndTime  time.Time           `json:"end_time"`
+	Id       *openapi_types.UUID `json:"id,omitempty"`
+
+	// LocationId Where the provider sees clients in these slots, needed to book a room with them
+	LocationId *openapi_types.UUID `json:"location_id,omitempty"`
 	ProviderId *openapi_types.UUID `json:"provider_id,omitempty"`
-	StartTime  *time.Time          `json:"start_time,omitempty"`
+	StartTime  time.Time           `json:"start_time"`
+}
+
+// CreateAppointmentTypeRequest defines model for CreateAppointmentTypeRequest.
+type CreateAppointmentTypeRequest struct {
+	IntakeQuestions *[]IntakeQuestion `json:"intake_questions,omitempty"`
+	Name            string            `json:"name"`
+}
+
+// CreateLocationRequest defines model for CreateLocationRequest.
+type CreateLocationRequest struct {
+	Address *string `json:"address,omitempty"`
+	Name    string  `json:"name"`
+}
+
+// CreateProviderGroupRequest defines model for CreateProviderGroupRequest.
+type CreateProviderGroupRequest struct {
+	Name string `json:"name"`
+
+	// ProviderIds The members in priority order
+	ProviderIds []openapi_types.UUID `json:"provider_ids"`
+
+	// Strategy How a group booking picks among the providers free at the time. round_robin takes the one assigned least recently, least_booked the one with the fewest appointments in the week of the booking and priority the first in the group's order. Ties go to the earlier provider in the group.
+	Strategy *GroupStrategy `json:"strategy,omitempty"`
+}
+
+// CreateRoomRequest defines model for CreateRoomRequest.
+type CreateRoomRequest struct {
+	Name string `json:"name"`
 }
 
 // CreateUserRequest defines model for CreateUserRequest.
 type CreateUserRequest struct {
-	Email string                `json:"email"`
+	Email openapi_types.Email   `json:"email"`
 	Name  string                `json:"name"`
 	Role  CreateUserRequestRole `json:"role"`
 }
@@ -62,59 +296,487 @@ type CreateUserRequest struct {
 // CreateUserRequestRole defines model for CreateUserRequest.Role.
 type CreateUserRequestRole string
 
-// User defines model for User.
-type User struct {
-	Email *string             `json:"email,omitempty"`
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Field Name of the request field or parameter, as it is spelled in the request
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// GroupStrategy How a group booking picks among the providers free at the time. round_robin takes the one assigned least recently, least_booked the one with the fewest appointments in the week of the booking and priority the first in the group's order. Ties go to the earlier provider in the group.
+type GroupStrategy string
+
+// IntakeQuestion A question clients answer when booking an appointment of a type
+type IntakeQuestion struct {
+	// Key Name of the answer in intake_answers
+	Key   string `json:"key"`
+	Label string `json:"label"`
+
+	// Options The answers a choice question allows, needed for choice questions only
+	Options  *[]string `json:"options,omitempty"`
+	Required *bool     `json:"required,omitempty"`
+
+	// Type A text answer is a string, a choice one of the options
+	Type IntakeQuestionType `json:"type"`
+}
+
+// IntakeQuestionType A text answer is a string, a choice one of the options
+type IntakeQuestionType string
+
+// JoinWaitlistRequest defines model for JoinWaitlistRequest.
+type JoinWaitlistRequest struct {
+	ClientId    openapi_types.UUID `json:"client_id"`
+	ProviderId  openapi_types.UUID `json:"provider_id"`
+	WindowEnd   time.Time          `json:"window_end"`
+	WindowStart time.Time          `json:"window_start"`
+}
+
+// Location A site the organization sees clients at, availability is offered at one
+type Location struct {
+	Address *string             `json:"address,omitempty"`
+	Id      *openapi_types.UUID `json:"id,omitempty"`
+	Name    *string             `json:"name,omitempty"`
+}
+
+// OccurrenceConflict defines model for OccurrenceConflict.
+type OccurrenceConflict struct {
+	Index     *int                      `json:"index,omitempty"`
+	Reason    *OccurrenceConflictReason `json:"reason,omitempty"`
+	StartTime *time.Time                `json:"start_time,omitempty"`
+}
+
+// OccurrenceConflictReason defines model for OccurrenceConflict.Reason.
+type OccurrenceConflictReason string
+
+// OccurrenceScope Apply to this occurrence only, or to this and the following occurrences in its series
+type OccurrenceScope string
+
+// Organization A clinic, its users and their bookings are invisible to every other organization
+type Organization struct {
+	// Hosts Request hosts that resolve to this organization when no token is sent
+	Hosts *[]string           `json:"hosts,omitempty"`
 	Id    *openapi_types.UUID `json:"id,omitempty"`
 	Name  *string             `json:"name,omitempty"`
-	Role  *UserRole           `json:"role,omitempty"`
+
+	// Settings Per organization overrides of the deployment's defaults
+	Settings *OrganizationSettings `json:"settings,omitempty"`
+	Slug     *string               `json:"slug,omitempty"`
+}
+
+// OrganizationSettings Per organization overrides of the deployment's defaults
+type OrganizationSettings struct {
+	// AllowOverlappingAppointments Lets a client hold appointments at overlapping times, by default they can not
+	AllowOverlappingAppointments *bool `json:"allow_overlapping_appointments,omitempty"`
+
+	// LeadTimeMinutes How far in advance reservations must be made, defaults to 24 hours
+	LeadTimeMinutes *int `json:"lead_time_minutes,omitempty"`
+
+	// MaxActiveHolds Unconfirmed reservations a client may hold at once, unlimited by default
+	MaxActiveHolds *int `json:"max_active_holds,omitempty"`
+
+	// MaxFutureAppointments Upcoming reservations and appointments a client may have, unlimited by default
+	MaxFutureAppointments *int `json:"max_future_appointments,omitempty"`
+
+	// MaxFutureAppointmentsPerProvider Upcoming reservations and appointments a client may have with one provider, unlimited by default
+	MaxFutureAppointmentsPerProvider *int `json:"max_future_appointments_per_provider,omitempty"`
+
+	// MaxNoShows No-shows in the last 90 days that stop a client from booking, unlimited by default
+	MaxNoShows *int `json:"max_no_shows,omitempty"`
+
+	// SlotIntervalMinutes Length of availability slots, defaults to the deployment's availability interval
+	SlotIntervalMinutes *int `json:"slot_interval_minutes,omitempty"`
+}
+
+// Problem An RFC 7807 problem details object, served as application/problem+json
+type Problem struct {
+	// Code Stable machine readable reason for an error, clients should branch on this and not on the text
+	Code ProblemCode `json:"code"`
+
+	// Conflicts The occurrences that could not be booked
+	Conflicts *[]OccurrenceConflict `json:"conflicts,omitempty"`
+
+	// Detail Explanation of this occurrence of the problem
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors The fields that failed validation
+	Errors *[]FieldError `json:"errors,omitempty"`
+
+	// Instance Path of the request that failed
+	Instance *string `json:"instance,omitempty"`
+	Status   int     `json:"status"`
+
+	// Title Short summary that is the same for every problem with this code
+	Title string `json:"title"`
+
+	// Type URI identifying the kind of problem, one per code
+	Type string `json:"type"`
+}
+
+// ProblemCode Stable machine readable reason for an error, clients should branch on this and not on the text
+type ProblemCode string
+
+// Provider defines model for Provider.
+type Provider struct {
+	Bio       *string             `json:"bio,omitempty"`
+	Id        *openapi_types.UUID `json:"id,omitempty"`
+	Languages *[]string           `json:"languages,omitempty"`
+	Location  *string             `json:"location,omitempty"`
+	Name      *string             `json:"name,omitempty"`
+	Specialty *string             `json:"specialty,omitempty"`
+}
+
+// ProviderGroup Providers that can stand in for each other, like a department, booked without picking one
+type ProviderGroup struct {
+	Id   *openapi_types.UUID `json:"id,omitempty"`
+	Name *string             `json:"name,omitempty"`
+
+	// ProviderIds The members in priority order
+	ProviderIds *[]openapi_types.UUID `json:"provider_ids,omitempty"`
+
+	// Strategy How a group booking picks among the providers free at the time. round_robin takes the one assigned least recently, least_booked the one with the fewest appointments in the week of the booking and priority the first in the group's order. Ties go to the earlier provider in the group.
+	Strategy *GroupStrategy `json:"strategy,omitempty"`
+}
+
+// ProviderProfile What clients see of a provider in the directory, every field is replaced when it is saved
+type ProviderProfile struct {
+	Bio *string `json:"bio,omitempty"`
+
+	// Languages Languages the provider sees clients in, such as ISO 639-1 codes, matched case insensitively
+	Languages *[]string `json:"languages,omitempty"`
+	Location  *string   `json:"location,omitempty"`
+	Specialty *string   `json:"specialty,omitempty"`
+}
+
+// Recurrence defines model for Recurrence.
+type Recurrence struct {
+	// Count Total number of occurrences including the first
+	Count     int                 `json:"count"`
+	Frequency RecurrenceFrequency `json:"frequency"`
+
+	// Interval Number of days or weeks between occurrences
+	Interval *int `json:"interval,omitempty"`
+}
+
+// RecurrenceFrequency defines model for Recurrence.Frequency.
+type RecurrenceFrequency string
+
+// RescheduleRequest defines model for RescheduleRequest.
+type RescheduleRequest struct {
+	AvailabilityId openapi_types.UUID `json:"availability_id"`
+
+	// Scope Apply to this occurrence only, or to this and the following occurrences in its series
+	Scope *OccurrenceScope `json:"scope,omitempty"`
+}
+
+// Room A room at a location, booking one claims it together with the provider
+type Room struct {
+	Id         *openapi_types.UUID `json:"id,omitempty"`
+	LocationId *openapi_types.UUID `json:"location_id,omitempty"`
+	Name       *string             `json:"name,omitempty"`
+}
+
+// SaveAppointmentNoteRequest defines model for SaveAppointmentNoteRequest.
+type SaveAppointmentNoteRequest struct {
+	// AuthorId The appointment's provider or one of its participants, recorded with the version. The server has no user accounts, so this attributes the note and is not authenticated
+	AuthorId openapi_types.UUID `json:"author_id"`
+	Body     string             `json:"body"`
+}
+
+// StatusChange One status an appointment took, its history lists every one from the reservation on
+type StatusChange struct {
+	ChangedAt *time.Time `json:"changed_at,omitempty"`
+
+	// ChangedBy The provider who recorded an outcome, the api has no user accounts to attribute other changes to
+	ChangedBy *openapi_types.UUID `json:"changed_by,omitempty"`
+
+	// Source What made the change: a request to the api, a provider recording an outcome, the waitlist holding a freed slot, a hold running out, or the status the appointment had when its history started being kept
+	Source *StatusChangeSource `json:"source,omitempty"`
+	Status *AppointmentStatus  `json:"status,omitempty"`
+}
+
+// StatusChangeSource What made the change: a request to the api, a provider recording an outcome, the waitlist holding a freed slot, a hold running out, or the status the appointment had when its history started being kept
+type StatusChangeSource string
+
+// UpdateAppointmentStatusRequest defines model for UpdateAppointmentStatusRequest.
+type UpdateAppointmentStatusRequest struct {
+	// ChangedBy The appointment's provider or one of its participants
+	ChangedBy openapi_types.UUID `json:"changed_by"`
+
+	// Status Confirmed appointments are checked in or marked a no-show and checked in ones completed, once they have started
+	Status AppointmentStatus `json:"status"`
+}
+
+// UpdateUserRequest defines model for UpdateUserRequest.
+type UpdateUserRequest struct {
+	Email *openapi_types.Email `json:"email,omitempty"`
+	Name  *string              `json:"name,omitempty"`
+}
+
+// User defines model for User.
+type User struct {
+	// DeactivatedAt When the user was deactivated, a deactivated user can not book or be booked
+	DeactivatedAt *time.Time          `json:"deactivated_at,omitempty"`
+	Email         *string             `json:"email,omitempty"`
+	Id            *openapi_types.UUID `json:"id,omitempty"`
+	Name          *string             `json:"name,omitempty"`
+	Role          *UserRole           `json:"role,omitempty"`
 }
 
 // UserRole defines model for User.Role.
 type UserRole string
 
+// WaitlistEntry defines model for WaitlistEntry.
+type WaitlistEntry struct {
+	// AppointmentId The hold placed for this client once a slot was offered
+	AppointmentId *openapi_types.UUID `json:"appointment_id,omitempty"`
+	ClientId      *openapi_types.UUID `json:"client_id,omitempty"`
+	CreatedAt     *time.Time          `json:"created_at,omitempty"`
+
+	// HoldExpiresAt Deadline for confirming the offered hold
+	HoldExpiresAt *time.Time          `json:"hold_expires_at,omitempty"`
+	Id            *openapi_types.UUID `json:"id,omitempty"`
+
+	// Position Place in the provider's queue, only set while waiting
+	Position    *int                 `json:"position,omitempty"`
+	ProviderId  *openapi_types.UUID  `json:"provider_id,omitempty"`
+	Status      *WaitlistEntryStatus `json:"status,omitempty"`
+	WindowEnd   *time.Time           `json:"window_end,omitempty"`
+	WindowStart *time.Time           `json:"window_start,omitempty"`
+}
+
+// WaitlistEntryStatus defines model for WaitlistEntry.Status.
+type WaitlistEntryStatus string
+
+// BadRequest An RFC 7807 problem details object, served as application/problem+json
+type BadRequest = Problem
+
+// Conflict An RFC 7807 problem details object, served as application/problem+json
+type Conflict = Problem
+
+// Gone An RFC 7807 problem details object, served as application/problem+json
+type Gone = Problem
+
+// InternalError An RFC 7807 problem details object, served as application/problem+json
+type InternalError = Problem
+
+// NotFound An RFC 7807 problem details object, served as application/problem+json
+type NotFound = Problem
+
+// Unauthorized An RFC 7807 problem details object, served as application/problem+json
+type Unauthorized = Problem
+
 // GetAppointmentsParams defines parameters for GetAppointments.
 type GetAppointmentsParams struct {
 	ProviderId *openapi_types.UUID `form:"providerId,omitempty" json:"providerId,omitempty"`
-	Date       *openapi_types.Date `form:"date,omitempty" json:"date,omitempty"`
+	LocationId *openapi_types.UUID `form:"locationId,omitempty" json:"locationId,omitempty"`
+
+	// ParticipantId Keep the provider's slots where this provider is free at the same time too, repeat it for several. Needs providerId.
+	ParticipantId *[]openapi_types.UUID `form:"participantId,omitempty" json:"participantId,omitempty"`
+	Date          *openapi_types.Date   `form:"date,omitempty" json:"date,omitempty"`
 }
 
 // PostAppointmentsJSONBody defines parameters for PostAppointments.
 type PostAppointmentsJSONBody struct {
+	// AllowPartial Reserve the available occurrences of a series instead of failing when some conflict
+	AllowPartial *bool `json:"allow_partial,omitempty"`
+
+	// AppointmentTypeId The kind of appointment, needed to give intake_answers
+	AppointmentTypeId *openapi_types.UUID `json:"appointment_type_id,omitempty"`
+
+	// AvailabilityId Needed unless group_id is given
 	AvailabilityId *openapi_types.UUID `json:"availability_id,omitempty"`
-	ClientId       *openapi_types.UUID `json:"client_id,omitempty"`
-	ProviderId     *openapi_types.UUID `json:"provider_id,omitempty"`
+	ClientId       openapi_types.UUID  `json:"client_id"`
+
+	// GroupId Book whichever provider of the group its strategy assigns, at start_time
+	GroupId *openapi_types.UUID `json:"group_id,omitempty"`
+
+	// IntakeAnswers Answers to the intake questions of the appointment type by question key
+	IntakeAnswers *map[string]interface{} `json:"intake_answers,omitempty"`
+
+	// ParticipantIds Other providers needed at the same time, the reservation holds a seat in each of their slots or fails
+	ParticipantIds *[]openapi_types.UUID `json:"participant_ids,omitempty"`
+
+	// ProviderId Needed unless group_id is given
+	ProviderId *openapi_types.UUID `json:"provider_id,omitempty"`
+
+	// Reason Why the client is booking, shown to the client and the providers
+	Reason     *string     `json:"reason,omitempty"`
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+
+	// RoomId A room at the slot's location to book together with the provider
+	RoomId *openapi_types.UUID `json:"room_id,omitempty"`
+
+	// StartTime The slot start time to assign a group provider at, needed with group_id
+	StartTime *time.Time `json:"start_time,omitempty"`
+}
+
+// PostAppointmentsAppointmentIdCancelParams defines parameters for PostAppointmentsAppointmentIdCancel.
+type PostAppointmentsAppointmentIdCancelParams struct {
+	Scope *OccurrenceScope `form:"scope,omitempty" json:"scope,omitempty"`
+}
+
+// GetProvidersParams defines parameters for GetProviders.
+type GetProvidersParams struct {
+	// Q Words that must all appear in the provider's name or bio
+	Q         *string `form:"q,omitempty" json:"q,omitempty"`
+	Specialty *string `form:"specialty,omitempty" json:"specialty,omitempty"`
+	Language  *string `form:"language,omitempty" json:"language,omitempty"`
+
+	// AvailableFrom Only providers with a bookable slot starting at or after this time
+	AvailableFrom *time.Time `form:"availableFrom,omitempty" json:"availableFrom,omitempty"`
+
+	// AvailableTo Only providers with a bookable slot ending at or before this time
+	AvailableTo *time.Time `form:"availableTo,omitempty" json:"availableTo,omitempty"`
+}
+
+// GetRoomsRoomIdAppointmentsParams defines parameters for GetRoomsRoomIdAppointments.
+type GetRoomsRoomIdAppointmentsParams struct {
+	Date *openapi_types.Date `form:"date,omitempty" json:"date,omitempty"`
 }
 
+// PostAppointmentTypesJSONRequestBody defines body for PostAppointmentTypes for application/json ContentType.
+type PostAppointmentTypesJSONRequestBody = CreateAppointmentTypeRequest
+
 // PostAppointmentsJSONRequestBody defines body for PostAppointments for application/json ContentType.
 type PostAppointmentsJSONRequestBody PostAppointmentsJSONBody
 
+// PutAppointmentsAppointmentIdNotesJSONRequestBody defines body for PutAppointmentsAppointmentIdNotes for application/json ContentType.
+type PutAppointmentsAppointmentIdNotesJSONRequestBody = SaveAppointmentNoteRequest
+
+// PostAppointmentsAppointmentIdRescheduleJSONRequestBody defines body for PostAppointmentsAppointmentIdReschedule for application/json ContentType.
+type PostAppointmentsAppointmentIdRescheduleJSONRequestBody = RescheduleRequest
+
+// PostAppointmentsAppointmentIdStatusJSONRequestBody defines body for PostAppointmentsAppointmentIdStatus for application/json ContentType.
+type PostAppointmentsAppointmentIdStatusJSONRequestBody = UpdateAppointmentStatusRequest
+
+// PostLocationsJSONRequestBody defines body for PostLocations for application/json ContentType.
+type PostLocationsJSONRequestBody = CreateLocationRequest
+
+// PostLocationsLocationIdRoomsJSONRequestBody defines body for PostLocationsLocationIdRooms for application/json ContentType.
+type PostLocationsLocationIdRoomsJSONRequestBody = CreateRoomRequest
+
+// PostProviderGroupsJSONRequestBody defines body for PostProviderGroups for application/json ContentType.
+type PostProviderGroupsJSONRequestBody = CreateProviderGroupRequest
+
 // PostProvidersProviderIdAvailabilityJSONRequestBody defines body for PostProvidersProviderIdAvailability for application/json ContentType.
 type PostProvidersProviderIdAvailabilityJSONRequestBody = Availability
 
+// PutProvidersProviderIdProfileJSONRequestBody defines body for PutProvidersProviderIdProfile for application/json ContentType.
+type PutProvidersProviderIdProfileJSONRequestBody = ProviderProfile
+
 // PostUsersJSONRequestBody defines body for PostUsers for application/json ContentType.
 type PostUsersJSONRequestBody = CreateUserRequest
 
+// PatchUsersUserIdJSONRequestBody defines body for PatchUsersUserId for application/json ContentType.
+type PatchUsersUserIdJSONRequestBody = UpdateUserRequest
+
+// PostWaitlistJSONRequestBody defines body for PostWaitlist for application/json ContentType.
+type PostWaitlistJSONRequestBody = JoinWaitlistRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get a recurring appointment series
+	// (GET /appointment-series/{seriesId})
+	GetAppointmentSeriesSeriesId(c *gin.Context, seriesId openapi_types.UUID)
+	// Confirm every reserved appointment in a series
+	// (POST /appointment-series/{seriesId}/confirm)
+	PostAppointmentSeriesSeriesIdConfirm(c *gin.Context, seriesId openapi_types.UUID)
+	// List the organization's appointment types
+	// (GET /appointment-types)
+	GetAppointmentTypes(c *gin.Context)
+	// Add an appointment type with its intake questions
+	// (POST /appointment-types)
+	PostAppointmentTypes(c *gin.Context)
 	// Get available appointment slots
 	// (GET /appointments)
 	GetAppointments(c *gin.Context, params GetAppointmentsParams)
 	// Reserve an appointment slot
 	// (POST /appointments)
 	PostAppointments(c *gin.Context)
+	// Cancel a reservation or confirmed appointment
+	// (POST /appointments/{appointmentId}/cancel)
+	PostAppointmentsAppointmentIdCancel(c *gin.Context, appointmentId openapi_types.UUID, params PostAppointmentsAppointmentIdCancelParams)
 	// Confirm a reservation
 	// (POST /appointments/{appointmentId}/confirm)
 	PostAppointmentsAppointmentIdConfirm(c *gin.Context, appointmentId openapi_types.UUID)
+	// Get every status an appointment has had, oldest first
+	// (GET /appointments/{appointmentId}/history)
+	GetAppointmentsAppointmentIdHistory(c *gin.Context, appointmentId openapi_types.UUID)
+	// Get every version of the providers' notes on an appointment
+	// (GET /appointments/{appointmentId}/notes)
+	GetAppointmentsAppointmentIdNotes(c *gin.Context, appointmentId openapi_types.UUID)
+	// Save the providers' notes on an appointment as a new version
+	// (PUT /appointments/{appointmentId}/notes)
+	PutAppointmentsAppointmentIdNotes(c *gin.Context, appointmentId openapi_types.UUID)
+	// Move a reservation or confirmed appointment to another slot
+	// (POST /appointments/{appointmentId}/reschedule)
+	PostAppointmentsAppointmentIdReschedule(c *gin.Context, appointmentId openapi_types.UUID)
+	// Check in, complete or mark a no-show an appointment that has started
+	// (POST /appointments/{appointmentId}/status)
+	PostAppointmentsAppointmentIdStatus(c *gin.Context, appointmentId openapi_types.UUID)
+	// List the organization's locations
+	// (GET /locations)
+	GetLocations(c *gin.Context)
+	// Add a location
+	// (POST /locations)
+	PostLocations(c *gin.Context)
+	// List the rooms at a location
+	// (GET /locations/{locationId}/rooms)
+	GetLocationsLocationIdRooms(c *gin.Context, locationId openapi_types.UUID)
+	// Add a room to a location
+	// (POST /locations/{locationId}/rooms)
+	PostLocationsLocationIdRooms(c *gin.Context, locationId openapi_types.UUID)
+	// Get the organization the request resolved to
+	// (GET /organization)
+	GetOrganization(c *gin.Context)
+	// List the organization's provider groups
+	// (GET /provider-groups)
+	GetProviderGroups(c *gin.Context)
+	// Add a provider group
+	// (POST /provider-groups)
+	PostProviderGroups(c *gin.Context)
+	// Get a provider group
+	// (GET /provider-groups/{groupId})
+	GetProviderGroupsGroupId(c *gin.Context, groupId openapi_types.UUID)
+	// Search the directory of active providers
+	// (GET /providers)
+	GetProviders(c *gin.Context, params GetProvidersParams)
+	// Get an active provider's profile
+	// (GET /providers/{providerId})
+	GetProvidersProviderId(c *gin.Context, providerId openapi_types.UUID)
 	// Submit provider availability
 	// (POST /providers/{providerId}/availability)
 	PostProvidersProviderIdAvailability(c *gin.Context, providerId openapi_types.UUID)
+	// Replace a provider's profile
+	// (PUT /providers/{providerId}/profile)
+	PutProvidersProviderIdProfile(c *gin.Context, providerId openapi_types.UUID)
+	// Get the provider's waitlist in the order it will be served
+	// (GET /providers/{providerId}/waitlist)
+	GetProvidersProviderIdWaitlist(c *gin.Context, providerId openapi_types.UUID)
+	// Get a room's calendar of reserved and confirmed appointments
+	// (GET /rooms/{roomId}/appointments)
+	GetRoomsRoomIdAppointments(c *gin.Context, roomId openapi_types.UUID, params GetRoomsRoomIdAppointmentsParams)
 	// Create a new user (client or provider)
 	// (POST /users)
 	PostUsers(c *gin.Context)
+	// Delete a user who has no appointments, deactivate users with history instead
+	// (DELETE /users/{userId})
+	DeleteUsersUserId(c *gin.Context, userId openapi_types.UUID)
 	// Get user details
 	// (GET /users/{userId})
 	GetUsersUserId(c *gin.Context, userId openapi_types.UUID)
+	// Change a user's name or email
+	// (PATCH /users/{userId})
+	PatchUsersUserId(c *gin.Context, userId openapi_types.UUID)
+	// Stop a user from booking or being booked, keeping their history
+	// (POST /users/{userId}/deactivate)
+	PostUsersUserIdDeactivate(c *gin.Context, userId openapi_types.UUID)
+	// Join a provider's waitlist for a time window
+	// (POST /waitlist)
+	PostWaitlist(c *gin.Context)
+	// Leave the waitlist
+	// (DELETE /waitlist/{entryId})
+	DeleteWaitlistEntryId(c *gin.Context, entryId openapi_types.UUID)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -126,42 +788,21 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
-// GetAppointments operation middleware
-func (siw *ServerInterfaceWrapper) GetAppointments(c *gin.Context) {
+// GetAppointmentSeriesSeriesId operation middleware
+func (siw *ServerInterfaceWrapper) GetAppointmentSeriesSeriesId(c *gin.Context) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetAppointmentsParams
-
-	// ------------- Optional query parameter "providerId" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "providerId", c.Request.URL.Query(), &params.ProviderId)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter providerId: %w", err), http.StatusBadRequest)
-		return
-	}
-
-	// ------------- Optional query parameter "date" -------------
+	// ------------- Path parameter "seriesId" -------------
+	var seriesId openapi_types.UUID
 
-	err = runtime.BindQueryParameter("form", true, false, "date", c.Request.URL.Query(), &params.Date)
+	err = runtime.BindStyledParameterWithOptions("simple", "seriesId", c.Param("seriesId"), &seriesId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter date: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter seriesId: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
-
-	siw.Handler.GetAppointments(c, params)
-}
-
-// PostAppointments operation middleware
-func (siw *ServerInterfaceWrapper) PostAppointments(c *gin.Context) {
+	c.Set(BearerAuthScopes, []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -170,23 +811,25 @@ func (siw *ServerInterfaceWrapper) PostAppointments(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostAppointments(c)
+	siw.Handler.GetAppointmentSeriesSeriesId(c, seriesId)
 }
 
-// PostAppointmentsAppointmentIdConfirm operation middleware
-func (siw *ServerInterfaceWrapper) PostAppointmentsAppointmentIdConfirm(c *gin.Context) {
+// PostAppointmentSeriesSeriesIdConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PostAppointmentSeriesSeriesIdConfirm(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "appointmentId" -------------
-	var appointmentId openapi_types.UUID
+	// ------------- Path parameter "seriesId" -------------
+	var seriesId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "appointmentId", c.Param("appointmentId"), &appointmentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "seriesId", c.Param("seriesId"), &seriesId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter appointmentId: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter seriesId: %w", err), http.StatusBadRequest)
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -194,22 +837,13 @@ func (siw *ServerInterfaceWrapper) PostAppointmentsAppointmentIdConfirm(c *gin.C
 		}
 	}
 
-	siw.Handler.PostAppointmentsAppointmentIdConfirm(c, appointmentId)
+	siw.Handler.PostAppointmentSeriesSeriesIdConfirm(c, seriesId)
 }
 
-// PostProvidersProviderIdAvailability operation middleware
-func (siw *ServerInterfaceWrapper) PostProvidersProviderIdAvailability(c *gin.Context) {
-
-	var err error
-
-	// ------------- Path parameter "providerId" -------------
-	var providerId openapi_types.UUID
+// GetAppointmentTypes operation middleware
+func (siw *ServerInterfaceWrapper) GetAppointmentTypes(c *gin.Context) {
 
-	err = runtime.BindStyledParameterWithOptions("simple", "providerId", c.Param("providerId"), &providerId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter providerId: %w", err), http.StatusBadRequest)
-		return
-	}
+	c.Set(BearerAuthScopes, []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -218,11 +852,13 @@ func (siw *ServerInterfaceWrapper) PostProvidersProviderIdAvailability(c *gin.Co
 		}
 	}
 
-	siw.Handler.PostProvidersProviderIdAvailability(c, providerId)
+	siw.Handler.GetAppointmentTypes(c)
 }
 
-// PostUsers operation middleware
-func (siw *ServerInterfaceWrapper) PostUsers(c *gin.Context) {
+// PostAppointmentTypes operation middleware
+func (siw *ServerInterfaceWrapper) PostAppointmentTypes(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -231,33 +867,783 @@ func (siw *ServerInterfaceWrapper) PostUsers(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostUsers(c)
+	siw.Handler.PostAppointmentTypes(c)
 }
 
-// GetUsersUserId operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersUserId(c *gin.Context) {
+// GetAppointments operation middleware
+func (siw *ServerInterfaceWrapper) GetAppointments(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "userId" -------------
-	var userId openapi_types.UUID
+	c.Set(BearerAuthScopes, []string{})
 
-	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAppointmentsParams
+
+	// ------------- Optional query parameter "providerId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "providerId", c.Request.URL.Query(), &params.ProviderId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userId: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter providerId: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
+	// ------------- Optional query parameter "locationId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "locationId", c.Request.URL.Query(), &params.LocationId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter locationId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "participantId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "participantId", c.Request.URL.Query(), &params.ParticipantId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter participantId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date", c.Request.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter date: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAppointments(c, params)
+}
+
+// PostAppointments operation middleware
+func (siw *ServerInterfaceWrapper) PostAppointments(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAppointments(c)
+}
+
+// PostAppointmentsAppointmentIdCancel operation middleware
+func (siw *ServerInterfaceWrapper) PostAppointmentsAppointmentIdCancel(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "appointmentId" -------------
+	var appointmentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "appointmentId", c.Param("appointmentId"), &appointmentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter appointmentId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostAppointmentsAppointmentIdCancelParams
+
+	// ------------- Optional query parameter "scope" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "scope", c.Request.URL.Query(), &params.Scope)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter scope: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAppointmentsAppointmentIdCancel(c, appointmentId, params)
+}
+
+// PostAppointmentsAppointmentIdConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PostAppointmentsAppointmentIdConfirm(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "appointmentId" -------------
+	var appointmentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "appointmentId", c.Param("appointmentId"), &appointmentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter appointmentId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAppointmentsAppointmentIdConfirm(c, appointmentId)
+}
+
+// GetAppointmentsAppointmentIdHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetAppointmentsAppointmentIdHistory(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "appointmentId" -------------
+	var appointmentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "appointmentId", c.Param("appointmentId"), &appointmentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter appointmentId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAppointmentsAppointmentIdHistory(c, appointmentId)
+}
+
+// GetAppointmentsAppointmentIdNotes operation middleware
+func (siw *ServerInterfaceWrapper) GetAppointmentsAppointmentIdNotes(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "appointmentId" -------------
+	var appointmentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "appointmentId", c.Param("appointmentId"), &appointmentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter appointmentId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAppointmentsAppointmentIdNotes(c, appointmentId)
+}
+
+// PutAppointmentsAppointmentIdNotes operation middleware
+func (siw *ServerInterfaceWrapper) PutAppointmentsAppointmentIdNotes(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "appointmentId" -------------
+	var appointmentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "appointmentId", c.Param("appointmentId"), &appointmentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter appointmentId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutAppointmentsAppointmentIdNotes(c, appointmentId)
+}
+
+// PostAppointmentsAppointmentIdReschedule operation middleware
+func (siw *ServerInterfaceWrapper) PostAppointmentsAppointmentIdReschedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "appointmentId" -------------
+	var appointmentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "appointmentId", c.Param("appointmentId"), &appointmentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter appointmentId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAppointmentsAppointmentIdReschedule(c, appointmentId)
+}
+
+// PostAppointmentsAppointmentIdStatus operation middleware
+func (siw *ServerInterfaceWrapper) PostAppointmentsAppointmentIdStatus(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "appointmentId" -------------
+	var appointmentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "appointmentId", c.Param("appointmentId"), &appointmentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter appointmentId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostAppointmentsAppointmentIdStatus(c, appointmentId)
+}
+
+// GetLocations operation middleware
+func (siw *ServerInterfaceWrapper) GetLocations(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetLocations(c)
+}
+
+// PostLocations operation middleware
+func (siw *ServerInterfaceWrapper) PostLocations(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostLocations(c)
+}
+
+// GetLocationsLocationIdRooms operation middleware
+func (siw *ServerInterfaceWrapper) GetLocationsLocationIdRooms(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "locationId" -------------
+	var locationId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "locationId", c.Param("locationId"), &locationId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter locationId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetLocationsLocationIdRooms(c, locationId)
+}
+
+// PostLocationsLocationIdRooms operation middleware
+func (siw *ServerInterfaceWrapper) PostLocationsLocationIdRooms(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "locationId" -------------
+	var locationId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "locationId", c.Param("locationId"), &locationId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter locationId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostLocationsLocationIdRooms(c, locationId)
+}
+
+// GetOrganization operation middleware
+func (siw *ServerInterfaceWrapper) GetOrganization(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOrganization(c)
+}
+
+// GetProviderGroups operation middleware
+func (siw *ServerInterfaceWrapper) GetProviderGroups(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetProviderGroups(c)
+}
+
+// PostProviderGroups operation middleware
+func (siw *ServerInterfaceWrapper) PostProviderGroups(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostProviderGroups(c)
+}
+
+// GetProviderGroupsGroupId operation middleware
+func (siw *ServerInterfaceWrapper) GetProviderGroupsGroupId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "groupId" -------------
+	var groupId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupId", c.Param("groupId"), &groupId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter groupId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetProviderGroupsGroupId(c, groupId)
+}
+
+// GetProviders operation middleware
+func (siw *ServerInterfaceWrapper) GetProviders(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProvidersParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", c.Request.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter q: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "specialty" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "specialty", c.Request.URL.Query(), &params.Specialty)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter specialty: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "language" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "language", c.Request.URL.Query(), &params.Language)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter language: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "availableFrom" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "availableFrom", c.Request.URL.Query(), &params.AvailableFrom)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter availableFrom: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "availableTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "availableTo", c.Request.URL.Query(), &params.AvailableTo)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter availableTo: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetProviders(c, params)
+}
+
+// GetProvidersProviderId operation middleware
+func (siw *ServerInterfaceWrapper) GetProvidersProviderId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "providerId" -------------
+	var providerId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "providerId", c.Param("providerId"), &providerId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter providerId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetProvidersProviderId(c, providerId)
+}
+
+// PostProvidersProviderIdAvailability operation middleware
+func (siw *ServerInterfaceWrapper) PostProvidersProviderIdAvailability(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "providerId" -------------
+	var providerId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "providerId", c.Param("providerId"), &providerId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter providerId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostProvidersProviderIdAvailability(c, providerId)
+}
+
+// PutProvidersProviderIdProfile operation middleware
+func (siw *ServerInterfaceWrapper) PutProvidersProviderIdProfile(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "providerId" -------------
+	var providerId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "providerId", c.Param("providerId"), &providerId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter providerId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutProvidersProviderIdProfile(c, providerId)
+}
+
+// GetProvidersProviderIdWaitlist operation middleware
+func (siw *ServerInterfaceWrapper) GetProvidersProviderIdWaitlist(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "providerId" -------------
+	var providerId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "providerId", c.Param("providerId"), &providerId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter providerId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetProvidersProviderIdWaitlist(c, providerId)
+}
+
+// GetRoomsRoomIdAppointments operation middleware
+func (siw *ServerInterfaceWrapper) GetRoomsRoomIdAppointments(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "roomId" -------------
+	var roomId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "roomId", c.Param("roomId"), &roomId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter roomId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRoomsRoomIdAppointmentsParams
+
+	// ------------- Optional query parameter "date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date", c.Request.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter date: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetRoomsRoomIdAppointments(c, roomId, params)
+}
+
+// PostUsers operation middleware
+func (siw *ServerInterfaceWrapper) PostUsers(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsers(c)
+}
+
+// DeleteUsersUserId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersUserId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteUsersUserId(c, userId)
+}
+
+// GetUsersUserId operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersUserId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
 	}
 
 	siw.Handler.GetUsersUserId(c, userId)
 }
 
+// PatchUsersUserId operation middleware
+func (siw *ServerInterfaceWrapper) PatchUsersUserId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchUsersUserId(c, userId)
+}
+
+// PostUsersUserIdDeactivate operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUserIdDeactivate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Param("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter userId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsersUserIdDeactivate(c, userId)
+}
+
+// PostWaitlist operation middleware
+func (siw *ServerInterfaceWrapper) PostWaitlist(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostWaitlist(c)
+}
+
+// DeleteWaitlistEntryId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWaitlistEntryId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "entryId" -------------
+	var entryId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "entryId", c.Param("entryId"), &entryId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter entryId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteWaitlistEntryId(c, entryId)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -285,10 +1671,224 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.GET(options.BaseURL+"/appointment-series/:seriesId", wrapper.GetAppointmentSeriesSeriesId)
+	router.POST(options.BaseURL+"/appointment-series/:seriesId/confirm", wrapper.PostAppointmentSeriesSeriesIdConfirm)
+	router.GET(options.BaseURL+"/appointment-types", wrapper.GetAppointmentTypes)
+	router.POST(options.BaseURL+"/appointment-types", wrapper.PostAppointmentTypes)
 	router.GET(options.BaseURL+"/appointments", wrapper.GetAppointments)
 	router.POST(options.BaseURL+"/appointments", wrapper.PostAppointments)
+	router.POST(options.BaseURL+"/appointments/:appointmentId/cancel", wrapper.PostAppointmentsAppointmentIdCancel)
 	router.POST(options.BaseURL+"/appointments/:appointmentId/confirm", wrapper.PostAppointmentsAppointmentIdConfirm)
+	router.GET(options.BaseURL+"/appointments/:appointmentId/history", wrapper.GetAppointmentsAppointmentIdHistory)
+	router.GET(options.BaseURL+"/appointments/:appointmentId/notes", wrapper.GetAppointmentsAppointmentIdNotes)
+	router.PUT(options.BaseURL+"/appointments/:appointmentId/notes", wrapper.PutAppointmentsAppointmentIdNotes)
+	router.POST(options.BaseURL+"/appointments/:appointmentId/reschedule", wrapper.PostAppointmentsAppointmentIdReschedule)
+	router.POST(options.BaseURL+"/appointments/:appointmentId/status", wrapper.PostAppointmentsAppointmentIdStatus)
+	router.GET(options.BaseURL+"/locations", wrapper.GetLocations)
+	router.POST(options.BaseURL+"/locations", wrapper.PostLocations)
+	router.GET(options.BaseURL+"/locations/:locationId/rooms", wrapper.GetLocationsLocationIdRooms)
+	router.POST(options.BaseURL+"/locations/:locationId/rooms", wrapper.PostLocationsLocationIdRooms)
+	router.GET(options.BaseURL+"/organization", wrapper.GetOrganization)
+	router.GET(options.BaseURL+"/provider-groups", wrapper.GetProviderGroups)
+	router.POST(options.BaseURL+"/provider-groups", wrapper.PostProviderGroups)
+	router.GET(options.BaseURL+"/provider-groups/:groupId", wrapper.GetProviderGroupsGroupId)
+	router.GET(options.BaseURL+"/providers", wrapper.GetProviders)
+	router.GET(options.BaseURL+"/providers/:providerId", wrapper.GetProvidersProviderId)
 	router.POST(options.BaseURL+"/providers/:providerId/availability", wrapper.PostProvidersProviderIdAvailability)
+	router.PUT(options.BaseURL+"/providers/:providerId/profile", wrapper.PutProvidersProviderIdProfile)
+	router.GET(options.BaseURL+"/providers/:providerId/waitlist", wrapper.GetProvidersProviderIdWaitlist)
+	router.GET(options.BaseURL+"/rooms/:roomId/appointments", wrapper.GetRoomsRoomIdAppointments)
 	router.POST(options.BaseURL+"/users", wrapper.PostUsers)
+	router.DELETE(options.BaseURL+"/users/:userId", wrapper.DeleteUsersUserId)
 	router.GET(options.BaseURL+"/users/:userId", wrapper.GetUsersUserId)
+	router.PATCH(options.BaseURL+"/users/:userId", wrapper.PatchUsersUserId)
+	router.POST(options.BaseURL+"/users/:userId/deactivate", wrapper.PostUsersUserIdDeactivate)
+	router.POST(options.BaseURL+"/waitlist", wrapper.PostWaitlist)
+	router.DELETE(options.BaseURL+"/waitlist/:entryId", wrapper.DeleteWaitlistEntryId)
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+w9a5PctpF/BcVLle5BaXcdOYn3m6LEju8cW6eVKlXn25vCDHtmEJEADYC7mqj2v1+h",
+	"8SBAgjOcfekRf5F2SIJoNBr97uaHYiWaVnDgWhXnHwoJqhVcAf74I61ewy8dKG1+rQTXwPFP2rY1W1HN",
+	"BD9ppVjW0PzH35Xg5p5abaGh5q/fSFgX58W/nPRTnNi76uSVHVXc3NyURQVqJVlrXlecF2+2QKSdljBF",
+	"GlqvhWygIkKSNWW1Ile0ZhXOXtyUxUvB1zVbfTQYV25+Ra6Z3hK9BbLqpASuyVKId4xvlAHzO8Hh8UFU",
+	"IK9wArIVdUXgfcskVGQJayGBME2uqcIVMINiA+f3XIPktP6zlEI+NsAGXLDbDBXRgmwpr2oguse3gfFH",
+	"ob8VHa8eE7wXBpuik6sEGsJpA4pUAhThQhN4zyyIbznt9FZI9g+oHhuLS6ASJNHiHfAetCXUgm8MUinf",
+	"ESE3lLN/IBSlOVrJogAqRQQHQnlFttS8gENhZnMgGAhftK1gXDduWa0ULUjNLO+g/c2F3rWwYIiGMbDv",
+	"GK+IWJNoAJ4bqEpyvRUKCOOavgOCoDHBlbuwoFxdg1TE/V+UhWEUVBfnRdexqigLM3FxXigtGd+YXaFK",
+	"sQ33EO9D8Yv+yZuyWNGWrpjejZfwY9csQZoFrGpm3kD0lmqyohxXQfSWKaJqoUsieL0jCjRhHK+QmimN",
+	"7CFAyriGDUicEl/n0HZwYcCrhWYNJA9XVMNTvJoZMfPFKa5xZ6uKmcXT+lW041p2kKNFu4wnYZcM/RlS",
+	"G22qWOP1mAwMMGS5Cw+Rd7DrYRTLv8MKd6cW9jRlaexvW5D2yCLOmSoJWxOmFaFXlNV0yWqmd8gHN+wK",
+	"OKHEv28ORbVUarZiLcW9UnkaF3oLkrRSXLHK4OB6Kwgu34w25DBYeUmArrbIshnfEEoUUP8ck0RcOwKi",
+	"2i6MNkDcPjMNjZq1s+4ClZLucCUOvLkkJ4E6pjVE+I7osPPhLDPc3x1RdOJ1QjSTTMLcdG8iWmwA8RnE",
+	"rYccJ6F8N2ffDEbVQkJDGTeX9hxsfNRi353l/owrzeqaLCEs87hDrkAyUNPLBqNJGBKwD1oI4iNiyNbh",
+	"hfHj1m9n5hW8H0/+PyAFWVIFFWmFwuNuD+hgesbxJNmX5VeoqdRHsialqe6QiIF3TXH+c2EVGTAr6ZUV",
+	"w5b5Cuoa/3aqjbm6hdU7qBaM4+NNW4PGG1ws1FZcF5eTcy5WW8o3UC2oznKS0UnFDaipUQPtrLgNYVIj",
+	"WBsqzXVKuHiK05dHoSGAtNzlicRTP3KVBJQhrCloTEfQMZ0HME8+NxkmHGkDPwoNY1h/4kCuQKpASz3o",
+	"6gnhQoMighPKU06o6JU5AHoLDaFVpQj1bynKPTrHTCZmlbTJAxhuO9lwbUmAqbASs/+KWtI8ONtSVLiJ",
+	"oxsrCVQHsptHHh4LI8AvzJFTRjicof62EaBI11p+CVcgdwhx5rge2NcLe8z36Xr4O8igvQpWPygnjY5T",
+	"f4INliG7lbXGVuBVM9HVldOIiWcsdmdRmNPac1sqgdC6Fte4u7NW1c8WzNLM4mau6niJ7Cc/BObr/slD",
+	"m/4G730YWUMZvb0MOrAzfJ0JYRQ/lBNDpW90go9TSvv3zCW673Hgf7txua0x9lzmiGaRlBgTKX7+Iq4J",
+	"JRspujYgo2VWQGgVaSxBXxDcmWDWAWP0ZMFhhKEV5RUzfGFC1aQrza6ANGC0F+V1SyGNgDB/KS2phs2O",
+	"SMqdjGjmEne/4JceihwK4X1bU2416ByzQ6zMpWkP7yHYvjMvvfAPH9ivHvy9vGxhOP3iGuDdGNevPeMw",
+	"HDboJPFpUImIQyva7YZ5oxeBjjhK8lfBK7oz237R4V9v37zMalTObKkhq6GgZjyY2CgfawmQGA2pWFkK",
+	"UQPFE2FUiAXdQ9u9Zmzeg7tp7LswIp3evI5sjP17GmwAbqSQuehIfLzIVjIhswa3USMGminjPSQTbzuG",
+	"kWZpJ7IVx0STugfWtKt1cX5WHukqQJvPugoagTYr5chB10I6VqJAKcc4G8ZZY7Tjs9yKH8wfMNPWDtuv",
+	"AFRYr90oZQlRlehnsn4+RAC1dp6365pZ9veRMvJ4ewQF6y8dGhfnP8cviNB8maGZl6jUDURp5FAfiL77",
+	"k2kNff+9Hfn16bSEa+j7H4Bv9LY4/+rrr5Gg/O+zQ0jgdO+af3BEMrlYWlUSlBqAcXZ6eprZskcA+JWj",
+	"IpQik1DfCpCERCeEdiStPeOzIvsoh0667Q3j7tfZgAbKouPslw7cbS07uJOgHeF5sORptL8WorlfbB+3",
+	"7W8VyMn5oaGsThBvr+yj0P2EIEUNsVfDY6nw5k7GM5HHr4cE35hb37cM6iqEcNKFrc29jFg3XkQn1H0g",
+	"AB8lQhrLiDagjcZq9BiMzKkWfS9e/LoxWcoEpehmQq+Ol2dB6wfklpYSYCxxC2mCQgspluj6maeRK0Ib",
+	"wTeJ1FJWXYo0pWckejf6bq16hwYOaj5QkRqMriNhBVzXu9L+Xni3pXs6eC3XcG1QnGiM0woi6pmBOeB4",
+	"JpVOdJ8nynKNZ+QNA0U2wjvbgcqaRR7oZNSz/+VFGWgyRWG8giLSynIetIEcypiMwY/v9QFnGqLt3a8z",
+	"8VkZCxMDASNLyAQD9hKxezvjg4BRUcY85XfPy6KlWoM04//vZ/r0H5fmn9On3ywu//03WR2ILqEesKav",
+	"T08PCgLRBsmeMdx8NIustoKteivZuiB6bclog4NHFJqRsbA4AMl+DaE/kdHhWtNaQc5e0BMOAg3vddgD",
+	"sy47fdmvUPCwVx41PSGa4UVZcFSaizLMWBZ29GFeaaNFdrPcszl+8p+C8b9Rpmum9KQwOM4ddaxSes14",
+	"Ja4XwKv5+robg+roLVXZflEpyIOXJ/DlMOjVvRwVKKatRRAHnFOrgOoyjcUxEw1cgzT2tM56QSL98baG",
+	"zBGOnoxTL6O+u0DK2BbrA2WetrlYxAu21G1ZLIbB0l9QK7g2ltVU4OJ4g2bPEi9WooXk5Bd6y9RInr5o",
+	"23pnBYzZrzAeWZHNKHD3jORCgSUMI0O3YOSTHYaQwvm3k4ZB2cX/FBFVjvpWNeNsVeIMnQIZgGEyJOeg",
+	"m5fxK6bYska/m/WR26BtTLYjMtwKlXM5Oy5C8LY18iUoUV9Bj6/4NKD848IlbDCDDK5jbn4wdns3mi8L",
+	"BdoGKQ+5tyOwL/wYM77uNnMPU+4VIxS+GqCeiCuQklUQEgUqaGuxa2xygaPVsUMZZefCDK5p2zK+WQyD",
+	"Fem8P4BGEWxj15gzFQ9AftS/DFVDVZocBQeB9W8Zlw4XOu9eA2p9BouG8S7rxzWq6pqi5kKrKxPjjDO5",
+	"FGk6hcGLhlZQhrUb2vrqOdmKTib+odOcf6ih7xfWVbwwi8wA8Zb3Xs1k9oCdhu4chgyTXkFJOl6zhmmo",
+	"IowcdFUZUNad7iQc2Ju37Uo0BuspOHy4RQl89Oqe4Vq0IBfBcrs3IK1dIHiczXBrsF2gO4PDH22IN1ga",
+	"6J395pRUdOc4ldKi7cFbS5d4gZrbrQBStdAL81te0Xqa6K26ivp+rAs4T2FM5KPTnwzwMx2AK8eafFrd",
+	"WJBw8vrbl+T3fzj9PXEZe6QCjcmodnhJfDhAkcn8vlEsR1QwM9XvpXl0f6ATE432BjuDWnGPoUyLhjE0",
+	"f+6DQCFzJFYSQgIAYjyX0SalkBPLRDeBW6HLFY1ygmeuL3KR5CQqV5q6SOpANFG99fB7P0kEyf6ElvHx",
+	"0EznQjkXWyE1UV3TULmz72eqz/YyRqBVUzw9Or8CUwTJajLha8SwXn9PWAVcs/XOJVqE2K57d2n5EsiJ",
+	"Vw/MCmet24WFxZeW3C+nz91LUWXgu9Am1kUautoybjBOK7xgtWpEBOUEqaWPP6st0v1SUr7a2tCqU0S5",
+	"CKFWZ2F6hZNxpKFF78fqaWoR9rYX31dM1J7gkMd1vA/N2UtqEV+J2dSCC71YYw5zWRghuujzl+I0lvgx",
+	"l7IVXzI67fgC41a0+99bqlLR6hyIC+MVwfPilo4KaGwMxq++djbyAriW6QJCUCi+iLbL6EKKJBsKHuEC",
+	"pcxCmoDYCCPDewHWfQ85S7cS3bKG3rxycnL0uMeHy8DSknIbaszsDtpfIygx5TleF6J2EY5JWTCXZ79A",
+	"0rXbe8VWkGAoZ/O8inSPVJ4smbiLRVxTvunoBtLQ00Hbo46s/yMsjhZWjNZ6N9NsSCI0GaYcPLdRaqY5",
+	"78yyCIytokVXkpq9A0KNCkGly1txPlrDQ0Wn0S/s0ldum6IyufDHigYNt+keEyk8sl9JsWb5PASzB54X",
+	"A1gv7tD7XDEJKy3krnSizEYbmCIS2pqufFaWiza4JLssyUeO2Of5IGJC3APN09/aG7kuiepWW6PffX/x",
+	"E/ndb795eobiUJWkodowDrKiWK2gALnFFdzSJfvV6f5DNgyI7T9bB57O7e/rJI9sqLN2uZyQN0LTmvCQ",
+	"4ZB6eFZ1V3ndAsMW1gVvlfOvv4o09a9yFsQaRTJf7WIfWkUZ4tfESup8RCKYAjPTMtAIEhLDL4osQV8D",
+	"8Hgph22KJJgVwC4d2i6zyDYnr+rq6dSARHGYm+fg3XjzVHzr9RuuYDhxFn4hchaTTeWgOiqnKJO0wFVN",
+	"WYOhxOmE/tsy30GSyn36gi/oFQxSnaf37UBycf+aJ31KoCFAFxjBTMG+vkSVJrZoJEHVo8plAT8jUfmc",
+	"LdhChyehKyQ9VRLl3bFaS7Y0Rji+gAtty7yYLRMzQAPXxoA9Lql5kMhxeshM6LHj3pGjrgvUwF5iCnw+",
+	"F8zqaMOwoRbinXX7bpkyMgbrMJR37XKwng09KJLMGelJRcC82MxRKfthTyknotMr0UDpUvdZdiuxhM9v",
+	"onNT2xnNrTlbZusYJ+S28Sri/Pad54T2Zq7wgJWxPLcrcMHbZAneZohKmUxkvXJpbhSvE9lxbm6KTodS",
+	"RLetwxKGLQ1KQb+1TgUnSzBveQdtbNb15lzEVTxcifG1Q/a+kVbQXn4KhSll8f7pRjx1F+NUfAtJlkW9",
+	"bas078w+PB3bPECtR/OpWSQYUJlO+DKfSkslRBU2ubIam4gbPcKN+uaRXKKH2nrn0d3am21+Kx9mdwap",
+	"g84T0mP8cnIDB8lJDeNxveVZ+cDpSlnKUjmzswJ0N0QFLBN1U8jFTMFMNKIkNP5pn3HxE5sVKmTiu5zH",
+	"gQM27jk8fOskrhEufcrBn7mWu/3101MKBDJPZymthXTePwTCkju1Gd/XNATU5xzNI0tvblG6FDm8VJZk",
+	"/gS0qplLfXaM1RsQbiW4+tn0MHMxPqU842AwaPaWq9/zJ8qk4HQQlXFcb1lt5Z7lEHdNRs8Jnf7t0a52",
+	"9ZqNJU6QQ5cfN+1kQP5mWcbIZHp3YWwRZ8xj34IXnd6O8f+CJ/FgE/VpmY2Yl14/UcGBIzj0IXdbMx0N",
+	"7nW/rUAdAO0hjNMiBP0Ktlq3tssC42vrXLPuelf/4f2/oRavOHt2+uzUJnsBpy0rzovf4iXMMtviOk+i",
+	"w/3UenVPPtj/v69uzBMbQOQahoBTfF8V58V3oEfFeBduFL7epWiq4vznDwUz0JgpC8/MCtU/3IslW7jf",
+	"9504VBxxWaZNYr46Pd3T3+K4vhaj5e3pE8LAhVXr2usgiY/7piyen55OzRiWcBI1ucEhZ4eHJL09cNDz",
+	"w4NCz5Kbsvh6DmBpIxY8MjYaZEkB1XJfHh6ryMpjrjxAaCeOsaLwESpDca+EmiY5p6l9dMobhIssZSQt",
+	"bb5cOnB74MzaUMw6KNGnkzRhUKxmMpw3+OwdT/+xpcFm0rE3O88Uhq1DlMlSQAK87YbeeX9+YEqPsh+f",
+	"qDGoTvk4fAb7XUBa/aNzvtwL+91bsHRzczM8vDcjYjh7CFFgaSDTkWmAROJU0cc88nemkBdVNfJdmbWg",
+	"gy9bMz08xHPPr5rg1L90gK4Px6q9eorMej5zLvNv857Y49+W7vV/AbRD1Rsj7OTa1RyyyDXB0gKO0B+H",
+	"aCGMtthiOx2N1oUyvJPWz8iP2PSqX/6zoswuKfJ0DFZ1m1Kts1GwZwqVWK+cRaK7c9+a2t3bOGTOLPph",
+	"oyyvOuXbuKef1QFGVWz/WjxjT1HxR1vyOiBoe/AjG9EquWkgBr2kNTjqtk5/W4UUvdBaOb7kCV/rK+/x",
+	"nX3iuKH0vULnLgInl4/r+mzk6kuyVfa4zh7JcXBRrIN6QxhXGihmLpl8HaMYo69YiQZCm8ZsUu5dutXF",
+	"ZcymS8y42uhwF5pxgG8Qo7QzdLwGpfptZK5n2f17deIeDRmyvd6y1RaL+Ht3cNQOwGb0+04TljJVSaiO",
+	"iG4OzHdpPvfi4frNHez59tOg35sjkKE4KkfxJ8zFjvq92bSVtfNhWIHn26AW5T0JnJFL6t5pb2arOKb6",
+	"VGPjced+89x9X0gSEFuUw6jjvXXF2dOQro9t+66CT1QIcodmBnuj2ke2Kcg4QIyowIe8YtMLAHsEw8Gk",
+	"PYdKhMBM3+lkzdg4cnH/FgLlu5/WqKrO10GOdjFdHrAtvFmNUnegqzvJg2LGO2QwwTn0k/ykHRDPT785",
+	"PCBO/76zuuQl+hCPtdBjy+bkQ/QLPVboz57tsFIv4uEv7eA5/qpk2js5rSaUeZueU840hsd5OrN8YTER",
+	"95GAX+kx8aAhXvDs9mK4DzqlzrQ5FHqkT3VAokf4VO+PRmcRUxTw+Ey8q0cT0/OzGSvBPu736btNSG8G",
+	"hbmsl7len4S+/uLGfhL0db+OiCRTbKbH2GdNibXLNyoJ6lRoOXq1LdmdW5L7x4gPuU6f2fQ4k1a2pUaj",
+	"qSvb50WqOdyNC53EC8aWgsq7u8eh2rQs+kCM1rbiRIsOKvL89OwZMZmXNi9Ibam0JYm4ZMEhpJuZ8W5i",
+	"jX5C83wun+6d8y82pJWYiUK0GLtF9h0thOdLPFjDxr4zz5YLiQdDG0mnJDXVgeBKAk2rd6YtIMfWOGaH",
+	"bJ7/lxi1cwkPSBZxqsPPlzeX+cN7dLtkNOa7j300+67Jg1xjv56pdOOMI7L7BE/c/Ufe9mSVz7KqHyQF",
+	"w572vaf719OKBQEzTycWhxMO1x59MySuDOUhtzQp+vqSL+OojOtlHuCEPEToy32QyQFffT7ZSo9vlP9V",
+	"GA/RLJPcfs/IFkHMdCL1CZW3OE4utfuLOEoHCgU+nuSZk+Hj+kYZbuo29NczlLgXTDlD8skPXzWR1Eyk",
+	"Z2lLrW3oqyPwMPnQxt5Ekx/CQ4/Bev1sc/luWMKnmxLWY3lvKliK54fKARs2cH7k5K9+e8fb6e99vtle",
+	"YasHx+vkQ58tdXMihWjmnbgfwqjXOGaObErysj5xB4RZ1WwFy2AgPeRfau5vYCR2zUmp9Uwe8rEp56HY",
+	"V9xX/JFZlyXWMXGa6x+DZT0+XVoehykSWiQ0abidGHTsnOJuSWfPB9Q6k3kmmEoC80dNNBw10o2bkDmP",
+	"XUW0sLj2HomnmPKxV5gk7XUeR4dLppzL3+1KPl0tzqPcAbqfD2eQ/lAcMfuBi0dmjYMNH28w3vgsmOTj",
+	"m3OWq6bklT3lJx/w/wOFhCnpfWdHzJL/m/Dsp1lDeJDKAhv5ZygR3EcwswRCRikc5HAK6VtxYl9iU49J",
+	"2xaozFRLc/w8gyRLJiaqK35JkqLmZlOFhle3GOw7gx0aO2w9U+/iLzozvSUUMz8xRb3Pz8TyTPzUKl1r",
+	"cEX6/mPNGXBCmvu3UjTT9R6T2Zq3ARR41YO5hLXwFTVz4Hwjjofy8jEVjLm6BbZxM3gI2LIJ2ktsfWO+",
+	"ZoFPgHKfXsGkz19CIjRGNZ1iYrvyXDMFn5V74gKoXG3T5nxY7WA/KtkzjpSPnHzoq6ZmSR31Ki4yOyxz",
+	"kpq0T1vsTBFXGxHjFy50+JBerF6MrSL3EM4JHX5j8aDiHNFR8oHGx6ap+9fak+XM19MH+b/RS35Vq/Mc",
+	"r1s2TEfFEinep4m17Xuftl2OTLsclfqOqZ8/gQ57wD5ysHAuu+3TOE2gMHChL5kHv7bNc9PS0jn8NzTF",
+	"O06C+0ZWn5Mkn6U/ph26ZiiRf7N9mVAR9F2qPFIJcG3Ljb50+T8w+QICkk+RM1PoXNems5troIiUiaGM",
+	"kw/mP9QIZnY5wOjFaxw0o+FBSpF2roeo8fkiCvYHaRfhwzHo31/uohrEf4qOS0I0TxRZ0Rp4RbHoWR7+",
+	"ELylbfz+2H699q2yDpeH8wPHTSUf2f1rps5R2Fvs+PgxtNNHT8nBRboUUCxB+FffrbGvFv+3iFpOPnQq",
+	"GNUV1KBhTDh/wutIOm/VbJO6Uw8lhDO7a0E3vdpEVFnDUnUbD9BnJS4fn4LsXhPqWqluhW8QHbObMmqo",
+	"ig86r59vl+zaZBiApgTqp0NND8963Ie8vnzx1Q1W2xpvZkYQmcsfiwAeKsn1aLn3SMTXIXS/emWGuaum",
+	"LtOxuShsZLs6Z+TjSc/xZmhYlq7/1A/58llc1G/bSl7XQ3gkcG2n9c+iWcDdnX/2U5fIF+MPXdoomPlj",
+	"iV3HSywPdfWkTHpBagkx9ppM013kJHkIJpf7hvkjq/cDb8mYEg2MUCWfhfiV7SX0aDCUuu3C6cTPHNpW",
+	"P7YDd0p8Jx/wo3yzLIVko2aKdwjPPrC58AOs9edDIndPIwNfPdivd1h9eFNmCxCx65/dsE7Wrlf5+Qmm",
+	"k9dbofT5H07/cHpi2hv9/wD1MS3c5aEAAA==",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
 }
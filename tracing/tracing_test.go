@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+// Setup replaces global state so these tests do not run in parallel
+
+func TestSetup_Stdout(t *testing.T) {
+	var buf bytes.Buffer
+	shutdown, err := Setup(context.Background(), ExporterStdout, &buf)
+	require.NoError(t, err)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	_, child := otel.Tracer("test").Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	require.NoError(t, shutdown(context.Background()))
+	require.Contains(t, buf.String(), `"Name":"parent"`)
+	require.Contains(t, buf.String(), `"Name":"child"`)
+	require.Contains(t, buf.String(), parent.SpanContext().TraceID().String())
+}
+
+func TestSetup_None(t *testing.T) {
+	shutdown, err := Setup(context.Background(), "", nil)
+	require.NoError(t, err)
+
+	// Spans still get ids so they can be correlated in logs
+	_, span := otel.Tracer("test").Start(context.Background(), "span")
+	span.End()
+	require.True(t, span.SpanContext().IsValid())
+
+	require.NoError(t, shutdown(context.Background()))
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	_, err := Setup(context.Background(), "zipkin", nil)
+	require.Error(t, err)
+}
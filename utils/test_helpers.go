@@ -2,47 +2,41 @@ package utils
 
 import (
 	"context"
-	"os"
-	"path/filepath"
-	"sort"
+	"database/sql"
 	"testing"
 
+	_ "github.com/lib/pq" // PostgreSQL driver
 	"github.com/stretchr/testify/require"
+	"github.com/tateexon/reservation/migrations"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 )
 
-func getMigrations(t *testing.T) []string {
-	// Read directory entries
-	migrationDir := filepath.Join("../", "migrations")
-	entries, err := os.ReadDir(migrationDir)
+// StartTestPostgres starts a postgres container with every migration applied
+func StartTestPostgres(ctx context.Context, t *testing.T, dbname, user, password string) *postgres.PostgresContainer {
+	ctr := StartEmptyTestPostgres(ctx, t, dbname, user, password)
+
+	// explicitly set sslmode=disable because the container is not configured to use TLS
+	connStr, err := ctr.ConnectionString(ctx, "sslmode=disable")
 	require.NoError(t, err)
 
-	// Sort entries by name to ensure correct order
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].Name() < entries[j].Name()
-	})
+	conn, err := sql.Open("postgres", connStr)
+	require.NoError(t, err)
+	defer conn.Close()
 
-	// Build a slice of strings with full paths
-	var scripts []string
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			scripts = append(scripts, filepath.Join(migrationDir, entry.Name()))
-		}
-	}
+	_, err = migrations.NewRunner(conn, migrations.Postgres, nil).Up(ctx)
+	require.NoError(t, err, "Failed to migrate the test database")
 
-	return scripts
+	return ctr
 }
 
-func StartTestPostgres(ctx context.Context, t *testing.T, dbname, user, password string) *postgres.PostgresContainer {
-	migrations := getMigrations(t)
-
+// StartEmptyTestPostgres starts a postgres container without any schema
+func StartEmptyTestPostgres(ctx context.Context, t *testing.T, dbname, user, password string) *postgres.PostgresContainer {
 	ctr, err := postgres.Run(ctx,
 		"postgres:16",
 		postgres.WithDatabase(dbname),
 		postgres.WithUsername(user),
 		postgres.WithPassword(password),
 		postgres.BasicWaitStrategies(),
-		postgres.WithInitScripts(migrations...),
 	)
 	require.NoError(t, err)
 